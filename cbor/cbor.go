@@ -0,0 +1,274 @@
+// Package cbor is a minimal CBOR (RFC 8949) encoder and decoder without dependencies.
+// The package is used by nullable objects to implement MarshalCBOR and UnmarshalCBOR methods,
+// which are called by github.com/fxamacker/cbor and compatible libraries.
+// The decoder supports a subset of CBOR: indefinite-length text and byte strings are read,
+// indefinite-length arrays and maps are not supported. The encoder always writes definite-length values
+package cbor // import "gopkg.in/webnice/lin.v1/cbor"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Основные типы CBOR, старшие три бита начального байта
+const (
+	majorUint   = 0 << 5
+	majorNegInt = 1 << 5
+	majorBytes  = 2 << 5
+	majorString = 3 << 5
+	majorArray  = 4 << 5
+	majorMap    = 5 << 5
+	majorTag    = 6 << 5
+	majorSimple = 7 << 5
+	majorMask   = 7 << 5
+)
+
+// Дополнительная информация, младшие пять бит начального байта
+const (
+	infoUint8      = 24
+	infoUint16     = 25
+	infoUint32     = 26
+	infoUint64     = 27
+	infoIndefinite = 31
+	infoMask       = 0x1f
+)
+
+// Коды простых значений и чисел с плавающей точкой
+const (
+	codeFalse   = 0xf4
+	codeTrue    = 0xf5
+	codeNull    = 0xf6
+	codeFloat16 = 0xf9
+	codeFloat32 = 0xfa
+	codeFloat64 = 0xfb
+	codeBreak   = 0xff
+)
+
+// Номера тегов CBOR
+const (
+	TagDateTime  = 0  // Строка даты и времени в формате RFC 3339
+	TagEpochTime = 1  // Время в секундах от начала эпохи Unix
+	TagPosBignum = 2  // Неотрицательное большое целое число
+	TagNegBignum = 3  // Отрицательное большое целое число
+	TagUUID      = 37 // UUID в виде 16 байт
+)
+
+// AppendNull Запись значения null
+func AppendNull(b []byte) []byte { return append(b, codeNull) }
+
+// AppendBool Запись логического значения
+func AppendBool(b []byte, value bool) []byte {
+	if value {
+		return append(b, codeTrue)
+	}
+	return append(b, codeFalse)
+}
+
+// AppendInt Запись целого числа в наиболее компактном формате
+func AppendInt(b []byte, value int64) []byte {
+	if value < 0 {
+		return appendHead(b, majorNegInt, uint64(^value))
+	}
+	return appendHead(b, majorUint, uint64(value))
+}
+
+// AppendUint Запись целого числа без знака в наиболее компактном формате
+func AppendUint(b []byte, value uint64) []byte { return appendHead(b, majorUint, value) }
+
+// AppendFloat32 Запись числа с плавающей точкой одинарной точности
+func AppendFloat32(b []byte, value float32) []byte {
+	return appendUint32(append(b, codeFloat32), math.Float32bits(value))
+}
+
+// AppendFloat64 Запись числа с плавающей точкой двойной точности
+func AppendFloat64(b []byte, value float64) []byte {
+	return appendUint64(append(b, codeFloat64), math.Float64bits(value))
+}
+
+// AppendString Запись текстовой строки
+func AppendString(b []byte, value string) []byte {
+	return append(appendHead(b, majorString, uint64(len(value))), value...)
+}
+
+// AppendBytes Запись строки байт
+func AppendBytes(b []byte, value []byte) []byte {
+	return append(appendHead(b, majorBytes, uint64(len(value))), value...)
+}
+
+// AppendArrayHeader Запись заголовка массива из n элементов
+func AppendArrayHeader(b []byte, n int) []byte { return appendHead(b, majorArray, uint64(n)) }
+
+// AppendMapHeader Запись заголовка карты из n пар ключ-значение
+func AppendMapHeader(b []byte, n int) []byte { return appendHead(b, majorMap, uint64(n)) }
+
+// AppendTag Запись тега, за тегом должно быть записано значение
+func AppendTag(b []byte, tag uint64) []byte { return appendHead(b, majorTag, tag) }
+
+// AppendTime Запись времени строкой в формате RFC 3339 с тегом 0
+// Часовой пояс и наносекунды сохраняются
+func AppendTime(b []byte, value time.Time) []byte {
+	return AppendString(AppendTag(b, TagDateTime), value.Format(time.RFC3339Nano))
+}
+
+// AppendEpochTime Запись времени числом секунд от начала эпохи Unix с тегом 1
+// Время без долей секунды записывается целым числом, иначе числом с плавающей точкой, часовой пояс не сохраняется
+func AppendEpochTime(b []byte, value time.Time) []byte {
+	b = AppendTag(b, TagEpochTime)
+	if value.Nanosecond() == 0 {
+		return AppendInt(b, value.Unix())
+	}
+	return AppendFloat64(b, float64(value.Unix())+float64(value.Nanosecond())/float64(time.Second))
+}
+
+// AppendBigInt Запись большого целого числа
+// Число записывается целым числом, если помещается в 64 бита без знака, иначе строкой байт с тегом 2 или 3
+func AppendBigInt(b []byte, value *big.Int) []byte {
+	var n = new(big.Int)
+
+	switch {
+	case value == nil:
+		return AppendUint(b, 0)
+	case value.Sign() >= 0 && value.IsUint64():
+		return AppendUint(b, value.Uint64())
+	case value.Sign() >= 0:
+		return AppendBytes(AppendTag(b, TagPosBignum), value.Bytes())
+	}
+	// Отрицательное число -1-n записывается значением n
+	if n.Not(value); n.IsUint64() {
+		return appendHead(b, majorNegInt, n.Uint64())
+	}
+
+	return AppendBytes(AppendTag(b, TagNegBignum), n.Bytes())
+}
+
+// AppendValue Запись значения произвольного вида
+// Поддерживаются nil, логические значения, числа, *big.Int, строки, срезы байт, время, json.Number,
+// срезы []interface{} и карты map[string]interface{}, ключи карт записываются в порядке SortKeys
+func AppendValue(b []byte, value interface{}) (ret []byte, err error) {
+	var keys []string
+
+	switch v := value.(type) {
+	case nil:
+		ret = AppendNull(b)
+	case bool:
+		ret = AppendBool(b, v)
+	case int:
+		ret = AppendInt(b, int64(v))
+	case int8:
+		ret = AppendInt(b, int64(v))
+	case int16:
+		ret = AppendInt(b, int64(v))
+	case int32:
+		ret = AppendInt(b, int64(v))
+	case int64:
+		ret = AppendInt(b, v)
+	case uint:
+		ret = AppendUint(b, uint64(v))
+	case uint8:
+		ret = AppendUint(b, uint64(v))
+	case uint16:
+		ret = AppendUint(b, uint64(v))
+	case uint32:
+		ret = AppendUint(b, uint64(v))
+	case uint64:
+		ret = AppendUint(b, v)
+	case float32:
+		ret = AppendFloat32(b, v)
+	case float64:
+		ret = AppendFloat64(b, v)
+	case *big.Int:
+		ret = AppendBigInt(b, v)
+	case string:
+		ret = AppendString(b, v)
+	case []byte:
+		ret = AppendBytes(b, v)
+	case time.Time:
+		ret = AppendTime(b, v)
+	case json.Number:
+		ret, err = appendNumber(b, v)
+	case []interface{}:
+		ret = AppendArrayHeader(b, len(v))
+		for i := 0; i < len(v) && err == nil; i++ {
+			ret, err = AppendValue(ret, v[i])
+		}
+	case map[string]interface{}:
+		keys = make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		SortKeys(keys)
+		ret = AppendMapHeader(b, len(v))
+		for i := 0; i < len(keys) && err == nil; i++ {
+			ret, err = AppendValue(AppendString(ret, keys[i]), v[keys[i]])
+		}
+	default:
+		err = fmt.Errorf("cbor: can't append value of type %T", value)
+	}
+
+	return
+}
+
+// SortKeys Сортировка строковых ключей карты в порядке детерминированного кодирования RFC 8949 (раздел 4.2.1)
+// Закодированные ключи сравниваются побайтно, поэтому более короткие ключи предшествуют более длинным
+func SortKeys(keys []string) {
+	sort.Slice(keys, func(i int, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+}
+
+// Запись числа json.Number как целого числа, если это возможно, иначе как числа с плавающей точкой
+func appendNumber(b []byte, value json.Number) (ret []byte, err error) {
+	var (
+		i int64
+		u uint64
+		f float64
+	)
+
+	if i, err = value.Int64(); err == nil {
+		return AppendInt(b, i), nil
+	}
+	if u, err = strconv.ParseUint(string(value), 10, 64); err == nil {
+		return AppendUint(b, u), nil
+	}
+	if f, err = value.Float64(); err == nil {
+		ret = AppendFloat64(b, f)
+	}
+
+	return
+}
+
+// Запись начального байта основного типа major с аргументом n в наиболее компактном формате
+func appendHead(b []byte, major byte, n uint64) []byte {
+	switch {
+	case n < infoUint8:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|infoUint8, byte(n))
+	case n <= math.MaxUint16:
+		return append(b, major|infoUint16, byte(n>>8), byte(n))
+	case n <= math.MaxUint32:
+		return appendUint32(append(b, major|infoUint32), uint32(n))
+	default:
+		return appendUint64(append(b, major|infoUint64), n)
+	}
+}
+
+// Запись 32-х битного числа в порядке big-endian
+func appendUint32(b []byte, value uint32) []byte {
+	return append(b, byte(value>>24), byte(value>>16), byte(value>>8), byte(value))
+}
+
+// Запись 64-х битного числа в порядке big-endian
+func appendUint64(b []byte, value uint64) []byte {
+	return appendUint32(appendUint32(b, uint32(value>>32)), uint32(value))
+}
@@ -0,0 +1,118 @@
+package cbor // import "gopkg.in/webnice/lin.v1/cbor"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func hexEquals(t *testing.T, data []byte, expected string, from string) {
+	if hex.EncodeToString(data) != expected {
+		t.Errorf("Bad %s data: %x ≠ %s", from, data, expected)
+	}
+}
+
+// Примеры приложения A RFC 8949
+func TestAppendInt(t *testing.T) {
+	var tests = []struct {
+		Value int64
+		Hex   string
+	}{
+		{0, "00"},
+		{10, "0a"},
+		{23, "17"},
+		{24, "1818"},
+		{100, "1864"},
+		{1000, "1903e8"},
+		{1000000, "1a000f4240"},
+		{1000000000000, "1b000000e8d4a51000"},
+		{-1, "20"},
+		{-10, "29"},
+		{-100, "3863"},
+		{-1000, "3903e7"},
+		{math.MinInt64, "3b7fffffffffffffff"},
+	}
+
+	for _, test := range tests {
+		hexEquals(t, AppendInt(nil, test.Value), test.Hex, "AppendInt()")
+	}
+	hexEquals(t, AppendUint(nil, math.MaxUint64), "1bffffffffffffffff", "AppendUint()")
+}
+
+func TestAppendScalar(t *testing.T) {
+	hexEquals(t, AppendNull(nil), "f6", "AppendNull()")
+	hexEquals(t, AppendBool(nil, true), "f5", "AppendBool()")
+	hexEquals(t, AppendBool(nil, false), "f4", "AppendBool()")
+	hexEquals(t, AppendFloat32(nil, 100000), "fa47c35000", "AppendFloat32()")
+	hexEquals(t, AppendFloat64(nil, 1.1), "fb3ff199999999999a", "AppendFloat64()")
+	hexEquals(t, AppendString(nil, "IETF"), "6449455446", "AppendString()")
+	hexEquals(t, AppendString(nil, "ü"), "62c3bc", "AppendString()")
+	hexEquals(t, AppendString(nil, strings.Repeat("a", 24))[:2], "7818", "AppendString()")
+	hexEquals(t, AppendBytes(nil, []byte{1, 2, 3, 4}), "4401020304", "AppendBytes()")
+	hexEquals(t, AppendBytes(nil, make([]byte, 65536))[:5], "5a00010000", "AppendBytes()")
+	hexEquals(t, AppendArrayHeader(nil, 25), "9819", "AppendArrayHeader()")
+	hexEquals(t, AppendMapHeader(nil, 2), "a2", "AppendMapHeader()")
+	hexEquals(t, AppendTag(nil, TagUUID), "d825", "AppendTag()")
+}
+
+func TestAppendTime(t *testing.T) {
+	hexEquals(t, AppendTime(nil, time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)),
+		"c074323031332d30332d32315432303a30343a30305a", "AppendTime()")
+	hexEquals(t, AppendEpochTime(nil, time.Unix(1363896240, 0)), "c11a514b67b0", "AppendEpochTime()")
+	hexEquals(t, AppendEpochTime(nil, time.Unix(1363896240, 500000000)), "c1fb41d452d9ec200000", "AppendEpochTime()")
+}
+
+func TestAppendBigInt(t *testing.T) {
+	var tests = map[string]string{
+		"18446744073709551615":  "1bffffffffffffffff",
+		"18446744073709551616":  "c249010000000000000000",
+		"-18446744073709551616": "3bffffffffffffffff",
+		"-18446744073709551617": "c349010000000000000000",
+		"-1":                    "20",
+	}
+
+	for str, expected := range tests {
+		value, _ := new(big.Int).SetString(str, 10)
+		hexEquals(t, AppendBigInt(nil, value), expected, "AppendBigInt("+str+")")
+	}
+	hexEquals(t, AppendBigInt(nil, nil), "00", "AppendBigInt(nil)")
+}
+
+func TestAppendValue(t *testing.T) {
+	var (
+		value interface{}
+		dec   = json.NewDecoder(strings.NewReader(`{"bb":[1,-1.5,"x",null,true,18446744073709551615],"a":{}}`))
+	)
+
+	dec.UseNumber()
+	errorPanic(dec.Decode(&value))
+	data, err := AppendValue(nil, value)
+	errorPanic(err)
+	hexEquals(t, data, "a26161a0626262"+"8601fbbff80000000000006178f6f51bffffffffffffffff", "AppendValue()")
+	if _, err = AppendValue(nil, struct{}{}); err == nil {
+		t.Error("AppendValue()", "error is nil, but should be not nil")
+	}
+	if _, err = AppendValue(nil, json.Number("abc")); err == nil {
+		t.Error("AppendValue()", "error is nil, but should be not nil")
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	var keys = []string{"bb", "b", "aa", "a", ""}
+
+	if SortKeys(keys); strings.Join(keys, ",") != ",a,b,aa,bb" {
+		t.Errorf("SortKeys() is %q, but should be [ a b aa bb]", keys)
+	}
+}
+
+func errorPanic(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
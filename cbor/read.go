@@ -0,0 +1,604 @@
+package cbor // import "gopkg.in/webnice/lin.v1/cbor"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+	"unicode/utf8"
+)
+
+// Type Вид значения CBOR
+type Type byte
+
+// Виды значений CBOR
+const (
+	InvalidType Type = iota // Данные отсутствуют или начальный байт не допустим
+	NullType                // null
+	BoolType                // Логическое значение
+	UintType                // Неотрицательное целое число, основной тип 0
+	NegIntType              // Отрицательное целое число, основной тип 1
+	BytesType               // Строка байт, основной тип 2
+	StringType              // Текстовая строка, основной тип 3
+	ArrayType               // Массив, основной тип 4
+	MapType                 // Карта, основной тип 5
+	TagType                 // Значение с тегом, основной тип 6
+	FloatType               // Число с плавающей точкой половинной, одинарной или двойной точности
+	SimpleType              // Простое значение, отличное от логических значений и null, в том числе undefined
+)
+
+// Максимальная вложенность массивов, карт и тегов при чтении значения произвольного вида
+const maxDepth = 1000
+
+// ErrShortBytes Данные закончились до окончания значения
+var ErrShortBytes = io.ErrUnexpectedEOF
+
+// String Реализация интерфейса fmt.Stringer
+func (t Type) String() string {
+	switch t {
+	case NullType:
+		return "null"
+	case BoolType:
+		return "bool"
+	case UintType:
+		return "unsigned integer"
+	case NegIntType:
+		return "negative integer"
+	case BytesType:
+		return "byte string"
+	case StringType:
+		return "text string"
+	case ArrayType:
+		return "array"
+	case MapType:
+		return "map"
+	case TagType:
+		return "tag"
+	case FloatType:
+		return "float"
+	case SimpleType:
+		return "simple value"
+	default:
+		return "invalid"
+	}
+}
+
+// NextType Вид следующего значения
+func NextType(b []byte) Type {
+	if len(b) == 0 {
+		return InvalidType
+	}
+	switch b[0] & majorMask {
+	case majorUint:
+		return UintType
+	case majorNegInt:
+		return NegIntType
+	case majorBytes:
+		return BytesType
+	case majorString:
+		return StringType
+	case majorArray:
+		return ArrayType
+	case majorMap:
+		return MapType
+	case majorTag:
+		return TagType
+	}
+	switch b[0] {
+	case codeFalse, codeTrue:
+		return BoolType
+	case codeNull:
+		return NullType
+	case codeFloat16, codeFloat32, codeFloat64:
+		return FloatType
+	}
+	if b[0]&infoMask <= infoUint8 {
+		return SimpleType
+	}
+
+	return InvalidType
+}
+
+// IsNull Следующее значение является null
+func IsNull(b []byte) bool { return NextType(b) == NullType }
+
+// Ошибка несоответствия вида значения
+func typeError(want string, b []byte) error {
+	if len(b) == 0 {
+		return ErrShortBytes
+	}
+	return fmt.Errorf("cbor: can't read %s from %s (initial byte 0x%02x)", want, NextType(b), b[0])
+}
+
+// Чтение n байт
+func next(b []byte, n uint64) (data []byte, o []byte, err error) {
+	if uint64(len(b)) < n {
+		return nil, b, ErrShortBytes
+	}
+	return b[:n], b[n:], nil
+}
+
+// Чтение начального байта и аргумента значения основного типа major
+// Значения неопределённой длины и зарезервированные значения дополнительной информации не поддерживаются
+func readHead(b []byte, major byte, want string) (n uint64, o []byte, err error) {
+	var data []byte
+
+	switch {
+	case len(b) == 0:
+		return 0, b, ErrShortBytes
+	case b[0]&majorMask != major:
+		return 0, b, typeError(want, b)
+	}
+	switch info := b[0] & infoMask; {
+	case info < infoUint8:
+		n, o = uint64(info), b[1:]
+	case info == infoUint8:
+		if data, o, err = next(b[1:], 1); err == nil {
+			n = uint64(data[0])
+		}
+	case info == infoUint16:
+		if data, o, err = next(b[1:], 2); err == nil {
+			n = uint64(binary.BigEndian.Uint16(data))
+		}
+	case info == infoUint32:
+		if data, o, err = next(b[1:], 4); err == nil {
+			n = uint64(binary.BigEndian.Uint32(data))
+		}
+	case info == infoUint64:
+		if data, o, err = next(b[1:], 8); err == nil {
+			n = binary.BigEndian.Uint64(data)
+		}
+	case info == infoIndefinite:
+		err = fmt.Errorf("cbor: indefinite-length %s is not supported", want)
+	default:
+		err = fmt.Errorf("cbor: reserved additional information %d (initial byte 0x%02x)", info, b[0])
+	}
+	if err != nil {
+		return 0, b, err
+	}
+
+	return
+}
+
+// ReadNull Чтение значения null
+func ReadNull(b []byte) (o []byte, err error) {
+	if !IsNull(b) {
+		return b, typeError("null", b)
+	}
+	return b[1:], nil
+}
+
+// ReadBool Чтение логического значения
+func ReadBool(b []byte) (ret bool, o []byte, err error) {
+	if NextType(b) != BoolType {
+		return false, b, typeError("bool", b)
+	}
+	return b[0] == codeTrue, b[1:], nil
+}
+
+// Чтение целого числа основного типа 0 или 1, для отрицательного числа возвращается значение n числа -1-n
+func readInteger(b []byte, want string) (n uint64, negative bool, o []byte, err error) {
+	switch NextType(b) {
+	case UintType:
+		n, o, err = readHead(b, majorUint, want)
+	case NegIntType:
+		n, o, err = readHead(b, majorNegInt, want)
+		negative = true
+	default:
+		err, o = typeError(want, b), b
+	}
+
+	return
+}
+
+// ReadInt Чтение целого числа размером bits бит, с проверкой диапазона
+func ReadInt(b []byte, bits int) (ret int64, o []byte, err error) {
+	var (
+		n        uint64
+		negative bool
+	)
+
+	if n, negative, o, err = readInteger(b, "integer"); err != nil {
+		return
+	}
+	if n > 1<<(bits-1)-1 {
+		return 0, b, fmt.Errorf("cbor: value overflows %d-bit integer", bits)
+	}
+	if ret = int64(n); negative {
+		ret = ^ret
+	}
+
+	return
+}
+
+// ReadUint Чтение целого числа без знака размером bits бит, с проверкой диапазона
+func ReadUint(b []byte, bits int) (ret uint64, o []byte, err error) {
+	var negative bool
+
+	if ret, negative, o, err = readInteger(b, "unsigned integer"); err != nil {
+		return
+	}
+	if negative || bits < 64 && ret > 1<<bits-1 {
+		return 0, b, fmt.Errorf("cbor: value overflows %d-bit unsigned integer", bits)
+	}
+
+	return
+}
+
+// ReadFloat Чтение числа с плавающей точкой размером bits бит, с проверкой диапазона
+// Читаются числа половинной, одинарной и двойной точности, целые числа не читаются
+func ReadFloat(b []byte, bits int) (ret float64, o []byte, err error) {
+	var data []byte
+
+	switch {
+	case NextType(b) != FloatType:
+		return 0, b, typeError("float", b)
+	case b[0] == codeFloat16:
+		if data, o, err = next(b[1:], 2); err == nil {
+			ret = float16(binary.BigEndian.Uint16(data))
+		}
+	case b[0] == codeFloat32:
+		if data, o, err = next(b[1:], 4); err == nil {
+			ret = float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+		}
+	default:
+		if data, o, err = next(b[1:], 8); err == nil {
+			ret = math.Float64frombits(binary.BigEndian.Uint64(data))
+		}
+	}
+	switch {
+	case err != nil:
+		return 0, b, err
+	case bits == 32 && math.Abs(ret) > math.MaxFloat32 && !math.IsInf(ret, 0):
+		return 0, b, fmt.Errorf("cbor: value overflows %d-bit float", bits)
+	}
+
+	return
+}
+
+// Преобразование числа половинной точности IEEE 754
+func float16(h uint16) (ret float64) {
+	var (
+		exp  = int(h >> 10 & 0x1f)
+		mant = float64(h & 0x3ff)
+	)
+
+	switch exp {
+	case 0:
+		ret = math.Ldexp(mant, -24)
+	case 0x1f:
+		if ret = math.Inf(1); mant != 0 {
+			ret = math.NaN()
+		}
+	default:
+		ret = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		ret = -ret
+	}
+
+	return
+}
+
+// Чтение данных строки основного типа major
+// Строка неопределённой длины читается как последовательность частей определённой длины того же основного типа,
+// завершённая кодом break
+func readRaw(b []byte, major byte, want string) (data []byte, o []byte, err error) {
+	var (
+		n     uint64
+		chunk []byte
+	)
+
+	if len(b) > 0 && b[0] == major|infoIndefinite {
+		for data, o = []byte{}, b[1:]; err == nil && (len(o) == 0 || o[0] != codeBreak); {
+			if len(o) > 0 && o[0] == major|infoIndefinite {
+				err = fmt.Errorf("cbor: nested indefinite-length %s", want)
+			} else if chunk, o, err = readRaw(o, major, want); err == nil {
+				data = append(data, chunk...)
+			}
+		}
+		if err == nil {
+			o = o[1:]
+		}
+	} else if n, o, err = readHead(b, major, want); err == nil {
+		data, o, err = next(o, n)
+	}
+	if err != nil {
+		return nil, b, err
+	}
+
+	return
+}
+
+// ReadString Чтение текстовой строки, с проверкой корректности UTF-8
+func ReadString(b []byte) (ret string, o []byte, err error) {
+	var data []byte
+
+	if data, o, err = readRaw(b, majorString, "text string"); err != nil {
+		return
+	}
+	if !utf8.Valid(data) {
+		return "", b, fmt.Errorf("cbor: invalid UTF-8 in text string")
+	}
+	ret = string(data)
+
+	return
+}
+
+// ReadBytes Чтение копии строки байт
+func ReadBytes(b []byte) (ret []byte, o []byte, err error) {
+	var data []byte
+
+	if data, o, err = readRaw(b, majorBytes, "byte string"); err == nil {
+		ret = append(make([]byte, 0, len(data)), data...)
+	}
+
+	return
+}
+
+// Чтение заголовка массива или карты, size является минимальным размером элемента в байтах
+func readHeader(b []byte, major byte, want string, size uint64) (n int, o []byte, err error) {
+	var u uint64
+
+	if u, o, err = readHead(b, major, want); err != nil {
+		return
+	}
+	if u > uint64(len(o))/size {
+		return 0, b, ErrShortBytes
+	}
+	n = int(u)
+
+	return
+}
+
+// ReadArrayHeader Чтение заголовка массива, возвращается количество элементов
+// Массивы неопределённой длины не поддерживаются
+func ReadArrayHeader(b []byte) (n int, o []byte, err error) {
+	return readHeader(b, majorArray, "array", 1)
+}
+
+// ReadMapHeader Чтение заголовка карты, возвращается количество пар ключ-значение
+// Карты неопределённой длины не поддерживаются
+func ReadMapHeader(b []byte) (n int, o []byte, err error) {
+	return readHeader(b, majorMap, "map", 2)
+}
+
+// ReadTag Чтение номера тега, возвращаются данные значения с тегом
+func ReadTag(b []byte) (tag uint64, o []byte, err error) {
+	if tag, o, err = readHead(b, majorTag, "tag"); err == nil && len(o) == 0 {
+		return 0, b, ErrShortBytes
+	}
+
+	return
+}
+
+// ReadTime Чтение времени с тегом 0 или 1
+// Время с тегом 0 читается вместе с часовым поясом, время с тегом 1 возвращается в UTC
+func ReadTime(b []byte) (ret time.Time, o []byte, err error) {
+	var (
+		tag uint64
+		str string
+		i   int64
+		f   float64
+	)
+
+	if tag, o, err = ReadTag(b); err != nil {
+		return
+	}
+	switch {
+	case tag == TagDateTime:
+		if str, o, err = ReadString(o); err == nil {
+			ret, err = time.Parse(time.RFC3339Nano, str)
+		}
+	case tag == TagEpochTime && NextType(o) == FloatType:
+		if f, o, err = ReadFloat(o, 64); err == nil {
+			ret, err = epochTime(f)
+		}
+	case tag == TagEpochTime:
+		if i, o, err = ReadInt(o, 64); err == nil {
+			ret = time.Unix(i, 0).UTC()
+		}
+	default:
+		err = fmt.Errorf("cbor: can't read time from tag %d", tag)
+	}
+	if err != nil {
+		return time.Time{}, b, err
+	}
+
+	return
+}
+
+// Время из числа секунд с плавающей точкой от начала эпохи Unix
+func epochTime(f float64) (ret time.Time, err error) {
+	var sec, frac float64
+
+	if math.IsNaN(f) || math.Abs(f) >= 1<<63 {
+		err = fmt.Errorf("cbor: invalid epoch time %v", f)
+		return
+	}
+	sec, frac = math.Modf(f)
+	ret = time.Unix(int64(sec), int64(math.Round(frac*float64(time.Second)))).UTC()
+
+	return
+}
+
+// ReadBigInt Чтение большого целого числа из целого числа либо из строки байт с тегом 2 или 3
+func ReadBigInt(b []byte) (ret *big.Int, o []byte, err error) {
+	var (
+		n        uint64
+		negative bool
+		tag      uint64
+		data     []byte
+	)
+
+	switch NextType(b) {
+	case UintType, NegIntType:
+		if n, negative, o, err = readInteger(b, "integer"); err == nil {
+			ret = new(big.Int).SetUint64(n)
+		}
+	case TagType:
+		if tag, o, err = ReadTag(b); err == nil && tag != TagPosBignum && tag != TagNegBignum {
+			err = fmt.Errorf("cbor: can't read bignum from tag %d", tag)
+		}
+		if err == nil {
+			data, o, err = readRaw(o, majorBytes, "bignum")
+		}
+		ret, negative = new(big.Int).SetBytes(data), tag == TagNegBignum
+	default:
+		err = typeError("integer", b)
+	}
+	if err != nil {
+		return nil, b, err
+	}
+	// Отрицательное число -1-n хранится значением n
+	if negative {
+		ret.Not(ret)
+	}
+
+	return
+}
+
+// ReadValue Чтение значения произвольного вида
+// Возвращаются nil, bool, int64, uint64 для чисел больше math.MaxInt64, *big.Int для чисел вне диапазона int64
+// и значений с тегами 2 и 3, float64, string, []byte, time.Time для значений с тегами 0 и 1, []interface{}
+// и map[string]interface{}, ключи карт не являющиеся строкой форматируются fmt.Sprint.
+// Значения с остальными тегами читаются без тега
+func ReadValue(b []byte) (ret interface{}, o []byte, err error) {
+	return readValue(b, 0)
+}
+
+// Чтение значения произвольного вида с ограничением вложенности
+func readValue(b []byte, depth int) (ret interface{}, o []byte, err error) {
+	var (
+		n      int
+		u      uint64
+		tag    uint64
+		bi     *big.Int
+		key    interface{}
+		str    string
+		ok     bool
+		items  []interface{}
+		values map[string]interface{}
+	)
+
+	if depth > maxDepth {
+		return nil, b, fmt.Errorf("cbor: maximum nesting depth %d exceeded", maxDepth)
+	}
+	switch NextType(b) {
+	case NullType:
+		o, err = ReadNull(b)
+	case BoolType:
+		ret, o, err = ReadBool(b)
+	case UintType:
+		if u, o, err = readHead(b, majorUint, "integer"); err == nil {
+			if ret = u; u <= math.MaxInt64 {
+				ret = int64(u)
+			}
+		}
+	case NegIntType:
+		if bi, o, err = ReadBigInt(b); err == nil {
+			if ret = bi; bi.IsInt64() {
+				ret = bi.Int64()
+			}
+		}
+	case FloatType:
+		ret, o, err = ReadFloat(b, 64)
+	case StringType:
+		ret, o, err = ReadString(b)
+	case BytesType:
+		ret, o, err = ReadBytes(b)
+	case TagType:
+		if tag, o, err = ReadTag(b); err != nil {
+			break
+		}
+		switch tag {
+		case TagDateTime, TagEpochTime:
+			ret, o, err = ReadTime(b)
+		case TagPosBignum, TagNegBignum:
+			ret, o, err = ReadBigInt(b)
+		default:
+			ret, o, err = readValue(o, depth+1)
+		}
+	case ArrayType:
+		if n, o, err = ReadArrayHeader(b); err != nil {
+			break
+		}
+		items = make([]interface{}, n)
+		for j := 0; j < n && err == nil; j++ {
+			items[j], o, err = readValue(o, depth+1)
+		}
+		ret = items
+	case MapType:
+		if n, o, err = ReadMapHeader(b); err != nil {
+			break
+		}
+		values = make(map[string]interface{}, n)
+		for j := 0; j < n && err == nil; j++ {
+			if key, o, err = readValue(o, depth+1); err != nil {
+				break
+			}
+			if str, ok = key.(string); !ok {
+				str = fmt.Sprint(key)
+			}
+			values[str], o, err = readValue(o, depth+1)
+		}
+		ret = values
+	default:
+		err = typeError("value", b)
+	}
+	if err != nil {
+		return nil, b, err
+	}
+
+	return
+}
+
+// Skip Пропуск следующего значения, возвращаются данные после значения
+func Skip(b []byte) (o []byte, err error) {
+	return skip(b, 0)
+}
+
+// Пропуск значения с ограничением вложенности
+func skip(b []byte, depth int) (o []byte, err error) {
+	var n int
+
+	if depth > maxDepth {
+		return b, fmt.Errorf("cbor: maximum nesting depth %d exceeded", maxDepth)
+	}
+	switch NextType(b) {
+	case NullType, BoolType:
+		o = b[1:]
+	case UintType, NegIntType:
+		_, _, o, err = readInteger(b, "integer")
+	case FloatType:
+		_, o, err = ReadFloat(b, 64)
+	case StringType:
+		_, o, err = ReadString(b)
+	case BytesType:
+		_, o, err = readRaw(b, majorBytes, "byte string")
+	case SimpleType:
+		_, o, err = next(b[1:], uint64(b[0]&infoMask/infoUint8))
+	case TagType:
+		_, o, err = ReadTag(b)
+		n = 1
+	case ArrayType:
+		n, o, err = ReadArrayHeader(b)
+	case MapType:
+		n, o, err = ReadMapHeader(b)
+		n *= 2
+	default:
+		err = typeError("value", b)
+	}
+	for i := 0; i < n && err == nil; i++ {
+		o, err = skip(o, depth+1)
+	}
+	if err != nil {
+		return b, err
+	}
+
+	return
+}
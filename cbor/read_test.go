@@ -0,0 +1,249 @@
+package cbor // import "gopkg.in/webnice/lin.v1/cbor"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/hex"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func hexBytes(str string) []byte {
+	data, err := hex.DecodeString(str)
+	errorPanic(err)
+	return data
+}
+
+func TestNextType(t *testing.T) {
+	var tests = map[string]Type{
+		"":   InvalidType,
+		"fc": InvalidType,
+		"ff": InvalidType,
+		"f6": NullType,
+		"f7": SimpleType,
+		"f5": BoolType,
+		"17": UintType,
+		"1b": UintType,
+		"20": NegIntType,
+		"40": BytesType,
+		"60": StringType,
+		"80": ArrayType,
+		"a0": MapType,
+		"c1": TagType,
+		"f9": FloatType,
+		"fb": FloatType,
+		"f0": SimpleType,
+		"f8": SimpleType,
+	}
+
+	for str, typ := range tests {
+		if NextType(hexBytes(str)) != typ {
+			t.Errorf("NextType(%s) is %s, but should be %s", str, NextType(hexBytes(str)), typ)
+		}
+	}
+}
+
+func TestReadInt(t *testing.T) {
+	for _, value := range []int64{0, 1, 23, 24, 255, 256, 65535, 65536, math.MaxInt64, -1, -24, -25, -256, -257, math.MinInt64} {
+		i, o, err := ReadInt(AppendInt(nil, value), 64)
+		if err != nil || i != value || len(o) != 0 {
+			t.Errorf("ReadInt() is %d (%v), but should be %d", i, err, value)
+		}
+	}
+	for _, value := range []uint64{0, 200, math.MaxUint32, math.MaxUint64} {
+		u, o, err := ReadUint(AppendUint(nil, value), 64)
+		if err != nil || u != value || len(o) != 0 {
+			t.Errorf("ReadUint() is %d (%v), but should be %d", u, err, value)
+		}
+	}
+	if i, _, err := ReadInt(hexBytes("387f"), 8); err != nil || i != math.MinInt8 {
+		t.Errorf("ReadInt() is %d (%v), but should be %d", i, err, math.MinInt8)
+	}
+
+	var errors = []struct {
+		Hex  string
+		Bits int
+		Uint bool
+	}{
+		{"1880", 8, false},
+		{"3880", 8, false},
+		{"20", 8, true},
+		{"190100", 8, true},
+		{"1bffffffffffffffff", 64, false},
+		{"19", 16, false},
+		{"1c", 64, false},
+		{"1f", 64, false},
+		{"f93c00", 64, false},
+		{"60", 64, true},
+		{"", 64, true},
+	}
+	for _, test := range errors {
+		var err error
+		if test.Uint {
+			_, _, err = ReadUint(hexBytes(test.Hex), test.Bits)
+		} else {
+			_, _, err = ReadInt(hexBytes(test.Hex), test.Bits)
+		}
+		if err == nil {
+			t.Errorf("Read(%s) error is nil, but should be not nil", test.Hex)
+		}
+	}
+}
+
+// Примеры приложения A RFC 8949
+func TestReadFloat(t *testing.T) {
+	var tests = map[string]float64{
+		"f90000":             0,
+		"f93c00":             1,
+		"f93e00":             1.5,
+		"f97bff":             65504,
+		"f90001":             5.960464477539063e-8,
+		"f90400":             0.00006103515625,
+		"f9c400":             -4,
+		"f97c00":             math.Inf(1),
+		"f9fc00":             math.Inf(-1),
+		"fa47c35000":         100000,
+		"fb3ff199999999999a": 1.1,
+	}
+
+	for str, value := range tests {
+		if f, o, err := ReadFloat(hexBytes(str), 64); err != nil || f != value || len(o) != 0 {
+			t.Errorf("ReadFloat(%s) is %v (%v), but should be %v", str, f, err, value)
+		}
+	}
+	if f, _, err := ReadFloat(hexBytes("f97e00"), 64); err != nil || !math.IsNaN(f) {
+		t.Errorf("ReadFloat() is %v, but should be NaN", f)
+	}
+	if _, _, err := ReadFloat(AppendFloat64(nil, math.MaxFloat64), 32); err == nil {
+		t.Error("ReadFloat()", "error is nil, but should be not nil")
+	}
+	if _, _, err := ReadFloat(AppendInt(nil, 1), 64); err == nil {
+		t.Error("ReadFloat()", "of integer error is nil, but should be not nil")
+	}
+}
+
+func TestReadStringBytes(t *testing.T) {
+	var long = strings.Repeat("a", 70000)
+
+	if str, o, err := ReadString(AppendString(nil, long)); err != nil || str != long || len(o) != 0 {
+		t.Error("ReadString()", "is wrong")
+	}
+	data := AppendBytes(nil, []byte("abc"))
+	buf, _, err := ReadBytes(data)
+	if errorPanic(err); string(buf) != "abc" {
+		t.Error("ReadBytes()", "is wrong")
+	}
+	if buf[0] = 'x'; data[1] != 'a' {
+		t.Error("ReadBytes()", "doesn't copy value")
+	}
+	if _, _, err = ReadString(hexBytes("6361")); err != ErrShortBytes {
+		t.Errorf("ReadString() error is %v, but should be %v", err, ErrShortBytes)
+	}
+	if str, o, err := ReadString(hexBytes("7f62616260ff01")); err != nil || str != "ab" || len(o) != 1 {
+		t.Errorf("ReadString() of indefinite-length string is %q (%v), but should be \"ab\"", str, err)
+	}
+	if buf, o, err := ReadBytes(hexBytes("5f41014102ff")); err != nil || string(buf) != "\x01\x02" || len(o) != 0 {
+		t.Errorf("ReadBytes() of indefinite-length string is %x (%v), but should be 0102", buf, err)
+	}
+	if buf, _, err := ReadBytes(hexBytes("5fff")); err != nil || buf == nil || len(buf) != 0 {
+		t.Errorf("ReadBytes() of empty indefinite-length string is %v (%v), but should be empty", buf, err)
+	}
+	for _, str := range []string{"43616263", "62c328", "7f4161ff", "7f7f6161ffff", "7f6161", "7f61c3ff"} {
+		if _, _, err = ReadString(hexBytes(str)); err == nil {
+			t.Errorf("ReadString(%s) error is nil, but should be not nil", str)
+		}
+	}
+	if _, _, err = ReadBytes(hexBytes("63616263")); err == nil {
+		t.Error("ReadBytes()", "of text string error is nil, but should be not nil")
+	}
+}
+
+func TestReadTime(t *testing.T) {
+	var zone = time.FixedZone("", 3*60*60)
+
+	for _, value := range []time.Time{
+		time.Unix(0, 0).UTC(),
+		time.Date(2013, 3, 21, 20, 4, 0, 999999999, zone),
+		time.Date(1, 1, 1, 0, 0, 0, 1, time.UTC),
+	} {
+		tm, o, err := ReadTime(AppendTime(nil, value))
+		if err != nil || !tm.Equal(value) || tm.Format(time.RFC3339) != value.Format(time.RFC3339) || len(o) != 0 {
+			t.Errorf("ReadTime() is %v (%v), but should be %v", tm, err, value)
+		}
+	}
+	for _, value := range []time.Time{time.Unix(-1, 0), time.Unix(1363896240, 500000000)} {
+		tm, _, err := ReadTime(AppendEpochTime(nil, value))
+		if err != nil || !tm.Equal(value) || tm.Location() != time.UTC {
+			t.Errorf("ReadTime() is %v (%v), but should be %v", tm, err, value)
+		}
+	}
+	for _, str := range []string{"c1", "c01a514b67b0", "c16130", "c2f6", "c1f97e00", "c1fb7ff0000000000000", "c06130", "1a514b67b0"} {
+		if _, _, err := ReadTime(hexBytes(str)); err == nil {
+			t.Errorf("ReadTime(%s) error is nil, but should be not nil", str)
+		}
+	}
+}
+
+func TestReadBigInt(t *testing.T) {
+	for _, str := range []string{"0", "-1", "18446744073709551615", "18446744073709551616", "-18446744073709551616", "-18446744073709551617"} {
+		value, _ := new(big.Int).SetString(str, 10)
+		bi, o, err := ReadBigInt(AppendBigInt(nil, value))
+		if err != nil || bi.Cmp(value) != 0 || len(o) != 0 {
+			t.Errorf("ReadBigInt() is %v (%v), but should be %s", bi, err, str)
+		}
+	}
+	for _, str := range []string{"c1f6", "c26130", "f6", "c2"} {
+		if _, _, err := ReadBigInt(hexBytes(str)); err == nil {
+			t.Errorf("ReadBigInt(%s) error is nil, but should be not nil", str)
+		}
+	}
+}
+
+func TestReadValue(t *testing.T) {
+	data := hexBytes("a36161f66162822" + "0fb3ff8000000000000" + "01d8254101")
+	value, o, err := ReadValue(data)
+	errorPanic(err)
+	expected := map[string]interface{}{"a": nil, "b": []interface{}{int64(-1), 1.5}, "1": []byte{1}}
+	if !reflect.DeepEqual(value, expected) || len(o) != 0 {
+		t.Errorf("ReadValue() is %#v, but should be %#v", value, expected)
+	}
+	if o, err = Skip(append(data, codeTrue)); err != nil || len(o) != 1 {
+		t.Errorf("Skip() is %x (%v), but should be f5", o, err)
+	}
+	if value, o, err = ReadValue(hexBytes("a17f6161ff5f4101ff")); err != nil || len(o) != 0 ||
+		!reflect.DeepEqual(value, map[string]interface{}{"a": []byte{1}}) {
+		t.Errorf("ReadValue() of indefinite-length strings is %#v (%v), but should be {a: 01}", value, err)
+	}
+	if o, err = Skip(hexBytes("5f4101fff5")); err != nil || len(o) != 1 {
+		t.Errorf("Skip() of indefinite-length string is %x (%v), but should be f5", o, err)
+	}
+	if value, _, _ = ReadValue(AppendUint(nil, math.MaxUint64)); value != uint64(math.MaxUint64) {
+		t.Errorf("ReadValue() is %v, but should be uint64", value)
+	}
+	if value, _, _ = ReadValue(hexBytes("3bffffffffffffffff")); reflect.TypeOf(value) != reflect.TypeOf(&big.Int{}) {
+		t.Errorf("ReadValue() is %T, but should be *big.Int", value)
+	}
+	if value, _, _ = ReadValue(hexBytes("c11a514b67b0")); value != time.Unix(1363896240, 0).UTC() {
+		t.Errorf("ReadValue() is %v, but should be time", value)
+	}
+
+	deep := []byte(strings.Repeat("\x81", maxDepth+2) + "\xf6")
+	if _, _, err = ReadValue(deep); err == nil {
+		t.Error("ReadValue()", "error is nil, but should be not nil")
+	}
+	if _, err = Skip(deep); err == nil {
+		t.Error("Skip()", "error is nil, but should be not nil")
+	}
+	for _, str := range []string{"", "ff", "fc", "82f6", "9f", "bf", "5f", "9a7fffffff", "a16161", "d825", "f0", "6180"} {
+		if _, _, err = ReadValue(hexBytes(str)); err == nil {
+			t.Errorf("ReadValue(%s) error is nil, but should be not nil", str)
+		}
+		if _, err = Skip(hexBytes(str)); err == nil && str != "f0" {
+			t.Errorf("Skip(%s) error is nil, but should be not nil", str)
+		}
+	}
+}
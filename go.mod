@@ -1,3 +1,9 @@
 module gopkg.in/webnice/lin.v1
 
-go 1.13
+go 1.18
+
+require (
+	google.golang.org/protobuf v1.33.0
+	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
+)
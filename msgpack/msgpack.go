@@ -0,0 +1,287 @@
+// Package msgpack is a minimal MessagePack (https://msgpack.org) encoder and decoder without dependencies.
+// The package is used by nullable objects to implement MarshalMsgpack and UnmarshalMsgpack methods,
+// which are called by github.com/vmihailenco/msgpack and compatible libraries
+package msgpack // import "gopkg.in/webnice/lin.v1/msgpack"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Коды форматов MessagePack
+const (
+	codePosFixIntMax = 0x7f
+	codeFixMap       = 0x80
+	codeFixArray     = 0x90
+	codeFixStr       = 0xa0
+	codeNil          = 0xc0
+	codeFalse        = 0xc2
+	codeTrue         = 0xc3
+	codeBin8         = 0xc4
+	codeBin16        = 0xc5
+	codeBin32        = 0xc6
+	codeExt8         = 0xc7
+	codeExt16        = 0xc8
+	codeExt32        = 0xc9
+	codeFloat32      = 0xca
+	codeFloat64      = 0xcb
+	codeUint8        = 0xcc
+	codeUint16       = 0xcd
+	codeUint32       = 0xce
+	codeUint64       = 0xcf
+	codeInt8         = 0xd0
+	codeInt16        = 0xd1
+	codeInt32        = 0xd2
+	codeInt64        = 0xd3
+	codeFixExt1      = 0xd4
+	codeFixExt2      = 0xd5
+	codeFixExt4      = 0xd6
+	codeFixExt8      = 0xd7
+	codeFixExt16     = 0xd8
+	codeStr8         = 0xd9
+	codeStr16        = 0xda
+	codeStr32        = 0xdb
+	codeArray16      = 0xdc
+	codeArray32      = 0xdd
+	codeMap16        = 0xde
+	codeMap32        = 0xdf
+	codeNegFixIntMin = 0xe0
+)
+
+// TimestampExt Тип расширения timestamp
+const TimestampExt = -1
+
+// AppendNil Запись значения nil
+func AppendNil(b []byte) []byte { return append(b, codeNil) }
+
+// AppendBool Запись логического значения
+func AppendBool(b []byte, value bool) []byte {
+	if value {
+		return append(b, codeTrue)
+	}
+	return append(b, codeFalse)
+}
+
+// AppendInt Запись целого числа в наиболее компактном формате
+// Неотрицательные числа записываются в формате uint
+func AppendInt(b []byte, value int64) []byte {
+	switch {
+	case value >= 0:
+		return AppendUint(b, uint64(value))
+	case value >= -32:
+		return append(b, byte(value))
+	case value >= math.MinInt8:
+		return append(b, codeInt8, byte(value))
+	case value >= math.MinInt16:
+		return append(b, codeInt16, byte(value>>8), byte(value))
+	case value >= math.MinInt32:
+		return appendUint32(append(b, codeInt32), uint32(value))
+	default:
+		return appendUint64(append(b, codeInt64), uint64(value))
+	}
+}
+
+// AppendUint Запись целого числа без знака в наиболее компактном формате
+func AppendUint(b []byte, value uint64) []byte {
+	switch {
+	case value <= codePosFixIntMax:
+		return append(b, byte(value))
+	case value <= math.MaxUint8:
+		return append(b, codeUint8, byte(value))
+	case value <= math.MaxUint16:
+		return append(b, codeUint16, byte(value>>8), byte(value))
+	case value <= math.MaxUint32:
+		return appendUint32(append(b, codeUint32), uint32(value))
+	default:
+		return appendUint64(append(b, codeUint64), value)
+	}
+}
+
+// AppendFloat32 Запись числа с плавающей точкой одинарной точности
+func AppendFloat32(b []byte, value float32) []byte {
+	return appendUint32(append(b, codeFloat32), math.Float32bits(value))
+}
+
+// AppendFloat64 Запись числа с плавающей точкой двойной точности
+func AppendFloat64(b []byte, value float64) []byte {
+	return appendUint64(append(b, codeFloat64), math.Float64bits(value))
+}
+
+// AppendString Запись строки
+func AppendString(b []byte, value string) []byte {
+	return append(appendHeader(b, len(value), codeFixStr, 31, codeStr8, codeStr16, codeStr32), value...)
+}
+
+// AppendBytes Запись среза байт в формате bin
+func AppendBytes(b []byte, value []byte) []byte {
+	return append(appendHeader(b, len(value), 0, -1, codeBin8, codeBin16, codeBin32), value...)
+}
+
+// AppendArrayHeader Запись заголовка массива из n элементов
+func AppendArrayHeader(b []byte, n int) []byte {
+	return appendHeader(b, n, codeFixArray, 15, 0, codeArray16, codeArray32)
+}
+
+// AppendMapHeader Запись заголовка карты из n пар ключ-значение
+func AppendMapHeader(b []byte, n int) []byte {
+	return appendHeader(b, n, codeFixMap, 15, 0, codeMap16, codeMap32)
+}
+
+// AppendExt Запись значения расширения
+func AppendExt(b []byte, typ int8, data []byte) []byte {
+	switch len(data) {
+	case 1:
+		b = append(b, codeFixExt1)
+	case 2:
+		b = append(b, codeFixExt2)
+	case 4:
+		b = append(b, codeFixExt4)
+	case 8:
+		b = append(b, codeFixExt8)
+	case 16:
+		b = append(b, codeFixExt16)
+	default:
+		b = appendHeader(b, len(data), 0, -1, codeExt8, codeExt16, codeExt32)
+	}
+
+	return append(append(b, byte(typ)), data...)
+}
+
+// AppendTime Запись времени в формате расширения timestamp в наиболее компактном виде
+// Часовой пояс не сохраняется
+func AppendTime(b []byte, value time.Time) []byte {
+	var (
+		sec  = value.Unix()
+		nsec = int64(value.Nanosecond())
+		data []byte
+	)
+
+	switch {
+	case sec>>34 != 0:
+		data = appendUint32(make([]byte, 0, 12), uint32(nsec))
+		data = appendUint64(data, uint64(sec))
+	case nsec == 0 && sec <= math.MaxUint32:
+		data = appendUint32(make([]byte, 0, 4), uint32(sec))
+	default:
+		data = appendUint64(make([]byte, 0, 8), uint64(nsec<<34|sec))
+	}
+
+	return AppendExt(b, TimestampExt, data)
+}
+
+// AppendValue Запись значения произвольного вида
+// Поддерживаются nil, логические значения, числа, строки, срезы байт, время, json.Number,
+// срезы []interface{} и карты map[string]interface{}, ключи карт записываются в порядке сортировки
+func AppendValue(b []byte, value interface{}) (ret []byte, err error) {
+	var keys []string
+
+	switch v := value.(type) {
+	case nil:
+		ret = AppendNil(b)
+	case bool:
+		ret = AppendBool(b, v)
+	case int:
+		ret = AppendInt(b, int64(v))
+	case int8:
+		ret = AppendInt(b, int64(v))
+	case int16:
+		ret = AppendInt(b, int64(v))
+	case int32:
+		ret = AppendInt(b, int64(v))
+	case int64:
+		ret = AppendInt(b, v)
+	case uint:
+		ret = AppendUint(b, uint64(v))
+	case uint8:
+		ret = AppendUint(b, uint64(v))
+	case uint16:
+		ret = AppendUint(b, uint64(v))
+	case uint32:
+		ret = AppendUint(b, uint64(v))
+	case uint64:
+		ret = AppendUint(b, v)
+	case float32:
+		ret = AppendFloat32(b, v)
+	case float64:
+		ret = AppendFloat64(b, v)
+	case string:
+		ret = AppendString(b, v)
+	case []byte:
+		ret = AppendBytes(b, v)
+	case time.Time:
+		ret = AppendTime(b, v)
+	case json.Number:
+		ret, err = appendNumber(b, v)
+	case []interface{}:
+		ret = AppendArrayHeader(b, len(v))
+		for i := 0; i < len(v) && err == nil; i++ {
+			ret, err = AppendValue(ret, v[i])
+		}
+	case map[string]interface{}:
+		keys = make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		ret = AppendMapHeader(b, len(v))
+		for i := 0; i < len(keys) && err == nil; i++ {
+			ret, err = AppendValue(AppendString(ret, keys[i]), v[keys[i]])
+		}
+	default:
+		err = fmt.Errorf("msgpack: can't append value of type %T", value)
+	}
+
+	return
+}
+
+// Запись числа json.Number как целого числа, если это возможно, иначе как числа с плавающей точкой
+func appendNumber(b []byte, value json.Number) (ret []byte, err error) {
+	var (
+		i int64
+		u uint64
+		f float64
+	)
+
+	if i, err = value.Int64(); err == nil {
+		return AppendInt(b, i), nil
+	}
+	if u, err = strconv.ParseUint(string(value), 10, 64); err == nil {
+		return AppendUint(b, u), nil
+	}
+	if f, err = value.Float64(); err == nil {
+		ret = AppendFloat64(b, f)
+	}
+
+	return
+}
+
+// Запись заголовка с длиной в наиболее компактном формате
+// Формат fix используется при длине не более fixMax, формат с 8-ми битной длиной, если код формата не равен нулю
+func appendHeader(b []byte, n int, fix byte, fixMax int, code8 byte, code16 byte, code32 byte) []byte {
+	switch {
+	case n <= fixMax:
+		return append(b, fix|byte(n))
+	case code8 != 0 && n <= math.MaxUint8:
+		return append(b, code8, byte(n))
+	case n <= math.MaxUint16:
+		return append(b, code16, byte(n>>8), byte(n))
+	default:
+		return appendUint32(append(b, code32), uint32(n))
+	}
+}
+
+// Запись 32-х битного числа в порядке big-endian
+func appendUint32(b []byte, value uint32) []byte {
+	return append(b, byte(value>>24), byte(value>>16), byte(value>>8), byte(value))
+}
+
+// Запись 64-х битного числа в порядке big-endian
+func appendUint64(b []byte, value uint64) []byte {
+	return appendUint32(appendUint32(b, uint32(value>>32)), uint32(value))
+}
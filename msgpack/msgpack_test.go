@@ -0,0 +1,92 @@
+package msgpack // import "gopkg.in/webnice/lin.v1/msgpack"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func hexEquals(t *testing.T, data []byte, expected string, from string) {
+	if hex.EncodeToString(data) != expected {
+		t.Errorf("Bad %s data: %x ≠ %s", from, data, expected)
+	}
+}
+
+func TestAppendInt(t *testing.T) {
+	var tests = []struct {
+		Value int64
+		Hex   string
+	}{
+		{0, "00"},
+		{127, "7f"},
+		{128, "cc80"},
+		{256, "cd0100"},
+		{65536, "ce00010000"},
+		{math.MaxInt64, "cf7fffffffffffffff"},
+		{-1, "ff"},
+		{-32, "e0"},
+		{-33, "d0df"},
+		{-129, "d1ff7f"},
+		{-32769, "d2ffff7fff"},
+		{math.MinInt64, "d38000000000000000"},
+	}
+
+	for _, test := range tests {
+		hexEquals(t, AppendInt(nil, test.Value), test.Hex, "AppendInt()")
+	}
+	hexEquals(t, AppendUint(nil, math.MaxUint64), "cfffffffffffffffff", "AppendUint()")
+}
+
+func TestAppendScalar(t *testing.T) {
+	hexEquals(t, AppendNil(nil), "c0", "AppendNil()")
+	hexEquals(t, AppendBool(nil, true), "c3", "AppendBool()")
+	hexEquals(t, AppendBool(nil, false), "c2", "AppendBool()")
+	hexEquals(t, AppendFloat32(nil, 1.5), "ca3fc00000", "AppendFloat32()")
+	hexEquals(t, AppendFloat64(nil, 1.5), "cb3ff8000000000000", "AppendFloat64()")
+	hexEquals(t, AppendString(nil, "abc"), "a3616263", "AppendString()")
+	hexEquals(t, AppendString(nil, strings.Repeat("a", 32))[:2], "d920", "AppendString()")
+	hexEquals(t, AppendString(nil, strings.Repeat("a", 256))[:3], "da0100", "AppendString()")
+	hexEquals(t, AppendBytes(nil, []byte{1, 2}), "c4020102", "AppendBytes()")
+	hexEquals(t, AppendBytes(nil, make([]byte, 65536))[:5], "c600010000", "AppendBytes()")
+	hexEquals(t, AppendArrayHeader(nil, 15), "9f", "AppendArrayHeader()")
+	hexEquals(t, AppendArrayHeader(nil, 16), "dc0010", "AppendArrayHeader()")
+	hexEquals(t, AppendMapHeader(nil, 1), "81", "AppendMapHeader()")
+	hexEquals(t, AppendMapHeader(nil, 65536), "df00010000", "AppendMapHeader()")
+	hexEquals(t, AppendExt(nil, 5, []byte{1, 2, 3}), "c70305010203", "AppendExt()")
+}
+
+func TestAppendTime(t *testing.T) {
+	hexEquals(t, AppendTime(nil, time.Unix(1, 0)), "d6ff00000001", "AppendTime(32)")
+	hexEquals(t, AppendTime(nil, time.Unix(1, 1)), "d7ff0000000400000001", "AppendTime(64)")
+	hexEquals(t, AppendTime(nil, time.Unix(-1, 0)), "c70cff00000000ffffffffffffffff", "AppendTime(96)")
+}
+
+func TestAppendValue(t *testing.T) {
+	var (
+		value interface{}
+		dec   = json.NewDecoder(strings.NewReader(`{"b":[1,-1.5,"x",null,true,18446744073709551615],"a":{}}`))
+	)
+
+	dec.UseNumber()
+	errorPanic(dec.Decode(&value))
+	data, err := AppendValue(nil, value)
+	errorPanic(err)
+	hexEquals(t, data, "82a16180a1629601cbbff8000000000000a178c0c3cfffffffffffffffff", "AppendValue()")
+	if _, err = AppendValue(nil, struct{}{}); err == nil {
+		t.Error("AppendValue()", "error is nil, but should be not nil")
+	}
+	if _, err = AppendValue(nil, json.Number("abc")); err == nil {
+		t.Error("AppendValue()", "error is nil, but should be not nil")
+	}
+}
+
+func errorPanic(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
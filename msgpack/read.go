@@ -0,0 +1,560 @@
+package msgpack // import "gopkg.in/webnice/lin.v1/msgpack"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Type Вид значения MessagePack
+type Type byte
+
+// Виды значений MessagePack
+const (
+	InvalidType Type = iota // Данные отсутствуют или код формата не допустим
+	NilType                 // nil
+	BoolType                // Логическое значение
+	IntType                 // Отрицательное целое число или целое число в формате int
+	UintType                // Неотрицательное целое число в формате uint
+	Float32Type             // Число с плавающей точкой одинарной точности
+	Float64Type             // Число с плавающей точкой двойной точности
+	StrType                 // Строка
+	BinType                 // Срез байт
+	ArrayType               // Массив
+	MapType                 // Карта
+	ExtType                 // Расширение
+)
+
+// Максимальная вложенность массивов и карт при чтении значения произвольного вида
+const maxDepth = 1000
+
+// ErrShortBytes Данные закончились до окончания значения
+var ErrShortBytes = io.ErrUnexpectedEOF
+
+// String Реализация интерфейса fmt.Stringer
+func (t Type) String() string {
+	switch t {
+	case NilType:
+		return "nil"
+	case BoolType:
+		return "bool"
+	case IntType:
+		return "int"
+	case UintType:
+		return "uint"
+	case Float32Type:
+		return "float32"
+	case Float64Type:
+		return "float64"
+	case StrType:
+		return "str"
+	case BinType:
+		return "bin"
+	case ArrayType:
+		return "array"
+	case MapType:
+		return "map"
+	case ExtType:
+		return "ext"
+	default:
+		return "invalid"
+	}
+}
+
+// NextType Вид следующего значения
+func NextType(b []byte) Type {
+	if len(b) == 0 {
+		return InvalidType
+	}
+	switch c := b[0]; {
+	case c <= codePosFixIntMax:
+		return UintType
+	case c < codeFixArray:
+		return MapType
+	case c < codeFixStr:
+		return ArrayType
+	case c < codeNil:
+		return StrType
+	case c >= codeNegFixIntMin:
+		return IntType
+	}
+	switch b[0] {
+	case codeNil:
+		return NilType
+	case codeFalse, codeTrue:
+		return BoolType
+	case codeBin8, codeBin16, codeBin32:
+		return BinType
+	case codeExt8, codeExt16, codeExt32, codeFixExt1, codeFixExt2, codeFixExt4, codeFixExt8, codeFixExt16:
+		return ExtType
+	case codeFloat32:
+		return Float32Type
+	case codeFloat64:
+		return Float64Type
+	case codeUint8, codeUint16, codeUint32, codeUint64:
+		return UintType
+	case codeInt8, codeInt16, codeInt32, codeInt64:
+		return IntType
+	case codeStr8, codeStr16, codeStr32:
+		return StrType
+	case codeArray16, codeArray32:
+		return ArrayType
+	case codeMap16, codeMap32:
+		return MapType
+	default:
+		return InvalidType
+	}
+}
+
+// IsNil Следующее значение является nil
+func IsNil(b []byte) bool { return len(b) > 0 && b[0] == codeNil }
+
+// Ошибка несоответствия вида значения
+func typeError(want string, b []byte) error {
+	if len(b) == 0 {
+		return ErrShortBytes
+	}
+	return fmt.Errorf("msgpack: can't read %s from %s (code 0x%02x)", want, NextType(b), b[0])
+}
+
+// Чтение n байт
+func next(b []byte, n int) (data []byte, o []byte, err error) {
+	if n < 0 || len(b) < n {
+		return nil, b, ErrShortBytes
+	}
+	return b[:n], b[n:], nil
+}
+
+// Чтение длины размером size байт
+func readLength(b []byte, size int) (n int, o []byte, err error) {
+	var data []byte
+
+	if data, o, err = next(b, size); err != nil {
+		return
+	}
+	switch size {
+	case 1:
+		n = int(data[0])
+	case 2:
+		n = int(binary.BigEndian.Uint16(data))
+	default:
+		n = int(binary.BigEndian.Uint32(data))
+	}
+
+	return
+}
+
+// ReadNil Чтение значения nil
+func ReadNil(b []byte) (o []byte, err error) {
+	if !IsNil(b) {
+		return b, typeError("nil", b)
+	}
+	return b[1:], nil
+}
+
+// ReadBool Чтение логического значения
+func ReadBool(b []byte) (ret bool, o []byte, err error) {
+	if NextType(b) != BoolType {
+		return false, b, typeError("bool", b)
+	}
+	return b[0] == codeTrue, b[1:], nil
+}
+
+// Чтение целого числа любого формата, unsigned является истиной для чисел больше math.MaxInt64
+func readInteger(b []byte, want string) (i int64, u uint64, unsigned bool, o []byte, err error) {
+	var data []byte
+
+	switch c := b; {
+	case len(c) == 0:
+		err = ErrShortBytes
+	case c[0] <= codePosFixIntMax:
+		i, o = int64(c[0]), c[1:]
+	case c[0] >= codeNegFixIntMin:
+		i, o = int64(int8(c[0])), c[1:]
+	case c[0] == codeUint8 || c[0] == codeInt8:
+		if data, o, err = next(c[1:], 1); err == nil {
+			if i = int64(int8(data[0])); c[0] == codeUint8 {
+				i = int64(data[0])
+			}
+		}
+	case c[0] == codeUint16 || c[0] == codeInt16:
+		if data, o, err = next(c[1:], 2); err == nil {
+			if i = int64(int16(binary.BigEndian.Uint16(data))); c[0] == codeUint16 {
+				i = int64(binary.BigEndian.Uint16(data))
+			}
+		}
+	case c[0] == codeUint32 || c[0] == codeInt32:
+		if data, o, err = next(c[1:], 4); err == nil {
+			if i = int64(int32(binary.BigEndian.Uint32(data))); c[0] == codeUint32 {
+				i = int64(binary.BigEndian.Uint32(data))
+			}
+		}
+	case c[0] == codeUint64 || c[0] == codeInt64:
+		if data, o, err = next(c[1:], 8); err == nil {
+			u = binary.BigEndian.Uint64(data)
+			i, unsigned = int64(u), c[0] == codeUint64 && u > math.MaxInt64
+		}
+	default:
+		err = typeError(want, b)
+	}
+	if err != nil {
+		o = b
+	}
+
+	return
+}
+
+// ReadInt Чтение целого числа размером bits бит, с проверкой диапазона
+// Читаются числа в форматах int и uint
+func ReadInt(b []byte, bits int) (ret int64, o []byte, err error) {
+	var unsigned bool
+
+	if ret, _, unsigned, o, err = readInteger(b, "int"); err != nil {
+		return
+	}
+	if unsigned || bits < 64 && (ret < -1<<(bits-1) || ret > 1<<(bits-1)-1) {
+		return 0, b, fmt.Errorf("msgpack: value overflows %d-bit integer", bits)
+	}
+
+	return
+}
+
+// ReadUint Чтение целого числа без знака размером bits бит, с проверкой диапазона
+// Читаются числа в форматах int и uint
+func ReadUint(b []byte, bits int) (ret uint64, o []byte, err error) {
+	var (
+		i        int64
+		unsigned bool
+	)
+
+	if i, ret, unsigned, o, err = readInteger(b, "uint"); err != nil {
+		return
+	}
+	if !unsigned {
+		if i < 0 || bits < 64 && uint64(i) > 1<<bits-1 {
+			return 0, b, fmt.Errorf("msgpack: value overflows %d-bit unsigned integer", bits)
+		}
+		ret = uint64(i)
+	}
+
+	return
+}
+
+// ReadFloat Чтение числа с плавающей точкой размером bits бит, с проверкой диапазона
+// Читаются числа с плавающей точкой и целые числа
+func ReadFloat(b []byte, bits int) (ret float64, o []byte, err error) {
+	var (
+		data     []byte
+		i        int64
+		u        uint64
+		unsigned bool
+	)
+
+	switch NextType(b) {
+	case Float32Type:
+		if data, o, err = next(b[1:], 4); err == nil {
+			ret = float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+		}
+	case Float64Type:
+		if data, o, err = next(b[1:], 8); err == nil {
+			ret = math.Float64frombits(binary.BigEndian.Uint64(data))
+		}
+	case IntType, UintType:
+		i, u, unsigned, o, err = readInteger(b, "float")
+		if ret = float64(i); unsigned {
+			ret = float64(u)
+		}
+	default:
+		err = typeError("float", b)
+	}
+	switch {
+	case err != nil:
+		return 0, b, err
+	case bits == 32 && math.Abs(ret) > math.MaxFloat32 && !math.IsInf(ret, 0):
+		return 0, b, fmt.Errorf("msgpack: value overflows %d-bit float", bits)
+	}
+
+	return
+}
+
+// Чтение данных строки или среза байт
+func readRaw(b []byte, want string) (data []byte, o []byte, err error) {
+	var n int
+
+	switch c := b; {
+	case len(c) == 0:
+		err = ErrShortBytes
+	case c[0] >= codeFixStr && c[0] < codeNil:
+		n, o = int(c[0]&0x1f), c[1:]
+	case c[0] == codeStr8 || c[0] == codeBin8:
+		n, o, err = readLength(c[1:], 1)
+	case c[0] == codeStr16 || c[0] == codeBin16:
+		n, o, err = readLength(c[1:], 2)
+	case c[0] == codeStr32 || c[0] == codeBin32:
+		n, o, err = readLength(c[1:], 4)
+	default:
+		err = typeError(want, b)
+	}
+	if err == nil {
+		data, o, err = next(o, n)
+	}
+	if err != nil {
+		return nil, b, err
+	}
+
+	return
+}
+
+// ReadString Чтение строки, читаются значения в форматах str и bin
+func ReadString(b []byte) (ret string, o []byte, err error) {
+	var data []byte
+
+	if data, o, err = readRaw(b, "str"); err == nil {
+		ret = string(data)
+	}
+
+	return
+}
+
+// ReadBytes Чтение копии среза байт, читаются значения в форматах bin и str
+func ReadBytes(b []byte) (ret []byte, o []byte, err error) {
+	var data []byte
+
+	if data, o, err = readRaw(b, "bin"); err == nil {
+		ret = append(make([]byte, 0, len(data)), data...)
+	}
+
+	return
+}
+
+// Чтение заголовка массива или карты
+func readHeader(b []byte, want string, fix byte, code16 byte, code32 byte) (n int, o []byte, err error) {
+	switch c := b; {
+	case len(c) == 0:
+		err = ErrShortBytes
+	case c[0]&0xf0 == fix:
+		n, o = int(c[0]&0x0f), c[1:]
+	case c[0] == code16:
+		n, o, err = readLength(c[1:], 2)
+	case c[0] == code32:
+		n, o, err = readLength(c[1:], 4)
+	default:
+		err = typeError(want, b)
+	}
+	// Каждый элемент занимает не менее одного байта
+	if err == nil && n > len(o) {
+		err = ErrShortBytes
+	}
+	if err != nil {
+		return 0, b, err
+	}
+
+	return
+}
+
+// ReadArrayHeader Чтение заголовка массива, возвращается количество элементов
+func ReadArrayHeader(b []byte) (n int, o []byte, err error) {
+	return readHeader(b, "array", codeFixArray, codeArray16, codeArray32)
+}
+
+// ReadMapHeader Чтение заголовка карты, возвращается количество пар ключ-значение
+func ReadMapHeader(b []byte) (n int, o []byte, err error) {
+	return readHeader(b, "map", codeFixMap, codeMap16, codeMap32)
+}
+
+// ReadExt Чтение значения расширения
+func ReadExt(b []byte) (typ int8, data []byte, o []byte, err error) {
+	var n int
+
+	switch c := b; {
+	case len(c) == 0:
+		err = ErrShortBytes
+	case c[0] >= codeFixExt1 && c[0] <= codeFixExt16:
+		n, o = 1<<(c[0]-codeFixExt1), c[1:]
+	case c[0] == codeExt8:
+		n, o, err = readLength(c[1:], 1)
+	case c[0] == codeExt16:
+		n, o, err = readLength(c[1:], 2)
+	case c[0] == codeExt32:
+		n, o, err = readLength(c[1:], 4)
+	default:
+		err = typeError("ext", b)
+	}
+	if err == nil && len(o) == 0 {
+		err = ErrShortBytes
+	}
+	if err == nil {
+		typ = int8(o[0])
+		data, o, err = next(o[1:], n)
+	}
+	if err != nil {
+		return 0, nil, b, err
+	}
+
+	return
+}
+
+// ReadTime Чтение времени в формате расширения timestamp, время возвращается в UTC
+func ReadTime(b []byte) (ret time.Time, o []byte, err error) {
+	var (
+		typ       int8
+		data      []byte
+		u         uint64
+		sec, nsec int64
+	)
+
+	if typ, data, o, err = ReadExt(b); err != nil {
+		return
+	}
+	switch {
+	case typ != TimestampExt:
+		err = fmt.Errorf("msgpack: can't read timestamp from ext type %d", typ)
+	case len(data) == 4:
+		ret = time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC()
+	case len(data) == 8:
+		u = binary.BigEndian.Uint64(data)
+		sec, nsec = int64(u&(1<<34-1)), int64(u>>34)
+	case len(data) == 12:
+		sec, nsec = int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data))
+	default:
+		err = fmt.Errorf("msgpack: invalid timestamp length %d", len(data))
+	}
+	switch {
+	case err != nil:
+		return time.Time{}, b, err
+	case nsec >= int64(time.Second):
+		return time.Time{}, b, fmt.Errorf("msgpack: invalid timestamp nanoseconds %d", nsec)
+	case len(data) > 4:
+		ret = time.Unix(sec, nsec).UTC()
+	}
+
+	return
+}
+
+// ReadValue Чтение значения произвольного вида
+// Возвращаются nil, bool, int64, uint64 для чисел больше math.MaxInt64, float32, float64, string, []byte,
+// time.Time, []interface{} и map[string]interface{}, ключи карт не являющиеся строкой форматируются fmt.Sprint
+func ReadValue(b []byte) (ret interface{}, o []byte, err error) {
+	return readValue(b, 0)
+}
+
+// Чтение значения произвольного вида с ограничением вложенности
+func readValue(b []byte, depth int) (ret interface{}, o []byte, err error) {
+	var (
+		i        int64
+		u        uint64
+		f        float64
+		n        int
+		unsigned bool
+		key      interface{}
+		str      string
+		ok       bool
+		items    []interface{}
+		values   map[string]interface{}
+	)
+
+	if depth > maxDepth {
+		return nil, b, fmt.Errorf("msgpack: maximum nesting depth %d exceeded", maxDepth)
+	}
+	switch NextType(b) {
+	case NilType:
+		o, err = ReadNil(b)
+	case BoolType:
+		ret, o, err = ReadBool(b)
+	case IntType, UintType:
+		i, u, unsigned, o, err = readInteger(b, "int")
+		if ret = i; unsigned {
+			ret = u
+		}
+	case Float32Type:
+		if f, o, err = ReadFloat(b, 32); err == nil {
+			ret = float32(f)
+		}
+	case Float64Type:
+		ret, o, err = ReadFloat(b, 64)
+	case StrType:
+		ret, o, err = ReadString(b)
+	case BinType:
+		ret, o, err = ReadBytes(b)
+	case ExtType:
+		ret, o, err = ReadTime(b)
+	case ArrayType:
+		if n, o, err = ReadArrayHeader(b); err != nil {
+			return
+		}
+		items = make([]interface{}, n)
+		for j := 0; j < n && err == nil; j++ {
+			items[j], o, err = readValue(o, depth+1)
+		}
+		ret = items
+	case MapType:
+		if n, o, err = ReadMapHeader(b); err != nil {
+			return
+		}
+		values = make(map[string]interface{}, n)
+		for j := 0; j < n && err == nil; j++ {
+			if key, o, err = readValue(o, depth+1); err != nil {
+				break
+			}
+			if str, ok = key.(string); !ok {
+				str = fmt.Sprint(key)
+			}
+			values[str], o, err = readValue(o, depth+1)
+		}
+		ret = values
+	default:
+		err = typeError("value", b)
+	}
+	if err != nil {
+		return nil, b, err
+	}
+
+	return
+}
+
+// Skip Пропуск следующего значения, возвращаются данные после значения
+func Skip(b []byte) (o []byte, err error) {
+	return skip(b, 0)
+}
+
+// Пропуск значения с ограничением вложенности
+func skip(b []byte, depth int) (o []byte, err error) {
+	var n int
+
+	if depth > maxDepth {
+		return b, fmt.Errorf("msgpack: maximum nesting depth %d exceeded", maxDepth)
+	}
+	switch NextType(b) {
+	case NilType, BoolType:
+		o = b[1:]
+	case IntType, UintType:
+		_, _, _, o, err = readInteger(b, "int")
+	case Float32Type:
+		_, o, err = next(b[1:], 4)
+	case Float64Type:
+		_, o, err = next(b[1:], 8)
+	case StrType, BinType:
+		_, o, err = readRaw(b, "str")
+	case ExtType:
+		_, _, o, err = ReadExt(b)
+	case ArrayType:
+		n, o, err = ReadArrayHeader(b)
+	case MapType:
+		n, o, err = ReadMapHeader(b)
+		n *= 2
+	default:
+		err = typeError("value", b)
+	}
+	for i := 0; i < n && err == nil; i++ {
+		o, err = skip(o, depth+1)
+	}
+	if err != nil {
+		return b, err
+	}
+
+	return
+}
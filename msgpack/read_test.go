@@ -0,0 +1,182 @@
+package msgpack // import "gopkg.in/webnice/lin.v1/msgpack"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/hex"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func hexBytes(str string) []byte {
+	data, err := hex.DecodeString(str)
+	errorPanic(err)
+	return data
+}
+
+func TestNextType(t *testing.T) {
+	var tests = map[string]Type{
+		"":   InvalidType,
+		"c1": InvalidType,
+		"c0": NilType,
+		"c3": BoolType,
+		"7f": UintType,
+		"cf": UintType,
+		"e0": IntType,
+		"d3": IntType,
+		"ca": Float32Type,
+		"cb": Float64Type,
+		"a0": StrType,
+		"d9": StrType,
+		"c4": BinType,
+		"90": ArrayType,
+		"dd": ArrayType,
+		"80": MapType,
+		"df": MapType,
+		"d6": ExtType,
+		"c9": ExtType,
+	}
+
+	for str, typ := range tests {
+		if NextType(hexBytes(str)) != typ {
+			t.Errorf("NextType(%s) is %s, but should be %s", str, NextType(hexBytes(str)), typ)
+		}
+	}
+}
+
+func TestReadInt(t *testing.T) {
+	for _, value := range []int64{0, 1, 127, 128, 255, 256, 65535, 65536, math.MaxInt64, -1, -32, -33, -128, -129, math.MinInt64} {
+		i, o, err := ReadInt(AppendInt(nil, value), 64)
+		if err != nil || i != value || len(o) != 0 {
+			t.Errorf("ReadInt() is %d (%v), but should be %d", i, err, value)
+		}
+	}
+	for _, value := range []uint64{0, 200, math.MaxUint32, math.MaxUint64} {
+		u, o, err := ReadUint(AppendUint(nil, value), 64)
+		if err != nil || u != value || len(o) != 0 {
+			t.Errorf("ReadUint() is %d (%v), but should be %d", u, err, value)
+		}
+	}
+
+	var errors = []struct {
+		Hex  string
+		Bits int
+		Uint bool
+	}{
+		{"cc80", 8, false},
+		{"d0ff", 8, true},
+		{"cd0100", 8, true},
+		{"cfffffffffffffffff", 64, false},
+		{"cd", 16, false},
+		{"a0", 64, false},
+		{"", 64, true},
+	}
+	for _, test := range errors {
+		var err error
+		if test.Uint {
+			_, _, err = ReadUint(hexBytes(test.Hex), test.Bits)
+		} else {
+			_, _, err = ReadInt(hexBytes(test.Hex), test.Bits)
+		}
+		if err == nil {
+			t.Errorf("Read(%s) error is nil, but should be not nil", test.Hex)
+		}
+	}
+}
+
+func TestReadFloat(t *testing.T) {
+	if f, _, err := ReadFloat(AppendFloat32(nil, 1.5), 32); err != nil || f != 1.5 {
+		t.Errorf("ReadFloat() is %v, but should be 1.5", f)
+	}
+	if f, _, err := ReadFloat(AppendInt(nil, -3), 64); err != nil || f != -3 {
+		t.Errorf("ReadFloat() is %v, but should be -3", f)
+	}
+	if _, _, err := ReadFloat(AppendFloat64(nil, math.MaxFloat64), 32); err == nil {
+		t.Error("ReadFloat()", "error is nil, but should be not nil")
+	}
+	if f, _, err := ReadFloat(AppendFloat64(nil, math.Inf(1)), 32); err != nil || !math.IsInf(f, 1) {
+		t.Errorf("ReadFloat() is %v, but should be +Inf", f)
+	}
+}
+
+func TestReadStringBytes(t *testing.T) {
+	var long = strings.Repeat("a", 70000)
+
+	if str, o, err := ReadString(AppendString(nil, long)); err != nil || str != long || len(o) != 0 {
+		t.Error("ReadString()", "is wrong")
+	}
+	if str, _, err := ReadString(AppendBytes(nil, []byte("abc"))); err != nil || str != "abc" {
+		t.Error("ReadString()", "of bin is wrong")
+	}
+	data := AppendBytes(nil, []byte("abc"))
+	buf, _, err := ReadBytes(data)
+	if errorPanic(err); string(buf) != "abc" {
+		t.Error("ReadBytes()", "is wrong")
+	}
+	if buf[0] = 'x'; data[2] != 'a' {
+		t.Error("ReadBytes()", "doesn't copy value")
+	}
+	if _, _, err = ReadString(hexBytes("a361")); err != ErrShortBytes {
+		t.Errorf("ReadString() error is %v, but should be %v", err, ErrShortBytes)
+	}
+	if _, _, err = ReadBytes(hexBytes("c0")); err == nil {
+		t.Error("ReadBytes()", "error is nil, but should be not nil")
+	}
+}
+
+func TestReadTime(t *testing.T) {
+	for _, value := range []time.Time{
+		time.Unix(0, 0),
+		time.Unix(1<<32, 999999999),
+		time.Unix(-62135596800, 1),
+		time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+	} {
+		tm, o, err := ReadTime(AppendTime(nil, value))
+		if err != nil || !tm.Equal(value) || tm.Location() != time.UTC || len(o) != 0 {
+			t.Errorf("ReadTime() is %v (%v), but should be %v", tm, err, value)
+		}
+	}
+	for _, str := range []string{"d60100000001", "d7ff", "c70cff00000000", "d7ffffffffff00000000", "c703ff000000"} {
+		if _, _, err := ReadTime(hexBytes(str)); err == nil {
+			t.Errorf("ReadTime(%s) error is nil, but should be not nil", str)
+		}
+	}
+}
+
+func TestReadValue(t *testing.T) {
+	data := hexBytes("83a161c0a16292ff" + "cb3ff8000000000000" + "01c4010a")
+	value, o, err := ReadValue(data)
+	errorPanic(err)
+	expected := map[string]interface{}{"a": nil, "b": []interface{}{int64(-1), 1.5}, "1": []byte{10}}
+	if !reflect.DeepEqual(value, expected) || len(o) != 0 {
+		t.Errorf("ReadValue() is %#v, but should be %#v", value, expected)
+	}
+	if o, err = Skip(append(data, 0xc3)); err != nil || len(o) != 1 {
+		t.Errorf("Skip() is %x (%v), but should be c3", o, err)
+	}
+	if value, _, _ = ReadValue(AppendUint(nil, math.MaxUint64)); value != uint64(math.MaxUint64) {
+		t.Errorf("ReadValue() is %v, but should be uint64", value)
+	}
+	if value, _, _ = ReadValue(AppendFloat32(nil, 0.1)); value != float32(0.1) {
+		t.Errorf("ReadValue() is %v, but should be float32", value)
+	}
+
+	deep := []byte(strings.Repeat("\x91", maxDepth+2) + "\xc0")
+	if _, _, err = ReadValue(deep); err == nil {
+		t.Error("ReadValue()", "error is nil, but should be not nil")
+	}
+	if _, err = Skip(deep); err == nil {
+		t.Error("Skip()", "error is nil, but should be not nil")
+	}
+	for _, str := range []string{"", "c1", "92c0", "dd7fffffff", "81a161", "d4"} {
+		if _, _, err = ReadValue(hexBytes(str)); err == nil {
+			t.Errorf("ReadValue(%s) error is nil, but should be not nil", str)
+		}
+		if _, err = Skip(hexBytes(str)); err == nil {
+			t.Errorf("Skip(%s) error is nil, but should be not nil", str)
+		}
+	}
+}
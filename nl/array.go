@@ -0,0 +1,897 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Array is an nullable one-dimensional or multi-dimensional PostgreSQL array of nullable objects
+// Тип элемента T должен реализовывать sql.Scanner и driver.Valuer, как все типы пакета
+type Array[T any] struct {
+	Array []T   // Value of object, elements of multi-dimensional array are stored in row-major order
+	Dims  []int // Dims is sizes of dimensions of multi-dimensional array, nil for one-dimensional array
+	Valid bool  // Valid is true if value is not NULL
+}
+
+// Массивы для всех типов пакета
+type (
+	BoolArray         = Array[Bool]         // BoolArray is an nullable array of Bool, for boolean[] columns
+	BytesArray        = Array[Bytes]        // BytesArray is an nullable array of Bytes, for bytea[] columns
+	Float64Array      = Array[Float64]      // Float64Array is an nullable array of Float64, for double precision[] columns
+	Float32Array      = Array[Float32]      // Float32Array is an nullable array of Float32, for real[] columns
+	Int64Array        = Array[Int64]        // Int64Array is an nullable array of Int64, for bigint[] columns
+	Int32Array        = Array[Int32]        // Int32Array is an nullable array of Int32, for integer[] columns
+	Int16Array        = Array[Int16]        // Int16Array is an nullable array of Int16, for smallint[] columns
+	Int8Array         = Array[Int8]         // Int8Array is an nullable array of Int8
+	Uint64Array       = Array[Uint64]       // Uint64Array is an nullable array of Uint64
+	Uint32Array       = Array[Uint32]       // Uint32Array is an nullable array of Uint32
+	Uint16Array       = Array[Uint16]       // Uint16Array is an nullable array of Uint16
+	Uint8Array        = Array[Uint8]        // Uint8Array is an nullable array of Uint8
+	StringArray       = Array[String]       // StringArray is an nullable array of String, for text[] and varchar[] columns
+	TimeArray         = Array[Time]         // TimeArray is an nullable array of Time, for timestamp[] and timestamptz[] columns
+	DateArray         = Array[Date]         // DateArray is an nullable array of Date, for date[] columns
+	TimeOfDayArray    = Array[TimeOfDay]    // TimeOfDayArray is an nullable array of TimeOfDay, for time[] and timetz[] columns
+	DecimalArray      = Array[Decimal]      // DecimalArray is an nullable array of Decimal, for numeric[] columns
+	UUIDArray         = Array[UUID]         // UUIDArray is an nullable array of UUID, for uuid[] columns
+	IPArray           = Array[IP]           // IPArray is an nullable array of IP, for inet[] columns
+	PrefixArray       = Array[Prefix]       // PrefixArray is an nullable array of Prefix, for cidr[] columns
+	HardwareAddrArray = Array[HardwareAddr] // HardwareAddrArray is an nullable array of HardwareAddr, for macaddr[] columns
+	BigIntArray       = Array[BigInt]       // BigIntArray is an nullable array of BigInt, for numeric[] columns
+	BigFloatArray     = Array[BigFloat]     // BigFloatArray is an nullable array of BigFloat, for numeric[] columns
+)
+
+// NewArray Создание нового не действительного объекта Array
+func NewArray[T any]() Array[T] {
+	return Array[T]{
+		Array: nil,
+		Valid: false,
+	}
+}
+
+// NewArrayValue Создание нового действительного одномерного объекта Array из значения, срез копируется
+func NewArrayValue[T any](value []T) Array[T] {
+	return Array[T]{
+		Array: append([]T{}, value...),
+		Valid: true,
+	}
+}
+
+// NewArrayPointerValue Создание нового действительного одномерного объекта Array из ссылки на значение
+func NewArrayPointerValue[T any](ptr *[]T) Array[T] {
+	if ptr == nil {
+		return NewArray[T]()
+	}
+	return NewArrayValue(*ptr)
+}
+
+// NewArrayDims Создание нового действительного многомерного объекта Array из элементов в порядке строк
+func NewArrayDims[T any](value []T, dims ...int) (ret Array[T], err error) {
+	ret = NewArrayValue(value)
+	if len(dims) > 1 {
+		ret.Dims = append([]int{}, dims...)
+	}
+	err = ret.checkDims()
+
+	return
+}
+
+// SetValid Изменение значения одномерного массива и установка флага действительного значения
+func (a *Array[T]) SetValid(value []T) { a.Array, a.Dims, a.Valid = append([]T{}, value...), nil, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (a *Array[T]) Reset() { a.Array, a.Dims, a.Valid = nil, nil, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (a *Array[T]) NullIfDefault() Array[T] {
+	if len(a.Array) == 0 {
+		a.Reset()
+	}
+	return *a
+}
+
+// MustValue Возвращает значение в любом случае
+func (a *Array[T]) MustValue() []T {
+	if !a.Valid {
+		return []T{}
+	}
+	return a.Array
+}
+
+// Pointer Возвращает ссылку на значение
+func (a *Array[T]) Pointer() *[]T {
+	if !a.Valid {
+		return nil
+	}
+	return &a.Array
+}
+
+// Dimensions Возвращает размеры измерений массива, пустой массив не имеет измерений
+func (a Array[T]) Dimensions() []int {
+	switch {
+	case len(a.Dims) > 1:
+		return append([]int{}, a.Dims...)
+	case len(a.Array) == 0:
+		return nil
+	default:
+		return []int{len(a.Array)}
+	}
+}
+
+// Проверка соответствия размеров измерений количеству элементов
+func (a Array[T]) checkDims() error {
+	var count = 1
+
+	if len(a.Dims) <= 1 {
+		return nil
+	}
+	for _, n := range a.Dims {
+		if n <= 0 {
+			return fmt.Errorf("invalid dimensions %v of nul.Array", a.Dims)
+		}
+		count *= n
+	}
+	if count != len(a.Array) {
+		return fmt.Errorf("dimensions %v of nul.Array don't match %d elements", a.Dims, len(a.Array))
+	}
+
+	return nil
+}
+
+// Установка значения из элементов литерала массива
+func (a *Array[T]) fromItems(items []arrayItem, dims []int) (err error) {
+	var scanner sql.Scanner
+
+	a.Array, a.Dims = make([]T, len(items)), nil
+	if len(dims) > 1 {
+		a.Dims = dims
+	}
+	for i := range items {
+		if scanner, err = arrayElementScanner(&a.Array[i]); err != nil {
+			return
+		}
+		if err = scanArrayElement(scanner, items[i]); err != nil {
+			err = fmt.Errorf("can't scan element %d of nul.Array: %s", i, err)
+			return
+		}
+	}
+
+	return
+}
+
+// Элементы литерала массива из значения
+func (a Array[T]) toItems() (items []arrayItem, err error) {
+	var (
+		valuer driver.Valuer
+		value  driver.Value
+		ok     bool
+	)
+
+	if err = a.checkDims(); err != nil {
+		return
+	}
+	items = make([]arrayItem, len(a.Array))
+	for i := range a.Array {
+		if valuer, ok = interface{}(&a.Array[i]).(driver.Valuer); !ok {
+			err = fmt.Errorf("type %T of element of nul.Array doesn't implement driver.Valuer", a.Array[i])
+			return
+		}
+		if value, err = valuer.Value(); err != nil {
+			return
+		}
+		if items[i], err = formatArrayValue(value); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Интерфейс sql.Scanner элемента массива
+func arrayElementScanner(ptr interface{}) (ret sql.Scanner, err error) {
+	var ok bool
+
+	if ret, ok = ptr.(sql.Scanner); !ok {
+		err = fmt.Errorf("type %T of element of nul.Array doesn't implement sql.Scanner", ptr)
+	}
+
+	return
+}
+
+// Сканирование элемента литерала массива с учётом текстового представления типов PostgreSQL
+func scanArrayElement(scanner sql.Scanner, item arrayItem) (err error) {
+	var buf []byte
+
+	if item.Null {
+		return scanner.Scan(nil)
+	}
+	switch x := scanner.(type) {
+	case *Bytes:
+		if !strings.HasPrefix(item.Value, `\x`) {
+			return x.Scan([]byte(item.Value))
+		}
+		if buf, err = hex.DecodeString(item.Value[2:]); err != nil {
+			return
+		}
+		return x.Scan(buf)
+	case *Time:
+		return scanTimestamp(x, item.Value)
+	}
+
+	return scanner.Scan(item.Value)
+}
+
+// Сканирование метки времени в текстовом представлении PostgreSQL
+func scanTimestamp(t *Time, str string) (err error) {
+	var tm, e = ParseTimestamp(str)
+
+	if e != nil {
+		t.Reset()
+		return e
+	}
+
+	return t.Scan(tm)
+}
+
+// Scan Реализация интерфейса Scanner
+func (a *Array[T]) Scan(value interface{}) (err error) {
+	var (
+		items []arrayItem
+		dims  []int
+	)
+
+	switch x := value.(type) {
+	case nil:
+		a.Reset()
+		return
+	case []byte:
+		if items, dims, err = parseArrayLiteral(string(x)); err == nil {
+			err = a.fromItems(items, dims)
+		}
+	case string:
+		if items, dims, err = parseArrayLiteral(x); err == nil {
+			err = a.fromItems(items, dims)
+		}
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.Array: %v", x, value)
+	}
+	if a.Valid = err == nil; !a.Valid {
+		a.Array, a.Dims = nil, nil
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (a Array[T]) Value() (driver.Value, error) {
+	var (
+		items []arrayItem
+		err   error
+	)
+
+	if !a.Valid {
+		return nil, nil
+	}
+	if items, err = a.toItems(); err != nil {
+		return nil, err
+	}
+
+	return formatArrayLiteral(items, a.Dims), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Многомерный массив представляется вложенными массивами JSON
+func (a *Array[T]) UnmarshalJSON(data []byte) (err error) {
+	const nullString = "null"
+	var (
+		raws  []json.RawMessage
+		dims  []int
+		depth = -1
+	)
+
+	if string(bytes.TrimSpace(data)) == nullString {
+		a.Reset()
+		return
+	}
+	if err = unmarshalJSONArray(data, 0, &raws, &dims, &depth); err == nil {
+		a.Array, a.Dims = make([]T, len(raws)), nil
+		if len(dims) > 1 {
+			a.Dims = dims
+		}
+		for i := range raws {
+			if err = json.Unmarshal(raws[i], &a.Array[i]); err != nil {
+				break
+			}
+		}
+	}
+	if a.Valid = err == nil; !a.Valid {
+		a.Array, a.Dims = nil, nil
+	}
+
+	return
+}
+
+// Разбор уровня вложенного массива JSON в элементы в порядке строк
+func unmarshalJSONArray(data []byte, level int, raws *[]json.RawMessage, dims *[]int, depth *int) (err error) {
+	var (
+		items  []json.RawMessage
+		nested bool
+	)
+
+	if err = json.Unmarshal(data, &items); err != nil {
+		return
+	}
+	if items == nil {
+		return fmt.Errorf("can't unmarshal %s into nul.Array: expected array", data)
+	}
+	if level == len(*dims) {
+		*dims = append(*dims, len(items))
+	} else if (*dims)[level] != len(items) {
+		return fmt.Errorf("can't unmarshal %s into nul.Array: sub-arrays have different sizes", data)
+	}
+	if len(items) == 0 {
+		if level > 0 {
+			return fmt.Errorf("can't unmarshal %s into nul.Array: empty sub-array", data)
+		}
+		*dims = nil
+		return
+	}
+	for i := range items {
+		nested = len(bytes.TrimSpace(items[i])) > 0 && bytes.TrimSpace(items[i])[0] == '['
+		switch {
+		case nested && (*depth < 0 || *depth > level):
+			err = unmarshalJSONArray(items[i], level+1, raws, dims, depth)
+		case !nested && (*depth < 0 || *depth == level):
+			*depth, *raws = level, append(*raws, items[i])
+		default:
+			err = fmt.Errorf("can't unmarshal %s into nul.Array: mixed elements and sub-arrays", data)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (a Array[T]) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+	var buf = &bytes.Buffer{}
+
+	if !a.Valid {
+		data = []byte(nullString)
+		return
+	}
+	if err = a.checkDims(); err != nil {
+		return
+	}
+	if len(a.Array) == 0 {
+		data = []byte("[]")
+		return
+	}
+	if err = a.writeJSONLevel(buf, a.Array, a.Dimensions()); err == nil {
+		data = buf.Bytes()
+	}
+
+	return
+}
+
+// Форматирование уровня массива в JSON
+func (a Array[T]) writeJSONLevel(buf *bytes.Buffer, elements []T, dims []int) (err error) {
+	var (
+		stride = 1
+		item   []byte
+	)
+
+	for _, n := range dims[1:] {
+		stride *= n
+	}
+	buf.WriteByte('[')
+	for i := 0; i < dims[0]; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if len(dims) > 1 {
+			if err = a.writeJSONLevel(buf, elements[i*stride:(i+1)*stride], dims[1:]); err != nil {
+				return
+			}
+			continue
+		}
+		if item, err = json.Marshal(elements[i]); err != nil {
+			return
+		}
+		buf.Write(item)
+	}
+	buf.WriteByte(']')
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+// Текстом является литерал массива PostgreSQL
+func (a *Array[T]) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	switch string(text) {
+	case nullString:
+		a.Reset()
+		return
+	case emptyString:
+		a.Array, a.Dims, a.Valid = []T{}, nil, true
+		return
+	default:
+		err = a.Scan(text)
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (a Array[T]) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+	var items []arrayItem
+
+	if !a.Valid {
+		text = []byte(nullString)
+		return
+	}
+	if items, err = a.toItems(); err == nil {
+		text = []byte(formatArrayLiteral(items, a.Dims))
+	}
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (a *Array[T]) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return a.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagArray, a)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (a Array[T]) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagArray, a) }
+
+// Запись значения в компактном двоичном формате
+func (a Array[T]) encodeBinary(w *binaryWriter) {
+	if w.flags(a.Valid); !a.Valid {
+		return
+	}
+	w.uvarint(uint64(len(a.Dims)))
+	for _, n := range a.Dims {
+		w.varint(int64(n))
+	}
+	w.uvarint(uint64(len(a.Array)))
+	for i := range a.Array {
+		writeBinaryValue(w, a.Array[i])
+	}
+}
+
+// Чтение значения в компактном двоичном формате
+func (a *Array[T]) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		dims  []int
+		value []T
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			a.Reset()
+		}
+		return
+	}
+	if n := r.count(); n > 0 {
+		dims = make([]int, n)
+		for i := range dims {
+			dims[i] = r.int()
+		}
+	}
+	value = make([]T, r.count())
+	for i := 0; i < len(value) && r.err == nil; i++ {
+		readBinaryValue(r, &value[i])
+	}
+	if r.err != nil {
+		return
+	}
+	a.Array, a.Dims, a.Valid = value, dims, valid
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (a *Array[T]) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.ArrayWrapper[T]
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.ArrayWrapper[T])
+	if err = dec.Decode(item); err == nil {
+		a.Array, a.Dims, a.Valid = item.Value, item.Dims, item.Valid
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Многомерный массив представляется вложенными массивами MessagePack
+func (a *Array[T]) UnmarshalMsgpack(data []byte) (err error) {
+	var (
+		raws  [][]byte
+		dims  []int
+		depth = -1
+		rest  []byte
+		value []T
+	)
+
+	if msgpack.IsNil(data) {
+		a.Reset()
+		return msgpackEnd(msgpack.ReadNil(data))
+	}
+	if rest, err = unmarshalMsgpackArray(data, 0, &raws, &dims, &depth); err != nil {
+		return
+	}
+	if err = msgpackEnd(rest, nil); err != nil {
+		return
+	}
+	value = make([]T, len(raws))
+	for i := range raws {
+		if _, err = readMsgpackValue(raws[i], &value[i]); err != nil {
+			err = fmt.Errorf("can't unmarshal element %d of nul.Array: %s", i, err)
+			return
+		}
+	}
+	if a.Array, a.Dims, a.Valid = value, nil, true; len(dims) > 1 {
+		a.Dims = dims
+	}
+
+	return
+}
+
+// Разбор уровня вложенного массива MessagePack в элементы в порядке строк
+func unmarshalMsgpackArray(data []byte, level int, raws *[][]byte, dims *[]int, depth *int) (rest []byte, err error) {
+	var (
+		n      int
+		item   []byte
+		nested bool
+	)
+
+	if n, rest, err = msgpack.ReadArrayHeader(data); err != nil {
+		return
+	}
+	if level == len(*dims) {
+		*dims = append(*dims, n)
+	} else if (*dims)[level] != n {
+		err = fmt.Errorf("can't unmarshal msgpack into nul.Array: sub-arrays have different sizes")
+		return
+	}
+	if n == 0 {
+		if level > 0 {
+			err = fmt.Errorf("can't unmarshal msgpack into nul.Array: empty sub-array")
+			return
+		}
+		*dims = nil
+		return
+	}
+	for i := 0; i < n && err == nil; i++ {
+		nested = msgpack.NextType(rest) == msgpack.ArrayType
+		switch {
+		case nested && (*depth < 0 || *depth > level):
+			rest, err = unmarshalMsgpackArray(rest, level+1, raws, dims, depth)
+		case !nested && (*depth < 0 || *depth == level):
+			item = rest
+			if rest, err = msgpack.Skip(rest); err == nil {
+				*depth, *raws = level, append(*raws, item[:len(item)-len(rest)])
+			}
+		default:
+			err = fmt.Errorf("can't unmarshal msgpack into nul.Array: mixed elements and sub-arrays")
+		}
+	}
+
+	return
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Многомерный массив представляется вложенными массивами MessagePack
+func (a Array[T]) MarshalMsgpack() (data []byte, err error) {
+	if !a.Valid {
+		data = msgpack.AppendNil(nil)
+		return
+	}
+	if err = a.checkDims(); err != nil {
+		return
+	}
+	if len(a.Array) == 0 {
+		data = msgpack.AppendArrayHeader(nil, 0)
+		return
+	}
+	data, err = a.appendMsgpackLevel(nil, a.Array, a.Dimensions())
+
+	return
+}
+
+// Кодирование уровня массива в MessagePack
+func (a Array[T]) appendMsgpackLevel(b []byte, elements []T, dims []int) (ret []byte, err error) {
+	var stride = 1
+
+	for _, n := range dims[1:] {
+		stride *= n
+	}
+	ret = msgpack.AppendArrayHeader(b, dims[0])
+	for i := 0; i < dims[0] && err == nil; i++ {
+		if len(dims) > 1 {
+			ret, err = a.appendMsgpackLevel(ret, elements[i*stride:(i+1)*stride], dims[1:])
+			continue
+		}
+		ret, err = appendMsgpackValue(ret, elements[i])
+	}
+
+	return
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Многомерный массив представляется вложенными массивами CBOR
+func (a *Array[T]) UnmarshalCBOR(data []byte) (err error) {
+	var (
+		raws  [][]byte
+		dims  []int
+		depth = -1
+		rest  []byte
+		value []T
+	)
+
+	if cbor.IsNull(data) {
+		a.Reset()
+		return cborEnd(cbor.ReadNull(data))
+	}
+	if rest, err = unmarshalCBORArray(data, 0, &raws, &dims, &depth); err != nil {
+		return
+	}
+	if err = cborEnd(rest, nil); err != nil {
+		return
+	}
+	value = make([]T, len(raws))
+	for i := range raws {
+		if _, err = readCBORValue(raws[i], &value[i]); err != nil {
+			err = fmt.Errorf("can't unmarshal element %d of nul.Array: %s", i, err)
+			return
+		}
+	}
+	if a.Array, a.Dims, a.Valid = value, nil, true; len(dims) > 1 {
+		a.Dims = dims
+	}
+
+	return
+}
+
+// Разбор уровня вложенного массива CBOR в элементы в порядке строк
+func unmarshalCBORArray(data []byte, level int, raws *[][]byte, dims *[]int, depth *int) (rest []byte, err error) {
+	var (
+		n      int
+		item   []byte
+		nested bool
+	)
+
+	if n, rest, err = cbor.ReadArrayHeader(data); err != nil {
+		return
+	}
+	if level == len(*dims) {
+		*dims = append(*dims, n)
+	} else if (*dims)[level] != n {
+		err = fmt.Errorf("can't unmarshal cbor into nul.Array: sub-arrays have different sizes")
+		return
+	}
+	if n == 0 {
+		if level > 0 {
+			err = fmt.Errorf("can't unmarshal cbor into nul.Array: empty sub-array")
+			return
+		}
+		*dims = nil
+		return
+	}
+	for i := 0; i < n && err == nil; i++ {
+		nested = cbor.NextType(rest) == cbor.ArrayType
+		switch {
+		case nested && (*depth < 0 || *depth > level):
+			rest, err = unmarshalCBORArray(rest, level+1, raws, dims, depth)
+		case !nested && (*depth < 0 || *depth == level):
+			item = rest
+			if rest, err = cbor.Skip(rest); err == nil {
+				*depth, *raws = level, append(*raws, item[:len(item)-len(rest)])
+			}
+		default:
+			err = fmt.Errorf("can't unmarshal cbor into nul.Array: mixed elements and sub-arrays")
+		}
+	}
+
+	return
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Многомерный массив представляется вложенными массивами CBOR
+func (a Array[T]) MarshalCBOR() (data []byte, err error) {
+	if !a.Valid {
+		data = cbor.AppendNull(nil)
+		return
+	}
+	if err = a.checkDims(); err != nil {
+		return
+	}
+	if len(a.Array) == 0 {
+		data = cbor.AppendArrayHeader(nil, 0)
+		return
+	}
+	data, err = a.appendCBORLevel(nil, a.Array, a.Dimensions())
+
+	return
+}
+
+// Кодирование уровня массива в CBOR
+func (a Array[T]) appendCBORLevel(b []byte, elements []T, dims []int) (ret []byte, err error) {
+	var stride = 1
+
+	for _, n := range dims[1:] {
+		stride *= n
+	}
+	ret = cbor.AppendArrayHeader(b, dims[0])
+	for i := 0; i < dims[0] && err == nil; i++ {
+		if len(dims) > 1 {
+			ret, err = a.appendCBORLevel(ret, elements[i*stride:(i+1)*stride], dims[1:])
+			continue
+		}
+		ret, err = appendCBORValue(ret, elements[i])
+	}
+
+	return
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Многомерный массив представляется вложенными последовательностями YAML
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (a *Array[T]) UnmarshalYAML(unmarshal func(interface{}) error) (err error) {
+	var (
+		value interface{}
+		items []interface{}
+		dims  []int
+		depth = -1
+		typ   = reflect.TypeOf((*T)(nil))
+		ptr   reflect.Value
+		ok    bool
+	)
+
+	if err = unmarshal(&value); err != nil {
+		return
+	}
+	if value == nil {
+		a.Reset()
+		return
+	}
+	if items, ok = value.([]interface{}); !ok {
+		return fmt.Errorf("can't unmarshal yaml %T into nul.Array: expected sequence", value)
+	}
+	if err = unmarshalYAMLArray(items, 0, &dims, &depth); err != nil {
+		return
+	}
+	// Элементы декодируются повторным вызовом unmarshal во вложенные срезы указателей на T по числу измерений,
+	// так как библиотека yaml.v3 пропускает значения null в срезах структур
+	for i := 0; i < len(dims) || i == 0; i++ {
+		typ = reflect.SliceOf(typ)
+	}
+	if ptr = reflect.New(typ); len(items) > 0 {
+		if err = unmarshal(ptr.Interface()); err != nil {
+			return
+		}
+	}
+	a.Array, a.Dims, a.Valid = make([]T, 0, len(items)), nil, true
+	if len(dims) > 1 {
+		a.Dims = dims
+	}
+	a.flattenYAML(ptr.Elem())
+
+	return
+}
+
+// Разбор уровня вложенной последовательности YAML, определение размеров измерений массива
+func unmarshalYAMLArray(items []interface{}, level int, dims *[]int, depth *int) (err error) {
+	var (
+		sub    []interface{}
+		nested bool
+	)
+
+	if level == len(*dims) {
+		*dims = append(*dims, len(items))
+	} else if (*dims)[level] != len(items) {
+		return fmt.Errorf("can't unmarshal yaml into nul.Array: sub-arrays have different sizes")
+	}
+	if len(items) == 0 {
+		if level > 0 {
+			return fmt.Errorf("can't unmarshal yaml into nul.Array: empty sub-array")
+		}
+		*dims = nil
+		return
+	}
+	for i := 0; i < len(items) && err == nil; i++ {
+		sub, nested = items[i].([]interface{})
+		switch {
+		case nested && (*depth < 0 || *depth > level):
+			err = unmarshalYAMLArray(sub, level+1, dims, depth)
+		case !nested && (*depth < 0 || *depth == level):
+			*depth = level
+		default:
+			err = fmt.Errorf("can't unmarshal yaml into nul.Array: mixed elements and sub-arrays")
+		}
+	}
+
+	return
+}
+
+// Добавление элементов вложенных срезов в массив в порядке строк, значение nil является null
+func (a *Array[T]) flattenYAML(rv reflect.Value) {
+	var element T
+
+	switch {
+	case rv.Kind() == reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			a.flattenYAML(rv.Index(i))
+		}
+	case rv.IsNil():
+		a.Array = append(a.Array, element)
+	default:
+		a.Array = append(a.Array, rv.Elem().Interface().(T))
+	}
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Многомерный массив представляется вложенными последовательностями YAML
+func (a Array[T]) MarshalYAML() (interface{}, error) {
+	if !a.Valid {
+		return nil, nil
+	}
+	if err := a.checkDims(); err != nil {
+		return nil, err
+	}
+	if len(a.Array) == 0 {
+		return []interface{}{}, nil
+	}
+
+	return a.yamlLevel(a.Array, a.Dimensions()), nil
+}
+
+// Последовательность YAML уровня массива
+func (a Array[T]) yamlLevel(elements []T, dims []int) (ret []interface{}) {
+	var stride = 1
+
+	for _, n := range dims[1:] {
+		stride *= n
+	}
+	ret = make([]interface{}, dims[0])
+	for i := range ret {
+		if len(dims) > 1 {
+			ret[i] = a.yamlLevel(elements[i*stride:(i+1)*stride], dims[1:])
+			continue
+		}
+		ret[i] = elements[i]
+	}
+
+	return
+}
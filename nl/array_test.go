@@ -0,0 +1,324 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	int64ArrayLiteral = `{1,NULL,-3}`
+	int64ArrayJSON    = []byte(`[1,null,-3]`)
+)
+
+func int64ArrayTestValue() []Int64 {
+	return []Int64{NewInt64Value(1), NewInt64(), NewInt64Value(-3)}
+}
+
+func isInt64ArrayValid(t *testing.T, a Int64Array, from string) {
+	if !reflect.DeepEqual(a.Array, int64ArrayTestValue()) {
+		t.Errorf("Bad %s array: %v ≠ %v\n", from, a.Array, int64ArrayTestValue())
+	}
+	if a.Dims != nil {
+		t.Errorf("Bad %s array dimensions: %v ≠ nil\n", from, a.Dims)
+	}
+	if !a.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isArrayNull[T any](t *testing.T, a Array[T], from string) {
+	if a.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+	if a.Array != nil || a.Dims != nil {
+		t.Errorf("Bad %s array: %v %v, but should be empty", from, a.Array, a.Dims)
+	}
+}
+
+func TestNewArray(t *testing.T) {
+	v1 := NewArray[Int64]()
+	isArrayNull(t, v1, "NewArray()")
+
+	value := int64ArrayTestValue()
+	v2 := NewArrayValue(value)
+	isInt64ArrayValid(t, v2, "NewArrayValue()")
+	value[0] = NewInt64()
+	isInt64ArrayValid(t, v2, "NewArrayValue(copy)")
+
+	v3 := NewArrayPointerValue[Int64](nil)
+	isArrayNull(t, v3, "NewArrayPointerValue(nil)")
+
+	value = int64ArrayTestValue()
+	v4 := NewArrayPointerValue(&value)
+	isInt64ArrayValid(t, v4, "NewArrayPointerValue()")
+
+	v5, err := NewArrayDims(make([]Int64, 6), 2, 3)
+	errorPanic(err)
+	if !reflect.DeepEqual(v5.Dimensions(), []int{2, 3}) {
+		t.Errorf("NewArrayDims() dimensions is %v, but should be %v", v5.Dimensions(), []int{2, 3})
+	}
+	if _, err = NewArrayDims(make([]Int64, 5), 2, 3); err == nil {
+		t.Error("NewArrayDims() error is nil, but should be not nil")
+	}
+}
+
+func TestArraySetValidReset(t *testing.T) {
+	var v Int64Array
+
+	v.SetValid(int64ArrayTestValue())
+	isInt64ArrayValid(t, v, "SetValid()")
+	if !reflect.DeepEqual(v.Dimensions(), []int{3}) {
+		t.Errorf("Dimensions() is %v, but should be %v", v.Dimensions(), []int{3})
+	}
+	if v.Pointer() == nil || len(v.MustValue()) != 3 {
+		t.Error("Pointer() or MustValue() returns wrong value")
+	}
+	v.Reset()
+	isArrayNull(t, v, "Reset()")
+	if v.Pointer() != nil || v.MustValue() == nil {
+		t.Error("Pointer() or MustValue() of null returns wrong value")
+	}
+	v.SetValid(nil)
+	v.NullIfDefault()
+	isArrayNull(t, v, "NullIfDefault()")
+}
+
+func TestArrayScanValue(t *testing.T) {
+	var (
+		v   Int64Array
+		val interface{}
+		err error
+	)
+
+	errorPanic(v.Scan(int64ArrayLiteral))
+	isInt64ArrayValid(t, v, "Scan(string)")
+	errorPanic(v.Scan([]byte(int64ArrayLiteral)))
+	isInt64ArrayValid(t, v, "Scan([]byte)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != int64ArrayLiteral {
+		t.Errorf("Value() is %v, but should be %v", val, int64ArrayLiteral)
+	}
+
+	errorPanic(v.Scan(`{{1,2,3},{4,NULL,6}}`))
+	if !reflect.DeepEqual(v.Dims, []int{2, 3}) || len(v.Array) != 6 || v.Array[4].Valid || v.Array[5].Int64 != 6 {
+		t.Errorf("Scan() of multi-dimensional array is %v %v", v.Array, v.Dims)
+	}
+	val, err = v.Value()
+	errorPanic(err)
+	if val != `{{1,2,3},{4,NULL,6}}` {
+		t.Errorf("Value() is %v, but should be %v", val, `{{1,2,3},{4,NULL,6}}`)
+	}
+
+	errorPanic(v.Scan(`{}`))
+	if !v.Valid || len(v.Array) != 0 || v.Dimensions() != nil {
+		t.Errorf("Scan() of empty array is %v %v", v.Array, v.Valid)
+	}
+
+	errorPanic(v.Scan(nil))
+	isArrayNull(t, v, "Scan(nil)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != nil {
+		t.Errorf("Value() is %v, but should be nil", val)
+	}
+
+	if err = v.Scan(`{1,a}`); err == nil {
+		t.Error("Scan() error is nil, but should be not nil")
+	}
+	isArrayNull(t, v, "Scan(error)")
+	if err = v.Scan(int64(1)); err == nil {
+		t.Error("Scan(int64) error is nil, but should be not nil")
+	}
+
+	v, err = NewArrayDims(make([]Int64, 4), 2, 3)
+	if err == nil {
+		t.Error("NewArrayDims() error is nil, but should be not nil")
+	}
+	v.Dims = []int{2, 3}
+	if _, err = v.Value(); err == nil {
+		t.Error("Value() with wrong dimensions error is nil, but should be not nil")
+	}
+}
+
+func TestStringArrayScanValue(t *testing.T) {
+	var (
+		v        StringArray
+		val      interface{}
+		err      error
+		literal  = `{"a b","",NULL,"NULL","{x}","q\"\\"}`
+		expected = []String{
+			NewStringValue("a b"),
+			NewStringValue(""),
+			NewString(),
+			NewStringValue("NULL"),
+			NewStringValue("{x}"),
+			NewStringValue(`q"\`),
+		}
+	)
+
+	errorPanic(v.Scan(literal))
+	if !reflect.DeepEqual(v.Array, expected) {
+		t.Errorf("Scan() is %v, but should be %v", v.Array, expected)
+	}
+	val, err = v.Value()
+	errorPanic(err)
+	if val != literal {
+		t.Errorf("Value() is %v, but should be %v", val, literal)
+	}
+}
+
+func TestTimeArrayScanValue(t *testing.T) {
+	var (
+		v        TimeArray
+		val      interface{}
+		err      error
+		expected = time.Date(2012, 12, 21, 21, 21, 21, 500000000, time.UTC)
+	)
+
+	errorPanic(v.Scan(`{"2012-12-21 23:21:21.5+02",NULL}`))
+	if len(v.Array) != 2 || !v.Array[0].Valid || !v.Array[0].Time.Equal(expected) || v.Array[1].Valid {
+		t.Errorf("Scan() is %v, but should be [%v null]", v.Array, expected)
+	}
+	v.Array[0].Time = v.Array[0].Time.UTC()
+	val, err = v.Value()
+	errorPanic(err)
+	if val != `{"2012-12-21 21:21:21.5Z",NULL}` {
+		t.Errorf("Value() is %v, but should be %v", val, `{"2012-12-21 21:21:21.5Z",NULL}`)
+	}
+	if err = v.Scan(`{2012-12-21}`); err == nil {
+		t.Error("Scan() error is nil, but should be not nil")
+	}
+}
+
+func TestBytesArrayScanValue(t *testing.T) {
+	var (
+		v   BytesArray
+		val interface{}
+		err error
+	)
+
+	errorPanic(v.Scan(`{"\\xdead",NULL}`))
+	if len(v.Array) != 2 || string(v.Array[0].MustValue()) != "\xde\xad" || v.Array[1].Valid {
+		t.Errorf("Scan() is %v, but should be [dead null]", v.Array)
+	}
+	val, err = v.Value()
+	errorPanic(err)
+	if val != `{"\\xdead",NULL}` {
+		t.Errorf("Value() is %v, but should be %v", val, `{"\\xdead",NULL}`)
+	}
+}
+
+func TestArrayUnmarshalJSON(t *testing.T) {
+	var (
+		v   Int64Array
+		err error
+	)
+
+	errorPanic(json.Unmarshal(int64ArrayJSON, &v))
+	isInt64ArrayValid(t, v, "UnmarshalJSON()")
+
+	errorPanic(json.Unmarshal([]byte(`[[1,2],[null,4],[5,6]]`), &v))
+	if !reflect.DeepEqual(v.Dims, []int{3, 2}) || len(v.Array) != 6 || v.Array[2].Valid || v.Array[5].Int64 != 6 {
+		t.Errorf("UnmarshalJSON() of multi-dimensional array is %v %v", v.Array, v.Dims)
+	}
+
+	errorPanic(json.Unmarshal([]byte(`[]`), &v))
+	if !v.Valid || len(v.Array) != 0 || v.Dims != nil {
+		t.Errorf("UnmarshalJSON() of empty array is %v %v", v.Array, v.Valid)
+	}
+
+	errorPanic(json.Unmarshal([]byte(`null`), &v))
+	isArrayNull(t, v, "UnmarshalJSON(null)")
+
+	for _, in := range []string{`{}`, `1`, `[[1,2],[3]]`, `[[1],2]`, `[1,[2]]`, `[[]]`, `["a"]`} {
+		if err = json.Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("UnmarshalJSON(%s) error is nil, but should be not nil", in)
+		}
+		isArrayNull(t, v, "UnmarshalJSON(error)")
+	}
+}
+
+func TestArrayMarshalJSON(t *testing.T) {
+	var (
+		data []byte
+		err  error
+	)
+
+	v1 := NewArrayValue(int64ArrayTestValue())
+	data, err = json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(int64ArrayJSON), "non-empty json marshal")
+
+	v2, err := NewArrayDims([]Int64{NewInt64Value(1), NewInt64Value(2), NewInt64Value(3), NewInt64()}, 2, 2)
+	errorPanic(err)
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, `[[1,2],[3,null]]`, "multi-dimensional json marshal")
+
+	v3 := NewArrayValue([]Int64{})
+	data, err = json.Marshal(v3)
+	errorPanic(err)
+	jsonEquals(t, data, "[]", "empty json marshal")
+
+	v4 := NewArray[Int64]()
+	data, err = json.Marshal(v4)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestArrayText(t *testing.T) {
+	var (
+		v    Int64Array
+		data []byte
+		err  error
+	)
+
+	errorPanic(v.UnmarshalText([]byte(int64ArrayLiteral)))
+	isInt64ArrayValid(t, v, "UnmarshalText()")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != int64ArrayLiteral {
+		t.Errorf("MarshalText() is %s, but should be %s", data, int64ArrayLiteral)
+	}
+
+	errorPanic(v.UnmarshalText([]byte("null")))
+	isArrayNull(t, v, "UnmarshalText(null)")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != "null" {
+		t.Errorf("MarshalText() is %s, but should be null", data)
+	}
+
+	errorPanic(v.UnmarshalText([]byte("")))
+	if !v.Valid || len(v.Array) != 0 {
+		t.Errorf("UnmarshalText() of empty text is %v %v", v.Array, v.Valid)
+	}
+}
+
+func TestArrayBinary(t *testing.T) {
+	var (
+		v2   Int64Array
+		data []byte
+		err  error
+	)
+
+	v1, err := NewArrayDims([]Int64{NewInt64Value(1), NewInt64(), NewInt64Value(3), NewInt64Value(4)}, 2, 2)
+	errorPanic(err)
+	data, err = v1.MarshalBinary()
+	errorPanic(err)
+	errorPanic(v2.UnmarshalBinary(data))
+	if !reflect.DeepEqual(v1, v2) {
+		t.Errorf("UnmarshalBinary() is %v, but should be %v", v2, v1)
+	}
+
+	v1 = NewArray[Int64]()
+	data, err = v1.MarshalBinary()
+	errorPanic(err)
+	errorPanic(v2.UnmarshalBinary(data))
+	isArrayNull(t, v2, "UnmarshalBinary(null)")
+}
@@ -0,0 +1,295 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	arrayNullString = "NULL"                                // Элемент NULL литерала массива
+	arrayQuoteChars = "{}\",\\ \t\n\r\v\f"                  // Символы, требующие заключения элемента в кавычки
+	arrayTimeLayout = "2006-01-02 15:04:05.999999999Z07:00" // Формат timestamptz в текстовом представлении PostgreSQL
+)
+
+// Элемент литерала массива PostgreSQL
+type arrayItem struct {
+	Value string // Текстовое представление элемента
+	Null  bool   // Элемент равен NULL
+}
+
+// Разбор литерала массива PostgreSQL
+type arrayParser struct {
+	src   string      // Литерал массива
+	pos   int         // Текущая позиция разбора
+	items []arrayItem // Элементы массива в порядке строк
+	dims  []int       // Размеры измерений массива
+	depth int         // Уровень вложенности элементов, -1 если ещё не известен
+}
+
+// Разбор литерала массива PostgreSQL вида {1,NULL,"a b"} и {{1,2},{3,4}}
+// Литерал может начинаться с указания границ измерений вида [1:2]=, границы отбрасываются
+func parseArrayLiteral(str string) (items []arrayItem, dims []int, err error) {
+	var p = &arrayParser{src: str, depth: -1}
+
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] == '[' {
+		n := strings.IndexByte(p.src[p.pos:], '=')
+		if n < 0 {
+			err = fmt.Errorf("can't parse %q as array: invalid dimensions", str)
+			return
+		}
+		p.pos += n + 1
+		p.skipSpace()
+	}
+	if err = p.parseLevel(0); err != nil {
+		err = fmt.Errorf("can't parse %q as array: %s", str, err)
+		return
+	}
+	if p.skipSpace(); p.pos != len(p.src) {
+		err = fmt.Errorf("can't parse %q as array: unexpected %q at position %d", str, p.src[p.pos], p.pos)
+		return
+	}
+	if items, dims = p.items, p.dims; len(items) == 0 {
+		dims = nil
+	}
+
+	return
+}
+
+// Пропуск пробельных символов
+func (p *arrayParser) skipSpace() {
+	for p.pos < len(p.src) && isArraySpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+// Разбор уровня массива, начиная с открывающей фигурной скобки
+func (p *arrayParser) parseLevel(level int) (err error) {
+	var count int
+
+	if p.pos >= len(p.src) || p.src[p.pos] != '{' {
+		return fmt.Errorf("expected '{' at position %d", p.pos)
+	}
+	if p.pos++; level == len(p.dims) {
+		p.dims = append(p.dims, -1)
+	}
+	if p.skipSpace(); p.pos < len(p.src) && p.src[p.pos] == '}' {
+		p.pos++
+		if level > 0 {
+			return fmt.Errorf("empty sub-array at position %d", p.pos-1)
+		}
+		p.dims[level] = 0
+		return
+	}
+	for {
+		p.skipSpace()
+		switch {
+		case p.pos < len(p.src) && p.src[p.pos] == '{':
+			if p.depth >= 0 && p.depth <= level {
+				return fmt.Errorf("unexpected sub-array at position %d", p.pos)
+			}
+			err = p.parseLevel(level + 1)
+		default:
+			if p.depth >= 0 && p.depth != level {
+				return fmt.Errorf("expected sub-array at position %d", p.pos)
+			}
+			p.depth = level
+			err = p.parseItem()
+		}
+		if err != nil {
+			return
+		}
+		count++
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return fmt.Errorf("unexpected end of array")
+		}
+		if p.src[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		if p.src[p.pos] == '}' {
+			p.pos++
+			break
+		}
+		return fmt.Errorf("unexpected %q at position %d", p.src[p.pos], p.pos)
+	}
+	switch p.dims[level] {
+	case -1:
+		p.dims[level] = count
+	case count:
+	default:
+		return fmt.Errorf("sub-arrays have different sizes")
+	}
+
+	return
+}
+
+// Разбор элемента массива в кавычках или без кавычек
+func (p *arrayParser) parseItem() (err error) {
+	var (
+		buf     strings.Builder
+		quoted  bool
+		escaped bool
+		end     int
+	)
+
+	if quoted = p.pos < len(p.src) && p.src[p.pos] == '"'; quoted {
+		p.pos++
+	}
+	for ; p.pos < len(p.src); p.pos++ {
+		c := p.src[p.pos]
+		switch {
+		case c == '\\':
+			if p.pos++; p.pos >= len(p.src) {
+				return fmt.Errorf("unexpected end of array")
+			}
+			buf.WriteByte(p.src[p.pos])
+			escaped, end = true, buf.Len()
+			continue
+		case quoted && c == '"':
+			p.pos++
+			p.items = append(p.items, arrayItem{Value: buf.String()})
+			return
+		case !quoted && (c == ',' || c == '}'):
+			if buf.Len() == 0 {
+				return fmt.Errorf("empty element at position %d", p.pos)
+			}
+			str := buf.String()[:end]
+			if !escaped && strings.EqualFold(str, arrayNullString) {
+				p.items = append(p.items, arrayItem{Null: true})
+				return
+			}
+			p.items = append(p.items, arrayItem{Value: str})
+			return
+		case !quoted && (c == '{' || c == '"'):
+			return fmt.Errorf("unexpected %q at position %d", c, p.pos)
+		}
+		if buf.WriteByte(c); quoted || !isArraySpace(c) {
+			end = buf.Len()
+		}
+	}
+
+	return fmt.Errorf("unexpected end of array")
+}
+
+// Пробельный символ литерала массива
+func isArraySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// Форматирование литерала массива PostgreSQL
+func formatArrayLiteral(items []arrayItem, dims []int) string {
+	var buf strings.Builder
+
+	if len(dims) == 0 {
+		dims = []int{len(items)}
+	}
+	writeArrayLevel(&buf, items, dims)
+
+	return buf.String()
+}
+
+// Форматирование уровня массива
+func writeArrayLevel(buf *strings.Builder, items []arrayItem, dims []int) {
+	var stride = 1
+
+	for _, n := range dims[1:] {
+		stride *= n
+	}
+	buf.WriteByte('{')
+	for i := 0; i < dims[0]; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if len(dims) > 1 {
+			writeArrayLevel(buf, items[i*stride:(i+1)*stride], dims[1:])
+			continue
+		}
+		writeArrayItem(buf, items[i])
+	}
+	buf.WriteByte('}')
+}
+
+// Форматирование элемента массива с заключением в кавычки при необходимости
+func writeArrayItem(buf *strings.Builder, item arrayItem) {
+	if item.Null {
+		buf.WriteString(arrayNullString)
+		return
+	}
+	if item.Value != "" && !strings.EqualFold(item.Value, arrayNullString) && !strings.ContainsAny(item.Value, arrayQuoteChars) {
+		buf.WriteString(item.Value)
+		return
+	}
+	buf.WriteByte('"')
+	for i := 0; i < len(item.Value); i++ {
+		if item.Value[i] == '"' || item.Value[i] == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(item.Value[i])
+	}
+	buf.WriteByte('"')
+}
+
+// Текстовое представление значения driver.Value в формате элемента массива PostgreSQL
+func formatArrayValue(value interface{}) (ret arrayItem, err error) {
+	switch x := value.(type) {
+	case nil:
+		ret.Null = true
+	case int64:
+		ret.Value = strconv.FormatInt(x, 10)
+	case float64:
+		switch {
+		case math.IsInf(x, 1):
+			ret.Value = "Infinity"
+		case math.IsInf(x, -1):
+			ret.Value = "-Infinity"
+		case math.IsNaN(x):
+			ret.Value = "NaN"
+		default:
+			ret.Value = strconv.FormatFloat(x, 'g', -1, 64)
+		}
+	case bool:
+		ret.Value = "f"
+		if x {
+			ret.Value = "t"
+		}
+	case []byte:
+		ret.Value = `\x` + hex.EncodeToString(x)
+	case string:
+		ret.Value = x
+	case time.Time:
+		ret.Value = x.Format(arrayTimeLayout)
+	default:
+		err = fmt.Errorf("can't format type %T as array element", value)
+	}
+
+	return
+}
+
+// ParseTimestamp Разбор метки времени в текстовом представлении PostgreSQL "2006-01-02 15:04:05.999999-07"
+// или в формате RFC3339. Метка времени без часового пояса считается меткой времени в UTC
+func ParseTimestamp(str string) (ret time.Time, err error) {
+	var layouts = []string{
+		"2006-01-02 15:04:05.999999999Z07:00:00",
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999Z07",
+		"2006-01-02 15:04:05.999999999",
+		time.RFC3339Nano,
+	}
+
+	for _, layout := range layouts {
+		if ret, err = time.Parse(layout, str); err == nil {
+			return
+		}
+	}
+	err = fmt.Errorf("can't parse %q as timestamp", str)
+
+	return
+}
@@ -0,0 +1,120 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseArrayLiteral(t *testing.T) {
+	var tests = []struct {
+		In    string
+		Items []arrayItem
+		Dims  []int
+	}{
+		{`{}`, nil, nil},
+		{` { } `, nil, nil},
+		{`{1,2,3}`, []arrayItem{{Value: "1"}, {Value: "2"}, {Value: "3"}}, []int{3}},
+		{`{1,NULL,null}`, []arrayItem{{Value: "1"}, {Null: true}, {Null: true}}, []int{3}},
+		{`{"NULL",\NULL}`, []arrayItem{{Value: "NULL"}, {Value: "NULL"}}, []int{2}},
+		{`{"a b","",  c d  }`, []arrayItem{{Value: "a b"}, {Value: ""}, {Value: "c d"}}, []int{3}},
+		{`{"a\"b","c\\d",e\,f}`, []arrayItem{{Value: `a"b`}, {Value: `c\d`}, {Value: "e,f"}}, []int{3}},
+		{`{{1,2},{3,NULL}}`, []arrayItem{{Value: "1"}, {Value: "2"}, {Value: "3"}, {Null: true}}, []int{2, 2}},
+		{`[1:1][0:1]={{1,2}}`, []arrayItem{{Value: "1"}, {Value: "2"}}, []int{1, 2}},
+	}
+
+	for _, test := range tests {
+		items, dims, err := parseArrayLiteral(test.In)
+		errorPanic(err)
+		if !reflect.DeepEqual(items, test.Items) {
+			t.Errorf("parseArrayLiteral(%q) items is %v, but should be %v", test.In, items, test.Items)
+		}
+		if !reflect.DeepEqual(dims, test.Dims) {
+			t.Errorf("parseArrayLiteral(%q) dims is %v, but should be %v", test.In, dims, test.Dims)
+		}
+	}
+	for _, in := range []string{``, `1,2`, `{1,2`, `{1,,2}`, `{1,2}x`, `{{1,2},{3}}`, `{{1},2}`, `{1,{2}}`, `{{}}`, `{"a}`, `{a"b}`, `[1:2]{1,2}`} {
+		if _, _, err := parseArrayLiteral(in); err == nil {
+			t.Errorf("parseArrayLiteral(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestFormatArrayLiteral(t *testing.T) {
+	var tests = []struct {
+		Items []arrayItem
+		Dims  []int
+		Out   string
+	}{
+		{nil, nil, `{}`},
+		{[]arrayItem{{Value: "1"}, {Null: true}}, nil, `{1,NULL}`},
+		{[]arrayItem{{Value: ""}, {Value: "null"}, {Value: "a b"}, {Value: `a"b\c`}}, nil, `{"","null","a b","a\"b\\c"}`},
+		{[]arrayItem{{Value: "{x}"}, {Value: "a,b"}}, nil, `{"{x}","a,b"}`},
+		{[]arrayItem{{Value: "1"}, {Value: "2"}, {Value: "3"}, {Value: "4"}, {Value: "5"}, {Value: "6"}}, []int{2, 3}, `{{1,2,3},{4,5,6}}`},
+	}
+
+	for _, test := range tests {
+		if out := formatArrayLiteral(test.Items, test.Dims); out != test.Out {
+			t.Errorf("formatArrayLiteral(%v, %v) is %q, but should be %q", test.Items, test.Dims, out, test.Out)
+		}
+		if len(test.Items) == 0 {
+			continue
+		}
+		items, _, err := parseArrayLiteral(test.Out)
+		errorPanic(err)
+		if !reflect.DeepEqual(items, test.Items) {
+			t.Errorf("parseArrayLiteral(%q) items is %v, but should be %v", test.Out, items, test.Items)
+		}
+	}
+}
+
+func TestFormatArrayValue(t *testing.T) {
+	var tests = []struct {
+		In  interface{}
+		Out arrayItem
+	}{
+		{nil, arrayItem{Null: true}},
+		{int64(-12), arrayItem{Value: "-12"}},
+		{float64(1.5), arrayItem{Value: "1.5"}},
+		{true, arrayItem{Value: "t"}},
+		{false, arrayItem{Value: "f"}},
+		{[]byte{0xde, 0xad}, arrayItem{Value: `\xdead`}},
+		{"text", arrayItem{Value: "text"}},
+		{time.Date(2012, 12, 21, 21, 21, 21, 500000000, time.UTC), arrayItem{Value: "2012-12-21 21:21:21.5Z"}},
+	}
+
+	for _, test := range tests {
+		out, err := formatArrayValue(test.In)
+		errorPanic(err)
+		if out != test.Out {
+			t.Errorf("formatArrayValue(%v) is %v, but should be %v", test.In, out, test.Out)
+		}
+	}
+	if _, err := formatArrayValue(struct{}{}); err == nil {
+		t.Error("formatArrayValue(struct{}{}) error is nil, but should be not nil")
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	var expected = time.Date(2012, 12, 21, 21, 21, 21, 500000000, time.UTC)
+
+	for _, in := range []string{
+		"2012-12-21 21:21:21.5+00",
+		"2012-12-21 23:21:21.5+02",
+		"2012-12-21 23:51:21.5+02:30",
+		"2012-12-21 21:21:21.5Z",
+		"2012-12-21 21:21:21.5",
+		"2012-12-21T21:21:21.5Z",
+	} {
+		tm, err := ParseTimestamp(in)
+		errorPanic(err)
+		if !tm.Equal(expected) {
+			t.Errorf("ParseTimestamp(%q) is %v, but should be %v", in, tm, expected)
+		}
+	}
+	if _, err := ParseTimestamp("2012-12-21"); err == nil {
+		t.Error("ParseTimestamp() error is nil, but should be not nil")
+	}
+}
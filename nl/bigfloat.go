@@ -0,0 +1,413 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+const (
+	bigFloatMaxExp   = -decMinScale // Наибольший десятичный порядок числа при разборе, как у NUMERIC в PostgreSQL
+	bigFloatMinExp   = -decMaxScale // Наименьший десятичный порядок числа при разборе, как у NUMERIC в PostgreSQL
+	bigFloatFixedExp = 1000         // Наибольший по модулю десятичный порядок числа, записываемого без экспоненты
+)
+
+// BigFloat is an nullable big.Float object, for NUMERIC columns with values of any length
+type BigFloat struct {
+	BigFloat *big.Float // Value of object, nil is zero
+	Valid    bool       // Valid is true if value is not NULL
+	Quoted   bool       // Quoted is true if MarshalJSON writes value as quoted string instead of number
+}
+
+// NewBigFloat Создание нового объекта BigFloat
+func NewBigFloat() BigFloat {
+	return BigFloat{
+		BigFloat: nil,
+		Valid:    false,
+	}
+}
+
+// NewBigFloatValue Создание нового действительного объекта BigFloat из значения, значение копируется
+func NewBigFloatValue(value *big.Float) BigFloat {
+	return BigFloat{
+		BigFloat: copyBigFloat(value),
+		Valid:    true,
+	}
+}
+
+// NewBigFloatPointerValue Создание нового объекта BigFloat из ссылки на значение, nil является значением null
+func NewBigFloatPointerValue(ptr *big.Float) BigFloat {
+	if ptr == nil {
+		return NewBigFloat()
+	}
+	return NewBigFloatValue(ptr)
+}
+
+// NewBigFloatString Создание нового действительного объекта BigFloat из десятичного представления числа
+func NewBigFloatString(str string) (ret BigFloat, err error) {
+	var value *big.Float
+
+	if value, err = ParseBigFloat(str); err != nil {
+		return
+	}
+	ret = BigFloat{BigFloat: value, Valid: true}
+
+	return
+}
+
+// ParseBigFloat Разбор числа в десятичной записи с необязательной экспонентой, а также "Infinity" и "-Infinity"
+// Точность результата достаточна для точного хранения всех десятичных цифр мантиссы, но не менее 64 бит.
+// Десятичный порядок числа ограничен диапазоном NUMERIC в PostgreSQL, так как время и память форматирования
+// числа растут вместе с порядком
+func ParseBigFloat(str string) (ret *big.Float, err error) {
+	const minPrec = 64
+	var (
+		src    = str
+		digits int
+		prec   uint
+	)
+
+	str = strings.TrimSpace(str)
+	switch strings.ToLower(strings.TrimLeft(str, "+-")) {
+	case "infinity", "inf":
+		ret = new(big.Float).SetInf(strings.HasPrefix(str, "-"))
+		return
+	}
+	for i := 0; i < len(str) && str[i] != 'e' && str[i] != 'E'; i++ {
+		if str[i] >= '0' && str[i] <= '9' {
+			digits++
+		}
+	}
+	if prec = uint(math.Ceil(float64(digits) * math.Log2(10))); prec < minPrec {
+		prec = minPrec
+	}
+	if ret, _, err = big.ParseFloat(str, 10, prec, big.ToNearestEven); err != nil || ret.IsInf() {
+		ret, err = nil, fmt.Errorf("can't parse %q as number", src)
+		return
+	}
+	if err = checkBigFloatExp(ret); err != nil {
+		ret, err = nil, fmt.Errorf("can't parse %q as number: %s", src, err)
+	}
+
+	return
+}
+
+// Приблизительный десятичный порядок числа, порядок нуля и бесконечности равен нулю
+func bigFloatExp(value *big.Float) int {
+	return int(math.Floor(float64(value.MantExp(nil)) * math.Log10(2)))
+}
+
+// Проверка десятичного порядка числа
+func checkBigFloatExp(value *big.Float) (err error) {
+	var exp int
+
+	if value == nil {
+		return
+	}
+	if exp = bigFloatExp(value); exp > bigFloatMaxExp || exp < bigFloatMinExp {
+		err = fmt.Errorf("exponent %d out of range", exp)
+	}
+
+	return
+}
+
+// Копия числа, nil копируется как ноль
+func copyBigFloat(value *big.Float) *big.Float {
+	if value == nil {
+		return new(big.Float)
+	}
+	return new(big.Float).Set(value)
+}
+
+// SetValid Изменение значения и установка флага действительного значения, значение копируется
+func (bf *BigFloat) SetValid(value *big.Float) { bf.BigFloat, bf.Valid = copyBigFloat(value), true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (bf *BigFloat) Reset() { bf.BigFloat, bf.Valid = nil, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (bf *BigFloat) NullIfDefault() BigFloat {
+	if bf.BigFloat == nil || bf.BigFloat.Sign() == 0 {
+		bf.Reset()
+	}
+	return *bf
+}
+
+// MustValue Возвращает значение в любом случае
+func (bf *BigFloat) MustValue() *big.Float {
+	if !bf.Valid || bf.BigFloat == nil {
+		return new(big.Float)
+	}
+	return bf.BigFloat
+}
+
+// Pointer Возвращает ссылку на значение
+func (bf *BigFloat) Pointer() *big.Float {
+	if !bf.Valid {
+		return nil
+	}
+	if bf.BigFloat == nil {
+		bf.BigFloat = new(big.Float)
+	}
+	return bf.BigFloat
+}
+
+// Десятичное представление значения
+// Бесконечность представляется как "Infinity" и "-Infinity", как у NUMERIC в PostgreSQL.
+// Число с десятичным порядком больше bigFloatFixedExp по модулю записывается в экспоненциальной форме
+func (bf BigFloat) String() string {
+	switch {
+	case bf.BigFloat == nil:
+		return "0"
+	case bf.BigFloat.IsInf() && bf.BigFloat.Signbit():
+		return "-Infinity"
+	case bf.BigFloat.IsInf():
+		return "Infinity"
+	case bigFloatExp(bf.BigFloat) > bigFloatFixedExp, bigFloatExp(bf.BigFloat) < -bigFloatFixedExp:
+		return bf.BigFloat.Text('e', -1)
+	default:
+		return bf.BigFloat.Text('f', -1)
+	}
+}
+
+// Scan Реализация интерфейса Scanner
+func (bf *BigFloat) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		bf.Reset()
+		return
+	case int64:
+		bf.BigFloat = new(big.Float).SetInt64(x)
+	case float64:
+		if math.IsNaN(x) {
+			err = fmt.Errorf("can't scan NaN into nul.BigFloat")
+			break
+		}
+		bf.BigFloat = new(big.Float).SetFloat64(x)
+	case []byte:
+		bf.BigFloat, err = ParseBigFloat(string(x))
+	case string:
+		bf.BigFloat, err = ParseBigFloat(x)
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.BigFloat: %v", x, value)
+	}
+	if bf.Valid = err == nil; !bf.Valid {
+		bf.BigFloat = nil
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (bf BigFloat) Value() (driver.Value, error) {
+	if !bf.Valid {
+		return nil, nil
+	}
+	return bf.String(), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (bf *BigFloat) UnmarshalJSON(data []byte) (err error) {
+	var (
+		dec *json.Decoder
+		v   interface{}
+	)
+
+	dec = json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		bf.Reset()
+		return
+	case json.Number:
+		bf.BigFloat, err = ParseBigFloat(x.String())
+	case string:
+		if len(x) == 0 {
+			bf.Reset()
+			return
+		}
+		bf.BigFloat, err = ParseBigFloat(x)
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.BigFloat", reflect.TypeOf(v).Kind())
+	}
+	if bf.Valid = err == nil; !bf.Valid {
+		bf.BigFloat = nil
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (bf BigFloat) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !bf.Valid {
+		data = []byte(nullString)
+		return
+	}
+	if bf.Quoted {
+		data = []byte(strconv.Quote(bf.String()))
+		return
+	}
+	if bf.BigFloat != nil && bf.BigFloat.IsInf() {
+		err = fmt.Errorf("can't marshal infinity into JSON number, use Quoted")
+		return
+	}
+	data = []byte(bf.String())
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (bf *BigFloat) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		bf.Reset()
+		return
+	case emptyString:
+		bf.BigFloat, bf.Valid = new(big.Float), true
+		return
+	default:
+		bf.BigFloat, err = ParseBigFloat(str)
+	}
+	if bf.Valid = err == nil; !bf.Valid {
+		bf.BigFloat = nil
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (bf BigFloat) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !bf.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(bf.String())
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (bf *BigFloat) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return bf.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagBigFloat, bf)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+// Значение кодируется методом big.Float.GobEncode с сохранением точности и режима округления
+func (bf BigFloat) MarshalBinary() (data []byte, err error) {
+	return marshalBinary(binaryTagBigFloat, bf)
+}
+
+// Запись значения в компактном двоичном формате
+func (bf BigFloat) encodeBinary(w *binaryWriter) {
+	var data, err = bf.BigFloat.GobEncode()
+
+	w.fail(err)
+	if w.flags(bf.Valid, bf.Quoted); !bf.Valid {
+		return
+	}
+	w.bytes(data)
+}
+
+// Чтение значения в компактном двоичном формате
+func (bf *BigFloat) decodeBinary(r *binaryReader) {
+	var (
+		valid, quoted bool
+		value         = new(big.Float)
+	)
+
+	if r.flags(&valid, &quoted); !valid {
+		if r.err == nil {
+			bf.BigFloat, bf.Valid, bf.Quoted = nil, false, quoted
+		}
+		return
+	}
+	if data := r.bytes(); r.err == nil {
+		r.fail(value.GobDecode(data))
+	}
+	if r.err == nil {
+		r.fail(checkBigFloatExp(value))
+	}
+	if r.err != nil {
+		return
+	}
+	bf.BigFloat, bf.Valid, bf.Quoted = value, valid, quoted
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (bf *BigFloat) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.BigFloatWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.BigFloatWrapper)
+	if err = dec.Decode(item); err == nil {
+		err = checkBigFloatExp(item.Value)
+	}
+	if err == nil {
+		bf.BigFloat, bf.Valid, bf.Quoted = item.Value, item.Valid, item.Quoted
+		if !bf.Valid {
+			bf.BigFloat = nil
+		}
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (bf *BigFloat) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, bf) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой текстового представления
+func (bf BigFloat) MarshalMsgpack() ([]byte, error) { return marshalMsgpackText(bf.Valid, bf) }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (bf *BigFloat) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, bf) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой текстового представления
+func (bf BigFloat) MarshalCBOR() ([]byte, error) { return marshalCBORText(bf.Valid, bf) }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (bf *BigFloat) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, bf)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (bf BigFloat) MarshalYAML() (interface{}, error) { return marshalYAMLText(bf.Valid, bf) }
@@ -0,0 +1,273 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+var (
+	bigFloatString     = "-12345678901234567890.123456789012345678901"
+	bigFloatJSON       = []byte(bigFloatString)
+	bigFloatQuotedJSON = []byte(`"` + bigFloatString + `"`)
+)
+
+func isBigFloatValid(t *testing.T, bf BigFloat, from string) {
+	if bf.String() != bigFloatString {
+		t.Errorf("Bad %s big float: %v ≠ %v\n", from, bf.String(), bigFloatString)
+	}
+	if !bf.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isBigFloatNull(t *testing.T, bf BigFloat, from string) {
+	if bf.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestParseBigFloat(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out string
+	}{
+		{bigFloatString, bigFloatString},
+		{"0.1", "0.1"},
+		{"1e3", "1000"},
+		{"-1.5E-3", "-0.0015"},
+		{"123456789012345678901234567890123456789", "123456789012345678901234567890123456789"},
+		{"Infinity", "Infinity"},
+		{"-Infinity", "-Infinity"},
+		{"-inf", "-Infinity"},
+		{"1e1000", "1" + strings.Repeat("0", 1000)},
+		{"1.5e1001", "1.5e+1001"},
+		{"-2.5e-1002", "-2.5e-1002"},
+		{"1e131072", "1e+131072"},
+	}
+
+	for _, test := range tests {
+		v, err := ParseBigFloat(test.In)
+		errorPanic(err)
+		if s := NewBigFloatValue(v).String(); s != test.Out {
+			t.Errorf("ParseBigFloat(%q) is %v, but should be %v", test.In, s, test.Out)
+		}
+	}
+	for _, in := range []string{"", "NaN", "1.2.3", "0x1p-2x", "one", "1e131073", "1e-16384", "1e300000000"} {
+		if _, err := ParseBigFloat(in); err == nil {
+			t.Errorf("ParseBigFloat(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestBigFloatHugeExponent(t *testing.T) {
+	var (
+		v    BigFloat
+		huge = new(big.Float).SetMantExp(big.NewFloat(1), 1<<30)
+		err  error
+	)
+
+	for _, data := range []string{`"1e300000000"`, `1e300000000`, `-1e-300000000`} {
+		v = NewBigFloatValue(big.NewFloat(1))
+		if err = v.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) error is nil, but should be not nil", data)
+		}
+		isBigFloatNull(t, v, "UnmarshalJSON("+data+")")
+	}
+	data, err := huge.GobEncode()
+	errorPanic(err)
+	data = append([]byte{binaryVersion1, binaryTagBigFloat, 1, byte(len(data))}, data...)
+	if err = v.UnmarshalBinary(data); err == nil {
+		t.Error("UnmarshalBinary()", "error is nil, but should be not nil")
+	}
+}
+
+func TestNewBigFloat(t *testing.T) {
+	v1 := NewBigFloat()
+	isBigFloatNull(t, v1, "NewBigFloat()")
+
+	value, err := ParseBigFloat(bigFloatString)
+	errorPanic(err)
+	v2 := NewBigFloatValue(value)
+	isBigFloatValid(t, v2, "NewBigFloatValue()")
+	value.SetInt64(0)
+	isBigFloatValid(t, v2, "NewBigFloatValue(copy)")
+
+	v3 := NewBigFloatPointerValue(nil)
+	isBigFloatNull(t, v3, "NewBigFloatPointerValue()")
+
+	v4 := NewBigFloatPointerValue(v2.Pointer())
+	isBigFloatValid(t, v4, "NewBigFloatPointerValue()")
+
+	v5, err := NewBigFloatString(bigFloatString)
+	errorPanic(err)
+	isBigFloatValid(t, v5, "NewBigFloatString()")
+}
+
+func TestBigFloatSetValidReset(t *testing.T) {
+	v1 := NewBigFloat()
+	if v1.Pointer() != nil || v1.MustValue().Sign() != 0 {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(big.NewFloat(1.5))
+	if !v1.Valid || v1.String() != "1.5" {
+		t.Error("SetValid()", "is wrong")
+	}
+	v1.NullIfDefault()
+	if !v1.Valid {
+		t.Error("NullIfDefault()", "is invalid, but should be valid")
+	}
+	v1.SetValid(new(big.Float))
+	v1.NullIfDefault()
+	isBigFloatNull(t, v1, "NullIfDefault()")
+}
+
+func TestBigFloatScanValue(t *testing.T) {
+	v1 := NewBigFloat()
+	errorPanic(v1.Scan([]byte(bigFloatString)))
+	isBigFloatValid(t, v1, "Scan([]byte)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != bigFloatString {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewBigFloat()
+	errorPanic(v2.Scan(bigFloatString))
+	isBigFloatValid(t, v2, "Scan(string)")
+
+	v3 := NewBigFloat()
+	errorPanic(v3.Scan(int64(-42)))
+	if !v3.Valid || v3.String() != "-42" {
+		t.Error("Scan(int64)", "is wrong")
+	}
+	errorPanic(v3.Scan(0.25))
+	if !v3.Valid || v3.String() != "0.25" {
+		t.Error("Scan(float64)", "is wrong")
+	}
+
+	v4 := NewBigFloatValue(big.NewFloat(1))
+	errorPanic(v4.Scan(nil))
+	isBigFloatNull(t, v4, "Scan(nil)")
+	dv, err = v4.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v5 := NewBigFloat()
+	if err = v5.Scan(math.NaN()); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v5.Scan(true); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isBigFloatNull(t, v5, "Scan()")
+}
+
+func TestBigFloatUnmarshalJSON(t *testing.T) {
+	var err error
+
+	for _, data := range [][]byte{bigFloatJSON, bigFloatQuotedJSON} {
+		v := NewBigFloat()
+		errorPanic(json.Unmarshal(data, &v))
+		isBigFloatValid(t, v, "UnmarshalJSON("+string(data)+")")
+	}
+
+	v1 := NewBigFloatValue(big.NewFloat(1))
+	errorPanic(json.Unmarshal(boolNullJSON, &v1))
+	isBigFloatNull(t, v1, "UnmarshalJSON(null)")
+
+	v2 := NewBigFloat()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v2))
+	isBigFloatNull(t, v2, "UnmarshalJSON(blank)")
+
+	v3 := NewBigFloat()
+	if err = json.Unmarshal(boolTrueJSON, &v3); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = v3.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isBigFloatNull(t, v3, "UnmarshalJSON()")
+}
+
+func TestBigFloatMarshalJSON(t *testing.T) {
+	v1, err := NewBigFloatString(bigFloatString)
+	errorPanic(err)
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(bigFloatJSON), "MarshalJSON()")
+
+	v1.Quoted = true
+	data, err = json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(bigFloatQuotedJSON), "MarshalJSON(quoted)")
+
+	v2 := NewBigFloat()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+
+	v3 := NewBigFloatValue(new(big.Float).SetInf(false))
+	if _, err = json.Marshal(v3); err == nil {
+		t.Error("MarshalJSON(Infinity)", "is nil, but should be not nil")
+	}
+	v3.Quoted = true
+	data, err = json.Marshal(v3)
+	errorPanic(err)
+	jsonEquals(t, data, `"Infinity"`, "MarshalJSON(Infinity)")
+}
+
+func TestBigFloatText(t *testing.T) {
+	v1 := NewBigFloat()
+	errorPanic(v1.UnmarshalText(bigFloatJSON))
+	isBigFloatValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, bigFloatString, "MarshalText()")
+
+	v2 := NewBigFloat()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || v2.BigFloat.Sign() != 0 {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewBigFloatValue(big.NewFloat(1))
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isBigFloatNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewBigFloat()
+	if err = v4.UnmarshalText([]byte("1,5")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isBigFloatNull(t, v4, "UnmarshalText()")
+}
+
+func TestBigFloatBinary(t *testing.T) {
+	v1, err := NewBigFloatString(bigFloatString)
+	errorPanic(err)
+	v1.Quoted = true
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewBigFloat()
+	errorPanic(v2.UnmarshalBinary(data))
+	isBigFloatValid(t, v2, "UnmarshalBinary()")
+	if !v2.Quoted || v2.BigFloat.Prec() != v1.BigFloat.Prec() {
+		t.Error("UnmarshalBinary()", "lost Quoted or precision")
+	}
+
+	v3 := NewBigFloat()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewBigFloatValue(big.NewFloat(1))
+	errorPanic(v4.UnmarshalBinary(data))
+	isBigFloatNull(t, v4, "UnmarshalBinary(null)")
+}
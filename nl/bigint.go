@@ -0,0 +1,394 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// BigInt is an nullable big.Int object, for NUMERIC columns with integer values of any length
+type BigInt struct {
+	BigInt *big.Int // Value of object, nil is zero
+	Valid  bool     // Valid is true if value is not NULL
+	Quoted bool     // Quoted is true if MarshalJSON writes value as quoted string instead of number
+}
+
+// NewBigInt Создание нового объекта BigInt
+func NewBigInt() BigInt {
+	return BigInt{
+		BigInt: nil,
+		Valid:  false,
+	}
+}
+
+// NewBigIntValue Создание нового действительного объекта BigInt из значения, значение копируется
+func NewBigIntValue(value *big.Int) BigInt {
+	return BigInt{
+		BigInt: copyBigInt(value),
+		Valid:  true,
+	}
+}
+
+// NewBigIntPointerValue Создание нового объекта BigInt из ссылки на значение, nil является значением null
+func NewBigIntPointerValue(ptr *big.Int) BigInt {
+	if ptr == nil {
+		return NewBigInt()
+	}
+	return NewBigIntValue(ptr)
+}
+
+// NewBigIntString Создание нового действительного объекта BigInt из десятичного представления числа
+func NewBigIntString(str string) (ret BigInt, err error) {
+	var value *big.Int
+
+	if value, err = ParseBigInt(str); err != nil {
+		return
+	}
+	ret = BigInt{BigInt: value, Valid: true}
+
+	return
+}
+
+// ParseBigInt Разбор целого числа в десятичной записи, допускается дробная часть из нулей, как у NUMERIC
+func ParseBigInt(str string) (ret *big.Int, err error) {
+	var (
+		src = str
+		ok  bool
+	)
+
+	str = strings.TrimSpace(str)
+	if n := strings.IndexByte(str, '.'); n >= 0 && strings.Trim(str[n+1:], "0") == "" {
+		str = str[:n]
+	}
+	if ret, ok = new(big.Int).SetString(str, 10); !ok {
+		ret, err = nil, fmt.Errorf("can't parse %q as integer", src)
+	}
+
+	return
+}
+
+// Копия числа, nil копируется как ноль
+func copyBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(value)
+}
+
+// SetValid Изменение значения и установка флага действительного значения, значение копируется
+func (bi *BigInt) SetValid(value *big.Int) { bi.BigInt, bi.Valid = copyBigInt(value), true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (bi *BigInt) Reset() { bi.BigInt, bi.Valid = nil, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (bi *BigInt) NullIfDefault() BigInt {
+	if bi.BigInt == nil || bi.BigInt.Sign() == 0 {
+		bi.Reset()
+	}
+	return *bi
+}
+
+// MustValue Возвращает значение в любом случае
+func (bi *BigInt) MustValue() *big.Int {
+	if !bi.Valid || bi.BigInt == nil {
+		return new(big.Int)
+	}
+	return bi.BigInt
+}
+
+// Pointer Возвращает ссылку на значение
+func (bi *BigInt) Pointer() *big.Int {
+	if !bi.Valid {
+		return nil
+	}
+	if bi.BigInt == nil {
+		bi.BigInt = new(big.Int)
+	}
+	return bi.BigInt
+}
+
+// Десятичное представление значения
+func (bi BigInt) String() string {
+	if bi.BigInt == nil {
+		return "0"
+	}
+	return bi.BigInt.String()
+}
+
+// Scan Реализация интерфейса Scanner
+// Целые числа любых знаковых и беззнаковых типов сканируются по виду значения
+func (bi *BigInt) Scan(value interface{}) (err error) {
+	var rv = reflect.ValueOf(value)
+
+	switch x := value.(type) {
+	case nil:
+		bi.Reset()
+		return
+	case []byte:
+		bi.BigInt, err = ParseBigInt(string(x))
+	case string:
+		bi.BigInt, err = ParseBigInt(x)
+	default:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			bi.BigInt = big.NewInt(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			bi.BigInt = new(big.Int).SetUint64(rv.Uint())
+		default:
+			err = fmt.Errorf("can't scan type %T into nul.BigInt: %v", x, value)
+		}
+	}
+	if bi.Valid = err == nil; !bi.Valid {
+		bi.BigInt = nil
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (bi BigInt) Value() (driver.Value, error) {
+	if !bi.Valid {
+		return nil, nil
+	}
+	return bi.String(), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (bi *BigInt) UnmarshalJSON(data []byte) (err error) {
+	var (
+		dec *json.Decoder
+		v   interface{}
+	)
+
+	dec = json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		bi.Reset()
+		return
+	case json.Number:
+		bi.BigInt, err = ParseBigInt(x.String())
+	case string:
+		if len(x) == 0 {
+			bi.Reset()
+			return
+		}
+		bi.BigInt, err = ParseBigInt(x)
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.BigInt", reflect.TypeOf(v).Kind())
+	}
+	if bi.Valid = err == nil; !bi.Valid {
+		bi.BigInt = nil
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (bi BigInt) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !bi.Valid {
+		data = []byte(nullString)
+		return
+	}
+	if bi.Quoted {
+		data = []byte(strconv.Quote(bi.String()))
+		return
+	}
+	data = []byte(bi.String())
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (bi *BigInt) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		bi.Reset()
+		return
+	case emptyString:
+		bi.BigInt, bi.Valid = new(big.Int), true
+		return
+	default:
+		bi.BigInt, err = ParseBigInt(str)
+	}
+	if bi.Valid = err == nil; !bi.Valid {
+		bi.BigInt = nil
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (bi BigInt) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !bi.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(bi.String())
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (bi *BigInt) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return bi.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagBigInt, bi)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+// Значение кодируется знаком и байтами абсолютного значения
+func (bi BigInt) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagBigInt, bi) }
+
+// Запись значения в компактном двоичном формате
+func (bi BigInt) encodeBinary(w *binaryWriter) {
+	var value = bi.BigInt
+
+	if value == nil {
+		value = new(big.Int)
+	}
+	if w.flags(bi.Valid, bi.Quoted, value.Sign() < 0); !bi.Valid {
+		return
+	}
+	w.bytes(value.Bytes())
+}
+
+// Чтение значения в компактном двоичном формате
+func (bi *BigInt) decodeBinary(r *binaryReader) {
+	var (
+		valid, quoted, neg bool
+		value              []byte
+	)
+
+	if r.flags(&valid, &quoted, &neg); !valid {
+		if r.err == nil {
+			bi.BigInt, bi.Valid, bi.Quoted = nil, false, quoted
+		}
+		return
+	}
+	if value = r.bytes(); r.err != nil {
+		return
+	}
+	bi.Valid, bi.Quoted = valid, quoted
+	if bi.BigInt = new(big.Int).SetBytes(value); neg {
+		bi.BigInt.Neg(bi.BigInt)
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (bi *BigInt) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.BigIntWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.BigIntWrapper)
+	if err = dec.Decode(item); err == nil {
+		bi.BigInt, bi.Valid, bi.Quoted = item.Value, item.Valid, item.Quoted
+		if !bi.Valid {
+			bi.BigInt = nil
+		}
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из целого числа либо из строки текстового представления
+func (bi *BigInt) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, bi) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется целым числом, если оно помещается в 64 бита, иначе строкой
+func (bi BigInt) MarshalMsgpack() ([]byte, error) {
+	switch {
+	case !bi.Valid:
+		return msgpack.AppendNil(nil), nil
+	case bi.BigInt == nil:
+		return msgpack.AppendInt(nil, 0), nil
+	case bi.BigInt.IsInt64():
+		return msgpack.AppendInt(nil, bi.BigInt.Int64()), nil
+	case bi.BigInt.IsUint64():
+		return msgpack.AppendUint(nil, bi.BigInt.Uint64()), nil
+	default:
+		return msgpack.AppendString(nil, bi.BigInt.String()), nil
+	}
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из целого числа либо из строки байт с тегом 2 или 3
+func (bi *BigInt) UnmarshalCBOR(data []byte) (err error) {
+	var (
+		value *big.Int
+		null  bool
+	)
+
+	if value, null, err = unmarshalCBOR(data, cbor.ReadBigInt); err == nil {
+		bi.BigInt, bi.Valid = value, !null
+	}
+
+	return
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется целым числом, если оно помещается в 64 бита, иначе строкой байт с тегом 2 или 3
+func (bi BigInt) MarshalCBOR() ([]byte, error) {
+	if !bi.Valid {
+		return cbor.AppendNull(nil), nil
+	}
+	return cbor.AppendBigInt(nil, bi.BigInt), nil
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (bi *BigInt) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, bi)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется целым числом, если оно помещается в 64 бита, иначе строкой
+func (bi BigInt) MarshalYAML() (interface{}, error) {
+	switch {
+	case !bi.Valid:
+		return nil, nil
+	case bi.BigInt == nil:
+		return 0, nil
+	case bi.BigInt.IsInt64():
+		return bi.BigInt.Int64(), nil
+	case bi.BigInt.IsUint64():
+		return bi.BigInt.Uint64(), nil
+	default:
+		return bi.BigInt.String(), nil
+	}
+}
@@ -0,0 +1,247 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+var (
+	bigIntString     = "-123456789012345678901234567890123456789"
+	bigIntJSON       = []byte(bigIntString)
+	bigIntQuotedJSON = []byte(`"` + bigIntString + `"`)
+)
+
+func bigIntTestValue() *big.Int {
+	ret, _ := new(big.Int).SetString(bigIntString, 10)
+	return ret
+}
+
+func isBigIntValid(t *testing.T, bi BigInt, from string) {
+	if bi.BigInt == nil || bi.BigInt.Cmp(bigIntTestValue()) != 0 {
+		t.Errorf("Bad %s big integer: %v ≠ %v\n", from, bi.BigInt, bigIntString)
+	}
+	if !bi.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isBigIntNull(t *testing.T, bi BigInt, from string) {
+	if bi.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestParseBigInt(t *testing.T) {
+	for _, in := range []string{bigIntString, bigIntString + ".000", " " + bigIntString + " ", bigIntString + "."} {
+		v, err := ParseBigInt(in)
+		errorPanic(err)
+		if v.Cmp(bigIntTestValue()) != 0 {
+			t.Errorf("ParseBigInt(%q) is %v, but should be %v", in, v, bigIntString)
+		}
+	}
+	for _, in := range []string{"", "1.5", "1e3", "0x10", "one", ".0"} {
+		if _, err := ParseBigInt(in); err == nil {
+			t.Errorf("ParseBigInt(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestNewBigInt(t *testing.T) {
+	v1 := NewBigInt()
+	isBigIntNull(t, v1, "NewBigInt()")
+
+	value := bigIntTestValue()
+	v2 := NewBigIntValue(value)
+	isBigIntValid(t, v2, "NewBigIntValue()")
+	value.SetInt64(0)
+	isBigIntValid(t, v2, "NewBigIntValue(copy)")
+
+	v3 := NewBigIntPointerValue(nil)
+	isBigIntNull(t, v3, "NewBigIntPointerValue()")
+
+	v4 := NewBigIntPointerValue(v2.Pointer())
+	isBigIntValid(t, v4, "NewBigIntPointerValue()")
+
+	v5, err := NewBigIntString(bigIntString)
+	errorPanic(err)
+	isBigIntValid(t, v5, "NewBigIntString()")
+	if _, err = NewBigIntString("x"); err == nil {
+		t.Error("NewBigIntString()", "is nil, but should be not nil")
+	}
+}
+
+func TestBigIntSetValidReset(t *testing.T) {
+	v1 := NewBigInt()
+	if v1.Pointer() != nil || v1.MustValue().Sign() != 0 {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(bigIntTestValue())
+	isBigIntValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isBigIntValid(t, v1, "NullIfDefault()")
+	v1.SetValid(nil)
+	if !v1.Valid || v1.MustValue().Sign() != 0 {
+		t.Error("SetValid(nil)", "is wrong")
+	}
+	v1.NullIfDefault()
+	isBigIntNull(t, v1, "NullIfDefault()")
+
+	v2 := BigInt{Valid: true}
+	if v2.Pointer() == nil || v2.String() != "0" {
+		t.Error("Pointer()", "is wrong for zero value")
+	}
+}
+
+func TestBigIntScanValue(t *testing.T) {
+	v1 := NewBigInt()
+	errorPanic(v1.Scan([]byte(bigIntString)))
+	isBigIntValid(t, v1, "Scan([]byte)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != bigIntString {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewBigInt()
+	errorPanic(v2.Scan(bigIntString))
+	isBigIntValid(t, v2, "Scan(string)")
+
+	v3 := NewBigInt()
+	errorPanic(v3.Scan(int64(-42)))
+	if !v3.Valid || v3.BigInt.Int64() != -42 {
+		t.Error("Scan(int64)", "is wrong")
+	}
+
+	v4 := NewBigIntValue(bigIntTestValue())
+	errorPanic(v4.Scan(nil))
+	isBigIntNull(t, v4, "Scan(nil)")
+	dv, err = v4.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v5 := NewBigInt()
+	for _, value := range []interface{}{int32(5), int8(-5), uint(5), uint64(1 << 63), uint8(255)} {
+		v := NewBigInt()
+		errorPanic(v.Scan(value))
+		if !v.Valid || v.String() != fmt.Sprint(value) {
+			t.Errorf("Scan(%T) is %v, but should be %v", value, v, value)
+		}
+	}
+	if err = v5.Scan(1.5); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v5.Scan("1.5"); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isBigIntNull(t, v5, "Scan()")
+}
+
+func TestBigIntUnmarshalJSON(t *testing.T) {
+	var err error
+
+	for _, data := range [][]byte{bigIntJSON, bigIntQuotedJSON} {
+		v := NewBigInt()
+		errorPanic(json.Unmarshal(data, &v))
+		isBigIntValid(t, v, "UnmarshalJSON("+string(data)+")")
+	}
+
+	v1 := NewBigIntValue(bigIntTestValue())
+	errorPanic(json.Unmarshal(boolNullJSON, &v1))
+	isBigIntNull(t, v1, "UnmarshalJSON(null)")
+
+	v2 := NewBigInt()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v2))
+	isBigIntNull(t, v2, "UnmarshalJSON(blank)")
+
+	v3 := NewBigInt()
+	if err = json.Unmarshal([]byte(`1.5`), &v3); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = json.Unmarshal(boolTrueJSON, &v3); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = v3.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isBigIntNull(t, v3, "UnmarshalJSON()")
+}
+
+func TestBigIntMarshalJSON(t *testing.T) {
+	v1 := NewBigIntValue(bigIntTestValue())
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(bigIntJSON), "MarshalJSON()")
+
+	v1.Quoted = true
+	data, err = json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(bigIntQuotedJSON), "MarshalJSON(quoted)")
+
+	v2 := NewBigInt()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+}
+
+func TestBigIntText(t *testing.T) {
+	v1 := NewBigInt()
+	errorPanic(v1.UnmarshalText(bigIntJSON))
+	isBigIntValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, bigIntString, "MarshalText()")
+
+	v2 := NewBigInt()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || v2.BigInt.Sign() != 0 {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewBigIntValue(bigIntTestValue())
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isBigIntNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewBigInt()
+	if err = v4.UnmarshalText([]byte("1e3")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isBigIntNull(t, v4, "UnmarshalText()")
+}
+
+func TestBigIntBinary(t *testing.T) {
+	v1 := NewBigIntValue(bigIntTestValue())
+	v1.Quoted = true
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewBigInt()
+	errorPanic(v2.UnmarshalBinary(data))
+	isBigIntValid(t, v2, "UnmarshalBinary()")
+	if !v2.Quoted {
+		t.Error("UnmarshalBinary()", "lost Quoted")
+	}
+
+	v3 := BigInt{Valid: true}
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewBigInt()
+	errorPanic(v4.UnmarshalBinary(data))
+	if !v4.Valid || v4.MustValue().Sign() != 0 {
+		t.Error("UnmarshalBinary(zero)", "is wrong")
+	}
+
+	v5 := NewBigInt()
+	data, err = v5.MarshalBinary()
+	errorPanic(err)
+	v6 := NewBigIntValue(bigIntTestValue())
+	errorPanic(v6.UnmarshalBinary(data))
+	isBigIntNull(t, v6, "UnmarshalBinary(null)")
+}
@@ -0,0 +1,495 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"reflect"
+)
+
+// Компактный двоичный формат MarshalBinary
+// Значение кодируется байтом версии формата, байтом тега типа, байтом флагов, младший бит которого является
+// флагом действительного значения, а остальные биты флагами конкретного типа, и данными значения.
+// Данные не действительного значения не записываются, значение определяется байтом флагов.
+// Целые числа записываются в формате varint, числа с плавающей точкой фиксированной длины в порядке little-endian,
+// строки и срезы байт с префиксом длины в формате varint.
+// Первый байт сообщения gob является длиной сообщения и не может принимать значения от 0x80 до 0xf7,
+// поэтому версия формата выбрана из этого диапазона, что позволяет отличать формат gob предыдущих версий.
+const binaryVersion1 = byte(0x81)
+
+// Теги типов компактного двоичного формата, значения тегов не изменяются, новые теги добавляются в конец
+const (
+	binaryTagBool byte = iota + 1
+	binaryTagBytes
+	binaryTagFloat64
+	binaryTagInt64
+	binaryTagString
+	binaryTagTime
+	binaryTagUint64
+	binaryTagNull
+	binaryTagInt32
+	binaryTagInt16
+	binaryTagInt8
+	binaryTagUint32
+	binaryTagUint16
+	binaryTagUint8
+	binaryTagFloat32
+	binaryTagDecimal
+	binaryTagUUID
+	binaryTagDuration
+	binaryTagDate
+	binaryTagTimeOfDay
+	binaryTagJSON
+	binaryTagJSONOf
+	binaryTagIP
+	binaryTagPrefix
+	binaryTagHardwareAddr
+	binaryTagBigInt
+	binaryTagBigFloat
+	binaryTagArray
+	binaryTagStringMap
+	binaryTagInterval
+	binaryTagRange
+	binaryTagPoint
+	binaryTagLineString
+	binaryTagPolygon
+	binaryTagOptional
+)
+
+// Способы кодирования значений обобщённых типов
+const (
+	binaryValueCodec     = iota // Тип библиотеки, поддерживающий компактный двоичный формат
+	binaryValueBasic            // Логическое значение, число, строка или срез байт
+	binaryValueMarshaler        // Тип, реализующий encoding.BinaryMarshaler и encoding.BinaryUnmarshaler
+	binaryValueGob              // Любой другой тип, кодируется gob
+)
+
+var (
+	binaryEncoderType     = reflect.TypeOf((*binaryEncoder)(nil)).Elem()
+	binaryDecoderType     = reflect.TypeOf((*binaryDecoder)(nil)).Elem()
+	binaryMarshalerType   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+// Запись значения в компактном двоичном формате без версии и тега
+type binaryEncoder interface {
+	encodeBinary(w *binaryWriter)
+}
+
+// Чтение значения в компактном двоичном формате без версии и тега
+// Значение изменяется только при отсутствии ошибки чтения
+type binaryDecoder interface {
+	decodeBinary(r *binaryReader)
+}
+
+// Кодирование значения в компактном двоичном формате
+func marshalBinary(tag byte, value binaryEncoder) (data []byte, err error) {
+	var w = &binaryWriter{buf: make([]byte, 0, 16)}
+
+	w.buf = append(w.buf, binaryVersion1, tag)
+	value.encodeBinary(w)
+	if err = w.err; err == nil {
+		data = w.buf
+	}
+
+	return
+}
+
+// Декодирование значения в компактном двоичном формате
+func unmarshalBinary(data []byte, tag byte, value binaryDecoder) (err error) {
+	var r *binaryReader
+
+	switch {
+	case len(data) < 2:
+		return io.ErrUnexpectedEOF
+	case data[0] != binaryVersion1:
+		return fmt.Errorf("unsupported binary format version 0x%02x", data[0])
+	case data[1] != tag:
+		return fmt.Errorf("can't unmarshal binary value with tag %d into %T", data[1], value)
+	}
+	r = &binaryReader{data: data[2:]}
+	value.decodeBinary(r)
+
+	return r.end()
+}
+
+// Данные являются сообщением gob предыдущих версий библиотеки
+func isGobBinary(data []byte) bool {
+	return len(data) > 0 && (data[0] < 0x80 || data[0] > 0xf7)
+}
+
+// Запись в компактном двоичном формате
+type binaryWriter struct {
+	buf []byte
+	err error
+}
+
+// Запись флагов, первым флагом передаётся флаг действительного значения
+func (w *binaryWriter) flags(flags ...bool) {
+	var b byte
+
+	for i := range flags {
+		if flags[i] {
+			b |= 1 << uint(i)
+		}
+	}
+	w.buf = append(w.buf, b)
+}
+
+// Запись логического значения
+func (w *binaryWriter) bool(value bool) {
+	if value {
+		w.buf = append(w.buf, 1)
+		return
+	}
+	w.buf = append(w.buf, 0)
+}
+
+// Запись целого числа со знаком в формате varint
+func (w *binaryWriter) varint(value int64) {
+	var tmp [binary.MaxVarintLen64]byte
+	w.buf = append(w.buf, tmp[:binary.PutVarint(tmp[:], value)]...)
+}
+
+// Запись целого числа без знака в формате varint
+func (w *binaryWriter) uvarint(value uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	w.buf = append(w.buf, tmp[:binary.PutUvarint(tmp[:], value)]...)
+}
+
+// Запись числа с плавающей точкой одинарной точности
+func (w *binaryWriter) float32(value float32) {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], math.Float32bits(value))
+	w.buf = append(w.buf, tmp[:]...)
+}
+
+// Запись числа с плавающей точкой двойной точности
+func (w *binaryWriter) float64(value float64) {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], math.Float64bits(value))
+	w.buf = append(w.buf, tmp[:]...)
+}
+
+// Запись среза байт фиксированной длины
+func (w *binaryWriter) fixed(value []byte) { w.buf = append(w.buf, value...) }
+
+// Запись среза байт с префиксом длины
+func (w *binaryWriter) bytes(value []byte) {
+	w.uvarint(uint64(len(value)))
+	w.buf = append(w.buf, value...)
+}
+
+// Запись строки с префиксом длины
+func (w *binaryWriter) string(value string) {
+	w.uvarint(uint64(len(value)))
+	w.buf = append(w.buf, value...)
+}
+
+// Запись координат
+func (w *binaryWriter) coords(value []Coord) {
+	w.uvarint(uint64(len(value)))
+	for i := range value {
+		w.float64(value[i].X)
+		w.float64(value[i].Y)
+	}
+}
+
+// Сохранение первой ошибки записи
+func (w *binaryWriter) fail(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// Чтение компактного двоичного формата
+// После первой ошибки чтения все методы возвращают нулевые значения
+type binaryReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// Сохранение первой ошибки чтения
+func (r *binaryReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// Завершение чтения, все данные должны быть прочитаны
+func (r *binaryReader) end() error {
+	if r.err == nil && r.pos != len(r.data) {
+		r.err = fmt.Errorf("unexpected %d bytes after end of binary value", len(r.data)-r.pos)
+	}
+	return r.err
+}
+
+// Чтение следующих n байт без копирования
+func (r *binaryReader) next(n int) (ret []byte) {
+	if r.err != nil {
+		return
+	}
+	if n < 0 || n > len(r.data)-r.pos {
+		r.fail(io.ErrUnexpectedEOF)
+		return
+	}
+	ret, r.pos = r.data[r.pos:r.pos+n], r.pos+n
+
+	return
+}
+
+// Чтение флагов в порядке записи, установленные биты неизвестных флагов являются ошибкой
+func (r *binaryReader) flags(flags ...*bool) {
+	var buf = r.next(1)
+
+	if buf == nil {
+		return
+	}
+	if buf[0]>>uint(len(flags)) != 0 {
+		r.fail(fmt.Errorf("unknown binary value flags 0x%02x", buf[0]))
+		return
+	}
+	for i := range flags {
+		*flags[i] = buf[0]&(1<<uint(i)) != 0
+	}
+}
+
+// Чтение логического значения
+func (r *binaryReader) bool() bool {
+	var buf = r.next(1)
+
+	if buf == nil {
+		return false
+	}
+	if buf[0] > 1 {
+		r.fail(fmt.Errorf("invalid binary boolean value 0x%02x", buf[0]))
+		return false
+	}
+
+	return buf[0] == 1
+}
+
+// Чтение целого числа со знаком в формате varint
+func (r *binaryReader) varint() (ret int64) {
+	var n int
+
+	if r.err != nil {
+		return
+	}
+	if ret, n = binary.Varint(r.data[r.pos:]); n <= 0 {
+		r.fail(r.varintError(n))
+		return 0
+	}
+	r.pos += n
+
+	return
+}
+
+// Чтение целого числа без знака в формате varint
+func (r *binaryReader) uvarint() (ret uint64) {
+	var n int
+
+	if r.err != nil {
+		return
+	}
+	if ret, n = binary.Uvarint(r.data[r.pos:]); n <= 0 {
+		r.fail(r.varintError(n))
+		return 0
+	}
+	r.pos += n
+
+	return
+}
+
+// Ошибка чтения varint
+func (r *binaryReader) varintError(n int) error {
+	if n == 0 {
+		return io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("binary varint value overflows 64-bit integer")
+}
+
+// Чтение целого числа со знаком заданной разрядности в формате varint с проверкой диапазона
+func (r *binaryReader) intN(bits int) int64 {
+	var ret = r.varint()
+
+	if bits < 64 && (ret < -1<<uint(bits-1) || ret > 1<<uint(bits-1)-1) {
+		r.fail(fmt.Errorf("binary value %d overflows %d-bit integer", ret, bits))
+		return 0
+	}
+
+	return ret
+}
+
+// Чтение целого числа без знака заданной разрядности в формате varint с проверкой диапазона
+func (r *binaryReader) uintN(bits int) uint64 {
+	var ret = r.uvarint()
+
+	if bits < 64 && ret > 1<<uint(bits)-1 {
+		r.fail(fmt.Errorf("binary value %d overflows %d-bit unsigned integer", ret, bits))
+		return 0
+	}
+
+	return ret
+}
+
+// Чтение целого числа типа int в формате varint с проверкой диапазона
+func (r *binaryReader) int() int { return int(r.intN(bits.UintSize)) }
+
+// Чтение количества элементов, каждый элемент занимает не меньше одного байта
+func (r *binaryReader) count() int {
+	var ret = r.uvarint()
+
+	if ret > uint64(len(r.data)-r.pos) {
+		r.fail(io.ErrUnexpectedEOF)
+		return 0
+	}
+
+	return int(ret)
+}
+
+// Чтение числа с плавающей точкой одинарной точности
+func (r *binaryReader) float32() float32 {
+	var buf = r.next(4)
+
+	if buf == nil {
+		return 0
+	}
+	return math.Float32frombits(binary.LittleEndian.Uint32(buf))
+}
+
+// Чтение числа с плавающей точкой двойной точности
+func (r *binaryReader) float64() float64 {
+	var buf = r.next(8)
+
+	if buf == nil {
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(buf))
+}
+
+// Чтение среза байт фиксированной длины в переданный срез
+func (r *binaryReader) fixed(dst []byte) { copy(dst, r.next(len(dst))) }
+
+// Чтение копии среза байт с префиксом длины, пустой срез возвращается как nil
+func (r *binaryReader) bytes() (ret []byte) {
+	var buf = r.next(r.count())
+
+	if len(buf) > 0 {
+		ret = append([]byte{}, buf...)
+	}
+
+	return
+}
+
+// Чтение строки с префиксом длины
+func (r *binaryReader) string() string { return string(r.next(r.count())) }
+
+// Чтение координат
+func (r *binaryReader) coords() (ret []Coord) {
+	var n = r.count()
+
+	ret = make([]Coord, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		ret = append(ret, Coord{X: r.float64(), Y: r.float64()})
+	}
+
+	return
+}
+
+// Способ кодирования значений типа
+func binaryValueKind(typ reflect.Type) int {
+	switch {
+	case typ.Implements(binaryEncoderType) && reflect.PointerTo(typ).Implements(binaryDecoderType):
+		return binaryValueCodec
+	case isBasicKind(reflect.Zero(typ)), typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		return binaryValueBasic
+	case typ.Implements(binaryMarshalerType) && reflect.PointerTo(typ).Implements(binaryUnmarshalerType):
+		return binaryValueMarshaler
+	default:
+		return binaryValueGob
+	}
+}
+
+// Запись значения произвольного типа
+func writeBinaryValue[T any](w *binaryWriter, value T) {
+	var (
+		rv   = reflect.ValueOf(&value).Elem()
+		data []byte
+		err  error
+		buf  *bytes.Buffer
+	)
+
+	switch binaryValueKind(rv.Type()) {
+	case binaryValueCodec:
+		interface{}(value).(binaryEncoder).encodeBinary(w)
+	case binaryValueBasic:
+		switch rv.Kind() {
+		case reflect.Bool:
+			w.bool(rv.Bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			w.varint(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			w.uvarint(rv.Uint())
+		case reflect.Float32:
+			w.float32(float32(rv.Float()))
+		case reflect.Float64:
+			w.float64(rv.Float())
+		case reflect.String:
+			w.string(rv.String())
+		default:
+			w.bytes(rv.Bytes())
+		}
+	case binaryValueMarshaler:
+		data, err = interface{}(value).(encoding.BinaryMarshaler).MarshalBinary()
+		w.fail(err)
+		w.bytes(data)
+	default:
+		buf = &bytes.Buffer{}
+		w.fail(gob.NewEncoder(buf).Encode(&value))
+		w.bytes(buf.Bytes())
+	}
+}
+
+// Чтение значения произвольного типа
+func readBinaryValue[T any](r *binaryReader, value *T) {
+	var rv = reflect.ValueOf(value).Elem()
+
+	switch binaryValueKind(rv.Type()) {
+	case binaryValueCodec:
+		interface{}(value).(binaryDecoder).decodeBinary(r)
+	case binaryValueBasic:
+		switch rv.Kind() {
+		case reflect.Bool:
+			rv.SetBool(r.bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			rv.SetInt(r.intN(rv.Type().Bits()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			rv.SetUint(r.uintN(rv.Type().Bits()))
+		case reflect.Float32:
+			rv.SetFloat(float64(r.float32()))
+		case reflect.Float64:
+			rv.SetFloat(r.float64())
+		case reflect.String:
+			rv.SetString(r.string())
+		default:
+			rv.SetBytes(r.bytes())
+		}
+	case binaryValueMarshaler:
+		if data := r.bytes(); r.err == nil {
+			r.fail(interface{}(value).(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+		}
+	default:
+		if data := r.bytes(); r.err == nil {
+			r.fail(gob.NewDecoder(bytes.NewReader(data)).Decode(value))
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/hex"
+	"io"
+	"math"
+	"math/big"
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+type binaryTestValue interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+type binaryTestStruct struct {
+	Name  string
+	Count int
+}
+
+func binaryTestValues() []binaryTestValue {
+	var (
+		dec, _     = NewDecimalString("-12345678901234567890.125")
+		uuid, _    = NewUUIDString("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+		mac, _     = net.ParseMAC("08:00:2b:01:02:03")
+		bounds, _  = NewBounds(NewInt64Value(1), NewInt64Value(10), true, false)
+		array, _   = NewArrayDims([]Int64{NewInt64Value(1), NewInt64(), NewInt64Value(3), NewInt64Value(-4)}, 2, 2)
+		point      = NewPointValue(Coord{X: 1.5, Y: -2})
+		duration   = NewDurationValue(90 * time.Minute)
+		bigInt     = NewBigIntValue(new(big.Int).Lsh(big.NewInt(-1), 100))
+		bigFloat   = NewBigFloatValue(big.NewFloat(math.Pi))
+		uuidBinary = uuid
+		optional   = NewOptionalNull[string]()
+	)
+
+	point.SRID, duration.Format, bigInt.Quoted, uuidBinary.Binary = 4326, DurationFormatISO8601, true, true
+	return []binaryTestValue{
+		&Bool{}, ptr(NewBoolValue(true)),
+		&Bytes{}, ptr(NewBytesValue(nil)), ptr(NewBytesValue([]byte(bytesTestString))),
+		&Float32{}, ptr(NewFloat32Value(-math.MaxFloat32)),
+		&Float64{}, ptr(NewFloat64Value(math.Inf(-1))),
+		&Int8{}, ptr(NewInt8Value(math.MinInt8)),
+		&Int16{}, ptr(NewInt16Value(math.MinInt16)),
+		&Int32{}, ptr(NewInt32Value(math.MinInt32)),
+		&Int64{}, ptr(NewInt64Value(math.MinInt64)),
+		&Uint8{}, ptr(NewUint8Value(math.MaxUint8)),
+		&Uint16{}, ptr(NewUint16Value(math.MaxUint16)),
+		&Uint32{}, ptr(NewUint32Value(math.MaxUint32)),
+		&Uint64{}, ptr(NewUint64Value(math.MaxUint64)),
+		&String{}, ptr(NewStringValue(stringTestBody)),
+		&Time{}, ptr(NewTimeValue(timeOkValidValue)), ptr(NewTimeValue(time.Date(2020, 2, 29, 1, 2, 3, 4, time.UTC))),
+		&Decimal{}, ptr(dec),
+		&BigInt{}, ptr(bigInt), ptr(NewBigIntValue(nil)),
+		&BigFloat{}, ptr(bigFloat),
+		&UUID{}, ptr(uuid), ptr(uuidBinary),
+		&Duration{}, ptr(duration),
+		&Date{}, ptr(NewDateValue(CivilDate{Year: -44, Month: time.March, Day: 15})),
+		&TimeOfDay{}, ptr(NewTimeOfDayValue(CivilTime{Hour: 23, Minute: 59, Second: 59, Nanosecond: 999, Offset: -3600, HasOffset: true})),
+		&Interval{}, ptr(NewIntervalValue(Period{Months: -14, Days: 3, Microseconds: 1})),
+		&JSON{}, ptr(NewJSONValue([]byte(`{"a":[1,2]}`))),
+		&JSONOf[binaryTestStruct]{}, ptr(NewJSONOfValue(binaryTestStruct{Name: "a", Count: 2})),
+		&IP{}, ptr(NewIPValue(netip.MustParseAddr("fe80::1%eth0"))),
+		&Prefix{}, ptr(NewPrefixValue(netip.MustParsePrefix("10.0.0.0/8"))),
+		&HardwareAddr{}, ptr(NewHardwareAddrValue(mac)),
+		&StringMap{}, ptr(NewStringMapValue(map[string]String{"a": NewStringValue("1"), "b": NewString(), "": NewStringValue("")})),
+		&Point{}, ptr(point),
+		&LineString{}, ptr(NewLineStringValue(lineStringValue)),
+		&Polygon{}, ptr(NewPolygonValue(polygonValue)),
+		&Int64Array{}, ptr(array), ptr(NewArrayValue([]Int64{})),
+		&Int64Range{}, ptr(NewRangeValue(bounds)), ptr(NewRangeValue(EmptyBounds[Int64]())),
+		&TimeRange{}, ptr(NewRangeValue(Bounds[Time]{Lower: NewTimeValue(timeOkValidValue)})),
+		&Null[int16]{}, ptr(NewNullValue[int16](-7)),
+		&Null[[]byte]{}, ptr(NewNullValue([]byte("abc"))),
+		&Null[Int64]{}, ptr(NewNullValue(NewInt64Value(7))),
+		&Null[netip.Addr]{}, ptr(NewNullValue(netip.MustParseAddr("127.0.0.1"))),
+		&Null[binaryTestStruct]{}, ptr(NewNullValue(binaryTestStruct{Name: "a", Count: 2})),
+		&Optional[string]{}, &optional, ptr(NewOptionalValue("a")),
+	}
+}
+
+func ptr[T any](value T) *T { return &value }
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, value := range binaryTestValues() {
+		data, err := value.MarshalBinary()
+		errorPanic(err)
+		if data[0] != binaryVersion1 {
+			t.Errorf("MarshalBinary() of %T starts with 0x%02x, but should start with version", value, data[0])
+		}
+		target := reflect.New(reflect.TypeOf(value).Elem()).Interface().(binaryTestValue)
+		if err = target.UnmarshalBinary(data); err != nil {
+			t.Errorf("UnmarshalBinary() of %T error: %s", value, err)
+			continue
+		}
+		again, err := target.MarshalBinary()
+		errorPanic(err)
+		if !bytes.Equal(again, data) {
+			t.Errorf("MarshalBinary() of decoded %T is %x, but should be %x", value, again, data)
+		}
+		switch value.(type) {
+		case *Decimal, *BigInt, *BigFloat, *Time:
+		default:
+			if !reflect.DeepEqual(target, value) {
+				t.Errorf("UnmarshalBinary() of %T is %v, but should be %v", value, target, value)
+			}
+		}
+	}
+}
+
+func TestBinaryGobCompatibility(t *testing.T) {
+	var tests = []struct {
+		Wrapper interface{}
+		Target  binaryTestValue
+		Result  binaryTestValue
+	}{
+		{&wrapper.BoolWrapper{Value: true, Valid: true}, &Bool{}, ptr(NewBoolValue(true))},
+		{&wrapper.Int8Wrapper{Value: -8, Valid: true}, &Int8{}, ptr(NewInt8Value(-8))},
+		{&wrapper.Uint16Wrapper{Value: 16, Valid: true}, &Uint16{}, ptr(NewUint16Value(16))},
+		{&wrapper.Float32Wrapper{Value: 1.5, Valid: true}, &Float32{}, ptr(NewFloat32Value(1.5))},
+		{&wrapper.DurationWrapper{Value: time.Second, Valid: true, Format: 1}, &Duration{}, &Duration{Duration: time.Second, Valid: true, Format: 1}},
+		{&wrapper.PointWrapper{X: 1, Y: 2, Valid: true, SRID: 4326}, &Point{}, &Point{Point: Coord{X: 1, Y: 2}, Valid: true, SRID: 4326}},
+		{&wrapper.UUIDWrapper{Value: [16]byte{1}, Valid: true, Binary: true}, &UUID{}, &UUID{UUID: [16]byte{1}, Valid: true, Binary: true}},
+		{&wrapper.NullWrapper[string]{Value: "a", Valid: true}, &Null[string]{}, ptr(NewNullValue("a"))},
+		{&wrapper.OptionalWrapper[int]{Valid: false, Present: true}, &Optional[int]{}, ptr(NewOptionalNull[int]())},
+		{&wrapper.StringWrapper{}, ptr(NewStringValue("a")), &String{}},
+	}
+
+	for _, test := range tests {
+		var buf = &bytes.Buffer{}
+		errorPanic(gob.NewEncoder(buf).Encode(test.Wrapper))
+		if !isGobBinary(buf.Bytes()) {
+			t.Errorf("isGobBinary(%T) is false, but should be true", test.Wrapper)
+		}
+		errorPanic(test.Target.UnmarshalBinary(buf.Bytes()))
+		if !reflect.DeepEqual(test.Target, test.Result) {
+			t.Errorf("UnmarshalBinary() of %T is %v, but should be %v", test.Wrapper, test.Target, test.Result)
+		}
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	var tests = []struct {
+		Hex    string
+		Target binaryTestValue
+	}{
+		{"", &Int64{}},
+		{"81", &Int64{}},
+		{"8104", &Int64{}},
+		{"810401", &Int64{}},
+		{"8204010c", &Int64{}},
+		{"8105010c", &Int64{}},
+		{"8104010c00", &Int64{}},
+		{"81040000", &Int64{}},
+		{"8111000000000000000000000000000000000000", &UUID{}},
+		{"8104030c", &Int64{}},
+		{"810401ffffffffffffffffffff01", &Int64{}},
+		{"810b01fe03", &Int8{}},
+		{"810e01ff03", &Uint8{}},
+		{"81010102", &Bool{}},
+		{"8105010a61", &String{}},
+		{"810201ffffffffffffffff7f", &Bytes{}},
+		{"8121010000ffffffff0f", &LineString{}},
+		{"81170102ff", &IP{}},
+	}
+
+	for _, test := range tests {
+		in, err := hex.DecodeString(test.Hex)
+		errorPanic(err)
+		if err = test.Target.UnmarshalBinary(in); err == nil {
+			t.Errorf("UnmarshalBinary(%s) into %T error is nil, but should be not nil", test.Hex, test.Target)
+		}
+	}
+	v := NewInt64Value(7)
+	if err := v.UnmarshalBinary([]byte{binaryVersion1, binaryTagInt64, 1}); err != io.ErrUnexpectedEOF {
+		t.Errorf("UnmarshalBinary() error is %v, but should be %v", err, io.ErrUnexpectedEOF)
+	}
+	if !v.Valid || v.Int64 != 7 {
+		t.Error("UnmarshalBinary()", "with error changes value")
+	}
+}
+
+func TestBinaryNullPayload(t *testing.T) {
+	var (
+		valid = make(map[reflect.Type]binaryTestValue)
+		isSet = func(value binaryTestValue) bool { return reflect.ValueOf(value).Elem().FieldByName("Valid").Bool() }
+	)
+
+	for _, value := range binaryTestValues() {
+		if isSet(value) {
+			valid[reflect.TypeOf(value)] = value
+		}
+	}
+	for _, value := range binaryTestValues() {
+		if isSet(value) {
+			continue
+		}
+		data, err := value.MarshalBinary()
+		errorPanic(err)
+		if len(data) != 3 {
+			t.Errorf("MarshalBinary() of null %T is %x, but should be version, tag and flags only", value, data)
+		}
+		target := valid[reflect.TypeOf(value)]
+		if err = target.UnmarshalBinary(data); err != nil {
+			t.Errorf("UnmarshalBinary(%x) into %T error: %s", data, target, err)
+			continue
+		}
+		if isSet(target) {
+			t.Errorf("UnmarshalBinary(%x) into %T is valid, but should be null", data, target)
+		}
+		again, err := target.MarshalBinary()
+		errorPanic(err)
+		if !bytes.Equal(again, data) {
+			t.Errorf("MarshalBinary() of decoded %T is %x, but should be %x", target, again, data)
+		}
+	}
+}
+
+func TestBinarySize(t *testing.T) {
+	var tests = []struct {
+		Value   binaryTestValue
+		Wrapper interface{}
+		Size    int
+	}{
+		{ptr(NewBoolValue(true)), &wrapper.BoolWrapper{Value: true, Valid: true}, 4},
+		{ptr(NewInt64Value(1)), &wrapper.Int64Wrapper{Value: 1, Valid: true}, 4},
+		{ptr(NewFloat64Value(1)), &wrapper.Float64Wrapper{Value: 1, Valid: true}, 11},
+		{ptr(NewStringValue("abc")), &wrapper.StringWrapper{Value: "abc", Valid: true}, 7},
+		{&Int64{}, &wrapper.Int64Wrapper{}, 3},
+		{&Time{}, &wrapper.TimeWrapper{}, 3},
+	}
+
+	for _, test := range tests {
+		var buf = &bytes.Buffer{}
+		data, err := test.Value.MarshalBinary()
+		errorPanic(err)
+		errorPanic(gob.NewEncoder(buf).Encode(test.Wrapper))
+		if len(data) != test.Size || len(data) >= buf.Len() {
+			t.Errorf("MarshalBinary() of %T is %d bytes, but should be %d bytes, gob is %d bytes", test.Value, len(data), test.Size, buf.Len())
+		}
+	}
+}
+
+func BenchmarkInt64MarshalBinary(b *testing.B) {
+	var v = NewInt64Value(math.MaxInt32)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = v.MarshalBinary()
+	}
+}
+
+func BenchmarkInt64MarshalBinaryGob(b *testing.B) {
+	var v = NewInt64Value(math.MaxInt32)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var buf = &bytes.Buffer{}
+		_ = gob.NewEncoder(buf).Encode(&wrapper.Int64Wrapper{Value: v.Int64, Valid: v.Valid})
+	}
+}
+
+func BenchmarkInt64UnmarshalBinary(b *testing.B) {
+	var (
+		v       Int64
+		data, _ = NewInt64Value(math.MaxInt32).MarshalBinary()
+	)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = v.UnmarshalBinary(data)
+	}
+}
+
+func BenchmarkInt64UnmarshalBinaryGob(b *testing.B) {
+	var (
+		v   Int64
+		buf = &bytes.Buffer{}
+	)
+
+	_ = gob.NewEncoder(buf).Encode(&wrapper.Int64Wrapper{Value: math.MaxInt32, Valid: true})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = v.UnmarshalBinary(buf.Bytes())
+	}
+}
+
+func BenchmarkStringMarshalBinary(b *testing.B) {
+	var v = NewStringValue(stringTestBody)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = v.MarshalBinary()
+	}
+}
+
+func BenchmarkStringMarshalBinaryGob(b *testing.B) {
+	var v = NewStringValue(stringTestBody)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var buf = &bytes.Buffer{}
+		_ = gob.NewEncoder(buf).Encode(&wrapper.StringWrapper{Value: v.String, Valid: v.Valid})
+	}
+}
+
+func BenchmarkTimeMarshalBinary(b *testing.B) {
+	var v = NewTimeValue(timeOkValidValue)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = v.MarshalBinary()
+	}
+}
+
+func BenchmarkTimeMarshalBinaryGob(b *testing.B) {
+	var v = NewTimeValue(timeOkValidValue)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var buf = &bytes.Buffer{}
+		_ = gob.NewEncoder(buf).Encode(&wrapper.TimeWrapper{Value: v.Time, Valid: v.Valid})
+	}
+}
+
+func BenchmarkInt64ArrayMarshalBinary(b *testing.B) {
+	var v = NewArrayValue(make([]Int64, 1000))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = v.MarshalBinary()
+	}
+}
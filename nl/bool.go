@@ -3,13 +3,7 @@ package nul // import "gopkg.in/webnice/lin.v1/nl"
 //import "gopkg.in/webnice/debug.v1"
 //import "gopkg.in/webnice/log.v2"
 import (
-	"bytes"
 	"database/sql/driver"
-	"encoding/gob"
-	"encoding/json"
-	"fmt"
-
-	"gopkg.in/webnice/lin.v1/wrapper"
 )
 
 // Bool is an nullable boolean object
@@ -42,6 +36,17 @@ func NewBoolPointerValue(ptr *bool) Bool {
 	return NewBoolValue(*ptr)
 }
 
+// NewBoolNull Создание нового объекта Bool из обобщённого объекта Null
+func NewBoolNull(n Null[bool]) Bool {
+	return Bool{
+		Bool:  n.V,
+		Valid: n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (b Bool) Null() Null[bool] { return Null[bool]{V: b.Bool, Valid: b.Valid} }
+
 // SetValid Изменение значения и установка флага действительного значения
 func (b *Bool) SetValid(value bool) { b.Bool, b.Valid = value, true }
 
@@ -68,162 +73,76 @@ func (b *Bool) Pointer() *bool {
 }
 
 // Scan Реализация интерфейса Scanner
-func (b *Bool) Scan(value interface{}) (err error) {
-	var (
-		v  interface{}
-		ok bool
-	)
-
-	b.Bool, b.Valid = false, false
-	if value == nil {
-		return
-	}
-	v, err = driver.Bool.ConvertValue(value)
-	b.Valid = err == nil
-	if b.Bool, ok = v.(bool); !ok {
-		b.Reset()
-	}
-
-	return
+func (b *Bool) Scan(value interface{}) error {
+	return setNull(&b.Bool, &b.Valid, (*Null[bool]).Scan, value)
 }
 
 // Value Реализация интерфейса driver.Valuer
-func (b Bool) Value() (driver.Value, error) {
-	if !b.Valid {
-		return nil, nil
-	}
-	return b.Bool, nil
-}
+func (b Bool) Value() (driver.Value, error) { return b.Null().Value() }
 
 // UnmarshalJSON Реализация интерфейса json.Unmarshaler
-func (b *Bool) UnmarshalJSON(data []byte) (err error) {
-	var v interface{}
-
-	if err = json.Unmarshal(data, &v); err != nil {
-		return
-	}
-	switch x := v.(type) {
-	case bool:
-		b.Bool = x
-	case map[string]interface{}:
-		value, okValue := x["Bool"].(bool)
-		valid, okValid := x["Valid"].(bool)
-		if !okValue || !okValid {
-			return fmt.Errorf("unmarshalling object into Go value of type nul.Bool requires key "+
-				`"Bool" to be of type string and key "Valid" to be of type bool; `+
-				"found %T and %T, respectively", x["Bool"], x["Valid"])
-		}
-		b.Bool = value
-		b.Valid = valid
-	case nil:
-		b.Valid = false
-		return
-	}
-	b.Valid = err == nil
-
-	return
+// Допускается также объект вида {"Bool": значение, "Valid": флаг}
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	return setNull(&b.Bool, &b.Valid, unmarshalNullJSONObject[bool]("Bool"), data)
 }
 
 // MarshalJSON Реализация интерфейса json.Marshaler
-func (b Bool) MarshalJSON() (data []byte, err error) {
-	const (
-		nullString  = "null"
-		trueString  = "true"
-		falseString = "false"
-	)
+func (b Bool) MarshalJSON() ([]byte, error) { return b.Null().MarshalJSON() }
 
-	if !b.Valid {
-		data = []byte(nullString)
-		return
-	}
-	if !b.Bool {
-		data = []byte(falseString)
-		return
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+// Пустая строка является null
+func (b *Bool) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		b.Reset()
+		return nil
 	}
-	data = []byte(trueString)
-
-	return
+	return setNull(&b.Bool, &b.Valid, (*Null[bool]).UnmarshalText, text)
 }
 
-// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
-func (b *Bool) UnmarshalText(text []byte) (err error) {
-	const (
-		emptyString = ""
-		nullString  = "null"
-		trueString  = "true"
-		falseString = "false"
-	)
-	var str string
-
-	switch str = string(text); str {
-	case emptyString, nullString:
-		b.Valid = false
-		return
-	case trueString:
-		b.Bool = true
-	case falseString:
-		b.Bool = false
-	default:
-		b.Valid, err = false, fmt.Errorf("Invalid input: %q", str)
-		return
-	}
-	b.Valid = true
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (b Bool) MarshalText() ([]byte, error) { return b.Null().MarshalText() }
 
-	return
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (b *Bool) UnmarshalBinary(data []byte) error {
+	return setNull(&b.Bool, &b.Valid, unmarshalNullBinary[bool](binaryTagBool), data)
 }
 
-// MarshalText Реализация интерфейса encoding.TextMarshaler
-func (b Bool) MarshalText() (text []byte, err error) {
-	const (
-		trueString  = "true"
-		falseString = "false"
-		nullString  = "null"
-	)
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (b Bool) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagBool, b.Null()) }
 
-	if !b.Valid {
-		text = []byte(nullString)
-		return
-	}
-	if !b.Bool {
-		text = []byte(falseString)
-		return
-	}
-	text = []byte(trueString)
+// Запись значения в компактном двоичном формате
+func (b Bool) encodeBinary(w *binaryWriter) { b.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (b *Bool) decodeBinary(r *binaryReader) {
+	var n = b.Null()
 
-	return
+	n.decodeBinary(r)
+	*b = NewBoolNull(n)
 }
 
-// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
-func (b *Bool) UnmarshalBinary(data []byte) (err error) {
-	var (
-		reader *bytes.Reader
-		dec    *gob.Decoder
-		item   *wrapper.BoolWrapper
-	)
-
-	reader = bytes.NewReader(data)
-	dec = gob.NewDecoder(reader)
-	item = new(wrapper.BoolWrapper)
-	if err = dec.Decode(item); err == nil {
-		b.Bool, b.Valid = item.Value, item.Valid
-	}
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (b *Bool) UnmarshalMsgpack(data []byte) error {
+	return setNull(&b.Bool, &b.Valid, (*Null[bool]).UnmarshalMsgpack, data)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (b Bool) MarshalMsgpack() ([]byte, error) { return b.Null().MarshalMsgpack() }
 
-	return
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (b *Bool) UnmarshalCBOR(data []byte) error {
+	return setNull(&b.Bool, &b.Valid, (*Null[bool]).UnmarshalCBOR, data)
 }
 
-// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
-func (b Bool) MarshalBinary() (data []byte, err error) {
-	var (
-		buf  *bytes.Buffer
-		enc  *gob.Encoder
-		item *wrapper.BoolWrapper
-	)
-
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-	item = &wrapper.BoolWrapper{Value: b.Bool, Valid: b.Valid}
-	err = enc.Encode(item)
-	data = buf.Bytes()
-
-	return
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (b Bool) MarshalCBOR() ([]byte, error) { return b.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (b *Bool) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&b.Bool, &b.Valid, (*Null[bool]).UnmarshalYAML, unmarshal)
 }
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (b Bool) MarshalYAML() (interface{}, error) { return b.Null().MarshalYAML() }
@@ -331,33 +331,35 @@ func TestBoolUnmarshalBinary(t *testing.T) {
 }
 
 func TestBoolMarshalBinary(t *testing.T) {
-	var err error
-	var buf *bytes.Buffer
-	var enc *gob.Encoder
-	var trueBool, falseBool, nullBool Bool
-
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-
-	trueBool = NewBoolValue(true)
-	err = enc.Encode(&trueBool)
+	trueBool := NewBoolValue(true)
+	data, err := trueBool.MarshalBinary()
 	errorPanic(err)
-	tbh := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(tbh), boolTrueValidGob, "NewBoolValue(true) -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), boolTrueValidBinary, "NewBoolValue(true) -> MarshalBinary()")
 
-	buf.Reset()
-	enc = gob.NewEncoder(buf)
-	falseBool = NewBoolValue(false)
-	err = enc.Encode(&falseBool)
+	falseBool := NewBoolValue(false)
+	data, err = falseBool.MarshalBinary()
 	errorPanic(err)
-	fbh := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(fbh), boolFalseValidGob, "NewBoolValue(false) -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), boolFalseValidBinary, "NewBoolValue(false) -> MarshalBinary()")
 
-	buf.Reset()
-	enc = gob.NewEncoder(buf)
-	nullBool = NewBool()
-	err = enc.Encode(&nullBool)
+	nullBool := NewBool()
+	data, err = nullBool.MarshalBinary()
 	errorPanic(err)
-	nbh := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(nbh), boolNullInvalidGob, "NewBool() -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), boolNullInvalidBinary, "NewBool() -> MarshalBinary()")
+}
+
+func TestBoolUnmarshalCBOR(t *testing.T) {
+	var b Bool
+
+	errorPanic(b.UnmarshalCBOR([]byte{0xf5}))
+	isTrueBool(t, b, "UnmarshalCBOR(f5)")
+	errorPanic(b.UnmarshalCBOR([]byte{0xf4}))
+	isFalseBool(t, b, "UnmarshalCBOR(f4)")
+	errorPanic(b.UnmarshalCBOR([]byte{0xf6}))
+	isNullBool(t, b, "UnmarshalCBOR(f6)")
+
+	for _, data := range [][]byte{{0xf7}, {0x01}, {0x61, 't'}, {0xf5, 0xf5}, {}} {
+		if err := b.UnmarshalCBOR(data); err == nil {
+			t.Errorf("UnmarshalCBOR(%x) error is nil, but should be not nil", data)
+		}
+	}
 }
@@ -5,39 +5,157 @@ package nul // import "gopkg.in/webnice/lin.v1/nl"
 import (
 	"bytes"
 	"database/sql/driver"
-	"encoding/base64"
-	"encoding/gob"
-	"encoding/json"
-	"fmt"
-
-	"gopkg.in/webnice/lin.v1/wrapper"
+	"io"
 )
 
 // Bytes is an nullable []byte object
+// Нулевое значение Bytes{} является не действительным объектом и безопасно для всех методов
 type Bytes struct {
-	Bytes *bytes.Buffer // Value of object
-	Valid bool          // Valid is true if value is not NULL
+	Bytes Buffer // Value of object
+	Valid bool   // Valid is true if value is not NULL
+}
+
+// Buffer Значение объекта Bytes
+// Тип является срезом байт и заменяет *bytes.Buffer, который использовался ранее. Методы Bytes, Len, String,
+// Reset, Truncate, Grow, Read, Write, WriteString, ReadFrom и WriteTo работают как одноимённые методы
+// *bytes.Buffer, поэтому код вида bt.Bytes.Bytes() продолжает работать. Тип поля изменён несовместимо:
+// присваивание *bytes.Buffer и сравнение с nil требуют изменения кода, для перехода используется FromBuffer
+type Buffer []byte
+
+// FromBuffer Возвращает значение Buffer с копией непрочитанной части *bytes.Buffer, для nil возвращается nil
+func FromBuffer(buf *bytes.Buffer) Buffer {
+	if buf == nil {
+		return nil
+	}
+	return copyBytes(buf.Bytes())
+}
+
+// Bytes Возвращает значение в виде среза байт без копирования
+func (b Buffer) Bytes() []byte { return b }
+
+// Len Возвращает длину значения
+func (b Buffer) Len() int { return len(b) }
+
+// String Возвращает значение в виде строки
+func (b Buffer) String() string { return string(b) }
+
+// Reset Сброс значения до пустого
+func (b *Buffer) Reset() { *b = (*b)[:0] }
+
+// Truncate Отбрасывает все байты значения, кроме первых n
+func (b *Buffer) Truncate(n int) {
+	if n < 0 || n > len(*b) {
+		panic("nul.Buffer: truncation out of range")
+	}
+	*b = (*b)[:n]
+}
+
+// Grow Увеличивает ёмкость значения так, чтобы следующие n байт были записаны без выделения памяти
+func (b *Buffer) Grow(n int) {
+	var buf []byte
+
+	if n < 0 {
+		panic("nul.Buffer: negative count")
+	}
+	if cap(*b)-len(*b) >= n {
+		return
+	}
+	buf = make([]byte, len(*b), 2*cap(*b)+n)
+	_ = copy(buf, *b)
+	*b = buf
+}
+
+// Read Чтение байт из начала значения, реализация интерфейса io.Reader
+// Прочитанные байты удаляются из значения, пустое значение возвращает io.EOF
+func (b *Buffer) Read(p []byte) (n int, err error) {
+	if len(*b) == 0 && len(p) > 0 {
+		err = io.EOF
+		return
+	}
+	n = copy(p, *b)
+	*b = (*b)[n:]
+
+	return
+}
+
+// Write Добавление байт к значению, реализация интерфейса io.Writer
+func (b *Buffer) Write(p []byte) (n int, err error) {
+	*b = append(*b, p...)
+	n = len(p)
+
+	return
+}
+
+// WriteString Добавление строки к значению
+func (b *Buffer) WriteString(s string) (n int, err error) {
+	*b = append(*b, s...)
+	n = len(s)
+
+	return
+}
+
+// ReadFrom Добавление к значению всех данных из r до io.EOF, реализация интерфейса io.ReaderFrom
+func (b *Buffer) ReadFrom(r io.Reader) (n int64, err error) {
+	var m int
+
+	for {
+		b.Grow(bytes.MinRead)
+		m, err = r.Read((*b)[len(*b):cap(*b)])
+		if m < 0 {
+			panic("nul.Buffer: reader returned negative count from Read")
+		}
+		*b, n = (*b)[:len(*b)+m], n+int64(m)
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// WriteTo Запись значения в w, записанные байты удаляются из значения, реализация интерфейса io.WriterTo
+func (b *Buffer) WriteTo(w io.Writer) (n int64, err error) {
+	var m int
+
+	if len(*b) == 0 {
+		return
+	}
+	if m, err = w.Write(*b); m > len(*b) {
+		panic("nul.Buffer: invalid Write count")
+	}
+	*b, n = (*b)[m:], int64(m)
+	if err == nil && len(*b) > 0 {
+		err = io.ErrShortWrite
+	}
+
+	return
+}
+
+// Копия среза байт, копия пустого среза является пустым, но не nil срезом
+func copyBytes(value []byte) []byte {
+	var buf = make([]byte, len(value))
+	_ = copy(buf, value)
+	return buf
 }
 
 // NewBytes Создание нового объекта []byte
 func NewBytes() Bytes {
 	return Bytes{
-		Bytes: &bytes.Buffer{},
+		Bytes: nil,
 		Valid: false,
 	}
 }
 
-// NewBytesValue Создание нового действительного объекта Bytes из значения
+// NewBytesValue Создание нового действительного объекта Bytes из значения, значение копируется
 func NewBytesValue(value []byte) Bytes {
-	var buf = make([]byte, len(value))
-	_ = copy(buf, value)
 	return Bytes{
-		Bytes: bytes.NewBuffer(buf),
+		Bytes: copyBytes(value),
 		Valid: true,
 	}
 }
 
-// NewBytesPointerValue Создание нового действительного объекта Bytes из ссылки на значение
+// NewBytesPointerValue Создание нового действительного объекта Bytes из ссылки на значение, значение копируется
 func NewBytesPointerValue(ptr *[]byte) Bytes {
 	if ptr == nil {
 		return NewBytes()
@@ -45,189 +163,154 @@ func NewBytesPointerValue(ptr *[]byte) Bytes {
 	return NewBytesValue(*ptr)
 }
 
-// SetValid Изменение значения и установка флага действительного значения
-func (bt *Bytes) SetValid(value []byte) {
-	var buf = make([]byte, len(value))
-	_ = copy(buf, value)
-	bt.Bytes, bt.Valid = bytes.NewBuffer(buf), true
+// NewBytesNull Создание нового объекта Bytes из обобщённого объекта Null
+func NewBytesNull(n Null[[]byte]) Bytes {
+	if !n.Valid {
+		return NewBytes()
+	}
+	return NewBytesValue(n.V)
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null, значение копируется
+func (bt Bytes) Null() Null[[]byte] {
+	if !bt.Valid {
+		return Null[[]byte]{V: nil, Valid: false}
+	}
+	return Null[[]byte]{V: copyBytes(bt.Bytes), Valid: true}
+}
+
+// Значение в виде обобщённого объекта Null без копирования
+func (bt Bytes) null() Null[[]byte] { return Null[[]byte]{V: bt.Bytes, Valid: bt.Valid} }
+
+// Изменение значения из обобщённого объекта Null без копирования, действительное значение не является nil срезом
+func (bt *Bytes) fromNull(n Null[[]byte]) {
+	if bt.Bytes, bt.Valid = n.V, n.Valid; bt.Valid && bt.Bytes == nil {
+		bt.Bytes = Buffer{}
+	}
+}
+
+// Изменение значения методом обобщённого объекта Null
+func setBytesNull[A any](bt *Bytes, method func(*Null[[]byte], A) error, arg A) (err error) {
+	var n = bt.null()
+
+	err = method(&n, arg)
+	bt.fromNull(n)
+
+	return
+}
+
+// Clone Возвращает копию объекта, не разделяющую память значения с исходным объектом
+func (bt Bytes) Clone() Bytes {
+	if !bt.Valid {
+		return NewBytes()
+	}
+	return NewBytesValue(bt.Bytes)
 }
 
+// SetValid Изменение значения и установка флага действительного значения, значение копируется
+func (bt *Bytes) SetValid(value []byte) { bt.Bytes, bt.Valid = copyBytes(value), true }
+
 // Reset Сброс значения и установка флага не действительного значения
-func (bt *Bytes) Reset() { bt.Bytes.Reset(); bt.Valid = false }
+func (bt *Bytes) Reset() { bt.Bytes, bt.Valid = nil, false }
 
 // NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
 func (bt *Bytes) NullIfDefault() Bytes {
-	if bt.Bytes != nil && bt.Bytes.Len() == 0 {
+	if len(bt.Bytes) == 0 {
 		bt.Reset()
 	}
 	return *bt
 }
 
-// MustValue Возвращает значение в любом случае
+// MustValue Возвращает копию значения в любом случае
 func (bt *Bytes) MustValue() []byte {
 	if !bt.Valid {
 		return []byte{}
 	}
-	return bt.Bytes.Bytes()
+	return copyBytes(bt.Bytes)
 }
 
-// Pointer Возвращает ссылку на значение
+// Pointer Возвращает ссылку на копию значения
+// Для доступа к значению без копирования используйте поле Bytes
 func (bt *Bytes) Pointer() *[]byte {
 	var ret []byte
 	if !bt.Valid {
 		return nil
 	}
-	ret = bt.Bytes.Bytes()
+	ret = copyBytes(bt.Bytes)
 	return &ret
 }
 
 // Scan Реализация интерфейса Scanner
-func (bt *Bytes) Scan(value interface{}) (err error) {
-	switch x := value.(type) {
-	case nil:
-		bt.Valid = false
-		return
-	case []byte:
-		bt.SetValid(value.([]byte))
-	case string:
-		bt.SetValid([]byte(value.(string)))
-	default:
-		err = fmt.Errorf("can't scan type %T into nul.Bytes: %v", x, value)
-	}
-	bt.Valid = err == nil
-
-	return
+// Значение копируется
+func (bt *Bytes) Scan(value interface{}) error {
+	return setBytesNull(bt, (*Null[[]byte]).Scan, value)
 }
 
 // Value Реализация интерфейса driver.Valuer
-func (bt Bytes) Value() (driver.Value, error) {
-	if !bt.Valid {
-		return nil, nil
-	}
-	return bt.Bytes.Bytes(), nil
-}
+func (bt Bytes) Value() (driver.Value, error) { return bt.null().Value() }
 
 // UnmarshalJSON Реализация интерфейса json.Unmarshaler
-func (bt *Bytes) UnmarshalJSON(data []byte) (err error) {
-	var (
-		v   interface{}
-		buf []byte
-	)
-
-	if err = json.Unmarshal(data, &v); err != nil {
-		return
-	}
-	switch x := v.(type) {
-	case nil:
-		bt.Valid = false
-		return
-	case map[string]interface{}:
-		value, okValue := x["Bytes"].(string)
-		valid, okValid := x["Valid"].(bool)
-		if !okValue || !okValid {
-			return fmt.Errorf("unmarshalling object into Go value of type nul.Bytes requires key "+
-				`"Bytes" to be of type string and key "Valid" to be of type string; `+
-				"found %T and %T, respectively", x["Bytes"], x["Valid"])
-		}
-		if buf, err = base64.StdEncoding.DecodeString(value); err == nil {
-			bt.SetValid(buf)
-		}
-		bt.Valid = valid
-	}
-	bt.Valid = err == nil
-
-	return
+// Значение декодируется из строки base64, допускается также объект вида {"Bytes": значение, "Valid": флаг}
+func (bt *Bytes) UnmarshalJSON(data []byte) error {
+	return setBytesNull(bt, unmarshalNullJSONObject[[]byte]("Bytes"), data)
 }
 
 // MarshalJSON Реализация интерфейса json.Marshaler
-func (bt Bytes) MarshalJSON() (data []byte, err error) {
-	const nullString = "null"
-
-	if !bt.Valid {
-		data = []byte(nullString)
-		return
-	}
-	data, err = json.Marshal(bt.Bytes.Bytes())
+func (bt Bytes) MarshalJSON() ([]byte, error) { return bt.null().MarshalJSON() }
 
-	return
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+// Значение декодируется из строки base64
+func (bt *Bytes) UnmarshalText(text []byte) error {
+	return setBytesNull(bt, (*Null[[]byte]).UnmarshalText, text)
 }
 
-// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
-func (bt *Bytes) UnmarshalText(text []byte) (err error) {
-	const (
-		emptyString = ""
-		nullString  = "null"
-	)
-	var (
-		str string
-		buf []byte
-	)
-
-	switch str = string(text); str {
-	case nullString:
-		bt.Bytes, bt.Valid = &bytes.Buffer{}, false
-		return
-	case emptyString:
-		bt.Bytes, bt.Valid = &bytes.Buffer{}, true
-		return
-	default:
-		if buf, err = base64.StdEncoding.DecodeString(str); err == nil {
-			bt.SetValid(buf)
-		}
-	}
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+// Значение кодируется строкой base64
+func (bt Bytes) MarshalText() ([]byte, error) { return bt.null().MarshalText() }
 
-	return
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (bt *Bytes) UnmarshalBinary(data []byte) error {
+	return setBytesNull(bt, unmarshalNullBinary[[]byte](binaryTagBytes), data)
 }
 
-// MarshalText Реализация интерфейса encoding.TextMarshaler
-func (bt Bytes) MarshalText() (text []byte, err error) {
-	const nullString = "null"
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (bt Bytes) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagBytes, bt.Null()) }
 
-	if !bt.Valid {
-		text = []byte(nullString)
-		return
-	}
-	if bt.Bytes.Len() == 0 {
-		text = make([]byte, 0)
-		return
-	}
-	text = []byte(base64.StdEncoding.EncodeToString(bt.Bytes.Bytes()))
+// Запись значения в компактном двоичном формате
+func (bt Bytes) encodeBinary(w *binaryWriter) { bt.null().encodeBinary(w) }
 
-	return
+// Чтение значения в компактном двоичном формате
+func (bt *Bytes) decodeBinary(r *binaryReader) {
+	var n = bt.null()
+
+	n.decodeBinary(r)
+	bt.fromNull(n)
 }
 
-// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
-func (bt *Bytes) UnmarshalBinary(data []byte) (err error) {
-	var (
-		reader *bytes.Reader
-		dec    *gob.Decoder
-		item   *wrapper.BytesWrapper
-	)
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (bt *Bytes) UnmarshalMsgpack(data []byte) error {
+	return setBytesNull(bt, (*Null[[]byte]).UnmarshalMsgpack, data)
+}
 
-	reader = bytes.NewReader(data)
-	dec = gob.NewDecoder(reader)
-	item = new(wrapper.BytesWrapper)
-	if err = dec.Decode(item); err == nil {
-		bt.SetValid(item.Value)
-		bt.Valid = item.Valid
-	}
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (bt Bytes) MarshalMsgpack() ([]byte, error) { return bt.null().MarshalMsgpack() }
 
-	return
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (bt *Bytes) UnmarshalCBOR(data []byte) error {
+	return setBytesNull(bt, (*Null[[]byte]).UnmarshalCBOR, data)
 }
 
-// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
-func (bt Bytes) MarshalBinary() (data []byte, err error) {
-	var (
-		buf  *bytes.Buffer
-		enc  *gob.Encoder
-		item *wrapper.BytesWrapper
-	)
-
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-	item = &wrapper.BytesWrapper{Value: bt.Bytes.Bytes(), Valid: bt.Valid}
-	err = enc.Encode(item)
-	data = buf.Bytes()
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (bt Bytes) MarshalCBOR() ([]byte, error) { return bt.null().MarshalCBOR() }
 
-	return
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из строки с тегом !!binary либо из текстовой строки
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (bt *Bytes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setBytesNull(bt, (*Null[[]byte]).UnmarshalYAML, unmarshal)
 }
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение, не являющееся текстом UTF-8, библиотека YAML записывает с тегом !!binary, иначе текстовой строкой
+func (bt Bytes) MarshalYAML() (interface{}, error) { return bt.null().MarshalYAML() }
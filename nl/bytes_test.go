@@ -8,8 +8,11 @@ import (
 	"encoding/gob"
 	"encoding/hex"
 	"encoding/json"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 const bytesTestString = `Test data 1pHuOxADZkeh8Y9WvL75`
@@ -286,32 +289,133 @@ func TestBytesUnmarshalBinary(t *testing.T) {
 }
 
 func TestBytesMarshalBinary(t *testing.T) {
-	var err error
-	var buf *bytes.Buffer
-	var enc *gob.Encoder
-
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-
 	bt := NewBytes()
-	err = enc.Encode(&bt)
+	data, err := bt.MarshalBinary()
 	errorPanic(err)
-	bth := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(bth), bytesNullInvalidGob, "NewBytes() -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), bytesNullInvalidBinary, "NewBytes() -> MarshalBinary()")
 
-	buf.Reset()
-	enc = gob.NewEncoder(buf)
 	btok := NewBytesValue([]byte(bytesTestString))
-	err = enc.Encode(&btok)
+	data, err = btok.MarshalBinary()
 	errorPanic(err)
-	bto := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(bto), bytesOkValidGob, "NewBytesValue(max) -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), bytesOkValidBinary, "NewBytesValue() -> MarshalBinary()")
 
-	buf.Reset()
-	enc = gob.NewEncoder(buf)
 	zero := NewBytesValue(nil)
-	err = enc.Encode(&zero)
+	data, err = zero.MarshalBinary()
+	errorPanic(err)
+	jsonEquals(t, []byte(hex.EncodeToString(data)), bytesZeroValidBinary, "NewBytesValue(nil) -> MarshalBinary()")
+}
+
+func TestBytesZeroValue(t *testing.T) {
+	var bt Bytes
+
+	bt.Reset()
+	isNullBytes(t, bt, "Bytes{}.Reset()")
+	if v := bt.MustValue(); v == nil || len(v) != 0 {
+		t.Error("MustValue()", "of zero value is wrong")
+	}
+	if dv, err := bt.Value(); err != nil || dv != nil {
+		t.Error("Value()", "of zero value is wrong")
+	}
+	if data, err := bt.MarshalJSON(); err != nil || string(data) != "null" {
+		t.Error("MarshalJSON()", "of zero value is wrong")
+	}
+	if data, err := bt.MarshalText(); err != nil || string(data) != "null" {
+		t.Error("MarshalText()", "of zero value is wrong")
+	}
+	data, err := bt.MarshalBinary()
+	errorPanic(err)
+	bt.SetValid([]byte(bytesTestString))
+	errorPanic(bt.UnmarshalBinary(data))
+	isNullBytes(t, bt, "UnmarshalBinary()")
+	bt.NullIfDefault()
+	isNullBytes(t, bt, "NullIfDefault()")
+}
+
+func TestBytesCopy(t *testing.T) {
+	var src = []byte(bytesTestString)
+
+	bt := NewBytesValue(src)
+	src[0] = 'X'
+	isBytesValid(t, bt, "NewBytesValue()")
+
+	bt.MustValue()[0] = 'X'
+	isBytesValid(t, bt, "MustValue()")
+	(*bt.Pointer())[0] = 'X'
+	isBytesValid(t, bt, "Pointer()")
+
+	clone := bt.Clone()
+	clone.Bytes[0] = 'X'
+	isBytesValid(t, bt, "Clone()")
+	if clone = NewBytes().Clone(); clone.Valid || clone.Bytes != nil {
+		t.Error("Clone()", "of null value is wrong")
+	}
+
+	if err := bt.Scan(src); err != nil || bt.Bytes.String() != string(src) {
+		t.Error("Scan()", "is wrong")
+	}
+	src[0] = 'T'
+	if bt.Bytes[0] != 'X' {
+		t.Error("Scan()", "doesn't copy value")
+	}
+}
+
+func TestBytesBuffer(t *testing.T) {
+	var bt = NewBytesValue(nil)
+
+	_, _ = bt.Bytes.WriteString("Test data")
+	_, _ = bt.Bytes.Write([]byte(" 1pHuOxADZkeh8Y9WvL75"))
+	isBytesValid(t, bt, "Write()")
+	if bt.Bytes.Len() != len(bytesTestString) {
+		t.Errorf("Len() is %d, but should be %d", bt.Bytes.Len(), len(bytesTestString))
+	}
+	bt.Bytes.Reset()
+	if !bt.Valid || bt.Bytes.Len() != 0 {
+		t.Error("Buffer.Reset()", "is wrong")
+	}
+}
+
+func TestBytesBufferIO(t *testing.T) {
+	var (
+		bt  = NewBytesValue(nil)
+		buf = make([]byte, 9)
+		out bytes.Buffer
+	)
+
+	n, err := bt.Bytes.ReadFrom(iotest.OneByteReader(strings.NewReader(bytesTestString)))
 	errorPanic(err)
-	btz := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(btz), bytesZeroValidGob, "NewBytesValue(nil) -> MarshalBinary()")
+	isBytesValid(t, bt, "ReadFrom()")
+	if n != int64(len(bytesTestString)) {
+		t.Errorf("ReadFrom() is %d, but should be %d", n, len(bytesTestString))
+	}
+	bt.Bytes.Grow(100)
+	if cap(bt.Bytes)-len(bt.Bytes) < 100 {
+		t.Errorf("Grow() capacity is %d, but should be at least %d", cap(bt.Bytes), len(bt.Bytes)+100)
+	}
+	isBytesValid(t, bt, "Grow()")
+	if m, err := bt.Bytes.Read(buf); err != nil || string(buf[:m]) != bytesTestString[:9] {
+		t.Errorf("Read() is %q, %v, but should be %q", buf[:m], err, bytesTestString[:9])
+	}
+	bt.Bytes.Truncate(5)
+	if bt.Bytes.String() != bytesTestString[9:14] {
+		t.Errorf("Truncate() is %q, but should be %q", bt.Bytes.String(), bytesTestString[9:14])
+	}
+	if n, err = bt.Bytes.WriteTo(&out); err != nil || n != 5 || out.String() != bytesTestString[9:14] {
+		t.Errorf("WriteTo() is %q, %d, %v, but should be %q", out.String(), n, err, bytesTestString[9:14])
+	}
+	if m, err := bt.Bytes.Read(buf); m != 0 || err != io.EOF {
+		t.Errorf("Read() of empty value is %d, %v, but should be %v", m, err, io.EOF)
+	}
+	if m, err := bt.Bytes.Read(nil); m != 0 || err != nil {
+		t.Errorf("Read(nil) of empty value is %d, %v, but should be 0, nil", m, err)
+	}
+
+	if FromBuffer(nil) != nil {
+		t.Error("FromBuffer(nil)", "is not nil, but should be nil")
+	}
+	src := bytes.NewBufferString("xx" + bytesTestString)
+	_, _ = src.Read(make([]byte, 2))
+	bt = Bytes{Bytes: FromBuffer(src), Valid: true}
+	isBytesValid(t, bt, "FromBuffer()")
+	src.Bytes()[0] = 'x'
+	isBytesValid(t, bt, "FromBuffer() copy")
 }
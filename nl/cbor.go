@@ -0,0 +1,246 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+)
+
+// Формат CBOR (RFC 8949)
+// Не действительное значение кодируется null, логические значения, числа, строки и срезы байт кодируются
+// соответствующими основными типами CBOR, время текстовой строкой с тегом 0, остальные типы текстовым
+// представлением MarshalText в виде текстовой строки. При декодировании основной тип значения проверяется строго,
+// например число не декодируется из строки, а строка из строки байт. Методы MarshalCBOR и UnmarshalCBOR
+// вызываются библиотекой github.com/fxamacker/cbor и совместимыми библиотеками.
+
+// Кодирование значения в формате CBOR
+type cborMarshaler interface {
+	MarshalCBOR() ([]byte, error)
+}
+
+// Декодирование значения в формате CBOR
+type cborUnmarshaler interface {
+	UnmarshalCBOR(data []byte) error
+}
+
+// Значение, декодируемое из текстового представления
+type cborTextUnmarshaler interface {
+	encoding.TextUnmarshaler
+	Reset()
+}
+
+var (
+	cborMarshalerType   = reflect.TypeOf((*cborMarshaler)(nil)).Elem()
+	cborUnmarshalerType = reflect.TypeOf((*cborUnmarshaler)(nil)).Elem()
+	cborTimeType        = reflect.TypeOf(time.Time{})
+)
+
+// Проверка отсутствия данных после значения
+func cborEnd(rest []byte, err error) error {
+	if err == nil && len(rest) > 0 {
+		err = fmt.Errorf("cbor: %d extra bytes after value", len(rest))
+	}
+	return err
+}
+
+// Декодирование значения функцией чтения, null является истиной, если значение является null
+func unmarshalCBOR[T any](data []byte, read func([]byte) (T, []byte, error)) (value T, null bool, err error) {
+	var rest []byte
+
+	if null = cbor.IsNull(data); null {
+		rest, err = cbor.ReadNull(data)
+	} else {
+		value, rest, err = read(data)
+	}
+	err = cborEnd(rest, err)
+
+	return
+}
+
+// Функция чтения целого числа размером bits бит
+func cborInt(bits int) func([]byte) (int64, []byte, error) {
+	return func(b []byte) (int64, []byte, error) { return cbor.ReadInt(b, bits) }
+}
+
+// Кодирование текстового представления значения в виде текстовой строки, не действительное значение кодируется null
+func marshalCBORText(valid bool, value encoding.TextMarshaler) (data []byte, err error) {
+	var text []byte
+
+	if !valid {
+		data = cbor.AppendNull(nil)
+		return
+	}
+	if text, err = value.MarshalText(); err == nil {
+		data = cbor.AppendString(nil, string(text))
+	}
+
+	return
+}
+
+// Декодирование значения из текстовой строки методом UnmarshalText, null сбрасывает значение
+func unmarshalCBORText(data []byte, value cborTextUnmarshaler) (err error) {
+	var (
+		text string
+		null bool
+	)
+
+	if cbor.NextType(data) != cbor.StringType && !cbor.IsNull(data) {
+		return fmt.Errorf("can't unmarshal cbor %s into %T", cbor.NextType(data), value)
+	}
+	if text, null, err = unmarshalCBOR(data, cbor.ReadString); err != nil {
+		return
+	}
+	if null {
+		value.Reset()
+		return
+	}
+	err = value.UnmarshalText([]byte(text))
+
+	return
+}
+
+// Кодирование значения произвольного типа
+// Значения типов, реализующих MarshalCBOR, кодируются этим методом, логические значения, числа, строки,
+// срезы байт и время соответствующими типами CBOR, значения остальных типов через JSON представление
+func appendCBORValue(b []byte, value interface{}) (ret []byte, err error) {
+	var (
+		rv   = reflect.ValueOf(value)
+		data []byte
+	)
+
+	switch {
+	case !rv.IsValid():
+		return cbor.AppendNull(b), nil
+	case rv.Type().Implements(cborMarshalerType):
+		if data, err = value.(cborMarshaler).MarshalCBOR(); err == nil {
+			ret = append(b, data...)
+		}
+		return
+	case rv.Type() == cborTimeType:
+		return cbor.AppendTime(b, value.(time.Time)), nil
+	case isBytesKind(rv):
+		return cbor.AppendBytes(b, rv.Bytes()), nil
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		ret = cbor.AppendBool(b, rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ret = cbor.AppendInt(b, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		ret = cbor.AppendUint(b, rv.Uint())
+	case reflect.Float32:
+		ret = cbor.AppendFloat32(b, float32(rv.Float()))
+	case reflect.Float64:
+		ret = cbor.AppendFloat64(b, rv.Float())
+	case reflect.String:
+		ret = cbor.AppendString(b, rv.String())
+	default:
+		if data, err = json.Marshal(value); err == nil {
+			ret, err = appendCBORJSON(b, data)
+		}
+	}
+
+	return
+}
+
+// Декодирование значения произвольного типа, возвращаются данные после значения
+func readCBORValue(data []byte, value interface{}) (rest []byte, err error) {
+	var (
+		rv  = reflect.ValueOf(value).Elem()
+		raw []byte
+		i   int64
+		u   uint64
+		f   float64
+		b   bool
+		str string
+		buf []byte
+		tm  time.Time
+	)
+
+	if rest, err = cbor.Skip(data); err != nil {
+		return
+	}
+	raw = data[:len(data)-len(rest)]
+	switch {
+	case reflect.PointerTo(rv.Type()).Implements(cborUnmarshalerType):
+		err = value.(cborUnmarshaler).UnmarshalCBOR(raw)
+		return
+	case rv.Type() == cborTimeType:
+		if tm, _, err = cbor.ReadTime(raw); err == nil {
+			rv.Set(reflect.ValueOf(tm))
+		}
+		return
+	case isBytesKind(rv):
+		if buf, _, err = cbor.ReadBytes(raw); err == nil {
+			rv.SetBytes(buf)
+		}
+		return
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		if b, _, err = cbor.ReadBool(raw); err == nil {
+			rv.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, _, err = cbor.ReadInt(raw, rv.Type().Bits()); err == nil {
+			rv.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, _, err = cbor.ReadUint(raw, rv.Type().Bits()); err == nil {
+			rv.SetUint(u)
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, _, err = cbor.ReadFloat(raw, rv.Type().Bits()); err == nil {
+			rv.SetFloat(f)
+		}
+	case reflect.String:
+		if str, _, err = cbor.ReadString(raw); err == nil {
+			rv.SetString(str)
+		}
+	default:
+		if buf, err = cborJSON(raw); err == nil {
+			err = json.Unmarshal(buf, value)
+		}
+	}
+
+	return
+}
+
+// Кодирование документа JSON в виде значений CBOR, объекты кодируются картами, массивы массивами
+func appendCBORJSON(b []byte, data []byte) (ret []byte, err error) {
+	var (
+		dec   = json.NewDecoder(bytes.NewReader(data))
+		value interface{}
+	)
+
+	dec.UseNumber()
+	if err = dec.Decode(&value); err == nil {
+		ret, err = cbor.AppendValue(b, value)
+	}
+
+	return
+}
+
+// Документ JSON из значения CBOR
+func cborJSON(data []byte) (ret []byte, err error) {
+	var (
+		value interface{}
+		rest  []byte
+	)
+
+	if value, rest, err = cbor.ReadValue(data); err == nil {
+		err = cborEnd(rest, nil)
+	}
+	if err == nil {
+		ret, err = json.Marshal(value)
+	}
+
+	return
+}
@@ -0,0 +1,205 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type cborTestValue interface {
+	cborMarshaler
+	cborUnmarshaler
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	for _, item := range binaryTestValues() {
+		value, ok := item.(cborTestValue)
+		if !ok {
+			t.Errorf("%T doesn't implement MarshalCBOR and UnmarshalCBOR", item)
+			continue
+		}
+		if date, ok := value.(*Date); ok && date.Date.Year < 0 {
+			// Текстовое представление даты не поддерживает годы до нашей эры
+			continue
+		}
+		data, err := value.MarshalCBOR()
+		errorPanic(err)
+		target := reflect.New(reflect.TypeOf(value).Elem()).Interface().(cborTestValue)
+		if err = target.UnmarshalCBOR(data); err != nil {
+			t.Errorf("UnmarshalCBOR() of %T error: %s", value, err)
+			continue
+		}
+		again, err := target.MarshalCBOR()
+		errorPanic(err)
+		if !bytes.Equal(again, data) {
+			t.Errorf("MarshalCBOR() of decoded %T is %x, but should be %x", value, again, data)
+		}
+		switch value.(type) {
+		case *Decimal, *BigInt, *BigFloat, *Time, *TimeRange, *Duration:
+		case *Optional[string]:
+			// Отсутствующее значение кодируется null и декодируется как присутствующее значение null
+		default:
+			if !reflect.DeepEqual(target, value) {
+				t.Errorf("UnmarshalCBOR() of %T is %v, but should be %v", value, target, value)
+			}
+		}
+	}
+}
+
+func TestCBORFormat(t *testing.T) {
+	var (
+		array, _ = NewArrayDims([]Int64{NewInt64Value(1), NewInt64(), NewInt64Value(3), NewInt64Value(-4)}, 2, 2)
+		dec, _   = NewDecimalString("1.50")
+		big, _   = NewBigIntString("18446744073709551616")
+		uuid, _  = NewUUIDString("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+		binary   = uuid
+		tests    []struct {
+			Value cborMarshaler
+			Hex   string
+		}
+	)
+
+	binary.Binary = true
+	tests = []struct {
+		Value cborMarshaler
+		Hex   string
+	}{
+		{NewInt64(), "f6"},
+		{NewInt64Value(-33), "3820"},
+		{NewUint8Value(200), "18c8"},
+		{NewFloat32Value(1.5), "fa3fc00000"},
+		{NewBoolValue(true), "f5"},
+		{NewStringValue("abc"), "63616263"},
+		{NewBytesValue([]byte{1, 2}), "420102"},
+		{NewTimeValue(time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)), "c074" + hex.EncodeToString([]byte("2013-03-21T20:04:00Z"))},
+		{NewDurationValue(time.Second), "1a3b9aca00"},
+		{dec, "64312e3530"},
+		{NewBigIntValue(nil), "00"},
+		{big, "c249010000000000000000"},
+		{NewDateValue(CivilDate{Year: 2020, Month: time.February, Day: 29}), "6a323032302d30322d3239"},
+		{uuid, "7824" + hex.EncodeToString([]byte("f81d4fae-7dec-11d0-a765-00a0c91e6bf6"))},
+		{binary, "d82550f81d4fae7dec11d0a76500a0c91e6bf6"},
+		{array, "828201f6820323"},
+		{NewArrayValue([]Int64{}), "80"},
+		{NewStringMapValue(map[string]String{"b": NewString(), "aa": NewStringValue("2"), "a": NewStringValue("1")}), "a3616161316162f66261616132"},
+		{NewJSONValue([]byte(`{"a":[1,2.5,null]}`)), "a1616183" + "01fb4004000000000000f6"},
+		{NewJSONOfValue(binaryTestStruct{Name: "a", Count: 2}), "a2644e616d65616165436f756e7402"},
+		{NewNullValue[int16](-7), "26"},
+		{NewNullValue(binaryTestStruct{Name: "a", Count: 2}), "a2644e616d65616165436f756e7402"},
+		{NewNullValue(NewStringValue("a")), "6161"},
+		{NewOptional[int](), "f6"},
+	}
+	for _, test := range tests {
+		data, err := test.Value.MarshalCBOR()
+		errorPanic(err)
+		if hex.EncodeToString(data) != test.Hex {
+			t.Errorf("MarshalCBOR() of %T is %x, but should be %s", test.Value, data, test.Hex)
+		}
+	}
+}
+
+func TestCBORUnmarshal(t *testing.T) {
+	var (
+		i8  Int8
+		u64 Uint64
+		f32 Float32
+		s   String
+		bt  Bytes
+		tm  Time
+		d   Duration
+		dec Decimal
+		bi  BigInt
+		u   UUID
+		o   Optional[int]
+	)
+
+	errorPanic(i8.UnmarshalCBOR([]byte{0x17}))
+	if !i8.Valid || i8.Int8 != 23 {
+		t.Errorf("UnmarshalCBOR() is %v, but should be 23", i8)
+	}
+	if err := i8.UnmarshalCBOR([]byte{0xf7}); err == nil {
+		t.Error("UnmarshalCBOR()", "of undefined error is nil, but should be not nil")
+	}
+	errorPanic(u64.UnmarshalCBOR([]byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}))
+	if !u64.Valid || u64.Uint64 != 1<<64-1 {
+		t.Errorf("UnmarshalCBOR() is %v, but should be %d", u64, uint64(1<<64-1))
+	}
+	errorPanic(f32.UnmarshalCBOR([]byte{0xf9, 0x3e, 0x00}))
+	if !f32.Valid || f32.Float32 != 1.5 {
+		t.Errorf("UnmarshalCBOR() is %v, but should be 1.5", f32)
+	}
+	errorPanic(tm.UnmarshalCBOR([]byte{0xc1, 0x1a, 0x51, 0x4b, 0x67, 0xb0}))
+	if !tm.Valid || !tm.Time.Equal(time.Unix(1363896240, 0)) {
+		t.Errorf("UnmarshalCBOR() is %v, but should be 2013-03-21T20:04:00Z", tm)
+	}
+	errorPanic(tm.UnmarshalCBOR(append([]byte{0xc0, 0x78, 0x19}, "2013-03-21T23:04:00+03:00"...)))
+	if _, offset := tm.Time.Zone(); !tm.Time.Equal(time.Unix(1363896240, 0)) || offset != 3*60*60 {
+		t.Errorf("UnmarshalCBOR() is %v, but should be 2013-03-21T23:04:00+03:00", tm)
+	}
+	errorPanic(bi.UnmarshalCBOR([]byte{0xc3, 0x49, 1, 0, 0, 0, 0, 0, 0, 0, 0}))
+	if text, _ := bi.MarshalText(); string(text) != "-18446744073709551617" {
+		t.Errorf("UnmarshalCBOR() is %s, but should be -18446744073709551617", text)
+	}
+	errorPanic(u.UnmarshalCBOR(append([]byte{0x50}, make([]byte, 16)...)))
+	if !u.Valid || !u.Binary {
+		t.Error("UnmarshalCBOR()", "of byte string doesn't set Binary")
+	}
+	errorPanic(u.UnmarshalCBOR([]byte{0xf6}))
+	if u.Valid {
+		t.Error("UnmarshalCBOR()", "of null is valid, but should be invalid")
+	}
+	errorPanic(o.UnmarshalCBOR([]byte{0xf6}))
+	isOptionalNull(t, o, "UnmarshalCBOR(null)")
+	errorPanic(s.UnmarshalCBOR([]byte{0x7f, 0x61, 0x61, 0x62, 0x62, 0x63, 0xff}))
+	if !s.Valid || s.String != "abc" {
+		t.Errorf("UnmarshalCBOR() of indefinite-length string is %v, but should be abc", s)
+	}
+
+	var errors = []struct {
+		Value cborUnmarshaler
+		Hex   string
+	}{
+		{&i8, "1880"},
+		{&i8, "6130"},
+		{&i8, "f93c00"},
+		{&i8, "0101"},
+		{&i8, "f6f6"},
+		{&i8, ""},
+		{&u64, "20"},
+		{&f32, "01"},
+		{&s, "4161"},
+		{&s, "7f6161"},
+		{&bt, "6161"},
+		{&tm, "1a514b67b0"},
+		{&tm, "74323031332d30332d32315432303a30343a30305a"},
+		{&tm, "c26130"},
+		{&d, "623168"},
+		{&dec, "01"},
+		{&dec, "4131"},
+		{&bi, "6131"},
+		{&u, "d8254101"},
+		{&u, "d8266130"},
+		{&u, "4101"},
+		{&Int64Array{}, "828201f68103"},
+		{&Int64Array{}, "82810101"},
+		{&Int64Array{}, "8260"},
+		{&Int64Array{}, "9f01ff"},
+		{&StringMap{}, "a101f6"},
+		{&StringMap{}, "a1616101"},
+		{&JSON{}, "a16161"},
+		{&JSONOf[binaryTestStruct]{}, "a1644e616d6501"},
+		{&Null[int8]{}, "1880"},
+		{&Null[binaryTestStruct]{}, "a1644e616d6501"},
+	}
+	for _, test := range errors {
+		data, err := hex.DecodeString(test.Hex)
+		errorPanic(err)
+		if err = test.Value.UnmarshalCBOR(data); err == nil {
+			t.Errorf("UnmarshalCBOR(%s) into %T error is nil, but should be not nil", test.Hex, test.Value)
+		}
+	}
+}
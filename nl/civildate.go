@@ -0,0 +1,69 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"fmt"
+	"time"
+)
+
+const civilDateLayout = "2006-01-02"
+
+// CivilDate Календарная дата без времени и часового пояса
+type CivilDate struct {
+	Year  int        // Год
+	Month time.Month // Месяц года, начиная с 1
+	Day   int        // День месяца, начиная с 1
+}
+
+// CivilDateOf Возвращает календарную дату момента времени в его часовом поясе
+func CivilDateOf(t time.Time) CivilDate {
+	var ret CivilDate
+
+	ret.Year, ret.Month, ret.Day = t.Date()
+
+	return ret
+}
+
+// ParseCivilDate Разбор календарной даты в формате "2006-01-02"
+// Допускается строка с временем после даты, отделённым символом 'T' или пробелом, время отбрасывается
+func ParseCivilDate(str string) (ret CivilDate, err error) {
+	var (
+		src = str
+		t   time.Time
+	)
+
+	if len(str) > len(civilDateLayout) && (str[len(civilDateLayout)] == 'T' || str[len(civilDateLayout)] == ' ') {
+		str = str[:len(civilDateLayout)]
+	}
+	if t, err = time.Parse(civilDateLayout, str); err != nil {
+		err = fmt.Errorf("can't parse %q as date: %s", src, err)
+		return
+	}
+	ret = CivilDateOf(t)
+
+	return
+}
+
+// String Возвращает дату в формате "2006-01-02"
+func (d CivilDate) String() string { return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day) }
+
+// IsZero Возвращает истину, если дата не задана
+func (d CivilDate) IsZero() bool { return d.Year == 0 && d.Month == 0 && d.Day == 0 }
+
+// IsValid Возвращает истину, если дата существует в календаре
+func (d CivilDate) IsValid() bool { return CivilDateOf(d.In(time.UTC)) == d }
+
+// In Возвращает начало дня даты в часовом поясе loc
+func (d CivilDate) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays Возвращает дату, отстоящую на n дней
+func (d CivilDate) AddDays(n int) CivilDate { return CivilDateOf(d.In(time.UTC).AddDate(0, 0, n)) }
+
+// Before Возвращает истину, если дата раньше o
+func (d CivilDate) Before(o CivilDate) bool { return d.In(time.UTC).Before(o.In(time.UTC)) }
+
+// After Возвращает истину, если дата позже o
+func (d CivilDate) After(o CivilDate) bool { return d.In(time.UTC).After(o.In(time.UTC)) }
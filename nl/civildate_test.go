@@ -0,0 +1,56 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCivilDate(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out CivilDate
+	}{
+		{"2019-03-31", CivilDate{2019, time.March, 31}},
+		{"0001-01-01", CivilDate{1, time.January, 1}},
+		{"2020-02-29T00:00:00Z", CivilDate{2020, time.February, 29}},
+		{"2020-02-29 23:59:59+03", CivilDate{2020, time.February, 29}},
+	}
+
+	for _, test := range tests {
+		d, err := ParseCivilDate(test.In)
+		errorPanic(err)
+		if d != test.Out {
+			t.Errorf("ParseCivilDate(%q) is %v, but should be %v", test.In, d, test.Out)
+		}
+	}
+	for _, in := range []string{"", "2019-02-29", "2019-13-01", "2019-3-1", "31.03.2019", "2019-03-31X"} {
+		if _, err := ParseCivilDate(in); err == nil {
+			t.Errorf("ParseCivilDate(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestCivilDate(t *testing.T) {
+	d := CivilDate{2019, time.December, 31}
+	if d.String() != "2019-12-31" {
+		t.Error("String()", "is wrong")
+	}
+	if d.IsZero() || !(CivilDate{}).IsZero() {
+		t.Error("IsZero()", "is wrong")
+	}
+	if !d.IsValid() || (CivilDate{2019, time.February, 29}).IsValid() {
+		t.Error("IsValid()", "is wrong")
+	}
+	if n := d.AddDays(1); n != (CivilDate{2020, time.January, 1}) || !n.After(d) || !d.Before(n) {
+		t.Error("AddDays()", "is wrong")
+	}
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	if tm := d.In(loc); !tm.Equal(time.Date(2019, time.December, 30, 21, 0, 0, 0, time.UTC)) {
+		t.Error("In()", "is wrong")
+	}
+	if CivilDateOf(time.Date(2019, time.December, 31, 23, 0, 0, 0, time.UTC).In(loc)) != d.AddDays(1) {
+		t.Error("CivilDateOf()", "is wrong")
+	}
+}
@@ -0,0 +1,192 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CivilTime Время суток без даты, с необязательным смещением часового пояса
+type CivilTime struct {
+	Hour       int  // Час, от 0 до 23, значение 24 допускается только для 24:00:00
+	Minute     int  // Минута, от 0 до 59
+	Second     int  // Секунда, от 0 до 59
+	Nanosecond int  // Наносекунда, от 0 до 999999999
+	Offset     int  // Смещение часового пояса в секундах к востоку от UTC, учитывается если HasOffset=true
+	HasOffset  bool // HasOffset is true if time has offset of time zone (TIME WITH TIME ZONE)
+}
+
+// CivilTimeOf Возвращает время суток момента времени в его часовом поясе, без смещения часового пояса
+func CivilTimeOf(t time.Time) CivilTime {
+	var ret CivilTime
+
+	ret.Hour, ret.Minute, ret.Second = t.Clock()
+	ret.Nanosecond = t.Nanosecond()
+
+	return ret
+}
+
+// CivilTimeZoneOf Возвращает время суток момента времени со смещением его часового пояса
+func CivilTimeZoneOf(t time.Time) CivilTime {
+	var ret = CivilTimeOf(t)
+
+	_, ret.Offset = t.Zone()
+	ret.HasOffset = true
+
+	return ret
+}
+
+// ParseCivilTime Разбор времени суток в формате "15:04[:05[.999999999]]" с необязательным смещением
+// часового пояса "Z", "±07", "±0700", "±07:00" или "±07:00:00"
+func ParseCivilTime(str string) (ret CivilTime, err error) {
+	var (
+		src   = str
+		n     int
+		clock []string
+		frac  string
+	)
+
+	if n = strings.IndexAny(str, "Zz+-"); n >= 0 {
+		if ret.Offset, err = parseCivilTimeOffset(str[n:]); err != nil {
+			err = fmt.Errorf("can't parse %q as time of day: invalid offset", src)
+			return
+		}
+		ret.HasOffset, str = true, str[:n]
+	}
+	if n = strings.IndexAny(str, ".,"); n >= 0 {
+		str, frac = str[:n], str[n+1:]
+		if len(frac) == 0 || len(frac) > 9 || strings.IndexFunc(frac, notDigit) >= 0 {
+			err = fmt.Errorf("can't parse %q as time of day: invalid fraction of second", src)
+			return
+		}
+		ret.Nanosecond, _ = strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+	}
+	if clock = strings.Split(str, ":"); len(clock) < 2 || len(clock) > 3 || (frac != "" && len(clock) != 3) {
+		err = fmt.Errorf("can't parse %q as time of day", src)
+		return
+	}
+	for i, item := range clock {
+		if len(item) != 2 || strings.IndexFunc(item, notDigit) >= 0 {
+			err = fmt.Errorf("can't parse %q as time of day", src)
+			return
+		}
+		n, _ = strconv.Atoi(item)
+		switch i {
+		case 0:
+			ret.Hour = n
+		case 1:
+			ret.Minute = n
+		default:
+			ret.Second = n
+		}
+	}
+	if !ret.IsValid() {
+		err = fmt.Errorf("can't parse %q as time of day: value out of range", src)
+	}
+
+	return
+}
+
+// Разбор смещения часового пояса в секундах
+func parseCivilTimeOffset(str string) (ret int, err error) {
+	var (
+		sign  = 1
+		parts []string
+		n     int
+	)
+
+	if str == "Z" || str == "z" {
+		return
+	}
+	if str[0] == '-' {
+		sign = -1
+	} else if str[0] != '+' {
+		err = strconv.ErrSyntax
+		return
+	}
+	switch str = str[1:]; {
+	case len(str) == 4 && strings.IndexByte(str, ':') < 0:
+		parts = []string{str[:2], str[2:]}
+	default:
+		parts = strings.Split(str, ":")
+	}
+	if len(parts) > 3 {
+		err = strconv.ErrSyntax
+		return
+	}
+	for i, item := range parts {
+		if len(item) != 2 || strings.IndexFunc(item, notDigit) >= 0 {
+			err = strconv.ErrSyntax
+			return
+		}
+		if n, _ = strconv.Atoi(item); i > 0 && n > 59 {
+			err = strconv.ErrRange
+			return
+		}
+		ret = ret*60 + n
+	}
+	for i := len(parts); i < 3; i++ {
+		ret *= 60
+	}
+	if ret > 18*60*60 {
+		err = strconv.ErrRange
+		return
+	}
+	ret *= sign
+
+	return
+}
+
+// String Возвращает время суток в формате "15:04:05[.999999999][±07:00]"
+func (c CivilTime) String() string {
+	var (
+		buf    strings.Builder
+		offset int
+	)
+
+	buf.WriteString(fmt.Sprintf("%02d:%02d:%02d", c.Hour, c.Minute, c.Second))
+	if c.Nanosecond != 0 {
+		buf.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", c.Nanosecond), "0"))
+	}
+	if !c.HasOffset {
+		return buf.String()
+	}
+	if offset = c.Offset; offset < 0 {
+		buf.WriteByte('-')
+		offset = -offset
+	} else {
+		buf.WriteByte('+')
+	}
+	buf.WriteString(fmt.Sprintf("%02d:%02d", offset/3600, offset/60%60))
+	if offset%60 != 0 {
+		buf.WriteString(fmt.Sprintf(":%02d", offset%60))
+	}
+
+	return buf.String()
+}
+
+// IsZero Возвращает истину, если время равно полуночи без смещения часового пояса
+func (c CivilTime) IsZero() bool { return c == CivilTime{} }
+
+// IsValid Возвращает истину, если значения компонентов времени находятся в допустимых пределах
+func (c CivilTime) IsValid() bool {
+	if c.Hour == 24 {
+		return c.Minute == 0 && c.Second == 0 && c.Nanosecond == 0
+	}
+	return c.Hour >= 0 && c.Hour < 24 &&
+		c.Minute >= 0 && c.Minute < 60 &&
+		c.Second >= 0 && c.Second < 60 &&
+		c.Nanosecond >= 0 && c.Nanosecond < int(time.Second)
+}
+
+// On Возвращает момент времени в дату d
+// Если время имеет смещение часового пояса, используется смещение, иначе часовой пояс loc
+func (c CivilTime) On(d CivilDate, loc *time.Location) time.Time {
+	if c.HasOffset {
+		loc = time.FixedZone("", c.Offset)
+	}
+	return time.Date(d.Year, d.Month, d.Day, c.Hour, c.Minute, c.Second, c.Nanosecond, loc)
+}
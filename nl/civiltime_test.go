@@ -0,0 +1,86 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCivilTime(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out CivilTime
+	}{
+		{"15:04:05", CivilTime{Hour: 15, Minute: 4, Second: 5}},
+		{"15:04", CivilTime{Hour: 15, Minute: 4}},
+		{"15:04:05.999999", CivilTime{Hour: 15, Minute: 4, Second: 5, Nanosecond: 999999000}},
+		{"00:00:00.000000001", CivilTime{Nanosecond: 1}},
+		{"24:00:00", CivilTime{Hour: 24}},
+		{"15:04:05Z", CivilTime{Hour: 15, Minute: 4, Second: 5, HasOffset: true}},
+		{"15:04:05+03", CivilTime{Hour: 15, Minute: 4, Second: 5, Offset: 3 * 3600, HasOffset: true}},
+		{"15:04:05.5-05:30", CivilTime{Hour: 15, Minute: 4, Second: 5, Nanosecond: 500000000, Offset: -5*3600 - 30*60, HasOffset: true}},
+		{"15:04:05+0530", CivilTime{Hour: 15, Minute: 4, Second: 5, Offset: 5*3600 + 30*60, HasOffset: true}},
+		{"15:04:05+02:30:15", CivilTime{Hour: 15, Minute: 4, Second: 5, Offset: 2*3600 + 30*60 + 15, HasOffset: true}},
+	}
+
+	for _, test := range tests {
+		c, err := ParseCivilTime(test.In)
+		errorPanic(err)
+		if c != test.Out {
+			t.Errorf("ParseCivilTime(%q) is %+v, but should be %+v", test.In, c, test.Out)
+		}
+	}
+	for _, in := range []string{
+		"", "15", "15:04:05:06", "25:00:00", "24:00:01", "15:60:00", "15:04:60", "1:04:05", "15:04.5",
+		"15:04:05.", "15:04:05.1234567890", "15:04:05+3", "15:04:05+19", "15:04:05+03:60", "15:04:05 +03",
+	} {
+		if _, err := ParseCivilTime(in); err == nil {
+			t.Errorf("ParseCivilTime(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestCivilTime(t *testing.T) {
+	var tests = []struct {
+		In  CivilTime
+		Out string
+	}{
+		{CivilTime{}, "00:00:00"},
+		{CivilTime{Hour: 15, Minute: 4, Second: 5, Nanosecond: 999999000}, "15:04:05.999999"},
+		{CivilTime{Hour: 15, Minute: 4, Second: 5, HasOffset: true}, "15:04:05+00:00"},
+		{CivilTime{Hour: 15, Minute: 4, Second: 5, Offset: -5*3600 - 30*60, HasOffset: true}, "15:04:05-05:30"},
+		{CivilTime{Hour: 15, Minute: 4, Second: 5, Offset: 2*3600 + 15, HasOffset: true}, "15:04:05+02:00:15"},
+	}
+
+	for _, test := range tests {
+		if s := test.In.String(); s != test.Out {
+			t.Errorf("String() is %q, but should be %q", s, test.Out)
+		}
+		if c, err := ParseCivilTime(test.Out); err != nil || c != test.In {
+			t.Errorf("ParseCivilTime(%q) is %+v, but should be %+v", test.Out, c, test.In)
+		}
+	}
+	if !(CivilTime{}).IsZero() || (CivilTime{HasOffset: true}).IsZero() {
+		t.Error("IsZero()", "is wrong")
+	}
+	if (CivilTime{Hour: -1}).IsValid() || (CivilTime{Nanosecond: int(time.Second)}).IsValid() {
+		t.Error("IsValid()", "is wrong")
+	}
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	tm := time.Date(2019, time.March, 31, 15, 4, 5, 6, loc)
+	if c := CivilTimeOf(tm); c != (CivilTime{Hour: 15, Minute: 4, Second: 5, Nanosecond: 6}) {
+		t.Error("CivilTimeOf()", "is wrong")
+	}
+	c := CivilTimeZoneOf(tm)
+	if c.Offset != 3*60*60 || !c.HasOffset {
+		t.Error("CivilTimeZoneOf()", "is wrong")
+	}
+	if !c.On(CivilDateOf(tm), time.UTC).Equal(tm) {
+		t.Error("On()", "is wrong")
+	}
+	if !CivilTimeOf(tm).On(CivilDateOf(tm), loc).Equal(tm) {
+		t.Error("On()", "is wrong")
+	}
+}
@@ -0,0 +1,316 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Date is an nullable date object without time and location
+type Date struct {
+	Date  CivilDate // Value of object
+	Valid bool      // Valid is true if value is not NULL
+}
+
+// NewDate Создание нового объекта Date
+func NewDate() Date {
+	return Date{
+		Date:  CivilDate{},
+		Valid: false,
+	}
+}
+
+// NewDateValue Создание нового действительного объекта Date из значения
+func NewDateValue(value CivilDate) Date {
+	return Date{
+		Date:  value,
+		Valid: true,
+	}
+}
+
+// NewDatePointerValue Создание нового действительного объекта Date из ссылки на значение
+func NewDatePointerValue(ptr *CivilDate) Date {
+	if ptr == nil {
+		return NewDate()
+	}
+	return NewDateValue(*ptr)
+}
+
+// NewDateTime Создание нового объекта Date из даты объекта Time в часовом поясе loc
+func NewDateTime(t Time, loc *time.Location) Date {
+	if !t.Valid {
+		return NewDate()
+	}
+	return NewDateValue(CivilDateOf(t.Time.In(loc)))
+}
+
+// Time Возвращает объект Time с началом дня даты в часовом поясе loc
+func (d Date) Time(loc *time.Location) Time {
+	if !d.Valid {
+		return NewTime()
+	}
+	return NewTimeValue(d.Date.In(loc))
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (d *Date) SetValid(value CivilDate) { d.Date, d.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (d *Date) Reset() { d.Date, d.Valid = CivilDate{}, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (d *Date) NullIfDefault() Date {
+	if d.Date.IsZero() {
+		d.Reset()
+	}
+	return *d
+}
+
+// MustValue Возвращает значение в любом случае
+func (d *Date) MustValue() CivilDate {
+	if !d.Valid {
+		return CivilDate{}
+	}
+	return d.Date
+}
+
+// Pointer Возвращает ссылку на значение
+func (d *Date) Pointer() *CivilDate {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Date
+}
+
+// Scan Реализация интерфейса Scanner
+func (d *Date) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		d.Reset()
+		return
+	case time.Time:
+		d.Date = CivilDateOf(x)
+	case []byte:
+		d.Date, err = ParseCivilDate(string(x))
+	case string:
+		d.Date, err = ParseCivilDate(x)
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.Date: %v", x, value)
+	}
+	if d.Valid = err == nil; !d.Valid {
+		d.Date = CivilDate{}
+	}
+
+	return
+}
+
+// Проверка даты перед преобразованием в формат "2006-01-02"
+// Дата, отсутствующая в календаре, в том числе нулевая дата, не имеет представления, которое принимает разбор
+func (d Date) check() (err error) {
+	if !d.Date.IsValid() {
+		err = fmt.Errorf("can't convert date %s of nul.Date: date doesn't exist", d.Date)
+	}
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+// Дата, отсутствующая в календаре, в том числе нулевая дата, и дата до 1 года нашей эры являются ошибкой,
+// так как не имеют представления в формате "2006-01-02", который принимает база данных
+func (d Date) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	if err := d.check(); err != nil {
+		return nil, err
+	}
+	if d.Date.Year < 1 {
+		return nil, fmt.Errorf("can't convert date %s of nul.Date: year before 1 AD", d.Date)
+	}
+	return d.Date.String(), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (d *Date) UnmarshalJSON(data []byte) (err error) {
+	var v interface{}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		d.Reset()
+		return
+	case string:
+		if len(x) == 0 {
+			d.Reset()
+			return
+		}
+		d.Date, err = ParseCivilDate(x)
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.Date", reflect.TypeOf(v).Kind())
+	}
+	if d.Valid = err == nil; !d.Valid {
+		d.Date = CivilDate{}
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+// Дата, отсутствующая в календаре, в том числе нулевая дата, является ошибкой, как и в Value()
+func (d Date) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !d.Valid {
+		data = []byte(nullString)
+		return
+	}
+	if err = d.check(); err != nil {
+		return
+	}
+	data = []byte(`"` + d.Date.String() + `"`)
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (d *Date) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		d.Reset()
+		return
+	case emptyString:
+		d.Date, d.Valid = CivilDate{}, true
+		return
+	default:
+		d.Date, err = ParseCivilDate(str)
+	}
+	if d.Valid = err == nil; !d.Valid {
+		d.Date = CivilDate{}
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+// Нулевая дата кодируется пустым текстом, другая дата, отсутствующая в календаре, является ошибкой
+func (d Date) MarshalText() (text []byte, err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	if !d.Valid {
+		text = []byte(nullString)
+		return
+	}
+	if d.Date.IsZero() {
+		text = []byte(emptyString)
+		return
+	}
+	if err = d.check(); err != nil {
+		return
+	}
+	text = []byte(d.Date.String())
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (d *Date) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return d.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagDate, d)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (d Date) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagDate, d) }
+
+// Запись значения в компактном двоичном формате
+func (d Date) encodeBinary(w *binaryWriter) {
+	if w.flags(d.Valid); !d.Valid {
+		return
+	}
+	w.varint(int64(d.Date.Year))
+	w.varint(int64(d.Date.Month))
+	w.varint(int64(d.Date.Day))
+}
+
+// Чтение значения в компактном двоичном формате
+func (d *Date) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		value CivilDate
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			d.Reset()
+		}
+		return
+	}
+	if value = (CivilDate{Year: r.int(), Month: time.Month(r.int()), Day: r.int()}); r.err == nil {
+		d.Date, d.Valid = value, valid
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (d *Date) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.DateWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.DateWrapper)
+	if err = dec.Decode(item); err == nil {
+		d.Date, d.Valid = CivilDate{Year: item.Year, Month: time.Month(item.Month), Day: item.Day}, item.Valid
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (d *Date) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, d) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой текстового представления
+func (d Date) MarshalMsgpack() ([]byte, error) { return marshalMsgpackText(d.Valid, d) }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (d *Date) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, d) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой текстового представления
+func (d Date) MarshalCBOR() ([]byte, error) { return marshalCBORText(d.Valid, d) }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (d *Date) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, d)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (d Date) MarshalYAML() (interface{}, error) { return marshalYAMLText(d.Valid, d) }
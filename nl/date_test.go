@@ -0,0 +1,241 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	dateValue  = CivilDate{Year: 2019, Month: time.March, Day: 31}
+	dateString = "2019-03-31"
+	dateJSON   = []byte(`"2019-03-31"`)
+)
+
+func isDateValid(t *testing.T, d Date, from string) {
+	if d.Date != dateValue {
+		t.Errorf("Bad %s date: %v ≠ %v\n", from, d.Date, dateValue)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isDateNull(t *testing.T, d Date, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewDate(t *testing.T) {
+	v1 := NewDate()
+	isDateNull(t, v1, "NewDate()")
+
+	v2 := NewDateValue(dateValue)
+	isDateValid(t, v2, "NewDateValue()")
+
+	v3 := NewDatePointerValue(nil)
+	isDateNull(t, v3, "NewDatePointerValue()")
+
+	v4 := NewDatePointerValue(v2.Pointer())
+	isDateValid(t, v4, "NewDatePointerValue()")
+}
+
+func TestDateSetValidReset(t *testing.T) {
+	v1 := NewDate()
+	if v1.Pointer() != nil || !v1.MustValue().IsZero() {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(dateValue)
+	isDateValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isDateValid(t, v1, "NullIfDefault()")
+	v1.SetValid(CivilDate{})
+	v1.NullIfDefault()
+	isDateNull(t, v1, "NullIfDefault()")
+}
+
+func TestDateTime(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+
+	v1 := NewDateValue(dateValue)
+	tm := v1.Time(loc)
+	if !tm.Valid || !tm.Time.Equal(time.Date(2019, time.March, 30, 21, 0, 0, 0, time.UTC)) {
+		t.Error("Time()", "is wrong")
+	}
+	if v2 := NewDate().Time(loc); v2.Valid {
+		t.Error("Time()", "is valid, but should be invalid")
+	}
+
+	v3 := NewDateTime(NewTimeValue(time.Date(2019, time.March, 30, 22, 0, 0, 0, time.UTC)), loc)
+	isDateValid(t, v3, "NewDateTime()")
+	v4 := NewDateTime(NewTimeValue(time.Date(2019, time.March, 30, 22, 0, 0, 0, time.UTC)), time.UTC)
+	if v4.Date != dateValue.AddDays(-1) {
+		t.Error("NewDateTime()", "is wrong")
+	}
+	v5 := NewDateTime(NewTime(), loc)
+	isDateNull(t, v5, "NewDateTime()")
+}
+
+func TestDateScanValue(t *testing.T) {
+	v1 := NewDate()
+	errorPanic(v1.Scan(time.Date(2019, time.March, 31, 23, 59, 59, 0, time.UTC)))
+	isDateValid(t, v1, "Scan(time.Time)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != dateString {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewDate()
+	errorPanic(v2.Scan(dateString))
+	isDateValid(t, v2, "Scan(string)")
+
+	v3 := NewDate()
+	errorPanic(v3.Scan([]byte("2019-03-31T00:00:00Z")))
+	isDateValid(t, v3, "Scan([]byte)")
+
+	v4 := NewDateValue(dateValue)
+	errorPanic(v4.Scan(nil))
+	isDateNull(t, v4, "Scan(nil)")
+	dv, err = v4.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v5 := NewDate()
+	if err = v5.Scan(int64(1)); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v5.Scan("2019-02-29"); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isDateNull(t, v5, "Scan()")
+
+	for _, value := range []CivilDate{{}, {Year: 2019, Month: time.February, Day: 29}, {Year: -44, Month: time.March, Day: 15}} {
+		if dv, err = NewDateValue(value).Value(); err == nil {
+			t.Errorf("Value() of %s is %v, but should be error", value, dv)
+		}
+	}
+}
+
+func TestDateUnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewDate()
+	errorPanic(json.Unmarshal(dateJSON, &v1))
+	isDateValid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewDateValue(dateValue)
+	errorPanic(json.Unmarshal(boolNullJSON, &v2))
+	isDateNull(t, v2, "UnmarshalJSON(null)")
+
+	v3 := NewDate()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v3))
+	isDateNull(t, v3, "UnmarshalJSON(blank)")
+
+	v4 := NewDate()
+	if err = json.Unmarshal(int64JSON, &v4); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = json.Unmarshal([]byte(`"31.03.2019"`), &v4); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = v4.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isDateNull(t, v4, "UnmarshalJSON()")
+}
+
+func TestDateMarshalJSON(t *testing.T) {
+	v1 := NewDateValue(dateValue)
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(dateJSON), "MarshalJSON()")
+
+	v2 := NewDate()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+
+	for _, value := range []CivilDate{{}, {Year: 2019, Month: time.February, Day: 30}} {
+		if data, err = json.Marshal(NewDateValue(value)); err == nil {
+			t.Errorf("MarshalJSON() of %s is %s, but should be error", value, data)
+		}
+		if value.IsZero() {
+			continue
+		}
+		if data, err = NewDateValue(value).MarshalText(); err == nil {
+			t.Errorf("MarshalText() of %s is %s, but should be error", value, data)
+		}
+	}
+}
+
+func TestDateRoundTrip(t *testing.T) {
+	for _, value := range []Date{NewDateValue(dateValue), NewDateValue(CivilDate{}), NewDate()} {
+		var (
+			fromJSON, fromText Date
+			data, err          = value.MarshalText()
+		)
+
+		errorPanic(err)
+		errorPanic(fromText.UnmarshalText(data))
+		if fromText != value {
+			t.Errorf("UnmarshalText(MarshalText()) is %v, but should be %v", fromText, value)
+		}
+		if data, err = json.Marshal(value); err != nil {
+			// Нулевая дата не имеет представления JSON, которое принимает разбор
+			if !value.Valid || !value.Date.IsZero() {
+				t.Errorf("MarshalJSON() of %v error: %s", value, err)
+			}
+			continue
+		}
+		errorPanic(json.Unmarshal(data, &fromJSON))
+		if fromJSON != value {
+			t.Errorf("UnmarshalJSON(MarshalJSON()) is %v, but should be %v", fromJSON, value)
+		}
+	}
+}
+
+func TestDateText(t *testing.T) {
+	v1 := NewDate()
+	errorPanic(v1.UnmarshalText([]byte(dateString)))
+	isDateValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, dateString, "MarshalText()")
+
+	v2 := NewDate()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || !v2.Date.IsZero() {
+		t.Errorf("Value should be valid")
+	}
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "", "MarshalText(empty)")
+
+	v3 := NewDateValue(dateValue)
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isDateNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewDate()
+	if err = v4.UnmarshalText([]byte("2019-03-32")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isDateNull(t, v4, "UnmarshalText()")
+}
+
+func TestDateBinary(t *testing.T) {
+	v1 := NewDateValue(dateValue)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewDate()
+	errorPanic(v2.UnmarshalBinary(data))
+	isDateValid(t, v2, "UnmarshalBinary()")
+}
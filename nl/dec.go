@@ -0,0 +1,262 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	decMaxScale = 16383   // Наибольшее количество знаков после точки при разборе, как у NUMERIC в PostgreSQL
+	decMinScale = -131072 // Наибольшее количество знаков до точки при разборе, как у NUMERIC в PostgreSQL
+)
+
+var bigTen = big.NewInt(10)
+
+// Dec Точное десятичное число, равное coefficient × 10^(-scale)
+// Нулевое значение Dec является нулём. Все операции возвращают новое значение и не изменяют операнды
+type Dec struct {
+	coef  *big.Int // Коэффициент, nil означает ноль
+	scale int32    // Количество знаков после десятичной точки
+}
+
+// NewDec Создание десятичного числа coefficient × 10^(-scale)
+func NewDec(coefficient int64, scale int32) Dec {
+	return Dec{coef: big.NewInt(coefficient), scale: scale}
+}
+
+// NewDecBig Создание десятичного числа из коэффициента произвольной длины, коэффициент копируется
+func NewDecBig(coefficient *big.Int, scale int32) Dec {
+	if coefficient == nil {
+		return Dec{scale: scale}
+	}
+	return Dec{coef: new(big.Int).Set(coefficient), scale: scale}
+}
+
+// ParseDec Разбор десятичного числа в формате [+-]digits[.digits][(e|E)[+-]digits]
+func ParseDec(str string) (ret Dec, err error) {
+	var (
+		src      = str
+		mantissa string
+		exp      int64
+		intPart  string
+		fracPart string
+		digits   string
+		scale    int64
+		negative bool
+		ok       bool
+	)
+
+	str = strings.TrimSpace(str)
+	if n := strings.IndexAny(str, "eE"); n >= 0 {
+		if exp, err = strconv.ParseInt(str[n+1:], 10, 32); err != nil {
+			err = fmt.Errorf("can't parse %q as decimal: invalid exponent", src)
+			return
+		}
+		mantissa = str[:n]
+	} else {
+		mantissa = str
+	}
+	if len(mantissa) > 0 && (mantissa[0] == '-' || mantissa[0] == '+') {
+		negative, mantissa = mantissa[0] == '-', mantissa[1:]
+	}
+	if n := strings.IndexByte(mantissa, '.'); n >= 0 {
+		intPart, fracPart = mantissa[:n], mantissa[n+1:]
+	} else {
+		intPart = mantissa
+	}
+	if digits = intPart + fracPart; len(digits) == 0 || strings.IndexFunc(digits, notDigit) >= 0 {
+		err = fmt.Errorf("can't parse %q as decimal", src)
+		return
+	}
+	if scale = int64(len(fracPart)) - exp; scale > decMaxScale || scale < decMinScale {
+		err = fmt.Errorf("can't parse %q as decimal: exponent out of range", src)
+		return
+	}
+	ret.coef, ret.scale = new(big.Int), int32(scale)
+	if _, ok = ret.coef.SetString(digits, 10); !ok {
+		err = fmt.Errorf("can't parse %q as decimal", src)
+		return
+	}
+	if negative {
+		ret.coef.Neg(ret.coef)
+	}
+
+	return
+}
+
+func notDigit(r rune) bool { return r < '0' || r > '9' }
+
+// Коэффициент, не равный nil
+func (d Dec) coefficient() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// Coefficient Возвращает копию коэффициента десятичного числа
+func (d Dec) Coefficient() *big.Int { return new(big.Int).Set(d.coefficient()) }
+
+// Scale Возвращает количество знаков после десятичной точки
+func (d Dec) Scale() int32 { return d.scale }
+
+// Sign Возвращает -1, 0 или +1 в зависимости от знака числа
+func (d Dec) Sign() int { return d.coefficient().Sign() }
+
+// IsZero Возвращает истину, если число равно нулю
+func (d Dec) IsZero() bool { return d.Sign() == 0 }
+
+// Rescale Возвращает число, приведённое к scale знакам после точки
+// При уменьшении количества знаков лишние знаки отбрасываются, как в Truncate
+func (d Dec) Rescale(scale int32) Dec {
+	var coef *big.Int
+
+	switch {
+	case scale == d.scale:
+		return NewDecBig(d.coefficient(), scale)
+	case scale > d.scale:
+		coef = new(big.Int).Mul(d.coefficient(), pow10(int64(scale)-int64(d.scale)))
+	default:
+		coef = new(big.Int).Quo(d.coefficient(), pow10(int64(d.scale)-int64(scale)))
+	}
+
+	return Dec{coef: coef, scale: scale}
+}
+
+// Приведение двух чисел к общему количеству знаков после точки
+func alignDec(a Dec, b Dec) (x *big.Int, y *big.Int, scale int32) {
+	if scale = a.scale; b.scale > scale {
+		scale = b.scale
+	}
+	x, y = a.Rescale(scale).coef, b.Rescale(scale).coef
+
+	return
+}
+
+// Add Возвращает точную сумму d + o
+func (d Dec) Add(o Dec) Dec {
+	x, y, scale := alignDec(d, o)
+	return Dec{coef: x.Add(x, y), scale: scale}
+}
+
+// Sub Возвращает точную разность d - o
+func (d Dec) Sub(o Dec) Dec {
+	x, y, scale := alignDec(d, o)
+	return Dec{coef: x.Sub(x, y), scale: scale}
+}
+
+// Mul Возвращает точное произведение d × o
+// Возвращается ошибка, если количество знаков после точки у произведения выходит за пределы NUMERIC
+func (d Dec) Mul(o Dec) (ret Dec, err error) {
+	var scale = int64(d.scale) + int64(o.scale)
+
+	if err = checkDecScale(scale); err != nil {
+		return
+	}
+	ret = Dec{coef: new(big.Int).Mul(d.coefficient(), o.coefficient()), scale: int32(scale)}
+
+	return
+}
+
+// Проверка, что количество знаков после точки находится в пределах NUMERIC
+func checkDecScale(scale int64) (err error) {
+	if scale > decMaxScale || scale < decMinScale {
+		err = fmt.Errorf("decimal scale %d out of range [%d, %d]", scale, decMinScale, decMaxScale)
+	}
+
+	return
+}
+
+// Neg Возвращает число с противоположным знаком
+func (d Dec) Neg() Dec { return Dec{coef: new(big.Int).Neg(d.coefficient()), scale: d.scale} }
+
+// Abs Возвращает абсолютное значение числа
+func (d Dec) Abs() Dec { return Dec{coef: new(big.Int).Abs(d.coefficient()), scale: d.scale} }
+
+// Cmp Сравнивает числа и возвращает -1 если d < o, 0 если d == o и +1 если d > o
+func (d Dec) Cmp(o Dec) int {
+	x, y, _ := alignDec(d, o)
+	return x.Cmp(y)
+}
+
+// Equal Возвращает истину, если числа равны независимо от количества знаков после точки
+func (d Dec) Equal(o Dec) bool { return d.Cmp(o) == 0 }
+
+// Truncate Отбрасывает знаки после places-го знака после точки
+func (d Dec) Truncate(places int32) Dec {
+	if d.scale <= places {
+		return d
+	}
+	return d.Rescale(places)
+}
+
+// Round Округляет число до places знаков после точки, половина округляется от нуля
+func (d Dec) Round(places int32) Dec { return d.round(places, false) }
+
+// RoundBank Округляет число до places знаков после точки, половина округляется до чётного (банковское округление)
+func (d Dec) RoundBank(places int32) Dec { return d.round(places, true) }
+
+func (d Dec) round(places int32, bank bool) Dec {
+	var (
+		div  *big.Int
+		quo  *big.Int
+		rem  *big.Int
+		half int
+	)
+
+	if d.scale <= places {
+		return d
+	}
+	div = pow10(int64(d.scale) - int64(places))
+	quo, rem = new(big.Int).QuoRem(d.coefficient(), div, new(big.Int))
+	half = new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(div)
+	if half > 0 || (half == 0 && (!bank || quo.Bit(0) == 1)) {
+		quo.Add(quo, big.NewInt(int64(d.Sign())))
+	}
+
+	return Dec{coef: quo, scale: places}
+}
+
+// Float64 Возвращает ближайшее к десятичному числу значение float64
+func (d Dec) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String Возвращает десятичное число в виде строки без экспоненты
+func (d Dec) String() string {
+	var (
+		buf    strings.Builder
+		digits string
+		point  int
+	)
+
+	digits = new(big.Int).Abs(d.coefficient()).String()
+	if d.Sign() < 0 {
+		buf.WriteByte('-')
+	}
+	switch {
+	case d.scale <= 0:
+		buf.WriteString(digits)
+		if d.Sign() != 0 {
+			buf.WriteString(strings.Repeat("0", -int(d.scale)))
+		}
+	default:
+		if len(digits) <= int(d.scale) {
+			digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+		}
+		point = len(digits) - int(d.scale)
+		buf.WriteString(digits[:point])
+		buf.WriteByte('.')
+		buf.WriteString(digits[point:])
+	}
+
+	return buf.String()
+}
+
+// Степень десяти
+func pow10(n int64) *big.Int { return new(big.Int).Exp(bigTen, big.NewInt(n), nil) }
@@ -0,0 +1,120 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func mustParseDec(str string) Dec {
+	d, err := ParseDec(str)
+	errorPanic(err)
+	return d
+}
+
+func TestParseDec(t *testing.T) {
+	var tests = []struct {
+		In    string
+		Out   string
+		Scale int32
+	}{
+		{"0", "0", 0},
+		{"-0.0000", "0.0000", 4},
+		{"12345678901234.5678", "12345678901234.5678", 4},
+		{"-1.5", "-1.5", 1},
+		{"+.25", "0.25", 2},
+		{"7.", "7", 0},
+		{"1.5e3", "1500", -2},
+		{"-1.5E-3", "-0.0015", 4},
+		{" 99999999999999999999999999999999999999 ", "99999999999999999999999999999999999999", 0},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDec(test.In)
+		errorPanic(err)
+		jsonEquals(t, []byte(d.String()), test.Out, "ParseDec("+test.In+")")
+		if d.Scale() != test.Scale {
+			t.Errorf("ParseDec(%q) scale is %d, but should be %d", test.In, d.Scale(), test.Scale)
+		}
+	}
+	for _, in := range []string{"", "-", ".", "1.2.3", "1e", "e5", "12a", "NaN", "1e999999"} {
+		if _, err := ParseDec(in); err == nil {
+			t.Errorf("ParseDec(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestDecZeroValue(t *testing.T) {
+	var d Dec
+
+	if !d.IsZero() || d.Sign() != 0 {
+		t.Error("Dec{}", "is not zero")
+	}
+	jsonEquals(t, []byte(d.String()), "0", "Dec{}.String()")
+	jsonEquals(t, []byte(d.Add(NewDec(15, 1)).String()), "1.5", "Dec{}.Add()")
+	if d.Coefficient().Sign() != 0 {
+		t.Error("Dec{}.Coefficient()", "is not zero")
+	}
+}
+
+func TestDecArithmetic(t *testing.T) {
+	a, b := mustParseDec("0.1"), mustParseDec("0.2")
+	jsonEquals(t, []byte(a.Add(b).String()), "0.3", "Add()")
+	jsonEquals(t, []byte(a.Sub(b).String()), "-0.1", "Sub()")
+	p, err := a.Mul(b)
+	errorPanic(err)
+	jsonEquals(t, []byte(p.String()), "0.02", "Mul()")
+	if _, err = NewDec(1, decMaxScale).Mul(NewDec(1, 1)); err == nil {
+		t.Error("Mul() of scale overflow", "is nil, but should be not nil")
+	}
+	if _, err = NewDec(1, math.MinInt32).Mul(NewDec(1, -1)); err == nil {
+		t.Error("Mul() of int32 scale overflow", "is nil, but should be not nil")
+	}
+	jsonEquals(t, []byte(a.Neg().Abs().String()), "0.1", "Neg().Abs()")
+	if a.Cmp(b) != -1 || b.Cmp(a) != 1 || !a.Equal(mustParseDec("0.1000")) {
+		t.Error("Cmp()", "is wrong")
+	}
+
+	c := NewDecBig(new(big.Int).Lsh(big.NewInt(1), 100), 4)
+	jsonEquals(t, []byte(c.Add(NewDec(1, 4)).String()), "126765060022822940149670320.5377", "Add(big)")
+	if a.String() != "0.1" || b.String() != "0.2" {
+		t.Error("Operands", "were modified")
+	}
+}
+
+func TestDecRound(t *testing.T) {
+	var tests = []struct {
+		In        string
+		Places    int32
+		Round     string
+		RoundBank string
+		Truncate  string
+	}{
+		{"2.5", 0, "3", "2", "2"},
+		{"3.5", 0, "4", "4", "3"},
+		{"-2.5", 0, "-3", "-2", "-2"},
+		{"1.23456", 4, "1.2346", "1.2346", "1.2345"},
+		{"1.00005", 4, "1.0001", "1.0000", "1.0000"},
+		{"-1.00015", 4, "-1.0002", "-1.0002", "-1.0001"},
+		{"12.3", 4, "12.3", "12.3", "12.3"},
+		{"1250", -2, "1300", "1200", "1200"},
+	}
+
+	for _, test := range tests {
+		d := mustParseDec(test.In)
+		jsonEquals(t, []byte(d.Round(test.Places).String()), test.Round, "Round("+test.In+")")
+		jsonEquals(t, []byte(d.RoundBank(test.Places).String()), test.RoundBank, "RoundBank("+test.In+")")
+		jsonEquals(t, []byte(d.Truncate(test.Places).String()), test.Truncate, "Truncate("+test.In+")")
+	}
+}
+
+func TestDecRescale(t *testing.T) {
+	d := mustParseDec("12.5")
+	jsonEquals(t, []byte(d.Rescale(4).String()), "12.5000", "Rescale(4)")
+	jsonEquals(t, []byte(d.Rescale(0).String()), "12", "Rescale(0)")
+	if d.Float64() != 12.5 {
+		t.Error("Float64()", "is wrong")
+	}
+}
@@ -0,0 +1,361 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Decimal is an nullable exact decimal object
+type Decimal struct {
+	Decimal Dec  // Value of object
+	Valid   bool // Valid is true if value is not NULL
+}
+
+// NewDecimal Создание нового объекта Decimal
+func NewDecimal() Decimal {
+	return Decimal{
+		Decimal: Dec{},
+		Valid:   false,
+	}
+}
+
+// NewDecimalValue Создание нового действительного объекта Decimal из значения
+func NewDecimalValue(value Dec) Decimal {
+	return Decimal{
+		Decimal: value,
+		Valid:   true,
+	}
+}
+
+// NewDecimalPointerValue Создание нового действительного объекта Decimal из ссылки на значение
+func NewDecimalPointerValue(ptr *Dec) Decimal {
+	if ptr == nil {
+		return NewDecimal()
+	}
+	return NewDecimalValue(*ptr)
+}
+
+// NewDecimalString Создание нового действительного объекта Decimal из строкового представления числа
+func NewDecimalString(str string) (ret Decimal, err error) {
+	var value Dec
+
+	if value, err = ParseDec(str); err != nil {
+		return
+	}
+	ret = NewDecimalValue(value)
+
+	return
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (d *Decimal) SetValid(value Dec) { d.Decimal, d.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (d *Decimal) Reset() { d.Decimal, d.Valid = Dec{}, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (d *Decimal) NullIfDefault() Decimal {
+	if d.Decimal.IsZero() {
+		d.Reset()
+	}
+	return *d
+}
+
+// MustValue Возвращает значение в любом случае
+func (d *Decimal) MustValue() Dec {
+	if !d.Valid {
+		return Dec{}
+	}
+	return d.Decimal
+}
+
+// Pointer Возвращает ссылку на значение
+func (d *Decimal) Pointer() *Dec {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Decimal
+}
+
+// Add Возвращает точную сумму, результат равен null если один из операндов равен null
+func (d Decimal) Add(o Decimal) Decimal {
+	if !d.Valid || !o.Valid {
+		return NewDecimal()
+	}
+	return NewDecimalValue(d.Decimal.Add(o.Decimal))
+}
+
+// Sub Возвращает точную разность, результат равен null если один из операндов равен null
+func (d Decimal) Sub(o Decimal) Decimal {
+	if !d.Valid || !o.Valid {
+		return NewDecimal()
+	}
+	return NewDecimalValue(d.Decimal.Sub(o.Decimal))
+}
+
+// Mul Возвращает точное произведение, результат равен null если один из операндов равен null
+// Возвращается ошибка, если количество знаков после точки у произведения выходит за пределы NUMERIC
+func (d Decimal) Mul(o Decimal) (ret Decimal, err error) {
+	var dec Dec
+
+	if !d.Valid || !o.Valid {
+		ret = NewDecimal()
+		return
+	}
+	if dec, err = d.Decimal.Mul(o.Decimal); err != nil {
+		ret = NewDecimal()
+		return
+	}
+	ret = NewDecimalValue(dec)
+
+	return
+}
+
+// Round Округляет значение до places знаков после точки, половина округляется от нуля
+func (d Decimal) Round(places int32) Decimal {
+	if !d.Valid {
+		return NewDecimal()
+	}
+	return NewDecimalValue(d.Decimal.Round(places))
+}
+
+// RoundBank Округляет значение до places знаков после точки, половина округляется до чётного
+func (d Decimal) RoundBank(places int32) Decimal {
+	if !d.Valid {
+		return NewDecimal()
+	}
+	return NewDecimalValue(d.Decimal.RoundBank(places))
+}
+
+// Truncate Отбрасывает знаки значения после places-го знака после точки
+func (d Decimal) Truncate(places int32) Decimal {
+	if !d.Valid {
+		return NewDecimal()
+	}
+	return NewDecimalValue(d.Decimal.Truncate(places))
+}
+
+// Scan Реализация интерфейса Scanner
+func (d *Decimal) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		d.Reset()
+		return
+	case int64:
+		d.Decimal = NewDec(x, 0)
+	case float64:
+		d.Decimal, err = ParseDec(strconv.FormatFloat(x, 'g', -1, 64))
+	case []byte:
+		d.Decimal, err = ParseDec(string(x))
+	case string:
+		d.Decimal, err = ParseDec(x)
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.Decimal: %v", x, value)
+	}
+	if d.Valid = err == nil; !d.Valid {
+		d.Decimal = Dec{}
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Decimal.String(), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (d *Decimal) UnmarshalJSON(data []byte) (err error) {
+	var (
+		dec *json.Decoder
+		v   interface{}
+	)
+
+	dec = json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		d.Reset()
+		return
+	case json.Number:
+		d.Decimal, err = ParseDec(x.String())
+	case string:
+		if len(x) == 0 {
+			d.Reset()
+			return
+		}
+		d.Decimal, err = ParseDec(x)
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.Decimal", reflect.TypeOf(v).Kind())
+	}
+	if d.Valid = err == nil; !d.Valid {
+		d.Decimal = Dec{}
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (d Decimal) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !d.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data = []byte(d.Decimal.String())
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (d *Decimal) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		d.Reset()
+		return
+	case emptyString:
+		d.Decimal, d.Valid = Dec{}, true
+		return
+	default:
+		d.Decimal, err = ParseDec(str)
+	}
+	if d.Valid = err == nil; !d.Valid {
+		d.Decimal = Dec{}
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (d Decimal) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !d.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(d.Decimal.String())
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (d *Decimal) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return d.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagDecimal, d)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (d Decimal) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagDecimal, d) }
+
+// Запись значения в компактном двоичном формате
+func (d Decimal) encodeBinary(w *binaryWriter) {
+	if w.flags(d.Valid, d.Decimal.Sign() < 0); !d.Valid {
+		return
+	}
+	w.bytes(d.Decimal.coefficient().Bytes())
+	w.varint(int64(d.Decimal.Scale()))
+}
+
+// Чтение значения в компактном двоичном формате
+func (d *Decimal) decodeBinary(r *binaryReader) {
+	var (
+		valid, neg bool
+		coef       = new(big.Int)
+		scale      int32
+	)
+
+	if r.flags(&valid, &neg); !valid {
+		if r.err == nil {
+			d.Reset()
+		}
+		return
+	}
+	coef.SetBytes(r.bytes())
+	if scale = int32(r.intN(32)); r.err != nil {
+		return
+	}
+	if err := checkDecScale(int64(scale)); err != nil {
+		r.fail(err)
+		return
+	}
+	if neg {
+		coef.Neg(coef)
+	}
+	d.Decimal, d.Valid = NewDecBig(coef, scale), valid
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (d *Decimal) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.DecimalWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.DecimalWrapper)
+	if err = dec.Decode(item); err != nil {
+		return
+	}
+	if err = checkDecScale(int64(item.Scale)); err != nil {
+		return
+	}
+	d.Decimal, d.Valid = NewDecBig(item.Value, item.Scale), item.Valid
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (d *Decimal) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, d) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой текстового представления
+func (d Decimal) MarshalMsgpack() ([]byte, error) { return marshalMsgpackText(d.Valid, d) }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (d *Decimal) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, d) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой текстового представления
+func (d Decimal) MarshalCBOR() ([]byte, error) { return marshalCBORText(d.Valid, d) }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (d *Decimal) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, d)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (d Decimal) MarshalYAML() (interface{}, error) { return marshalYAMLText(d.Valid, d) }
@@ -0,0 +1,231 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+var (
+	decimalString     = `12345678901234.5678`
+	decimalJSON       = []byte(decimalString)
+	decimalStringJSON = []byte(`"` + decimalString + `"`)
+)
+
+func isDecimalValid(t *testing.T, d Decimal, from string) {
+	dv, _ := d.Value()
+	if dv.(string) != decimalString {
+		t.Errorf("Bad %s decimal: %q ≠ %q\n", from, dv, decimalString)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isDecimalNull(t *testing.T, d Decimal, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewDecimal(t *testing.T) {
+	v1 := NewDecimal()
+	isDecimalNull(t, v1, "NewDecimal()")
+
+	v2 := NewDecimalValue(mustParseDec(decimalString))
+	isDecimalValid(t, v2, "NewDecimalValue()")
+
+	v3 := NewDecimalPointerValue(nil)
+	isDecimalNull(t, v3, "NewDecimalPointerValue()")
+
+	dv := mustParseDec(decimalString)
+	v4 := NewDecimalPointerValue(&dv)
+	isDecimalValid(t, v4, "NewDecimalPointerValue()")
+
+	v5, err := NewDecimalString(decimalString)
+	errorPanic(err)
+	isDecimalValid(t, v5, "NewDecimalString()")
+
+	if _, err = NewDecimalString("bad"); err == nil {
+		t.Error("NewDecimalString()", "is nil, but should be not nil")
+	}
+}
+
+func TestDecimalSetValidReset(t *testing.T) {
+	v1 := NewDecimal()
+	v1.SetValid(mustParseDec(decimalString))
+	isDecimalValid(t, v1, "SetValid()")
+	if p := v1.Pointer(); p == nil || p.String() != decimalString {
+		t.Error("Pointer()", "is wrong")
+	}
+	v1.Reset()
+	isDecimalNull(t, v1, "Reset()")
+	if v1.Pointer() != nil || !v1.MustValue().IsZero() {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+
+	v2 := NewDecimalValue(mustParseDec("0.0000"))
+	v2.NullIfDefault()
+	isDecimalNull(t, v2, "NullIfDefault()")
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	v1 := NewDecimalValue(mustParseDec("10.25"))
+	v2 := NewDecimalValue(mustParseDec("0.0001"))
+	jsonEquals(t, []byte(v1.Add(v2).Decimal.String()), "10.2501", "Add()")
+	jsonEquals(t, []byte(v1.Sub(v2).Decimal.String()), "10.2499", "Sub()")
+	v3, err := v1.Mul(v2)
+	errorPanic(err)
+	jsonEquals(t, []byte(v3.Decimal.String()), "0.001025", "Mul()")
+	jsonEquals(t, []byte(v1.Round(1).Decimal.String()), "10.3", "Round()")
+	jsonEquals(t, []byte(v1.RoundBank(1).Decimal.String()), "10.2", "RoundBank()")
+	jsonEquals(t, []byte(v1.Truncate(1).Decimal.String()), "10.2", "Truncate()")
+	isDecimalNull(t, v1.Add(NewDecimal()), "Add(null)")
+	v3, err = NewDecimal().Mul(v1)
+	errorPanic(err)
+	isDecimalNull(t, v3, "Mul(null)")
+	v4 := NewDecimalValue(NewDec(1, decMaxScale))
+	if v3, err = v4.Mul(v4); err == nil {
+		t.Error("Mul() of scale overflow", "is nil, but should be not nil")
+	}
+	isDecimalNull(t, v3, "Mul() of scale overflow")
+	isDecimalNull(t, NewDecimal().Round(2), "Round(null)")
+}
+
+func TestDecimalScan(t *testing.T) {
+	v1 := NewDecimal()
+	errorPanic(v1.Scan([]byte(decimalString)))
+	isDecimalValid(t, v1, "Scan([]byte)")
+
+	v2 := NewDecimal()
+	errorPanic(v2.Scan(decimalString))
+	isDecimalValid(t, v2, "Scan(string)")
+
+	v3 := NewDecimal()
+	errorPanic(v3.Scan(int64(-42)))
+	jsonEquals(t, []byte(v3.Decimal.String()), "-42", "Scan(int64)")
+
+	v4 := NewDecimal()
+	errorPanic(v4.Scan(float64(0.5)))
+	jsonEquals(t, []byte(v4.Decimal.String()), "0.5", "Scan(float64)")
+
+	v5 := NewDecimalValue(NewDec(1, 0))
+	errorPanic(v5.Scan(nil))
+	isDecimalNull(t, v5, "Scan(nil)")
+
+	v6 := NewDecimal()
+	if err := v6.Scan("abc"); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isDecimalNull(t, v6, "Scan(abc)")
+
+	v7 := NewDecimal()
+	if err := v7.Scan(true); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+}
+
+func TestDecimalValue(t *testing.T) {
+	v1 := NewDecimal()
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+}
+
+func TestDecimalUnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewDecimal()
+	errorPanic(json.Unmarshal(decimalJSON, &v1))
+	isDecimalValid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewDecimal()
+	errorPanic(json.Unmarshal(decimalStringJSON, &v2))
+	isDecimalValid(t, v2, "UnmarshalJSON(string)")
+
+	v3 := NewDecimalValue(NewDec(1, 0))
+	errorPanic(json.Unmarshal(boolNullJSON, &v3))
+	isDecimalNull(t, v3, "UnmarshalJSON(null)")
+
+	v4 := NewDecimal()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v4))
+	isDecimalNull(t, v4, "UnmarshalJSON(blank)")
+
+	v5 := NewDecimal()
+	if err = json.Unmarshal(boolTrueJSON, &v5); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isDecimalNull(t, v5, "UnmarshalJSON(true)")
+
+	v6 := NewDecimal()
+	if err = v6.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+}
+
+func TestDecimalMarshalJSON(t *testing.T) {
+	v1 := NewDecimalValue(mustParseDec(decimalString))
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, decimalString, "non-empty json marshal")
+
+	v2 := NewDecimal()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestDecimalText(t *testing.T) {
+	v1 := NewDecimal()
+	errorPanic(v1.UnmarshalText(decimalJSON))
+	isDecimalValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, decimalString, "MarshalText()")
+
+	v2 := NewDecimal()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || !v2.Decimal.IsZero() {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewDecimalValue(NewDec(1, 0))
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isDecimalNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewDecimal()
+	if err = v4.UnmarshalText([]byte("1,5")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+}
+
+func TestDecimalBinary(t *testing.T) {
+	v1 := NewDecimalValue(mustParseDec(decimalString))
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewDecimal()
+	errorPanic(v2.UnmarshalBinary(data))
+	isDecimalValid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewDecimal()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewDecimalValue(NewDec(1, 0))
+	errorPanic(v4.UnmarshalBinary(data))
+	isDecimalNull(t, v4, "UnmarshalBinary()")
+
+	for _, scale := range []int32{decMaxScale + 1, decMinScale - 1, math.MaxInt32, math.MinInt32} {
+		data, err = NewDecimalValue(NewDec(1, scale)).MarshalBinary()
+		errorPanic(err)
+		if err = v2.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary() of scale %d is nil, but should be not nil", scale)
+		}
+	}
+}
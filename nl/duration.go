@@ -0,0 +1,522 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// DurationFormat Формат представления Duration в JSON и тексте
+type DurationFormat uint8
+
+const (
+	// DurationFormatGo Формат time.Duration.String(), например "1h30m0s"
+	DurationFormatGo = DurationFormat(iota)
+
+	// DurationFormatISO8601 Формат ISO-8601, например "PT1H30M"
+	DurationFormatISO8601
+
+	// DurationFormatNanoseconds Целое количество наносекунд, например 5400000000000
+	DurationFormatNanoseconds
+)
+
+// Duration is an nullable time.Duration object
+type Duration struct {
+	Duration time.Duration  // Value of object
+	Valid    bool           // Valid is true if value is not NULL
+	Format   DurationFormat // Format of value for MarshalJSON and MarshalText
+}
+
+// NewDuration Создание нового объекта Duration
+func NewDuration() Duration {
+	return Duration{
+		Duration: 0,
+		Valid:    false,
+	}
+}
+
+// NewDurationValue Создание нового действительного объекта Duration из значения
+func NewDurationValue(value time.Duration) Duration {
+	return Duration{
+		Duration: value,
+		Valid:    true,
+	}
+}
+
+// NewDurationPointerValue Создание нового действительного объекта Duration из ссылки на значение
+func NewDurationPointerValue(ptr *time.Duration) Duration {
+	if ptr == nil {
+		return NewDuration()
+	}
+	return NewDurationValue(*ptr)
+}
+
+// ParseDuration Разбор продолжительности в формате Go ("1h30m"), ISO-8601 ("PT1H30M") или целого числа наносекунд
+func ParseDuration(str string) (ret time.Duration, err error) {
+	var ns int64
+
+	if str = strings.TrimSpace(str); str == "" {
+		err = fmt.Errorf("can't parse empty string as duration")
+		return
+	}
+	if ns, err = strconv.ParseInt(str, 10, 64); err == nil {
+		ret = time.Duration(ns)
+		return
+	}
+	if strings.ContainsAny(str, "Pp") {
+		ret, err = parseDurationISO8601(str)
+		return
+	}
+	ret, err = time.ParseDuration(str)
+
+	return
+}
+
+// Разбор продолжительности в формате ISO-8601: [-]PnW, [-]P[nD][T[nH][nM][n[.n]S]]
+// Годы и месяцы не имеют фиксированной продолжительности и не поддерживаются
+func parseDurationISO8601(src string) (ret time.Duration, err error) {
+	var (
+		str      = strings.ToUpper(src)
+		negative bool
+		inTime   bool
+		total    uint64
+		limit    uint64 = math.MaxInt64
+		part     int64
+		number   string
+		whole    string
+		fraction string
+		unit     time.Duration
+		hasValue bool
+	)
+
+	if strings.HasPrefix(str, "-") {
+		negative, limit, str = true, limit+1, str[1:]
+	} else if strings.HasPrefix(str, "+") {
+		str = str[1:]
+	}
+	if !strings.HasPrefix(str, "P") || len(str) < 2 {
+		err = fmt.Errorf("can't parse %q as ISO-8601 duration", src)
+		return
+	}
+	for str = str[1:]; len(str) > 0; {
+		if str[0] == 'T' {
+			if inTime {
+				err = fmt.Errorf("can't parse %q as ISO-8601 duration", src)
+				return
+			}
+			inTime, hasValue, str = true, false, str[1:]
+			continue
+		}
+		n := strings.IndexFunc(str, func(r rune) bool { return (r < '0' || r > '9') && r != '.' && r != ',' })
+		if n <= 0 {
+			err = fmt.Errorf("can't parse %q as ISO-8601 duration", src)
+			return
+		}
+		number = strings.Replace(str[:n], ",", ".", 1)
+		if m := strings.IndexByte(number, '.'); m >= 0 {
+			whole, fraction = number[:m], "0"+number[m:]
+		} else {
+			whole, fraction = number, ""
+		}
+		switch {
+		case !inTime && str[n] == 'W':
+			unit = time.Hour * 24 * 7
+		case !inTime && str[n] == 'D':
+			unit = time.Hour * 24
+		case inTime && str[n] == 'H':
+			unit = time.Hour
+		case inTime && str[n] == 'M':
+			unit = time.Minute
+		case inTime && str[n] == 'S':
+			unit = time.Second
+		case !inTime && (str[n] == 'Y' || str[n] == 'M'):
+			err = fmt.Errorf("can't parse %q as ISO-8601 duration: years and months have no fixed duration", src)
+			return
+		default:
+			err = fmt.Errorf("can't parse %q as ISO-8601 duration: unknown unit %q", src, str[n])
+			return
+		}
+		if part, err = durationPart(whole, fraction, unit); err != nil || uint64(part) > limit-total {
+			err = fmt.Errorf("can't parse %q as ISO-8601 duration: invalid or out of range value", src)
+			return
+		}
+		total += uint64(part)
+		hasValue, str = true, str[n+1:]
+	}
+	if !hasValue {
+		err = fmt.Errorf("can't parse %q as ISO-8601 duration", src)
+		return
+	}
+	if ret = time.Duration(total); negative {
+		ret = time.Duration(-total)
+	}
+
+	return
+}
+
+// Количество наносекунд в компоненте продолжительности из целой и дробной части числа единиц unit
+func durationPart(whole string, fraction string, unit time.Duration) (ret int64, err error) {
+	var (
+		w int64
+		f float64
+	)
+
+	if whole != "" {
+		if w, err = strconv.ParseInt(whole, 10, 64); err != nil {
+			return
+		}
+	}
+	if w > math.MaxInt64/int64(unit) {
+		err = strconv.ErrRange
+		return
+	}
+	if fraction != "" {
+		if f, err = strconv.ParseFloat(fraction, 64); err != nil {
+			return
+		}
+	}
+	ret = w*int64(unit) + int64(math.Round(f*float64(unit)))
+	if ret < 0 {
+		err = strconv.ErrRange
+	}
+
+	return
+}
+
+// FormatDurationISO8601 Форматирование продолжительности в формате ISO-8601, например "PT1H30M"
+func FormatDurationISO8601(value time.Duration) string {
+	var (
+		buf     strings.Builder
+		u       uint64
+		hours   uint64
+		minutes uint64
+		seconds uint64
+		nanos   uint64
+	)
+
+	if value == 0 {
+		return "PT0S"
+	}
+	if u = uint64(value); value < 0 {
+		buf.WriteByte('-')
+		u = -u
+	}
+	buf.WriteString("PT")
+	hours, u = u/uint64(time.Hour), u%uint64(time.Hour)
+	minutes, u = u/uint64(time.Minute), u%uint64(time.Minute)
+	seconds, nanos = u/uint64(time.Second), u%uint64(time.Second)
+	if hours > 0 {
+		buf.WriteString(strconv.FormatUint(hours, 10) + "H")
+	}
+	if minutes > 0 {
+		buf.WriteString(strconv.FormatUint(minutes, 10) + "M")
+	}
+	if seconds > 0 || nanos > 0 {
+		buf.WriteString(strconv.FormatUint(seconds, 10))
+		if nanos > 0 {
+			buf.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0"))
+		}
+		buf.WriteByte('S')
+	}
+
+	return buf.String()
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (d *Duration) SetValid(value time.Duration) { d.Duration, d.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (d *Duration) Reset() { d.Duration, d.Valid = 0, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (d *Duration) NullIfDefault() Duration {
+	if d.Duration == 0 {
+		d.Reset()
+	}
+	return *d
+}
+
+// MustValue Возвращает значение в любом случае
+func (d *Duration) MustValue() time.Duration {
+	if !d.Valid {
+		return 0
+	}
+	return d.Duration
+}
+
+// Pointer Возвращает ссылку на значение
+func (d *Duration) Pointer() *time.Duration {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Duration
+}
+
+// Форматирование значения в соответствии с Format
+func (d Duration) format() string {
+	switch d.Format {
+	case DurationFormatISO8601:
+		return FormatDurationISO8601(d.Duration)
+	case DurationFormatNanoseconds:
+		return strconv.FormatInt(int64(d.Duration), 10)
+	default:
+		return d.Duration.String()
+	}
+}
+
+// Scan Реализация интерфейса Scanner
+func (d *Duration) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		d.Reset()
+		return
+	case int64:
+		d.Duration = time.Duration(x)
+	case []byte:
+		d.Duration, err = ParseDuration(string(x))
+	case string:
+		d.Duration, err = ParseDuration(x)
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.Duration: %v", x, value)
+	}
+	if d.Valid = err == nil; !d.Valid {
+		d.Duration = 0
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (d Duration) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return int64(d.Duration), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (d *Duration) UnmarshalJSON(data []byte) (err error) {
+	var (
+		dec *json.Decoder
+		v   interface{}
+		ns  int64
+	)
+
+	dec = json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		d.Reset()
+		return
+	case json.Number:
+		ns, err = x.Int64()
+		d.Duration = time.Duration(ns)
+	case string:
+		if len(x) == 0 {
+			d.Reset()
+			return
+		}
+		d.Duration, err = ParseDuration(x)
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.Duration", reflect.TypeOf(v).Kind())
+	}
+	if d.Valid = err == nil; !d.Valid {
+		d.Duration = 0
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (d Duration) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !d.Valid {
+		data = []byte(nullString)
+		return
+	}
+	if d.Format == DurationFormatNanoseconds {
+		data = []byte(d.format())
+		return
+	}
+	data = []byte(strconv.Quote(d.format()))
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (d *Duration) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		d.Reset()
+		return
+	case emptyString:
+		d.Duration, d.Valid = 0, true
+		return
+	default:
+		d.Duration, err = ParseDuration(str)
+	}
+	if d.Valid = err == nil; !d.Valid {
+		d.Duration = 0
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (d Duration) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !d.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(d.format())
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (d *Duration) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return d.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagDuration, d)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (d Duration) MarshalBinary() (data []byte, err error) {
+	return marshalBinary(binaryTagDuration, d)
+}
+
+// Запись значения в компактном двоичном формате
+func (d Duration) encodeBinary(w *binaryWriter) {
+	if w.flags(d.Valid); !d.Valid {
+		return
+	}
+	w.varint(int64(d.Duration))
+	w.uvarint(uint64(d.Format))
+}
+
+// Чтение значения в компактном двоичном формате
+func (d *Duration) decodeBinary(r *binaryReader) {
+	var (
+		valid  bool
+		value  time.Duration
+		format DurationFormat
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			d.Duration, d.Valid, d.Format = 0, false, DurationFormat(0)
+		}
+		return
+	}
+	value = time.Duration(r.intN(64))
+	if format = DurationFormat(r.uintN(8)); r.err == nil {
+		d.Duration, d.Valid, d.Format = value, valid, format
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (d *Duration) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.DurationWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.DurationWrapper)
+	if err = dec.Decode(item); err == nil {
+		d.Duration, d.Valid, d.Format = item.Value, item.Valid, DurationFormat(item.Format)
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из количества наносекунд либо из строки текстового представления
+func (d *Duration) UnmarshalMsgpack(data []byte) (err error) {
+	var (
+		value int64
+		null  bool
+	)
+
+	if msgpack.NextType(data) == msgpack.StrType {
+		return unmarshalMsgpackText(data, d)
+	}
+	if value, null, err = unmarshalMsgpack(data, msgpackInt(64)); err == nil {
+		d.Duration, d.Valid = time.Duration(value), !null
+	}
+
+	return
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется количеством наносекунд
+func (d Duration) MarshalMsgpack() ([]byte, error) {
+	if !d.Valid {
+		return msgpack.AppendNil(nil), nil
+	}
+	return msgpack.AppendInt(nil, int64(d.Duration)), nil
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из целого числа наносекунд
+func (d *Duration) UnmarshalCBOR(data []byte) (err error) {
+	var (
+		value int64
+		null  bool
+	)
+
+	if value, null, err = unmarshalCBOR(data, cborInt(64)); err == nil {
+		d.Duration, d.Valid = time.Duration(value), !null
+	}
+
+	return
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется целым числом наносекунд
+func (d Duration) MarshalCBOR() ([]byte, error) {
+	if !d.Valid {
+		return cbor.AppendNull(nil), nil
+	}
+	return cbor.AppendInt(nil, int64(d.Duration)), nil
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, d)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (d Duration) MarshalYAML() (interface{}, error) { return marshalYAMLText(d.Valid, d) }
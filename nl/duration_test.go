@@ -0,0 +1,246 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	durationValue      = time.Hour + 30*time.Minute
+	durationGoJSON     = []byte(`"1h30m0s"`)
+	durationISOJSON    = []byte(`"PT1H30M"`)
+	durationNanosJSON  = []byte(`5400000000000`)
+	durationNanosQJSON = []byte(`"5400000000000"`)
+)
+
+func isDurationValid(t *testing.T, d Duration, from string) {
+	if d.Duration != durationValue {
+		t.Errorf("Bad %s duration: %v ≠ %v\n", from, d.Duration, durationValue)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isDurationNull(t *testing.T, d Duration, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out time.Duration
+	}{
+		{"1h30m", durationValue},
+		{"-1.5h", -durationValue},
+		{"5400000000000", durationValue},
+		{"PT1H30M", durationValue},
+		{"pt1h30m", durationValue},
+		{"PT1.5H", durationValue},
+		{"PT90M", durationValue},
+		{"PT5400S", durationValue},
+		{"-PT1H30M", -durationValue},
+		{"P1D", 24 * time.Hour},
+		{"P1W", 7 * 24 * time.Hour},
+		{"P1DT1H", 25 * time.Hour},
+		{"PT0.000000001S", time.Nanosecond},
+		{"PT1,5S", 1500 * time.Millisecond},
+		{"PT0S", 0},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDuration(test.In)
+		errorPanic(err)
+		if d != test.Out {
+			t.Errorf("ParseDuration(%q) is %v, but should be %v", test.In, d, test.Out)
+		}
+	}
+	for _, in := range []string{"", "P", "PT", "P1Y", "P1M", "PT1D", "P1H", "P1DT", "PTT1H", "P-1D", "PT1X", "1x", "P999999999W"} {
+		if _, err := ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestFormatDurationISO8601(t *testing.T) {
+	var tests = []struct {
+		In  time.Duration
+		Out string
+	}{
+		{0, "PT0S"},
+		{durationValue, "PT1H30M"},
+		{-durationValue, "-PT1H30M"},
+		{36*time.Hour + time.Second + 500*time.Millisecond, "PT36H1.5S"},
+		{time.Nanosecond, "PT0.000000001S"},
+		{math.MinInt64, "-PT2562047H47M16.854775808S"},
+	}
+
+	for _, test := range tests {
+		jsonEquals(t, []byte(FormatDurationISO8601(test.In)), test.Out, "FormatDurationISO8601()")
+		if d, err := ParseDuration(test.Out); err != nil || d != test.In {
+			t.Errorf("ParseDuration(%q) is %v, but should be %v", test.Out, d, test.In)
+		}
+	}
+}
+
+func TestNewDuration(t *testing.T) {
+	v1 := NewDuration()
+	isDurationNull(t, v1, "NewDuration()")
+
+	v2 := NewDurationValue(durationValue)
+	isDurationValid(t, v2, "NewDurationValue()")
+
+	v3 := NewDurationPointerValue(nil)
+	isDurationNull(t, v3, "NewDurationPointerValue()")
+
+	v4 := NewDurationPointerValue(v2.Pointer())
+	isDurationValid(t, v4, "NewDurationPointerValue()")
+}
+
+func TestDurationSetValidReset(t *testing.T) {
+	v1 := NewDuration()
+	if v1.Pointer() != nil || v1.MustValue() != 0 {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(durationValue)
+	isDurationValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isDurationValid(t, v1, "NullIfDefault()")
+	v1.SetValid(0)
+	v1.NullIfDefault()
+	isDurationNull(t, v1, "NullIfDefault()")
+}
+
+func TestDurationScanValue(t *testing.T) {
+	v1 := NewDuration()
+	errorPanic(v1.Scan(int64(durationValue)))
+	isDurationValid(t, v1, "Scan(int64)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(int64) != int64(durationValue) {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewDuration()
+	errorPanic(v2.Scan("PT1H30M"))
+	isDurationValid(t, v2, "Scan(string)")
+
+	v3 := NewDuration()
+	errorPanic(v3.Scan([]byte("1h30m")))
+	isDurationValid(t, v3, "Scan([]byte)")
+
+	v4 := NewDurationValue(durationValue)
+	errorPanic(v4.Scan(nil))
+	isDurationNull(t, v4, "Scan(nil)")
+	dv, err = v4.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v5 := NewDuration()
+	if err = v5.Scan(1.5); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isDurationNull(t, v5, "Scan(float64)")
+}
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	var err error
+
+	for _, data := range [][]byte{durationGoJSON, durationISOJSON, durationNanosJSON, durationNanosQJSON} {
+		v := NewDuration()
+		errorPanic(json.Unmarshal(data, &v))
+		isDurationValid(t, v, "UnmarshalJSON("+string(data)+")")
+	}
+
+	v1 := NewDurationValue(durationValue)
+	errorPanic(json.Unmarshal(boolNullJSON, &v1))
+	isDurationNull(t, v1, "UnmarshalJSON(null)")
+
+	v2 := NewDuration()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v2))
+	isDurationNull(t, v2, "UnmarshalJSON(blank)")
+
+	v3 := NewDuration()
+	if err = json.Unmarshal([]byte(`1.5`), &v3); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = json.Unmarshal(boolTrueJSON, &v3); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = v3.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isDurationNull(t, v3, "UnmarshalJSON()")
+}
+
+func TestDurationMarshalJSON(t *testing.T) {
+	v1 := NewDurationValue(durationValue)
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(durationGoJSON), "MarshalJSON(go)")
+
+	v1.Format = DurationFormatISO8601
+	data, err = json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(durationISOJSON), "MarshalJSON(iso8601)")
+
+	v1.Format = DurationFormatNanoseconds
+	data, err = json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(durationNanosJSON), "MarshalJSON(nanoseconds)")
+
+	v2 := NewDuration()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+}
+
+func TestDurationText(t *testing.T) {
+	v1 := NewDuration()
+	errorPanic(v1.UnmarshalText([]byte("PT1H30M")))
+	isDurationValid(t, v1, "UnmarshalText()")
+	v1.Format = DurationFormatISO8601
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "PT1H30M", "MarshalText()")
+
+	v2 := NewDuration()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || v2.Duration != 0 {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewDurationValue(durationValue)
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isDurationNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewDuration()
+	if err = v4.UnmarshalText([]byte("P1M")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isDurationNull(t, v4, "UnmarshalText(P1M)")
+}
+
+func TestDurationBinary(t *testing.T) {
+	v1 := NewDurationValue(durationValue)
+	v1.Format = DurationFormatISO8601
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewDuration()
+	errorPanic(v2.UnmarshalBinary(data))
+	isDurationValid(t, v2, "UnmarshalBinary()")
+	if v2.Format != DurationFormatISO8601 {
+		t.Error("UnmarshalBinary()", "lost Format")
+	}
+}
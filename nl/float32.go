@@ -0,0 +1,148 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+)
+
+// Float32 is an nullable float32 object
+type Float32 struct {
+	Float32 float32 // Value of object
+	Valid   bool    // Valid is true if value is not NULL
+}
+
+// NewFloat32 Создание нового объекта Float32
+func NewFloat32() Float32 {
+	return Float32{
+		Float32: 0,
+		Valid:   false,
+	}
+}
+
+// NewFloat32Value Создание нового действительного объекта Float32 из значения
+func NewFloat32Value(value float32) Float32 {
+	return Float32{
+		Float32: value,
+		Valid:   true,
+	}
+}
+
+// NewFloat32PointerValue Создание нового действительного объекта Float32 из ссылки на значение
+func NewFloat32PointerValue(ptr *float32) Float32 {
+	if ptr == nil {
+		return NewFloat32()
+	}
+	return NewFloat32Value(*ptr)
+}
+
+// NewFloat32Null Создание нового объекта Float32 из обобщённого объекта Null
+func NewFloat32Null(n Null[float32]) Float32 {
+	return Float32{
+		Float32: n.V,
+		Valid:   n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (f Float32) Null() Null[float32] { return Null[float32]{V: f.Float32, Valid: f.Valid} }
+
+// SetValid Изменение значения и установка флага действительного значения
+func (f *Float32) SetValid(value float32) { f.Float32, f.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (f *Float32) Reset() { f.Float32, f.Valid = 0, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (f *Float32) NullIfDefault() Float32 {
+	if f.Float32 == 0 {
+		f.Reset()
+	}
+	return *f
+}
+
+// MustValue Возвращает значение в любом случае
+func (f *Float32) MustValue() float32 {
+	if !f.Valid {
+		return 0
+	}
+	return f.Float32
+}
+
+// Pointer Возвращает ссылку на значение
+func (f *Float32) Pointer() *float32 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float32
+}
+
+// Scan Реализация интерфейса Scanner
+func (f *Float32) Scan(value interface{}) error {
+	return setNull(&f.Float32, &f.Valid, (*Null[float32]).Scan, value)
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (f Float32) Value() (driver.Value, error) { return f.Null().Value() }
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение допускается в виде числа либо строки, пустая строка является null
+func (f *Float32) UnmarshalJSON(data []byte) error {
+	return setNull(&f.Float32, &f.Valid, (*Null[float32]).UnmarshalJSON, data)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (f Float32) MarshalJSON() ([]byte, error) { return f.Null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (f *Float32) UnmarshalText(text []byte) error {
+	return setNull(&f.Float32, &f.Valid, (*Null[float32]).UnmarshalText, text)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (f Float32) MarshalText() ([]byte, error) { return f.Null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (f *Float32) UnmarshalBinary(data []byte) error {
+	return setNull(&f.Float32, &f.Valid, unmarshalNullBinary[float32](binaryTagFloat32), data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (f Float32) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagFloat32, f.Null()) }
+
+// Запись значения в компактном двоичном формате
+func (f Float32) encodeBinary(w *binaryWriter) { f.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (f *Float32) decodeBinary(r *binaryReader) {
+	var n = f.Null()
+
+	n.decodeBinary(r)
+	*f = NewFloat32Null(n)
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (f *Float32) UnmarshalMsgpack(data []byte) error {
+	return setNull(&f.Float32, &f.Valid, (*Null[float32]).UnmarshalMsgpack, data)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (f Float32) MarshalMsgpack() ([]byte, error) { return f.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (f *Float32) UnmarshalCBOR(data []byte) error {
+	return setNull(&f.Float32, &f.Valid, (*Null[float32]).UnmarshalCBOR, data)
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (f Float32) MarshalCBOR() ([]byte, error) { return f.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (f *Float32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&f.Float32, &f.Valid, (*Null[float32]).UnmarshalYAML, unmarshal)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (f Float32) MarshalYAML() (interface{}, error) { return f.Null().MarshalYAML() }
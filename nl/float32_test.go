@@ -0,0 +1,229 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+var (
+	float32JSON         = []byte(`3.4028235e+38`)
+	float32StringJSON   = []byte(`"3.4028235e+38"`)
+	float32ValidJSON    = []byte(`{"Float32":3.4028235e+38,"Valid":true}`)
+	float32OverflowJSON = []byte(`3.5e+38`)
+	float32ShortJSON    = []byte(`0.1`)
+)
+
+func isFloat32Valid(t *testing.T, f Float32, from string) {
+	dv, _ := f.Value()
+	if dv.(float64) != math.MaxFloat32 {
+		t.Errorf("Bad %s float32: \"%v\" ≠ \"%v\"\n", from, dv, float32(math.MaxFloat32))
+	}
+	if !f.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isFloat32Null(t *testing.T, f Float32, from string) {
+	if f.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewFloat32(t *testing.T) {
+	v1 := NewFloat32()
+	isFloat32Null(t, v1, "NewFloat32()")
+
+	v2 := NewFloat32Value(math.MaxFloat32)
+	isFloat32Valid(t, v2, "NewFloat32Value()")
+
+	var bv = float32(math.MaxFloat32)
+	v3 := NewFloat32PointerValue(&bv)
+	isFloat32Valid(t, v3, "NewFloat32PointerValue()")
+
+	v4 := NewFloat32PointerValue(nil)
+	isFloat32Null(t, v4, "NewFloat32PointerValue()")
+}
+
+func TestFloat32SetValidReset(t *testing.T) {
+	v1 := NewFloat32()
+	v1.SetValid(math.MaxFloat32)
+	isFloat32Valid(t, v1, "SetValid()")
+	v1.Reset()
+	isFloat32Null(t, v1, "Reset()")
+}
+
+func TestFloat32NullIfDefault(t *testing.T) {
+	v1 := NewFloat32Value(math.MaxFloat32)
+	v1.NullIfDefault()
+	isFloat32Valid(t, v1, "NullIfDefault()")
+
+	v1.SetValid(0)
+	v1.NullIfDefault()
+	isFloat32Null(t, v1, "NullIfDefault()")
+}
+
+func TestFloat32MustValuePointer(t *testing.T) {
+	v1 := NewFloat32()
+	if v1.MustValue() != 0 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if v1.Pointer() != nil {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(math.MaxFloat32)
+	if v1.MustValue() != math.MaxFloat32 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if pb := v1.Pointer(); pb == nil || *pb != math.MaxFloat32 {
+		t.Error("Pointer()", "is wrong")
+	}
+}
+
+func TestFloat32Scan(t *testing.T) {
+	v1 := NewFloat32()
+	errorPanic(v1.Scan(float64(math.MaxFloat32)))
+	isFloat32Valid(t, v1, "Scan()")
+
+	v2 := NewFloat32()
+	errorPanic(v2.Scan(float32JSON))
+	isFloat32Valid(t, v2, "Scan()")
+
+	v3 := NewFloat32Value(1)
+	errorPanic(v3.Scan(nil))
+	isFloat32Null(t, v3, "Scan()")
+
+	v4 := NewFloat32()
+	if err := v4.Scan(false); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+
+	v5 := NewFloat32()
+	if err := v5.Scan(float64(math.MaxFloat64)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isFloat32Null(t, v5, "Scan(overflow)")
+
+	v6 := NewFloat32()
+	if err := v6.Scan(string(float32OverflowJSON)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isFloat32Null(t, v6, "Scan(overflow)")
+}
+
+func TestFloat32Value(t *testing.T) {
+	v1 := NewFloat32()
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+}
+
+func TestFloat32UnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewFloat32()
+	errorPanic(json.Unmarshal(float32JSON, &v1))
+	isFloat32Valid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewFloat32()
+	errorPanic(json.Unmarshal(float32StringJSON, &v2))
+	isFloat32Valid(t, v2, "UnmarshalJSON()")
+
+	v3 := NewFloat32()
+	if err = json.Unmarshal(float32ValidJSON, &v3); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isFloat32Null(t, v3, "UnmarshalJSON(object)")
+
+	v4 := NewFloat32()
+	errorPanic(json.Unmarshal(boolNullJSON, &v4))
+	isFloat32Null(t, v4, "UnmarshalJSON(null)")
+
+	v5 := NewFloat32()
+	if err = json.Unmarshal(float32OverflowJSON, &v5); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isFloat32Null(t, v5, "UnmarshalJSON(overflow)")
+
+	v6 := NewFloat32()
+	if err = v6.UnmarshalJSON(invalidJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+}
+
+func TestFloat32MarshalJSON(t *testing.T) {
+	v1 := NewFloat32Value(math.MaxFloat32)
+	data, err := v1.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, string(float32JSON), "non-empty json marshal")
+
+	v2 := NewFloat32()
+	data, err = v2.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+
+	v3 := NewFloat32()
+	errorPanic(json.Unmarshal(float32ShortJSON, &v3))
+	data, err = v3.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, string(float32ShortJSON), "shortest json marshal")
+
+	v4 := NewFloat32Value(float32(math.Inf(1)))
+	if _, err = v4.MarshalJSON(); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+}
+
+func TestFloat32UnmarshalText(t *testing.T) {
+	v1 := NewFloat32()
+	errorPanic(v1.UnmarshalText(float32JSON))
+	isFloat32Valid(t, v1, "UnmarshalText()")
+
+	v2 := NewFloat32()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if v2.Float32 != 0 || !v2.Valid {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewFloat32()
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isFloat32Null(t, v3, "UnmarshalText()")
+
+	v4 := NewFloat32()
+	if err := v4.UnmarshalText(float32OverflowJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isFloat32Null(t, v4, "UnmarshalText(overflow)")
+}
+
+func TestFloat32MarshalText(t *testing.T) {
+	v1 := NewFloat32Value(math.MaxFloat32)
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(float32JSON), "Non-empty text marshal")
+
+	v2 := NewFloat32()
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "Null text marshal")
+}
+
+func TestFloat32Binary(t *testing.T) {
+	v1 := NewFloat32Value(math.MaxFloat32)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewFloat32()
+	errorPanic(v2.UnmarshalBinary(data))
+	isFloat32Valid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewFloat32()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewFloat32Value(1)
+	errorPanic(v4.UnmarshalBinary(data))
+	isFloat32Null(t, v4, "UnmarshalBinary()")
+}
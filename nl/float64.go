@@ -3,16 +3,9 @@ package nul // import "gopkg.in/webnice/lin.v1/nl"
 //import "gopkg.in/webnice/debug.v1"
 //import "gopkg.in/webnice/log.v2"
 import (
-	"bytes"
 	"database/sql/driver"
-	"encoding/gob"
-	"encoding/json"
 	"fmt"
 	"math"
-	"reflect"
-	"strconv"
-
-	"gopkg.in/webnice/lin.v1/wrapper"
 )
 
 // Float64 is an nullable float64 object
@@ -45,6 +38,17 @@ func NewFloat64PointerValue(ptr *float64) Float64 {
 	return NewFloat64Value(*ptr)
 }
 
+// NewFloat64Null Создание нового объекта Float64 из обобщённого объекта Null
+func NewFloat64Null(n Null[float64]) Float64 {
+	return Float64{
+		Float64: n.V,
+		Valid:   n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (f Float64) Null() Null[float64] { return Null[float64]{V: f.Float64, Valid: f.Valid} }
+
 // SetValid Изменение значения и установка флага действительного значения
 func (f *Float64) SetValid(value float64) { f.Float64, f.Valid = value, true }
 
@@ -76,147 +80,82 @@ func (f *Float64) Pointer() *float64 {
 }
 
 // Scan Реализация интерфейса Scanner
-func (f *Float64) Scan(value interface{}) (err error) {
-	switch x := value.(type) {
-	case nil:
-		f.Valid = false
-		return
-	case float64:
-		f.Float64 = value.(float64)
-	default:
-		buf := asString(x)
-		f.Float64, err = strconv.ParseFloat(buf, 64)
-	}
-	f.Valid = err == nil
-
-	return
+func (f *Float64) Scan(value interface{}) error {
+	return setNull(&f.Float64, &f.Valid, (*Null[float64]).Scan, value)
 }
 
 // Value Реализация интерфейса driver.Valuer
-func (f Float64) Value() (driver.Value, error) {
-	if !f.Valid {
-		return nil, nil
-	}
-	return f.Float64, nil
-}
+func (f Float64) Value() (driver.Value, error) { return f.Null().Value() }
 
 // UnmarshalJSON Реализация интерфейса json.Unmarshaler
-func (f *Float64) UnmarshalJSON(data []byte) (err error) {
-	var v interface{}
-
-	if err = json.Unmarshal(data, &v); err != nil {
-		return
-	}
-	switch x := v.(type) {
-	case float64:
-		f.Float64 = x
-	case string:
-		var str = x
-		if len(str) == 0 {
-			f.Valid = false
-			return
-		}
-		f.Float64, err = strconv.ParseFloat(str, 64)
-	case map[string]interface{}:
-		err = json.Unmarshal(data, &f.Float64)
-	case nil:
-		f.Valid = false
-		return
-	default:
-		err = fmt.Errorf("can't unmarshal %q into go value of type nul.Float", reflect.TypeOf(v).Name())
-	}
-	f.Valid = err == nil
-
-	return
+func (f *Float64) UnmarshalJSON(data []byte) error {
+	return setNull(&f.Float64, &f.Valid, (*Null[float64]).UnmarshalJSON, data)
 }
 
 // MarshalJSON Реализация интерфейса json.Marshaler
-func (f Float64) MarshalJSON() (data []byte, err error) {
-	const nullString = "null"
-
-	if !f.Valid {
-		data = []byte(nullString)
-		return
-	}
-	if math.IsInf(f.Float64, 0) || math.IsNaN(f.Float64) {
-		data, err = nil, &json.UnsupportedValueError{
-			Value: reflect.ValueOf(f.Float64),
-			Str:   strconv.FormatFloat(f.Float64, 'g', -1, 64),
-		}
-		return
+// Значение записывается с шестью знаками после точки
+func (f Float64) MarshalJSON() ([]byte, error) {
+	if !f.Valid || math.IsInf(f.Float64, 0) || math.IsNaN(f.Float64) {
+		return f.Null().MarshalJSON()
 	}
-	data = []byte(fmt.Sprintf("%f", f.Float64))
-
-	return
+	return []byte(fmt.Sprintf("%f", f.Float64)), nil
 }
 
 // UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
-func (f *Float64) UnmarshalText(text []byte) (err error) {
-	const (
-		emptyString = ""
-		nullString  = "null"
-	)
-	var str string
-
-	switch str = string(text); str {
-	case nullString:
-		f.Float64, f.Valid = 0, false
-		return
-	case emptyString:
-		f.Float64, f.Valid = 0, true
-		return
-	default:
-		f.Float64, err = strconv.ParseFloat(string(text), 64)
-	}
-	f.Valid = err == nil
-
-	return
+func (f *Float64) UnmarshalText(text []byte) error {
+	return setNull(&f.Float64, &f.Valid, (*Null[float64]).UnmarshalText, text)
 }
 
 // MarshalText Реализация интерфейса encoding.TextMarshaler
-func (f Float64) MarshalText() (text []byte, err error) {
-	const nullString = "null"
-
+// Значение записывается с шестью знаками после точки
+func (f Float64) MarshalText() ([]byte, error) {
 	if !f.Valid {
-		text = []byte(nullString)
-		return
+		return f.Null().MarshalText()
 	}
-	text = []byte(fmt.Sprintf("%f", f.Float64))
-
-	return
+	return []byte(fmt.Sprintf("%f", f.Float64)), nil
 }
 
 // UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
-func (f *Float64) UnmarshalBinary(data []byte) (err error) {
-	var (
-		reader *bytes.Reader
-		dec    *gob.Decoder
-		item   *wrapper.Float64Wrapper
-	)
-
-	reader = bytes.NewReader(data)
-	dec = gob.NewDecoder(reader)
-	item = new(wrapper.Float64Wrapper)
-	if err = dec.Decode(item); err == nil {
-		f.Float64, f.Valid = item.Value, item.Valid
-	}
-
-	return
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (f *Float64) UnmarshalBinary(data []byte) error {
+	return setNull(&f.Float64, &f.Valid, unmarshalNullBinary[float64](binaryTagFloat64), data)
 }
 
 // MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
-func (f Float64) MarshalBinary() (data []byte, err error) {
-	var (
-		buf  *bytes.Buffer
-		enc  *gob.Encoder
-		item *wrapper.Float64Wrapper
-	)
+func (f Float64) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagFloat64, f.Null()) }
 
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-	item = &wrapper.Float64Wrapper{Value: f.Float64, Valid: f.Valid}
-	err = enc.Encode(item)
-	data = buf.Bytes()
+// Запись значения в компактном двоичном формате
+func (f Float64) encodeBinary(w *binaryWriter) { f.Null().encodeBinary(w) }
 
-	return
+// Чтение значения в компактном двоичном формате
+func (f *Float64) decodeBinary(r *binaryReader) {
+	var n = f.Null()
+
+	n.decodeBinary(r)
+	*f = NewFloat64Null(n)
 }
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (f *Float64) UnmarshalMsgpack(data []byte) error {
+	return setNull(&f.Float64, &f.Valid, (*Null[float64]).UnmarshalMsgpack, data)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (f Float64) MarshalMsgpack() ([]byte, error) { return f.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (f *Float64) UnmarshalCBOR(data []byte) error {
+	return setNull(&f.Float64, &f.Valid, (*Null[float64]).UnmarshalCBOR, data)
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (f Float64) MarshalCBOR() ([]byte, error) { return f.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (f *Float64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&f.Float64, &f.Valid, (*Null[float64]).UnmarshalYAML, unmarshal)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (f Float64) MarshalYAML() (interface{}, error) { return f.Null().MarshalYAML() }
@@ -290,24 +290,13 @@ func TestFloat64UnmarshalBinary(t *testing.T) {
 }
 
 func TestFloat64MarshalBinary(t *testing.T) {
-	var err error
-	var buf *bytes.Buffer
-	var enc *gob.Encoder
-
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-
 	v1 := NewFloat64()
-	err = enc.Encode(&v1)
+	data, err := v1.MarshalBinary()
 	errorPanic(err)
-	v1h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v1h), floatNullInvalidGob, "NewBytes() -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), floatNullInvalidBinary, "NewFloat64() -> MarshalBinary()")
 
-	buf.Reset()
-	enc = gob.NewEncoder(buf)
 	v2 := NewFloat64Value(float64(math.MaxFloat64))
-	err = enc.Encode(&v2)
+	data, err = v2.MarshalBinary()
 	errorPanic(err)
-	v2h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v2h), floatOkValidGob, "NewBoolValue(false) -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), floatOkValidBinary, "NewFloat64Value(max) -> MarshalBinary()")
 }
@@ -0,0 +1,525 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Типы геометрии WKB
+const (
+	geometryPoint      uint32 = 1 // Точка
+	geometryLineString uint32 = 2 // Ломаная линия
+	geometryPolygon    uint32 = 3 // Многоугольник
+)
+
+// Флаги типа геометрии EWKB
+const (
+	ewkbFlagZ    uint32 = 0x80000000 // Координаты содержат Z
+	ewkbFlagM    uint32 = 0x40000000 // Координаты содержат M
+	ewkbFlagSRID uint32 = 0x20000000 // После типа следует SRID
+)
+
+// Названия типов геометрии в WKT и GeoJSON
+var geometryNames = map[uint32]string{
+	geometryPoint:      "Point",
+	geometryLineString: "LineString",
+	geometryPolygon:    "Polygon",
+}
+
+// Coord Координаты точки на плоскости, для географических координат X является долготой, Y широтой
+type Coord struct {
+	X float64 // Координата X или долгота
+	Y float64 // Координата Y или широта
+}
+
+// Разбор геометрии в формате WKB, EWKB, шестнадцатеричного EWKB PostGIS, внутреннего формата MySQL или WKT
+// Результатом являются кольца координат: одна точка, одна линия или кольца многоугольника
+func decodeGeometry(data []byte, geomType uint32) (rings [][]Coord, srid int32, err error) {
+	var buf []byte
+
+	if isWKTGeometry(data) {
+		return parseWKT(string(data), geomType)
+	}
+	if isHexGeometry(data) {
+		if buf, err = hex.DecodeString(string(data)); err != nil {
+			return
+		}
+		return parseWKB(buf, geomType)
+	}
+	if rings, srid, err = parseWKB(data, geomType); err == nil {
+		return
+	}
+	// Внутренний формат MySQL: SRID в порядке little-endian, за которым следует WKB
+	if len(data) > 4 {
+		var e error
+		if rings, srid, e = parseWKB(data[4:], geomType); e == nil {
+			srid, err = int32(binary.LittleEndian.Uint32(data)), nil
+			return
+		}
+	}
+
+	return
+}
+
+// Ключевые слова, с которых начинается геометрия в формате WKT или EWKT
+var wktPrefixes = []string{"SRID=", "POINT", "LINESTRING", "POLYGON", "MULTI", "GEOMETRYCOLLECTION"}
+
+// Проверка, что данные являются геометрией в формате WKT или EWKT.
+// Проверяется ключевое слово, а не первый байт, так как SRID внутреннего формата MySQL
+// может начинаться с байта буквы, например SRID 3395 (0x0D43) начинается с 'C'
+func isWKTGeometry(data []byte) bool {
+	if len(data) > 32 {
+		data = data[:32]
+	}
+	str := strings.ToUpper(strings.TrimSpace(string(data)))
+	for _, prefix := range wktPrefixes {
+		if strings.HasPrefix(str, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Проверка, что данные являются шестнадцатеричной строкой
+func isHexGeometry(data []byte) bool {
+	if len(data) == 0 || len(data)%2 != 0 {
+		return false
+	}
+	for _, c := range data {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// Чтение WKB
+type wkbReader struct {
+	data  []byte           // Данные
+	pos   int              // Текущая позиция
+	order binary.ByteOrder // Порядок байт
+}
+
+// Чтение беззнакового целого
+func (r *wkbReader) uint32() (ret uint32, err error) {
+	if r.pos+4 > len(r.data) {
+		err = fmt.Errorf("unexpected end of WKB")
+		return
+	}
+	ret, r.pos = r.order.Uint32(r.data[r.pos:]), r.pos+4
+
+	return
+}
+
+// Чтение координат точки
+func (r *wkbReader) coord() (ret Coord, err error) {
+	if r.pos+16 > len(r.data) {
+		err = fmt.Errorf("unexpected end of WKB")
+		return
+	}
+	ret.X = math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+	ret.Y = math.Float64frombits(r.order.Uint64(r.data[r.pos+8:]))
+	r.pos += 16
+
+	return
+}
+
+// Чтение последовательности координат с количеством точек
+func (r *wkbReader) coords() (ret []Coord, err error) {
+	var n uint32
+
+	if n, err = r.uint32(); err != nil {
+		return
+	}
+	if int(n) > (len(r.data)-r.pos)/16 {
+		err = fmt.Errorf("unexpected end of WKB")
+		return
+	}
+	ret = make([]Coord, n)
+	for i := range ret {
+		if ret[i], err = r.coord(); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Разбор геометрии в формате WKB или EWKB
+func parseWKB(data []byte, geomType uint32) (rings [][]Coord, srid int32, err error) {
+	var (
+		r     = &wkbReader{data: data}
+		typ   uint32
+		n     uint32
+		point Coord
+	)
+
+	if len(data) == 0 {
+		err = fmt.Errorf("can't parse empty data as WKB")
+		return
+	}
+	switch data[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		err = fmt.Errorf("can't parse WKB: invalid byte order %d", data[0])
+		return
+	}
+	r.pos = 1
+	if typ, err = r.uint32(); err != nil {
+		return
+	}
+	if typ&ewkbFlagSRID != 0 {
+		if n, err = r.uint32(); err != nil {
+			return
+		}
+		srid = int32(n)
+	}
+	if typ&(ewkbFlagZ|ewkbFlagM) != 0 || typ&0x0fffffff > 1000 {
+		err = fmt.Errorf("can't parse WKB: geometry with Z or M coordinates isn't supported")
+		return
+	}
+	if typ &= 0x0fffffff; typ != geomType {
+		err = fmt.Errorf("can't parse WKB: geometry type %d isn't %s", typ, geometryNames[geomType])
+		return
+	}
+	switch geomType {
+	case geometryPoint:
+		if point, err = r.coord(); err == nil {
+			rings = [][]Coord{{point}}
+		}
+	case geometryLineString:
+		rings = make([][]Coord, 1)
+		rings[0], err = r.coords()
+	case geometryPolygon:
+		if n, err = r.uint32(); err != nil {
+			return
+		}
+		if int(n) > (len(r.data)-r.pos)/4 {
+			err = fmt.Errorf("unexpected end of WKB")
+			return
+		}
+		rings = make([][]Coord, n)
+		for i := range rings {
+			if rings[i], err = r.coords(); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil && r.pos != len(data) {
+		err = fmt.Errorf("can't parse WKB: unexpected %d bytes at the end", len(data)-r.pos)
+	}
+	if err != nil {
+		rings, srid = nil, 0
+	}
+
+	return
+}
+
+// Форматирование геометрии в формате WKB с порядком байт little-endian
+// Если SRID не равен нулю, геометрия форматируется в формате EWKB
+func formatWKB(geomType uint32, srid int32, rings [][]Coord) []byte {
+	var (
+		buf = make([]byte, 0, 64)
+		typ = geomType
+	)
+
+	if srid != 0 {
+		typ |= ewkbFlagSRID
+	}
+	buf = append(buf, 1)
+	buf = appendWKBUint32(buf, typ)
+	if srid != 0 {
+		buf = appendWKBUint32(buf, uint32(srid))
+	}
+	if geomType == geometryPolygon {
+		buf = appendWKBUint32(buf, uint32(len(rings)))
+	}
+	for _, ring := range rings {
+		if geomType != geometryPoint {
+			buf = appendWKBUint32(buf, uint32(len(ring)))
+		}
+		for _, c := range ring {
+			buf = appendWKBUint64(buf, math.Float64bits(c.X))
+			buf = appendWKBUint64(buf, math.Float64bits(c.Y))
+		}
+	}
+
+	return buf
+}
+
+// Добавление беззнакового целого в порядке little-endian
+func appendWKBUint32(buf []byte, v uint32) []byte {
+	var tmp [4]byte
+
+	binary.LittleEndian.PutUint32(tmp[:], v)
+
+	return append(buf, tmp[:]...)
+}
+
+// Добавление беззнакового целого в порядке little-endian
+func appendWKBUint64(buf []byte, v uint64) []byte {
+	var tmp [8]byte
+
+	binary.LittleEndian.PutUint64(tmp[:], v)
+
+	return append(buf, tmp[:]...)
+}
+
+// Форматирование геометрии в формате WKT, например POINT(1 2) или POLYGON((0 0,1 0,1 1,0 0))
+func formatWKT(geomType uint32, rings [][]Coord) string {
+	var buf strings.Builder
+
+	buf.WriteString(strings.ToUpper(geometryNames[geomType]))
+	if geomType != geometryPoint && (len(rings) == 0 || geomType == geometryLineString && len(rings[0]) == 0) {
+		buf.WriteString(" EMPTY")
+		return buf.String()
+	}
+	if geomType == geometryPolygon {
+		buf.WriteByte('(')
+	}
+	for i, ring := range rings {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('(')
+		for j, c := range ring {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.FormatFloat(c.X, 'f', -1, 64))
+			buf.WriteByte(' ')
+			buf.WriteString(strconv.FormatFloat(c.Y, 'f', -1, 64))
+		}
+		buf.WriteByte(')')
+	}
+	if geomType == geometryPolygon {
+		buf.WriteByte(')')
+	}
+
+	return buf.String()
+}
+
+// Форматирование геометрии в формате EWKT с префиксом SRID, если SRID не равен нулю, иначе в формате WKT
+func formatEWKT(geomType uint32, srid int32, rings [][]Coord) string {
+	if srid == 0 {
+		return formatWKT(geomType, rings)
+	}
+	return fmt.Sprintf("SRID=%d;%s", srid, formatWKT(geomType, rings))
+}
+
+// Разбор геометрии в формате WKT или EWKT с префиксом SRID=4326;
+func parseWKT(str string, geomType uint32) (rings [][]Coord, srid int32, err error) {
+	var (
+		src  = str
+		name = strings.ToUpper(geometryNames[geomType])
+		n    int64
+	)
+
+	str = strings.TrimSpace(str)
+	if strings.HasPrefix(strings.ToUpper(str), "SRID=") {
+		i := strings.IndexByte(str, ';')
+		if i < 0 {
+			err = fmt.Errorf("can't parse %q as WKT: invalid SRID", src)
+			return
+		}
+		if n, err = strconv.ParseInt(str[5:i], 10, 32); err != nil {
+			err = fmt.Errorf("can't parse %q as WKT: invalid SRID", src)
+			return
+		}
+		srid, str = int32(n), strings.TrimSpace(str[i+1:])
+	}
+	if !strings.HasPrefix(strings.ToUpper(str), name) {
+		err = fmt.Errorf("can't parse %q as WKT: expected %s", src, name)
+		return
+	}
+	str = strings.TrimSpace(str[len(name):])
+	if strings.EqualFold(str, "EMPTY") && geomType != geometryPoint {
+		if geomType == geometryLineString {
+			rings = [][]Coord{{}}
+		} else {
+			rings = [][]Coord{}
+		}
+		return
+	}
+	switch geomType {
+	case geometryPolygon:
+		if !strings.HasPrefix(str, "(") || !strings.HasSuffix(str, ")") {
+			err = fmt.Errorf("can't parse %q as WKT: expected '(' and ')'", src)
+			return
+		}
+		rings, err = parseWKTRings(strings.TrimSpace(str[1 : len(str)-1]))
+	default:
+		rings = make([][]Coord, 1)
+		rings[0], err = parseWKTRing(str)
+		if err == nil && geomType == geometryPoint && len(rings[0]) != 1 {
+			err = fmt.Errorf("point must have one coordinate")
+		}
+	}
+	if err != nil {
+		rings, srid, err = nil, 0, fmt.Errorf("can't parse %q as WKT: %s", src, err)
+	}
+
+	return
+}
+
+// Разбор колец многоугольника вида (0 0,1 0,1 1,0 0),(...)
+func parseWKTRings(str string) (rings [][]Coord, err error) {
+	var ring []Coord
+
+	rings = [][]Coord{}
+	for len(str) > 0 {
+		i := strings.IndexByte(str, ')')
+		if i < 0 {
+			return nil, fmt.Errorf("expected ')'")
+		}
+		if ring, err = parseWKTRing(str[:i+1]); err != nil {
+			return
+		}
+		rings = append(rings, ring)
+		if str = strings.TrimSpace(str[i+1:]); len(str) > 0 {
+			if str[0] != ',' {
+				return nil, fmt.Errorf("expected ','")
+			}
+			str = strings.TrimSpace(str[1:])
+			if len(str) == 0 {
+				return nil, fmt.Errorf("unexpected end")
+			}
+		}
+	}
+
+	return
+}
+
+// Разбор последовательности координат вида (1 2,3 4)
+func parseWKTRing(str string) (ret []Coord, err error) {
+	var c Coord
+
+	if !strings.HasPrefix(str, "(") || !strings.HasSuffix(str, ")") {
+		return nil, fmt.Errorf("expected '(' and ')'")
+	}
+	ret = []Coord{}
+	for _, item := range strings.Split(str[1:len(str)-1], ",") {
+		fields := strings.Fields(item)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid coordinate %q", strings.TrimSpace(item))
+		}
+		if c.X, err = strconv.ParseFloat(fields[0], 64); err != nil {
+			return nil, fmt.Errorf("invalid coordinate %q", strings.TrimSpace(item))
+		}
+		if c.Y, err = strconv.ParseFloat(fields[1], 64); err != nil {
+			return nil, fmt.Errorf("invalid coordinate %q", strings.TrimSpace(item))
+		}
+		ret = append(ret, c)
+	}
+
+	return
+}
+
+// Представление геометрии в GeoJSON
+type geoJSON struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// Координаты в виде массивов чисел GeoJSON
+func coordsToGeoJSON(ring []Coord) [][]float64 {
+	var ret = make([][]float64, len(ring))
+
+	for i, c := range ring {
+		ret[i] = []float64{c.X, c.Y}
+	}
+
+	return ret
+}
+
+// Координаты из массивов чисел GeoJSON, высота отбрасывается
+func coordsFromGeoJSON(items [][]float64) (ret []Coord, err error) {
+	ret = make([]Coord, len(items))
+	for i := range items {
+		if len(items[i]) < 2 {
+			return nil, fmt.Errorf("position must have at least two elements")
+		}
+		ret[i] = Coord{X: items[i][0], Y: items[i][1]}
+	}
+
+	return
+}
+
+// Форматирование геометрии в GeoJSON
+func formatGeoJSON(geomType uint32, rings [][]Coord) (data []byte, err error) {
+	var item = struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates"`
+	}{Type: geometryNames[geomType]}
+
+	switch geomType {
+	case geometryPoint:
+		item.Coordinates = coordsToGeoJSON(rings[0])[0]
+	case geometryLineString:
+		item.Coordinates = coordsToGeoJSON(rings[0])
+	case geometryPolygon:
+		coordinates := make([][][]float64, len(rings))
+		for i := range rings {
+			coordinates[i] = coordsToGeoJSON(rings[i])
+		}
+		item.Coordinates = coordinates
+	}
+	data, err = json.Marshal(item)
+
+	return
+}
+
+// Разбор геометрии в формате GeoJSON
+func parseGeoJSON(data []byte, geomType uint32) (rings [][]Coord, err error) {
+	var (
+		item     geoJSON
+		point    []float64
+		line     [][]float64
+		polygon  [][][]float64
+		expected = geometryNames[geomType]
+	)
+
+	if err = json.Unmarshal(data, &item); err != nil {
+		return
+	}
+	if item.Type != expected {
+		return nil, fmt.Errorf("can't unmarshal GeoJSON type %q into nul.%s", item.Type, expected)
+	}
+	switch geomType {
+	case geometryPoint:
+		if err = json.Unmarshal(item.Coordinates, &point); err == nil {
+			rings = make([][]Coord, 1)
+			rings[0], err = coordsFromGeoJSON([][]float64{point})
+		}
+	case geometryLineString:
+		if err = json.Unmarshal(item.Coordinates, &line); err == nil {
+			rings = make([][]Coord, 1)
+			rings[0], err = coordsFromGeoJSON(line)
+		}
+	case geometryPolygon:
+		if err = json.Unmarshal(item.Coordinates, &polygon); err == nil {
+			rings = make([][]Coord, len(polygon))
+			for i := range polygon {
+				if rings[i], err = coordsFromGeoJSON(polygon[i]); err != nil {
+					break
+				}
+			}
+		}
+	}
+	if err != nil {
+		rings, err = nil, fmt.Errorf("can't unmarshal GeoJSON into nul.%s: %s", expected, err)
+	}
+
+	return
+}
@@ -0,0 +1,155 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+const (
+	pointWKBHex    = "0101000000000000000000f03f0000000000000040"                 // POINT(1 2)
+	pointWKBBigHex = "00000000013ff00000000000004000000000000000"                 // POINT(1 2), big-endian
+	pointEWKBHex   = "0101000020e6100000000000000000f03f0000000000000040"         // SRID=4326;POINT(1 2)
+	pointMySQLHex  = "e61000000101000000000000000000f03f0000000000000040"         // MySQL POINT(1 2) with SRID 4326
+	pointMySQL3395 = "430d00000101000000000000000000f03f0000000000000040"         // MySQL POINT(1 2) with SRID 3395
+	pointZWKBHex   = "0101000080000000000000f03f00000000000000400000000000000840" // POINT Z(1 2 3)
+)
+
+func geometryHex(str string) []byte {
+	ret, err := hex.DecodeString(str)
+	errorPanic(err)
+	return ret
+}
+
+func TestDecodeGeometry(t *testing.T) {
+	var tests = []struct {
+		In   []byte
+		SRID int32
+	}{
+		{geometryHex(pointWKBHex), 0},
+		{geometryHex(pointWKBBigHex), 0},
+		{geometryHex(pointEWKBHex), 4326},
+		{geometryHex(pointMySQLHex), 4326},
+		{geometryHex(pointMySQL3395), 3395},
+		{[]byte(pointEWKBHex), 4326},
+		{[]byte("0101000020E6100000000000000000F03F0000000000000040"), 4326},
+		{[]byte("POINT(1 2)"), 0},
+		{[]byte("SRID=4326;point (1 2)"), 4326},
+	}
+
+	for _, test := range tests {
+		rings, srid, err := decodeGeometry(test.In, geometryPoint)
+		errorPanic(err)
+		if !reflect.DeepEqual(rings, [][]Coord{{{X: 1, Y: 2}}}) || srid != test.SRID {
+			t.Errorf("decodeGeometry(%x) is %v SRID=%d, but should be POINT(1 2) SRID=%d", test.In, rings, srid, test.SRID)
+		}
+	}
+	for _, in := range [][]byte{
+		nil,
+		geometryHex(pointWKBHex)[:10],
+		append(geometryHex(pointWKBHex), 0),
+		geometryHex(pointZWKBHex),
+		geometryHex("0102000000ffffff7f"),
+		[]byte("LINESTRING(1 2,3 4)"),
+	} {
+		if _, _, err := decodeGeometry(in, geometryPoint); err == nil {
+			t.Errorf("decodeGeometry(%x) error is nil, but should be not nil", in)
+		}
+	}
+	if _, _, err := decodeGeometry(geometryHex(pointWKBHex), geometryLineString); err == nil {
+		t.Error("decodeGeometry() of point as line string error is nil, but should be not nil")
+	}
+}
+
+func TestFormatWKB(t *testing.T) {
+	rings := [][]Coord{{{X: 1, Y: 2}}}
+	if out := hex.EncodeToString(formatWKB(geometryPoint, 0, rings)); out != pointWKBHex {
+		t.Errorf("formatWKB() is %s, but should be %s", out, pointWKBHex)
+	}
+	if out := hex.EncodeToString(formatWKB(geometryPoint, 4326, rings)); out != pointEWKBHex {
+		t.Errorf("formatWKB() is %s, but should be %s", out, pointEWKBHex)
+	}
+
+	polygon := [][]Coord{{{0, 0}, {4, 0}, {4, 4}, {0, 0}}, {{1, 1}, {2, 1}, {2, 2}, {1, 1}}}
+	back, srid, err := parseWKB(formatWKB(geometryPolygon, 3857, polygon), geometryPolygon)
+	errorPanic(err)
+	if !reflect.DeepEqual(back, polygon) || srid != 3857 {
+		t.Errorf("parseWKB(formatWKB()) is %v SRID=%d, but should be %v SRID=3857", back, srid, polygon)
+	}
+}
+
+func TestWKT(t *testing.T) {
+	var tests = []struct {
+		Type  uint32
+		Rings [][]Coord
+		WKT   string
+	}{
+		{geometryPoint, [][]Coord{{{X: 1.5, Y: -2}}}, "POINT(1.5 -2)"},
+		{geometryLineString, [][]Coord{{{X: 1, Y: 2}, {X: 3, Y: 4}}}, "LINESTRING(1 2,3 4)"},
+		{geometryLineString, [][]Coord{{}}, "LINESTRING EMPTY"},
+		{geometryPolygon, [][]Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, "POLYGON((0 0,1 0,1 1,0 0))"},
+		{geometryPolygon, [][]Coord{{{0, 0}, {4, 0}, {4, 4}, {0, 0}}, {{1, 1}, {2, 1}, {2, 2}, {1, 1}}}, "POLYGON((0 0,4 0,4 4,0 0),(1 1,2 1,2 2,1 1))"},
+		{geometryPolygon, [][]Coord{}, "POLYGON EMPTY"},
+	}
+
+	for _, test := range tests {
+		if out := formatWKT(test.Type, test.Rings); out != test.WKT {
+			t.Errorf("formatWKT() is %q, but should be %q", out, test.WKT)
+		}
+		rings, _, err := parseWKT(test.WKT, test.Type)
+		errorPanic(err)
+		if !reflect.DeepEqual(rings, test.Rings) {
+			t.Errorf("parseWKT(%q) is %v, but should be %v", test.WKT, rings, test.Rings)
+		}
+	}
+	rings, srid, err := parseWKT(" SRID=4326; Polygon ( ( 0 0 , 1 0 , 1 1 , 0 0 ) ) ", geometryPolygon)
+	errorPanic(err)
+	if len(rings) != 1 || len(rings[0]) != 4 || srid != 4326 {
+		t.Errorf("parseWKT() is %v SRID=%d", rings, srid)
+	}
+	for _, in := range []string{"POINT EMPTY", "POINT(1)", "POINT(1 2,3 4)", "POINT(a b)", "POINT 1 2", "SRID=x;POINT(1 2)", "SRID=1 POINT(1 2)", "LINESTRING(1 2)"} {
+		if _, _, err = parseWKT(in, geometryPoint); err == nil {
+			t.Errorf("parseWKT(%q) error is nil, but should be not nil", in)
+		}
+	}
+	for _, in := range []string{"POLYGON(0 0,1 1)", "POLYGON((0 0,1 1)", "POLYGON((0 0,1 1),)", "POLYGON((0 0,1 1) (1 1,0 0))"} {
+		if _, _, err = parseWKT(in, geometryPolygon); err == nil {
+			t.Errorf("parseWKT(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestGeoJSON(t *testing.T) {
+	var tests = []struct {
+		Type  uint32
+		Rings [][]Coord
+		JSON  string
+	}{
+		{geometryPoint, [][]Coord{{{X: 1.5, Y: -2}}}, `{"type":"Point","coordinates":[1.5,-2]}`},
+		{geometryLineString, [][]Coord{{{X: 1, Y: 2}, {X: 3, Y: 4}}}, `{"type":"LineString","coordinates":[[1,2],[3,4]]}`},
+		{geometryPolygon, [][]Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`},
+	}
+
+	for _, test := range tests {
+		data, err := formatGeoJSON(test.Type, test.Rings)
+		errorPanic(err)
+		jsonEquals(t, data, test.JSON, "GeoJSON")
+		rings, err := parseGeoJSON([]byte(test.JSON), test.Type)
+		errorPanic(err)
+		if !reflect.DeepEqual(rings, test.Rings) {
+			t.Errorf("parseGeoJSON(%s) is %v, but should be %v", test.JSON, rings, test.Rings)
+		}
+	}
+	rings, err := parseGeoJSON([]byte(`{"type":"Point","coordinates":[1,2,3]}`), geometryPoint)
+	errorPanic(err)
+	if !reflect.DeepEqual(rings, [][]Coord{{{X: 1, Y: 2}}}) {
+		t.Errorf("parseGeoJSON() is %v, but should be POINT(1 2)", rings)
+	}
+	for _, in := range []string{`[]`, `{"type":"LineString","coordinates":[1,2]}`, `{"type":"Point","coordinates":[1]}`, `{"type":"Point","coordinates":"a"}`} {
+		if _, err = parseGeoJSON([]byte(in), geometryPoint); err == nil {
+			t.Errorf("parseGeoJSON(%s) error is nil, but should be not nil", in)
+		}
+	}
+}
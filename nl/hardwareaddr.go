@@ -0,0 +1,299 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// HardwareAddr is an nullable net.HardwareAddr object, for macaddr and macaddr8 columns
+type HardwareAddr struct {
+	HardwareAddr net.HardwareAddr // Value of object
+	Valid        bool             // Valid is true if value is not NULL
+}
+
+// NewHardwareAddr Создание нового объекта HardwareAddr
+func NewHardwareAddr() HardwareAddr {
+	return HardwareAddr{
+		HardwareAddr: nil,
+		Valid:        false,
+	}
+}
+
+// NewHardwareAddrValue Создание нового действительного объекта HardwareAddr из значения, значение копируется
+func NewHardwareAddrValue(value net.HardwareAddr) HardwareAddr {
+	return HardwareAddr{
+		HardwareAddr: append(net.HardwareAddr{}, value...),
+		Valid:        true,
+	}
+}
+
+// NewHardwareAddrPointerValue Создание нового действительного объекта HardwareAddr из ссылки на значение
+func NewHardwareAddrPointerValue(ptr *net.HardwareAddr) HardwareAddr {
+	if ptr == nil {
+		return NewHardwareAddr()
+	}
+	return NewHardwareAddrValue(*ptr)
+}
+
+// ParseHardwareAddr Разбор аппаратного адреса в форматах net.ParseMAC, а также в форматах PostgreSQL
+// без разделителей "08002b010203" и с произвольным расположением разделителей "08002b:010203", "0800-2b01-0203"
+func ParseHardwareAddr(str string) (ret net.HardwareAddr, err error) {
+	const (
+		lengthEUI48 = 6
+		lengthEUI64 = 8
+	)
+	var digits string
+
+	if ret, err = net.ParseMAC(str); err == nil {
+		return
+	}
+	digits = strings.Map(func(r rune) rune {
+		if r == ':' || r == '-' || r == '.' {
+			return -1
+		}
+		return r
+	}, str)
+	if len(digits) != lengthEUI48*2 && len(digits) != lengthEUI64*2 {
+		err = fmt.Errorf("can't parse %q as hardware address", str)
+		return
+	}
+	if ret, err = hex.DecodeString(digits); err != nil {
+		err = fmt.Errorf("can't parse %q as hardware address: %s", str, err)
+	}
+
+	return
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (ha *HardwareAddr) SetValid(value net.HardwareAddr) {
+	ha.HardwareAddr, ha.Valid = append(net.HardwareAddr{}, value...), true
+}
+
+// Reset Сброс значения и установка флага не действительного значения
+func (ha *HardwareAddr) Reset() { ha.HardwareAddr, ha.Valid = nil, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (ha *HardwareAddr) NullIfDefault() HardwareAddr {
+	if len(ha.HardwareAddr) == 0 {
+		ha.Reset()
+	}
+	return *ha
+}
+
+// MustValue Возвращает значение в любом случае
+func (ha *HardwareAddr) MustValue() net.HardwareAddr {
+	if !ha.Valid {
+		return net.HardwareAddr{}
+	}
+	return ha.HardwareAddr
+}
+
+// Pointer Возвращает ссылку на значение
+func (ha *HardwareAddr) Pointer() *net.HardwareAddr {
+	if !ha.Valid {
+		return nil
+	}
+	return &ha.HardwareAddr
+}
+
+// Scan Реализация интерфейса Scanner
+func (ha *HardwareAddr) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		ha.Reset()
+		return
+	case []byte:
+		ha.HardwareAddr, err = ParseHardwareAddr(string(x))
+	case string:
+		ha.HardwareAddr, err = ParseHardwareAddr(x)
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.HardwareAddr: %v", x, value)
+	}
+	if ha.Valid = err == nil; !ha.Valid {
+		ha.HardwareAddr = nil
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (ha HardwareAddr) Value() (driver.Value, error) {
+	if !ha.Valid {
+		return nil, nil
+	}
+	return ha.HardwareAddr.String(), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (ha *HardwareAddr) UnmarshalJSON(data []byte) (err error) {
+	var v interface{}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		ha.Reset()
+		return
+	case string:
+		if len(x) == 0 {
+			ha.Reset()
+			return
+		}
+		ha.HardwareAddr, err = ParseHardwareAddr(x)
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.HardwareAddr", reflect.TypeOf(v).Kind())
+	}
+	if ha.Valid = err == nil; !ha.Valid {
+		ha.HardwareAddr = nil
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (ha HardwareAddr) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !ha.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data = []byte(`"` + ha.HardwareAddr.String() + `"`)
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (ha *HardwareAddr) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		ha.Reset()
+		return
+	case emptyString:
+		ha.HardwareAddr, ha.Valid = net.HardwareAddr{}, true
+		return
+	default:
+		ha.HardwareAddr, err = ParseHardwareAddr(str)
+	}
+	if ha.Valid = err == nil; !ha.Valid {
+		ha.HardwareAddr = nil
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (ha HardwareAddr) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !ha.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(ha.HardwareAddr.String())
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (ha *HardwareAddr) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return ha.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagHardwareAddr, ha)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (ha HardwareAddr) MarshalBinary() (data []byte, err error) {
+	return marshalBinary(binaryTagHardwareAddr, ha)
+}
+
+// Запись значения в компактном двоичном формате
+func (ha HardwareAddr) encodeBinary(w *binaryWriter) {
+	if w.flags(ha.Valid); !ha.Valid {
+		return
+	}
+	w.bytes(ha.HardwareAddr)
+}
+
+// Чтение значения в компактном двоичном формате
+func (ha *HardwareAddr) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		value net.HardwareAddr
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			ha.Reset()
+		}
+		return
+	}
+	if value = r.bytes(); r.err == nil {
+		ha.HardwareAddr, ha.Valid = value, valid
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (ha *HardwareAddr) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.HardwareAddrWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.HardwareAddrWrapper)
+	if err = dec.Decode(item); err == nil {
+		ha.HardwareAddr, ha.Valid = item.Value, item.Valid
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (ha *HardwareAddr) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, ha) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой текстового представления
+func (ha HardwareAddr) MarshalMsgpack() ([]byte, error) { return marshalMsgpackText(ha.Valid, ha) }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (ha *HardwareAddr) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, ha) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой текстового представления
+func (ha HardwareAddr) MarshalCBOR() ([]byte, error) { return marshalCBORText(ha.Valid, ha) }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (ha *HardwareAddr) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, ha)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (ha HardwareAddr) MarshalYAML() (interface{}, error) { return marshalYAMLText(ha.Valid, ha) }
@@ -0,0 +1,195 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"testing"
+)
+
+var (
+	hardwareAddrValue  = net.HardwareAddr{0x08, 0x00, 0x2b, 0x01, 0x02, 0x03}
+	hardwareAddrString = "08:00:2b:01:02:03"
+	hardwareAddrJSON   = []byte(`"08:00:2b:01:02:03"`)
+)
+
+func isHardwareAddrValid(t *testing.T, ha HardwareAddr, from string) {
+	if !bytes.Equal(ha.HardwareAddr, hardwareAddrValue) {
+		t.Errorf("Bad %s hardware address: %v ≠ %v\n", from, ha.HardwareAddr, hardwareAddrValue)
+	}
+	if !ha.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isHardwareAddrNull(t *testing.T, ha HardwareAddr, from string) {
+	if ha.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestParseHardwareAddr(t *testing.T) {
+	for _, in := range []string{
+		"08:00:2b:01:02:03", "08-00-2B-01-02-03", "0800.2b01.0203", "08002b010203", "08002b:010203", "0800-2b01-0203",
+	} {
+		ha, err := ParseHardwareAddr(in)
+		errorPanic(err)
+		if !bytes.Equal(ha, hardwareAddrValue) {
+			t.Errorf("ParseHardwareAddr(%q) is %v, but should be %v", in, ha, hardwareAddrValue)
+		}
+	}
+	ha, err := ParseHardwareAddr("08:00:2b:01:02:03:04:05")
+	errorPanic(err)
+	if len(ha) != 8 {
+		t.Error("ParseHardwareAddr(EUI-64)", "is wrong")
+	}
+	for _, in := range []string{"", "08:00:2b:01:02", "08002b0102", "08002b01020g", "08:00:2b:01:02:03:04"} {
+		if _, err = ParseHardwareAddr(in); err == nil {
+			t.Errorf("ParseHardwareAddr(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestNewHardwareAddr(t *testing.T) {
+	v1 := NewHardwareAddr()
+	isHardwareAddrNull(t, v1, "NewHardwareAddr()")
+
+	v2 := NewHardwareAddrValue(hardwareAddrValue)
+	isHardwareAddrValid(t, v2, "NewHardwareAddrValue()")
+	v2.HardwareAddr[0] = 0
+	if hardwareAddrValue[0] == 0 {
+		t.Error("NewHardwareAddrValue()", "shares memory with the source")
+	}
+
+	v3 := NewHardwareAddrPointerValue(nil)
+	isHardwareAddrNull(t, v3, "NewHardwareAddrPointerValue()")
+
+	v4 := NewHardwareAddrPointerValue(&hardwareAddrValue)
+	isHardwareAddrValid(t, v4, "NewHardwareAddrPointerValue()")
+}
+
+func TestHardwareAddrSetValidReset(t *testing.T) {
+	v1 := NewHardwareAddr()
+	if v1.Pointer() != nil || len(v1.MustValue()) != 0 {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(hardwareAddrValue)
+	isHardwareAddrValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isHardwareAddrValid(t, v1, "NullIfDefault()")
+	v1.SetValid(net.HardwareAddr{})
+	v1.NullIfDefault()
+	isHardwareAddrNull(t, v1, "NullIfDefault()")
+}
+
+func TestHardwareAddrScanValue(t *testing.T) {
+	v1 := NewHardwareAddr()
+	errorPanic(v1.Scan([]byte("08002b:010203")))
+	isHardwareAddrValid(t, v1, "Scan([]byte)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != hardwareAddrString {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewHardwareAddr()
+	errorPanic(v2.Scan(hardwareAddrString))
+	isHardwareAddrValid(t, v2, "Scan(string)")
+
+	v3 := NewHardwareAddrValue(hardwareAddrValue)
+	errorPanic(v3.Scan(nil))
+	isHardwareAddrNull(t, v3, "Scan(nil)")
+	dv, err = v3.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v4 := NewHardwareAddr()
+	if err = v4.Scan(int64(1)); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v4.Scan("08:00:2b"); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isHardwareAddrNull(t, v4, "Scan()")
+}
+
+func TestHardwareAddrUnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewHardwareAddr()
+	errorPanic(json.Unmarshal(hardwareAddrJSON, &v1))
+	isHardwareAddrValid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewHardwareAddrValue(hardwareAddrValue)
+	errorPanic(json.Unmarshal(boolNullJSON, &v2))
+	isHardwareAddrNull(t, v2, "UnmarshalJSON(null)")
+
+	v3 := NewHardwareAddr()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v3))
+	isHardwareAddrNull(t, v3, "UnmarshalJSON(blank)")
+
+	v4 := NewHardwareAddr()
+	if err = json.Unmarshal(int64JSON, &v4); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = v4.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isHardwareAddrNull(t, v4, "UnmarshalJSON()")
+}
+
+func TestHardwareAddrMarshalJSON(t *testing.T) {
+	v1 := NewHardwareAddrValue(hardwareAddrValue)
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(hardwareAddrJSON), "MarshalJSON()")
+
+	v2 := NewHardwareAddr()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+}
+
+func TestHardwareAddrText(t *testing.T) {
+	v1 := NewHardwareAddr()
+	errorPanic(v1.UnmarshalText([]byte("08-00-2B-01-02-03")))
+	isHardwareAddrValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, hardwareAddrString, "MarshalText()")
+
+	v2 := NewHardwareAddr()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || len(v2.HardwareAddr) != 0 {
+		t.Errorf("Value should be valid")
+	}
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "", "MarshalText(empty)")
+
+	v3 := NewHardwareAddrValue(hardwareAddrValue)
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isHardwareAddrNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewHardwareAddr()
+	if err = v4.UnmarshalText([]byte("mac")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isHardwareAddrNull(t, v4, "UnmarshalText()")
+}
+
+func TestHardwareAddrBinary(t *testing.T) {
+	v1 := NewHardwareAddrValue(hardwareAddrValue)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewHardwareAddr()
+	errorPanic(v2.UnmarshalBinary(data))
+	isHardwareAddrValid(t, v2, "UnmarshalBinary()")
+}
@@ -0,0 +1,148 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+)
+
+// Int16 is an nullable int16 object
+type Int16 struct {
+	Int16 int16 // Value of object
+	Valid bool  // Valid is true if value is not NULL
+}
+
+// NewInt16 Создание нового объекта Int16
+func NewInt16() Int16 {
+	return Int16{
+		Int16: 0,
+		Valid: false,
+	}
+}
+
+// NewInt16Value Создание нового действительного объекта Int16 из значения
+func NewInt16Value(value int16) Int16 {
+	return Int16{
+		Int16: value,
+		Valid: true,
+	}
+}
+
+// NewInt16PointerValue Создание нового действительного объекта Int16 из ссылки на значение
+func NewInt16PointerValue(ptr *int16) Int16 {
+	if ptr == nil {
+		return NewInt16()
+	}
+	return NewInt16Value(*ptr)
+}
+
+// NewInt16Null Создание нового объекта Int16 из обобщённого объекта Null
+func NewInt16Null(n Null[int16]) Int16 {
+	return Int16{
+		Int16: n.V,
+		Valid: n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (i Int16) Null() Null[int16] { return Null[int16]{V: i.Int16, Valid: i.Valid} }
+
+// SetValid Изменение значения и установка флага действительного значения
+func (i *Int16) SetValid(value int16) { i.Int16, i.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (i *Int16) Reset() { i.Int16, i.Valid = 0, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (i *Int16) NullIfDefault() Int16 {
+	if i.Int16 == 0 {
+		i.Reset()
+	}
+	return *i
+}
+
+// MustValue Возвращает значение в любом случае
+func (i *Int16) MustValue() int16 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int16
+}
+
+// Pointer Возвращает ссылку на значение
+func (i *Int16) Pointer() *int16 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int16
+}
+
+// Scan Реализация интерфейса Scanner
+func (i *Int16) Scan(value interface{}) error {
+	return setNull(&i.Int16, &i.Valid, (*Null[int16]).Scan, value)
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (i Int16) Value() (driver.Value, error) { return i.Null().Value() }
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение допускается в виде числа либо строки, пустая строка является null
+func (i *Int16) UnmarshalJSON(data []byte) error {
+	return setNull(&i.Int16, &i.Valid, (*Null[int16]).UnmarshalJSON, data)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (i Int16) MarshalJSON() ([]byte, error) { return i.Null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (i *Int16) UnmarshalText(text []byte) error {
+	return setNull(&i.Int16, &i.Valid, (*Null[int16]).UnmarshalText, text)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (i Int16) MarshalText() ([]byte, error) { return i.Null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (i *Int16) UnmarshalBinary(data []byte) error {
+	return setNull(&i.Int16, &i.Valid, unmarshalNullBinary[int16](binaryTagInt16), data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (i Int16) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagInt16, i.Null()) }
+
+// Запись значения в компактном двоичном формате
+func (i Int16) encodeBinary(w *binaryWriter) { i.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (i *Int16) decodeBinary(r *binaryReader) {
+	var n = i.Null()
+
+	n.decodeBinary(r)
+	*i = NewInt16Null(n)
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (i *Int16) UnmarshalMsgpack(data []byte) error {
+	return setNull(&i.Int16, &i.Valid, (*Null[int16]).UnmarshalMsgpack, data)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (i Int16) MarshalMsgpack() ([]byte, error) { return i.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (i *Int16) UnmarshalCBOR(data []byte) error {
+	return setNull(&i.Int16, &i.Valid, (*Null[int16]).UnmarshalCBOR, data)
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (i Int16) MarshalCBOR() ([]byte, error) { return i.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (i *Int16) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&i.Int16, &i.Valid, (*Null[int16]).UnmarshalYAML, unmarshal)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (i Int16) MarshalYAML() (interface{}, error) { return i.Null().MarshalYAML() }
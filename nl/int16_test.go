@@ -0,0 +1,238 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var (
+	int16JSON         = []byte(strconv.FormatInt(math.MaxInt16, 10))
+	int16StringJSON   = []byte(`"` + strconv.FormatInt(math.MaxInt16, 10) + `"`)
+	int16OverflowJSON = []byte(strconv.FormatInt(math.MaxInt16+1, 10))
+)
+
+func isInt16Valid(t *testing.T, i Int16, from string) {
+	dv, _ := i.Value()
+	if dv.(int64) != math.MaxInt16 {
+		t.Errorf("Bad %s int16: \"%d\" ≠ \"%d\"\n", from, dv, int16(math.MaxInt16))
+	}
+	if !i.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isInt16Null(t *testing.T, i Int16, from string) {
+	if i.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewInt16(t *testing.T) {
+	v1 := NewInt16()
+	isInt16Null(t, v1, "NewInt16()")
+
+	v2 := NewInt16Value(math.MaxInt16)
+	isInt16Valid(t, v2, "NewInt16Value()")
+
+	var bv = int16(math.MaxInt16)
+	v3 := NewInt16PointerValue(&bv)
+	isInt16Valid(t, v3, "NewInt16PointerValue()")
+
+	v4 := NewInt16PointerValue(nil)
+	isInt16Null(t, v4, "NewInt16PointerValue()")
+}
+
+func TestInt16SetValidReset(t *testing.T) {
+	v1 := NewInt16()
+	v1.SetValid(math.MaxInt16)
+	isInt16Valid(t, v1, "SetValid()")
+	v1.Reset()
+	isInt16Null(t, v1, "Reset()")
+}
+
+func TestInt16NullIfDefault(t *testing.T) {
+	v1 := NewInt16Value(math.MaxInt16)
+	v1.NullIfDefault()
+	isInt16Valid(t, v1, "NullIfDefault()")
+
+	v1.SetValid(0)
+	v1.NullIfDefault()
+	isInt16Null(t, v1, "NullIfDefault()")
+}
+
+func TestInt16MustValuePointer(t *testing.T) {
+	v1 := NewInt16()
+	if v1.MustValue() != 0 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if v1.Pointer() != nil {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(math.MaxInt16)
+	if v1.MustValue() != math.MaxInt16 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if pb := v1.Pointer(); pb == nil || *pb != math.MaxInt16 {
+		t.Error("Pointer()", "is wrong")
+	}
+}
+
+func TestInt16Scan(t *testing.T) {
+	v1 := NewInt16()
+	errorPanic(v1.Scan(int64(math.MaxInt16)))
+	isInt16Valid(t, v1, "Scan()")
+
+	v2 := NewInt16()
+	errorPanic(v2.Scan(string(int16JSON)))
+	isInt16Valid(t, v2, "Scan()")
+
+	v3 := NewInt16Value(1)
+	errorPanic(v3.Scan(nil))
+	isInt16Null(t, v3, "Scan()")
+
+	v4 := NewInt16()
+	if err := v4.Scan(false); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+
+	v5 := NewInt16()
+	if err := v5.Scan(int64(math.MaxInt16 + 1)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isInt16Null(t, v5, "Scan(overflow)")
+
+	v6 := NewInt16()
+	if err := v6.Scan(int64(math.MinInt16 - 1)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isInt16Null(t, v6, "Scan(overflow)")
+}
+
+func TestInt16Value(t *testing.T) {
+	v1 := NewInt16Value(math.MaxInt16)
+	dv, err := v1.Value()
+	errorPanic(err)
+	if _, ok := dv.(int64); !ok {
+		t.Errorf("%s returns type %q, but should be %q", "Value()", reflect.TypeOf(dv).Name(), "int64")
+	}
+	if !driver.IsValue(dv) {
+		t.Error("Value()", "is not a driver.Value")
+	}
+
+	v2 := NewInt16()
+	dv, err = v2.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+}
+
+func TestInt16UnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewInt16()
+	errorPanic(json.Unmarshal(int16JSON, &v1))
+	isInt16Valid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewInt16()
+	errorPanic(json.Unmarshal(int16StringJSON, &v2))
+	isInt16Valid(t, v2, "UnmarshalJSON()")
+
+	v3 := NewInt16()
+	errorPanic(json.Unmarshal(boolNullJSON, &v3))
+	isInt16Null(t, v3, "UnmarshalJSON(null)")
+
+	v4 := NewInt16()
+	if err = v4.UnmarshalJSON(invalidJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+
+	v5 := NewInt16()
+	if err = json.Unmarshal(int16OverflowJSON, &v5); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt16Null(t, v5, "UnmarshalJSON(overflow)")
+
+	v6 := NewInt16()
+	if err = json.Unmarshal(boolFalseJSON, &v6); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt16Null(t, v6, "UnmarshalJSON()")
+}
+
+func TestInt16MarshalJSON(t *testing.T) {
+	v1 := NewInt16Value(math.MaxInt16)
+	data, err := v1.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, string(int16JSON), "non-empty json marshal")
+
+	v2 := NewInt16()
+	data, err = v2.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestInt16UnmarshalText(t *testing.T) {
+	v1 := NewInt16()
+	errorPanic(v1.UnmarshalText(int16JSON))
+	isInt16Valid(t, v1, "UnmarshalText()")
+
+	v2 := NewInt16()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if v2.Int16 != 0 || !v2.Valid {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewInt16()
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isInt16Null(t, v3, "UnmarshalText()")
+
+	v4 := NewInt16()
+	if err := v4.UnmarshalText(int16OverflowJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt16Null(t, v4, "UnmarshalText(overflow)")
+}
+
+func TestInt16MarshalText(t *testing.T) {
+	v1 := NewInt16Value(math.MaxInt16)
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(int16JSON), "Non-empty text marshal")
+
+	v2 := NewInt16()
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "Null text marshal")
+}
+
+func TestInt16Binary(t *testing.T) {
+	v1 := NewInt16Value(math.MaxInt16)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewInt16()
+	errorPanic(v2.UnmarshalBinary(data))
+	isInt16Valid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewInt16()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewInt16Value(1)
+	errorPanic(v4.UnmarshalBinary(data))
+	isInt16Null(t, v4, "UnmarshalBinary()")
+
+	v5 := NewInt64Value(math.MaxInt16 + 1)
+	data, err = v5.MarshalBinary()
+	errorPanic(err)
+	v6 := NewInt16()
+	if err = v6.UnmarshalBinary(data); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt16Null(t, v6, "UnmarshalBinary(overflow)")
+}
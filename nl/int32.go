@@ -0,0 +1,148 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+)
+
+// Int32 is an nullable int32 object
+type Int32 struct {
+	Int32 int32 // Value of object
+	Valid bool  // Valid is true if value is not NULL
+}
+
+// NewInt32 Создание нового объекта Int32
+func NewInt32() Int32 {
+	return Int32{
+		Int32: 0,
+		Valid: false,
+	}
+}
+
+// NewInt32Value Создание нового действительного объекта Int32 из значения
+func NewInt32Value(value int32) Int32 {
+	return Int32{
+		Int32: value,
+		Valid: true,
+	}
+}
+
+// NewInt32PointerValue Создание нового действительного объекта Int32 из ссылки на значение
+func NewInt32PointerValue(ptr *int32) Int32 {
+	if ptr == nil {
+		return NewInt32()
+	}
+	return NewInt32Value(*ptr)
+}
+
+// NewInt32Null Создание нового объекта Int32 из обобщённого объекта Null
+func NewInt32Null(n Null[int32]) Int32 {
+	return Int32{
+		Int32: n.V,
+		Valid: n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (i Int32) Null() Null[int32] { return Null[int32]{V: i.Int32, Valid: i.Valid} }
+
+// SetValid Изменение значения и установка флага действительного значения
+func (i *Int32) SetValid(value int32) { i.Int32, i.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (i *Int32) Reset() { i.Int32, i.Valid = 0, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (i *Int32) NullIfDefault() Int32 {
+	if i.Int32 == 0 {
+		i.Reset()
+	}
+	return *i
+}
+
+// MustValue Возвращает значение в любом случае
+func (i *Int32) MustValue() int32 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int32
+}
+
+// Pointer Возвращает ссылку на значение
+func (i *Int32) Pointer() *int32 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int32
+}
+
+// Scan Реализация интерфейса Scanner
+func (i *Int32) Scan(value interface{}) error {
+	return setNull(&i.Int32, &i.Valid, (*Null[int32]).Scan, value)
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (i Int32) Value() (driver.Value, error) { return i.Null().Value() }
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение допускается в виде числа либо строки, пустая строка является null
+func (i *Int32) UnmarshalJSON(data []byte) error {
+	return setNull(&i.Int32, &i.Valid, (*Null[int32]).UnmarshalJSON, data)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (i Int32) MarshalJSON() ([]byte, error) { return i.Null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (i *Int32) UnmarshalText(text []byte) error {
+	return setNull(&i.Int32, &i.Valid, (*Null[int32]).UnmarshalText, text)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (i Int32) MarshalText() ([]byte, error) { return i.Null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (i *Int32) UnmarshalBinary(data []byte) error {
+	return setNull(&i.Int32, &i.Valid, unmarshalNullBinary[int32](binaryTagInt32), data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (i Int32) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagInt32, i.Null()) }
+
+// Запись значения в компактном двоичном формате
+func (i Int32) encodeBinary(w *binaryWriter) { i.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (i *Int32) decodeBinary(r *binaryReader) {
+	var n = i.Null()
+
+	n.decodeBinary(r)
+	*i = NewInt32Null(n)
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (i *Int32) UnmarshalMsgpack(data []byte) error {
+	return setNull(&i.Int32, &i.Valid, (*Null[int32]).UnmarshalMsgpack, data)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (i Int32) MarshalMsgpack() ([]byte, error) { return i.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (i *Int32) UnmarshalCBOR(data []byte) error {
+	return setNull(&i.Int32, &i.Valid, (*Null[int32]).UnmarshalCBOR, data)
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (i Int32) MarshalCBOR() ([]byte, error) { return i.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (i *Int32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&i.Int32, &i.Valid, (*Null[int32]).UnmarshalYAML, unmarshal)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (i Int32) MarshalYAML() (interface{}, error) { return i.Null().MarshalYAML() }
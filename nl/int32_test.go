@@ -0,0 +1,238 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var (
+	int32JSON         = []byte(strconv.FormatInt(math.MaxInt32, 10))
+	int32StringJSON   = []byte(`"` + strconv.FormatInt(math.MaxInt32, 10) + `"`)
+	int32OverflowJSON = []byte(strconv.FormatInt(math.MaxInt32+1, 10))
+)
+
+func isInt32Valid(t *testing.T, i Int32, from string) {
+	dv, _ := i.Value()
+	if dv.(int64) != math.MaxInt32 {
+		t.Errorf("Bad %s int32: \"%d\" ≠ \"%d\"\n", from, dv, int32(math.MaxInt32))
+	}
+	if !i.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isInt32Null(t *testing.T, i Int32, from string) {
+	if i.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewInt32(t *testing.T) {
+	v1 := NewInt32()
+	isInt32Null(t, v1, "NewInt32()")
+
+	v2 := NewInt32Value(math.MaxInt32)
+	isInt32Valid(t, v2, "NewInt32Value()")
+
+	var bv = int32(math.MaxInt32)
+	v3 := NewInt32PointerValue(&bv)
+	isInt32Valid(t, v3, "NewInt32PointerValue()")
+
+	v4 := NewInt32PointerValue(nil)
+	isInt32Null(t, v4, "NewInt32PointerValue()")
+}
+
+func TestInt32SetValidReset(t *testing.T) {
+	v1 := NewInt32()
+	v1.SetValid(math.MaxInt32)
+	isInt32Valid(t, v1, "SetValid()")
+	v1.Reset()
+	isInt32Null(t, v1, "Reset()")
+}
+
+func TestInt32NullIfDefault(t *testing.T) {
+	v1 := NewInt32Value(math.MaxInt32)
+	v1.NullIfDefault()
+	isInt32Valid(t, v1, "NullIfDefault()")
+
+	v1.SetValid(0)
+	v1.NullIfDefault()
+	isInt32Null(t, v1, "NullIfDefault()")
+}
+
+func TestInt32MustValuePointer(t *testing.T) {
+	v1 := NewInt32()
+	if v1.MustValue() != 0 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if v1.Pointer() != nil {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(math.MaxInt32)
+	if v1.MustValue() != math.MaxInt32 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if pb := v1.Pointer(); pb == nil || *pb != math.MaxInt32 {
+		t.Error("Pointer()", "is wrong")
+	}
+}
+
+func TestInt32Scan(t *testing.T) {
+	v1 := NewInt32()
+	errorPanic(v1.Scan(int64(math.MaxInt32)))
+	isInt32Valid(t, v1, "Scan()")
+
+	v2 := NewInt32()
+	errorPanic(v2.Scan(string(int32JSON)))
+	isInt32Valid(t, v2, "Scan()")
+
+	v3 := NewInt32Value(1)
+	errorPanic(v3.Scan(nil))
+	isInt32Null(t, v3, "Scan()")
+
+	v4 := NewInt32()
+	if err := v4.Scan(false); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+
+	v5 := NewInt32()
+	if err := v5.Scan(int64(math.MaxInt32 + 1)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isInt32Null(t, v5, "Scan(overflow)")
+
+	v6 := NewInt32()
+	if err := v6.Scan(int64(math.MinInt32 - 1)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isInt32Null(t, v6, "Scan(overflow)")
+}
+
+func TestInt32Value(t *testing.T) {
+	v1 := NewInt32Value(math.MaxInt32)
+	dv, err := v1.Value()
+	errorPanic(err)
+	if _, ok := dv.(int64); !ok {
+		t.Errorf("%s returns type %q, but should be %q", "Value()", reflect.TypeOf(dv).Name(), "int64")
+	}
+	if !driver.IsValue(dv) {
+		t.Error("Value()", "is not a driver.Value")
+	}
+
+	v2 := NewInt32()
+	dv, err = v2.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+}
+
+func TestInt32UnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewInt32()
+	errorPanic(json.Unmarshal(int32JSON, &v1))
+	isInt32Valid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewInt32()
+	errorPanic(json.Unmarshal(int32StringJSON, &v2))
+	isInt32Valid(t, v2, "UnmarshalJSON()")
+
+	v3 := NewInt32()
+	errorPanic(json.Unmarshal(boolNullJSON, &v3))
+	isInt32Null(t, v3, "UnmarshalJSON(null)")
+
+	v4 := NewInt32()
+	if err = v4.UnmarshalJSON(invalidJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+
+	v5 := NewInt32()
+	if err = json.Unmarshal(int32OverflowJSON, &v5); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt32Null(t, v5, "UnmarshalJSON(overflow)")
+
+	v6 := NewInt32()
+	if err = json.Unmarshal(boolFalseJSON, &v6); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt32Null(t, v6, "UnmarshalJSON()")
+}
+
+func TestInt32MarshalJSON(t *testing.T) {
+	v1 := NewInt32Value(math.MaxInt32)
+	data, err := v1.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, string(int32JSON), "non-empty json marshal")
+
+	v2 := NewInt32()
+	data, err = v2.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestInt32UnmarshalText(t *testing.T) {
+	v1 := NewInt32()
+	errorPanic(v1.UnmarshalText(int32JSON))
+	isInt32Valid(t, v1, "UnmarshalText()")
+
+	v2 := NewInt32()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if v2.Int32 != 0 || !v2.Valid {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewInt32()
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isInt32Null(t, v3, "UnmarshalText()")
+
+	v4 := NewInt32()
+	if err := v4.UnmarshalText(int32OverflowJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt32Null(t, v4, "UnmarshalText(overflow)")
+}
+
+func TestInt32MarshalText(t *testing.T) {
+	v1 := NewInt32Value(math.MaxInt32)
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(int32JSON), "Non-empty text marshal")
+
+	v2 := NewInt32()
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "Null text marshal")
+}
+
+func TestInt32Binary(t *testing.T) {
+	v1 := NewInt32Value(math.MaxInt32)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewInt32()
+	errorPanic(v2.UnmarshalBinary(data))
+	isInt32Valid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewInt32()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewInt32Value(1)
+	errorPanic(v4.UnmarshalBinary(data))
+	isInt32Null(t, v4, "UnmarshalBinary()")
+
+	v5 := NewInt64Value(math.MaxInt32 + 1)
+	data, err = v5.MarshalBinary()
+	errorPanic(err)
+	v6 := NewInt32()
+	if err = v6.UnmarshalBinary(data); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt32Null(t, v6, "UnmarshalBinary(overflow)")
+}
@@ -3,15 +3,7 @@ package nul // import "gopkg.in/webnice/lin.v1/nl"
 //import "gopkg.in/webnice/debug.v1"
 //import "gopkg.in/webnice/log.v2"
 import (
-	"bytes"
 	"database/sql/driver"
-	"encoding/gob"
-	"encoding/json"
-	"fmt"
-	"reflect"
-	"strconv"
-
-	"gopkg.in/webnice/lin.v1/wrapper"
 )
 
 // Int64 is an nullable int64 object
@@ -44,6 +36,17 @@ func NewInt64PointerValue(ptr *int64) Int64 {
 	return NewInt64Value(*ptr)
 }
 
+// NewInt64Null Создание нового объекта Int64 из обобщённого объекта Null
+func NewInt64Null(n Null[int64]) Int64 {
+	return Int64{
+		Int64: n.V,
+		Valid: n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (i Int64) Null() Null[int64] { return Null[int64]{V: i.Int64, Valid: i.Valid} }
+
 // SetValid Изменение значения и установка флага действительного значения
 func (i *Int64) SetValid(value int64) { i.Int64, i.Valid = value, true }
 
@@ -75,140 +78,71 @@ func (i *Int64) Pointer() *int64 {
 }
 
 // Scan Реализация интерфейса Scanner
-func (i *Int64) Scan(value interface{}) (err error) {
-	switch x := value.(type) {
-	case nil:
-		i.Int64, i.Valid = 0, false
-		return
-	case int64:
-		i.Int64 = value.(int64)
-	default:
-		buf := asString(x)
-		i.Int64, err = strconv.ParseInt(buf, 10, 64)
-	}
-	i.Valid = err == nil
-
-	return
+func (i *Int64) Scan(value interface{}) error {
+	return setNull(&i.Int64, &i.Valid, (*Null[int64]).Scan, value)
 }
 
 // Value Реализация интерфейса driver.Valuer
-func (i Int64) Value() (driver.Value, error) {
-	if !i.Valid {
-		return nil, nil
-	}
-	return i.Int64, nil
-}
+func (i Int64) Value() (driver.Value, error) { return i.Null().Value() }
 
 // UnmarshalJSON Реализация интерфейса json.Unmarshaler
-func (i *Int64) UnmarshalJSON(data []byte) (err error) {
-	var v interface{}
-
-	if err = json.Unmarshal(data, &v); err != nil {
-		return
-	}
-	switch x := v.(type) {
-	case float64:
-		i.Int64, err = strconv.ParseInt(string(data), 10, 64)
-	case string:
-		var str = x
-		if len(str) == 0 {
-			i.Valid = false
-			return
-		}
-		i.Int64, err = strconv.ParseInt(str, 10, 64)
-	case map[string]interface{}:
-		err = json.Unmarshal(data, &i.Int64)
-	case nil:
-		i.Valid = false
-		return
-	default:
-		err = fmt.Errorf("can't unmarshal %q into go value of type nul.Int64", reflect.TypeOf(v).Name())
-	}
-	i.Valid = err == nil
-
-	return
+// Значение допускается в виде числа либо строки, пустая строка является null
+func (i *Int64) UnmarshalJSON(data []byte) error {
+	return setNull(&i.Int64, &i.Valid, (*Null[int64]).UnmarshalJSON, data)
 }
 
 // MarshalJSON Реализация интерфейса json.Marshaler
-func (i Int64) MarshalJSON() (data []byte, err error) {
-	const nullString = "null"
-
-	if !i.Valid {
-		data = []byte(nullString)
-		return
-	}
-	data = []byte(strconv.FormatInt(i.Int64, 10))
+func (i Int64) MarshalJSON() ([]byte, error) { return i.Null().MarshalJSON() }
 
-	return
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (i *Int64) UnmarshalText(text []byte) error {
+	return setNull(&i.Int64, &i.Valid, (*Null[int64]).UnmarshalText, text)
 }
 
-// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
-func (i *Int64) UnmarshalText(text []byte) (err error) {
-	const (
-		emptyString = ""
-		nullString  = "null"
-	)
-	var str string
-
-	switch str = string(text); str {
-	case nullString:
-		i.Int64, i.Valid = 0, false
-		return
-	case emptyString:
-		i.Int64, i.Valid = 0, true
-		return
-	default:
-		i.Int64, err = strconv.ParseInt(str, 10, 64)
-	}
-	i.Valid = err == nil
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (i Int64) MarshalText() ([]byte, error) { return i.Null().MarshalText() }
 
-	return
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (i *Int64) UnmarshalBinary(data []byte) error {
+	return setNull(&i.Int64, &i.Valid, unmarshalNullBinary[int64](binaryTagInt64), data)
 }
 
-// MarshalText Реализация интерфейса encoding.TextMarshaler
-func (i Int64) MarshalText() (text []byte, err error) {
-	const nullString = "null"
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (i Int64) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagInt64, i.Null()) }
 
-	if !i.Valid {
-		text = []byte(nullString)
-		return
-	}
-	text = []byte(strconv.FormatInt(i.Int64, 10))
+// Запись значения в компактном двоичном формате
+func (i Int64) encodeBinary(w *binaryWriter) { i.Null().encodeBinary(w) }
 
-	return
-}
+// Чтение значения в компактном двоичном формате
+func (i *Int64) decodeBinary(r *binaryReader) {
+	var n = i.Null()
 
-// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
-func (i *Int64) UnmarshalBinary(data []byte) (err error) {
-	var (
-		reader *bytes.Reader
-		dec    *gob.Decoder
-		item   *wrapper.Int64Wrapper
-	)
-
-	reader = bytes.NewReader(data)
-	dec = gob.NewDecoder(reader)
-	item = new(wrapper.Int64Wrapper)
-	if err = dec.Decode(item); err == nil {
-		i.Int64, i.Valid = item.Value, item.Valid
-	}
+	n.decodeBinary(r)
+	*i = NewInt64Null(n)
+}
 
-	return
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (i *Int64) UnmarshalMsgpack(data []byte) error {
+	return setNull(&i.Int64, &i.Valid, (*Null[int64]).UnmarshalMsgpack, data)
 }
 
-// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
-func (i Int64) MarshalBinary() (data []byte, err error) {
-	var (
-		buf  *bytes.Buffer
-		enc  *gob.Encoder
-		item *wrapper.Int64Wrapper
-	)
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (i Int64) MarshalMsgpack() ([]byte, error) { return i.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (i *Int64) UnmarshalCBOR(data []byte) error {
+	return setNull(&i.Int64, &i.Valid, (*Null[int64]).UnmarshalCBOR, data)
+}
 
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-	item = &wrapper.Int64Wrapper{Value: i.Int64, Valid: i.Valid}
-	err = enc.Encode(item)
-	data = buf.Bytes()
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (i Int64) MarshalCBOR() ([]byte, error) { return i.Null().MarshalCBOR() }
 
-	return
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (i *Int64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&i.Int64, &i.Valid, (*Null[int64]).UnmarshalYAML, unmarshal)
 }
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (i Int64) MarshalYAML() (interface{}, error) { return i.Null().MarshalYAML() }
@@ -284,24 +284,13 @@ func TestInt64UnmarshalBinary(t *testing.T) {
 }
 
 func TestInt64MarshalBinary(t *testing.T) {
-	var err error
-	var buf *bytes.Buffer
-	var enc *gob.Encoder
-
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-
 	v1 := NewInt64()
-	err = enc.Encode(&v1)
+	data, err := v1.MarshalBinary()
 	errorPanic(err)
-	v1h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v1h), intNullInvalidGob, "NewBytes() -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), intNullInvalidBinary, "NewInt64() -> MarshalBinary()")
 
-	buf.Reset()
-	enc = gob.NewEncoder(buf)
 	v2 := NewInt64Value(int64(math.MaxInt64))
-	err = enc.Encode(&v2)
+	data, err = v2.MarshalBinary()
 	errorPanic(err)
-	v2h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v2h), intOkValidGob, "NewBoolValue(false) -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), intOkValidBinary, "NewInt64Value(max) -> MarshalBinary()")
 }
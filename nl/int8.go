@@ -0,0 +1,148 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+)
+
+// Int8 is an nullable int8 object
+type Int8 struct {
+	Int8  int8 // Value of object
+	Valid bool // Valid is true if value is not NULL
+}
+
+// NewInt8 Создание нового объекта Int8
+func NewInt8() Int8 {
+	return Int8{
+		Int8:  0,
+		Valid: false,
+	}
+}
+
+// NewInt8Value Создание нового действительного объекта Int8 из значения
+func NewInt8Value(value int8) Int8 {
+	return Int8{
+		Int8:  value,
+		Valid: true,
+	}
+}
+
+// NewInt8PointerValue Создание нового действительного объекта Int8 из ссылки на значение
+func NewInt8PointerValue(ptr *int8) Int8 {
+	if ptr == nil {
+		return NewInt8()
+	}
+	return NewInt8Value(*ptr)
+}
+
+// NewInt8Null Создание нового объекта Int8 из обобщённого объекта Null
+func NewInt8Null(n Null[int8]) Int8 {
+	return Int8{
+		Int8:  n.V,
+		Valid: n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (i Int8) Null() Null[int8] { return Null[int8]{V: i.Int8, Valid: i.Valid} }
+
+// SetValid Изменение значения и установка флага действительного значения
+func (i *Int8) SetValid(value int8) { i.Int8, i.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (i *Int8) Reset() { i.Int8, i.Valid = 0, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (i *Int8) NullIfDefault() Int8 {
+	if i.Int8 == 0 {
+		i.Reset()
+	}
+	return *i
+}
+
+// MustValue Возвращает значение в любом случае
+func (i *Int8) MustValue() int8 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int8
+}
+
+// Pointer Возвращает ссылку на значение
+func (i *Int8) Pointer() *int8 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int8
+}
+
+// Scan Реализация интерфейса Scanner
+func (i *Int8) Scan(value interface{}) error {
+	return setNull(&i.Int8, &i.Valid, (*Null[int8]).Scan, value)
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (i Int8) Value() (driver.Value, error) { return i.Null().Value() }
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение допускается в виде числа либо строки, пустая строка является null
+func (i *Int8) UnmarshalJSON(data []byte) error {
+	return setNull(&i.Int8, &i.Valid, (*Null[int8]).UnmarshalJSON, data)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (i Int8) MarshalJSON() ([]byte, error) { return i.Null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (i *Int8) UnmarshalText(text []byte) error {
+	return setNull(&i.Int8, &i.Valid, (*Null[int8]).UnmarshalText, text)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (i Int8) MarshalText() ([]byte, error) { return i.Null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (i *Int8) UnmarshalBinary(data []byte) error {
+	return setNull(&i.Int8, &i.Valid, unmarshalNullBinary[int8](binaryTagInt8), data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (i Int8) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagInt8, i.Null()) }
+
+// Запись значения в компактном двоичном формате
+func (i Int8) encodeBinary(w *binaryWriter) { i.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (i *Int8) decodeBinary(r *binaryReader) {
+	var n = i.Null()
+
+	n.decodeBinary(r)
+	*i = NewInt8Null(n)
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (i *Int8) UnmarshalMsgpack(data []byte) error {
+	return setNull(&i.Int8, &i.Valid, (*Null[int8]).UnmarshalMsgpack, data)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (i Int8) MarshalMsgpack() ([]byte, error) { return i.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (i *Int8) UnmarshalCBOR(data []byte) error {
+	return setNull(&i.Int8, &i.Valid, (*Null[int8]).UnmarshalCBOR, data)
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (i Int8) MarshalCBOR() ([]byte, error) { return i.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (i *Int8) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&i.Int8, &i.Valid, (*Null[int8]).UnmarshalYAML, unmarshal)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (i Int8) MarshalYAML() (interface{}, error) { return i.Null().MarshalYAML() }
@@ -0,0 +1,238 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var (
+	int8JSON         = []byte(strconv.FormatInt(math.MaxInt8, 10))
+	int8StringJSON   = []byte(`"` + strconv.FormatInt(math.MaxInt8, 10) + `"`)
+	int8OverflowJSON = []byte(strconv.FormatInt(math.MaxInt8+1, 10))
+)
+
+func isInt8Valid(t *testing.T, i Int8, from string) {
+	dv, _ := i.Value()
+	if dv.(int64) != math.MaxInt8 {
+		t.Errorf("Bad %s int8: \"%d\" ≠ \"%d\"\n", from, dv, int8(math.MaxInt8))
+	}
+	if !i.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isInt8Null(t *testing.T, i Int8, from string) {
+	if i.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewInt8(t *testing.T) {
+	v1 := NewInt8()
+	isInt8Null(t, v1, "NewInt8()")
+
+	v2 := NewInt8Value(math.MaxInt8)
+	isInt8Valid(t, v2, "NewInt8Value()")
+
+	var bv = int8(math.MaxInt8)
+	v3 := NewInt8PointerValue(&bv)
+	isInt8Valid(t, v3, "NewInt8PointerValue()")
+
+	v4 := NewInt8PointerValue(nil)
+	isInt8Null(t, v4, "NewInt8PointerValue()")
+}
+
+func TestInt8SetValidReset(t *testing.T) {
+	v1 := NewInt8()
+	v1.SetValid(math.MaxInt8)
+	isInt8Valid(t, v1, "SetValid()")
+	v1.Reset()
+	isInt8Null(t, v1, "Reset()")
+}
+
+func TestInt8NullIfDefault(t *testing.T) {
+	v1 := NewInt8Value(math.MaxInt8)
+	v1.NullIfDefault()
+	isInt8Valid(t, v1, "NullIfDefault()")
+
+	v1.SetValid(0)
+	v1.NullIfDefault()
+	isInt8Null(t, v1, "NullIfDefault()")
+}
+
+func TestInt8MustValuePointer(t *testing.T) {
+	v1 := NewInt8()
+	if v1.MustValue() != 0 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if v1.Pointer() != nil {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(math.MaxInt8)
+	if v1.MustValue() != math.MaxInt8 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if pb := v1.Pointer(); pb == nil || *pb != math.MaxInt8 {
+		t.Error("Pointer()", "is wrong")
+	}
+}
+
+func TestInt8Scan(t *testing.T) {
+	v1 := NewInt8()
+	errorPanic(v1.Scan(int64(math.MaxInt8)))
+	isInt8Valid(t, v1, "Scan()")
+
+	v2 := NewInt8()
+	errorPanic(v2.Scan(string(int8JSON)))
+	isInt8Valid(t, v2, "Scan()")
+
+	v3 := NewInt8Value(1)
+	errorPanic(v3.Scan(nil))
+	isInt8Null(t, v3, "Scan()")
+
+	v4 := NewInt8()
+	if err := v4.Scan(false); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+
+	v5 := NewInt8()
+	if err := v5.Scan(int64(math.MaxInt8 + 1)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isInt8Null(t, v5, "Scan(overflow)")
+
+	v6 := NewInt8()
+	if err := v6.Scan(int64(math.MinInt8 - 1)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isInt8Null(t, v6, "Scan(overflow)")
+}
+
+func TestInt8Value(t *testing.T) {
+	v1 := NewInt8Value(math.MaxInt8)
+	dv, err := v1.Value()
+	errorPanic(err)
+	if _, ok := dv.(int64); !ok {
+		t.Errorf("%s returns type %q, but should be %q", "Value()", reflect.TypeOf(dv).Name(), "int64")
+	}
+	if !driver.IsValue(dv) {
+		t.Error("Value()", "is not a driver.Value")
+	}
+
+	v2 := NewInt8()
+	dv, err = v2.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+}
+
+func TestInt8UnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewInt8()
+	errorPanic(json.Unmarshal(int8JSON, &v1))
+	isInt8Valid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewInt8()
+	errorPanic(json.Unmarshal(int8StringJSON, &v2))
+	isInt8Valid(t, v2, "UnmarshalJSON()")
+
+	v3 := NewInt8()
+	errorPanic(json.Unmarshal(boolNullJSON, &v3))
+	isInt8Null(t, v3, "UnmarshalJSON(null)")
+
+	v4 := NewInt8()
+	if err = v4.UnmarshalJSON(invalidJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+
+	v5 := NewInt8()
+	if err = json.Unmarshal(int8OverflowJSON, &v5); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt8Null(t, v5, "UnmarshalJSON(overflow)")
+
+	v6 := NewInt8()
+	if err = json.Unmarshal(boolFalseJSON, &v6); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt8Null(t, v6, "UnmarshalJSON()")
+}
+
+func TestInt8MarshalJSON(t *testing.T) {
+	v1 := NewInt8Value(math.MaxInt8)
+	data, err := v1.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, string(int8JSON), "non-empty json marshal")
+
+	v2 := NewInt8()
+	data, err = v2.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestInt8UnmarshalText(t *testing.T) {
+	v1 := NewInt8()
+	errorPanic(v1.UnmarshalText(int8JSON))
+	isInt8Valid(t, v1, "UnmarshalText()")
+
+	v2 := NewInt8()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if v2.Int8 != 0 || !v2.Valid {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewInt8()
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isInt8Null(t, v3, "UnmarshalText()")
+
+	v4 := NewInt8()
+	if err := v4.UnmarshalText(int8OverflowJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt8Null(t, v4, "UnmarshalText(overflow)")
+}
+
+func TestInt8MarshalText(t *testing.T) {
+	v1 := NewInt8Value(math.MaxInt8)
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(int8JSON), "Non-empty text marshal")
+
+	v2 := NewInt8()
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "Null text marshal")
+}
+
+func TestInt8Binary(t *testing.T) {
+	v1 := NewInt8Value(math.MaxInt8)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewInt8()
+	errorPanic(v2.UnmarshalBinary(data))
+	isInt8Valid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewInt8()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewInt8Value(1)
+	errorPanic(v4.UnmarshalBinary(data))
+	isInt8Null(t, v4, "UnmarshalBinary()")
+
+	v5 := NewInt64Value(math.MaxInt8 + 1)
+	data, err = v5.MarshalBinary()
+	errorPanic(err)
+	v6 := NewInt8()
+	if err = v6.UnmarshalBinary(data); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isInt8Null(t, v6, "UnmarshalBinary(overflow)")
+}
@@ -0,0 +1,278 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Interval is an nullable PostgreSQL interval object, for INTERVAL columns
+type Interval struct {
+	Interval Period // Value of object
+	Valid    bool   // Valid is true if value is not NULL
+}
+
+// NewInterval Создание нового объекта Interval
+func NewInterval() Interval {
+	return Interval{
+		Interval: Period{},
+		Valid:    false,
+	}
+}
+
+// NewIntervalValue Создание нового действительного объекта Interval из значения
+func NewIntervalValue(value Period) Interval {
+	return Interval{
+		Interval: value,
+		Valid:    true,
+	}
+}
+
+// NewIntervalPointerValue Создание нового действительного объекта Interval из ссылки на значение
+func NewIntervalPointerValue(ptr *Period) Interval {
+	if ptr == nil {
+		return NewInterval()
+	}
+	return NewIntervalValue(*ptr)
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (iv *Interval) SetValid(value Period) { iv.Interval, iv.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (iv *Interval) Reset() { iv.Interval, iv.Valid = Period{}, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (iv *Interval) NullIfDefault() Interval {
+	if iv.Interval.IsZero() {
+		iv.Reset()
+	}
+	return *iv
+}
+
+// MustValue Возвращает значение в любом случае
+func (iv *Interval) MustValue() Period {
+	if !iv.Valid {
+		return Period{}
+	}
+	return iv.Interval
+}
+
+// Pointer Возвращает ссылку на значение
+func (iv *Interval) Pointer() *Period {
+	if !iv.Valid {
+		return nil
+	}
+	return &iv.Interval
+}
+
+// AddTo Прибавление интервала к моменту времени, если интервал или время равны null, результат равен null
+func (iv Interval) AddTo(t Time) Time {
+	if !iv.Valid || !t.Valid {
+		return NewTime()
+	}
+	return NewTimeValue(iv.Interval.AddTo(t.Time))
+}
+
+// Scan Реализация интерфейса Scanner
+func (iv *Interval) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		iv.Reset()
+		return
+	case []byte:
+		iv.Interval, err = ParsePeriod(string(x))
+	case string:
+		iv.Interval, err = ParsePeriod(x)
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.Interval: %v", x, value)
+	}
+	if iv.Valid = err == nil; !iv.Valid {
+		iv.Interval = Period{}
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+// Значение передаётся в формате ISO 8601, который PostgreSQL принимает при любом стиле вывода
+func (iv Interval) Value() (driver.Value, error) {
+	if !iv.Valid {
+		return nil, nil
+	}
+	return iv.Interval.String(), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (iv *Interval) UnmarshalJSON(data []byte) (err error) {
+	var v interface{}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		iv.Reset()
+		return
+	case string:
+		if len(x) == 0 {
+			iv.Reset()
+			return
+		}
+		iv.Interval, err = ParsePeriod(x)
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.Interval", reflect.TypeOf(v).Kind())
+	}
+	if iv.Valid = err == nil; !iv.Valid {
+		iv.Interval = Period{}
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (iv Interval) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !iv.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data = []byte(`"` + iv.Interval.String() + `"`)
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (iv *Interval) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		iv.Reset()
+		return
+	case emptyString:
+		iv.Interval, iv.Valid = Period{}, true
+		return
+	default:
+		iv.Interval, err = ParsePeriod(str)
+	}
+	if iv.Valid = err == nil; !iv.Valid {
+		iv.Interval = Period{}
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (iv Interval) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !iv.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(iv.Interval.String())
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (iv *Interval) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return iv.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagInterval, iv)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (iv Interval) MarshalBinary() (data []byte, err error) {
+	return marshalBinary(binaryTagInterval, iv)
+}
+
+// Запись значения в компактном двоичном формате
+func (iv Interval) encodeBinary(w *binaryWriter) {
+	if w.flags(iv.Valid); !iv.Valid {
+		return
+	}
+	w.varint(int64(iv.Interval.Months))
+	w.varint(int64(iv.Interval.Days))
+	w.varint(iv.Interval.Microseconds)
+}
+
+// Чтение значения в компактном двоичном формате
+func (iv *Interval) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		value Period
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			iv.Reset()
+		}
+		return
+	}
+	value.Months, value.Days = int32(r.intN(32)), int32(r.intN(32))
+	if value.Microseconds = r.intN(64); r.err == nil {
+		iv.Interval, iv.Valid = value, valid
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (iv *Interval) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.IntervalWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.IntervalWrapper)
+	if err = dec.Decode(item); err == nil {
+		iv.Interval = Period{Months: item.Months, Days: item.Days, Microseconds: item.Microseconds}
+		iv.Valid = item.Valid
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (iv *Interval) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, iv) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой текстового представления
+func (iv Interval) MarshalMsgpack() ([]byte, error) { return marshalMsgpackText(iv.Valid, iv) }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (iv *Interval) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, iv) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой текстового представления
+func (iv Interval) MarshalCBOR() ([]byte, error) { return marshalCBORText(iv.Valid, iv) }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (iv *Interval) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, iv)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (iv Interval) MarshalYAML() (interface{}, error) { return marshalYAMLText(iv.Valid, iv) }
@@ -0,0 +1,173 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	intervalValue    = Period{Months: 14, Days: 3, Microseconds: 14706500000}
+	intervalString   = "P1Y2M3DT4H5M6.5S"
+	intervalPostgres = "1 year 2 mons 3 days 04:05:06.5"
+	intervalJSON     = []byte(`"P1Y2M3DT4H5M6.5S"`)
+)
+
+func isIntervalValid(t *testing.T, iv Interval, from string) {
+	if iv.Interval != intervalValue {
+		t.Errorf("Bad %s interval: %v ≠ %v\n", from, iv.Interval, intervalValue)
+	}
+	if !iv.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isIntervalNull(t *testing.T, iv Interval, from string) {
+	if iv.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewInterval(t *testing.T) {
+	v1 := NewInterval()
+	isIntervalNull(t, v1, "NewInterval()")
+
+	v2 := NewIntervalValue(intervalValue)
+	isIntervalValid(t, v2, "NewIntervalValue()")
+
+	v3 := NewIntervalPointerValue(nil)
+	isIntervalNull(t, v3, "NewIntervalPointerValue()")
+
+	v4 := NewIntervalPointerValue(v2.Pointer())
+	isIntervalValid(t, v4, "NewIntervalPointerValue()")
+}
+
+func TestIntervalSetValidReset(t *testing.T) {
+	v1 := NewInterval()
+	if v1.Pointer() != nil || !v1.MustValue().IsZero() {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(intervalValue)
+	isIntervalValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isIntervalValid(t, v1, "NullIfDefault()")
+	v1.SetValid(Period{})
+	v1.NullIfDefault()
+	isIntervalNull(t, v1, "NullIfDefault()")
+}
+
+func TestIntervalAddTo(t *testing.T) {
+	tm := NewTimeValue(time.Date(2023, time.January, 31, 10, 0, 0, 0, time.UTC))
+
+	v1 := NewIntervalValue(Period{Months: 1})
+	if r := v1.AddTo(tm); !r.Valid || !r.Time.Equal(time.Date(2023, time.February, 28, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("AddTo() is %v, but should be 2023-02-28", r.Time)
+	}
+	if r := NewInterval().AddTo(tm); r.Valid {
+		t.Error("AddTo()", "is valid, but should be invalid")
+	}
+	if r := v1.AddTo(NewTime()); r.Valid {
+		t.Error("AddTo()", "is valid, but should be invalid")
+	}
+}
+
+func TestIntervalScanValue(t *testing.T) {
+	var v Interval
+
+	for _, in := range []interface{}{intervalPostgres, []byte(intervalString), "@ 1 year 2 mons 3 days 4 hours 5 mins 6.5 secs", "1-2 3 4:05:06.5"} {
+		errorPanic(v.Scan(in))
+		isIntervalValid(t, v, "Scan()")
+	}
+	val, err := v.Value()
+	errorPanic(err)
+	if val != intervalString {
+		t.Errorf("Value() is %v, but should be %v", val, intervalString)
+	}
+
+	errorPanic(v.Scan(nil))
+	isIntervalNull(t, v, "Scan(nil)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != nil {
+		t.Errorf("Value() is %v, but should be nil", val)
+	}
+
+	if err = v.Scan("1 fortnight"); err == nil {
+		t.Error("Scan()", "error is nil, but should be not nil")
+	}
+	isIntervalNull(t, v, "Scan(error)")
+	if err = v.Scan(int64(1)); err == nil {
+		t.Error("Scan(int64)", "error is nil, but should be not nil")
+	}
+}
+
+func TestIntervalUnmarshalJSON(t *testing.T) {
+	var v Interval
+
+	errorPanic(json.Unmarshal(intervalJSON, &v))
+	isIntervalValid(t, v, "UnmarshalJSON()")
+
+	errorPanic(json.Unmarshal([]byte(`"`+intervalPostgres+`"`), &v))
+	isIntervalValid(t, v, "UnmarshalJSON(postgres)")
+
+	errorPanic(json.Unmarshal([]byte(`null`), &v))
+	isIntervalNull(t, v, "UnmarshalJSON(null)")
+
+	errorPanic(json.Unmarshal([]byte(`""`), &v))
+	isIntervalNull(t, v, "UnmarshalJSON(empty)")
+
+	for _, in := range []string{`1`, `"P1"`, `{}`} {
+		if err := json.Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("UnmarshalJSON(%s) error is nil, but should be not nil", in)
+		}
+		isIntervalNull(t, v, "UnmarshalJSON(error)")
+	}
+}
+
+func TestIntervalMarshalJSON(t *testing.T) {
+	v1 := NewIntervalValue(intervalValue)
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(intervalJSON), "non-empty json marshal")
+
+	v2 := NewInterval()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestIntervalText(t *testing.T) {
+	var v Interval
+
+	errorPanic(v.UnmarshalText([]byte(intervalString)))
+	isIntervalValid(t, v, "UnmarshalText()")
+	data, err := v.MarshalText()
+	errorPanic(err)
+	if string(data) != intervalString {
+		t.Errorf("MarshalText() is %s, but should be %s", data, intervalString)
+	}
+
+	errorPanic(v.UnmarshalText([]byte("")))
+	if !v.Valid || !v.Interval.IsZero() {
+		t.Error("UnmarshalText()", "of empty text is wrong")
+	}
+
+	errorPanic(v.UnmarshalText([]byte("null")))
+	isIntervalNull(t, v, "UnmarshalText(null)")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != "null" {
+		t.Errorf("MarshalText() is %s, but should be null", data)
+	}
+}
+
+func TestIntervalBinary(t *testing.T) {
+	v1 := NewIntervalValue(intervalValue)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewInterval()
+	errorPanic(v2.UnmarshalBinary(data))
+	isIntervalValid(t, v2, "UnmarshalBinary()")
+}
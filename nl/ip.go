@@ -0,0 +1,205 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// IP is an nullable netip.Addr object, for inet columns
+type IP struct {
+	IP    netip.Addr // Value of object
+	Valid bool       // Valid is true if value is not NULL
+}
+
+// NewIP Создание нового объекта IP
+func NewIP() IP {
+	return IP{
+		IP:    netip.Addr{},
+		Valid: false,
+	}
+}
+
+// NewIPValue Создание нового действительного объекта IP из значения
+func NewIPValue(value netip.Addr) IP {
+	return IP{
+		IP:    value,
+		Valid: true,
+	}
+}
+
+// NewIPPointerValue Создание нового действительного объекта IP из ссылки на значение
+func NewIPPointerValue(ptr *netip.Addr) IP {
+	if ptr == nil {
+		return NewIP()
+	}
+	return NewIPValue(*ptr)
+}
+
+// NewIPNull Создание нового объекта IP из обобщённого объекта Null
+func NewIPNull(n Null[netip.Addr]) IP {
+	return IP{
+		IP:    n.V,
+		Valid: n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (ip IP) Null() Null[netip.Addr] { return Null[netip.Addr]{V: ip.IP, Valid: ip.Valid} }
+
+// ParseIP Разбор IP адреса, длина префикса сети допускается только для адреса узла "192.168.0.1/32"
+// Адрес с длиной префикса сети "192.168.0.1/24" из колонки inet является ошибкой, так как длина префикса
+// была бы потеряна при записи значения, для таких значений используется тип Prefix
+func ParseIP(str string) (ret netip.Addr, err error) {
+	var prefix netip.Prefix
+
+	if strings.IndexByte(str, '/') < 0 {
+		ret, err = netip.ParseAddr(str)
+		return
+	}
+	if prefix, err = netip.ParsePrefix(str); err != nil {
+		return
+	}
+	if prefix.Bits() != prefix.Addr().BitLen() {
+		err = fmt.Errorf("can't parse %q as IP: network prefix length is lost, use nul.Prefix", str)
+		return
+	}
+	ret = prefix.Addr()
+
+	return
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (ip *IP) SetValid(value netip.Addr) { ip.IP, ip.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (ip *IP) Reset() { ip.IP, ip.Valid = netip.Addr{}, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (ip *IP) NullIfDefault() IP {
+	if !ip.IP.IsValid() {
+		ip.Reset()
+	}
+	return *ip
+}
+
+// MustValue Возвращает значение в любом случае
+func (ip *IP) MustValue() netip.Addr {
+	if !ip.Valid {
+		return netip.Addr{}
+	}
+	return ip.IP
+}
+
+// Pointer Возвращает ссылку на значение
+func (ip *IP) Pointer() *netip.Addr {
+	if !ip.Valid {
+		return nil
+	}
+	return &ip.IP
+}
+
+// Scan Реализация интерфейса Scanner
+func (ip *IP) Scan(value interface{}) error {
+	return setNull(&ip.IP, &ip.Valid, nullParser[netip.Addr](ParseIP).scan, value)
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (ip IP) Value() (driver.Value, error) {
+	if !ip.Valid {
+		return nil, nil
+	}
+	return ip.IP.String(), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение допускается только в виде строки, пустая строка является null
+func (ip *IP) UnmarshalJSON(data []byte) error {
+	return setNull(&ip.IP, &ip.Valid, nullParser[netip.Addr](ParseIP).unmarshalJSON, data)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (ip IP) MarshalJSON() ([]byte, error) { return ip.Null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (ip *IP) UnmarshalText(text []byte) error {
+	return setNull(&ip.IP, &ip.Valid, nullParser[netip.Addr](ParseIP).unmarshalText, text)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (ip IP) MarshalText() ([]byte, error) { return ip.Null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (ip *IP) UnmarshalBinary(data []byte) error {
+	if isGobBinary(data) {
+		return ip.unmarshalGob(data)
+	}
+	return setNull(&ip.IP, &ip.Valid, unmarshalNullBinary[netip.Addr](binaryTagIP), data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (ip IP) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagIP, ip.Null()) }
+
+// Запись значения в компактном двоичном формате
+func (ip IP) encodeBinary(w *binaryWriter) { ip.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (ip *IP) decodeBinary(r *binaryReader) {
+	var n = ip.Null()
+
+	n.decodeBinary(r)
+	*ip = NewIPNull(n)
+}
+
+// Разбор значения в формате gob предыдущих версий, значение хранится в обёртке в двоичном формате netip.Addr
+func (ip *IP) unmarshalGob(data []byte) (err error) {
+	var (
+		item  *wrapper.IPWrapper
+		value netip.Addr
+	)
+
+	item = new(wrapper.IPWrapper)
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(item); err != nil {
+		return
+	}
+	if err = value.UnmarshalBinary(item.Value); err == nil {
+		ip.IP, ip.Valid = value, item.Valid
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (ip *IP) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, ip) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой текстового представления
+func (ip IP) MarshalMsgpack() ([]byte, error) { return ip.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (ip *IP) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, ip) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой текстового представления
+func (ip IP) MarshalCBOR() ([]byte, error) { return ip.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (ip *IP) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, ip)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (ip IP) MarshalYAML() (interface{}, error) { return marshalYAMLText(ip.Valid, ip) }
@@ -0,0 +1,204 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+)
+
+var (
+	ipValue  = netip.MustParseAddr("2001:db8::1")
+	ipString = "2001:db8::1"
+	ipJSON   = []byte(`"2001:db8::1"`)
+)
+
+func isIPValid(t *testing.T, ip IP, from string) {
+	if ip.IP != ipValue {
+		t.Errorf("Bad %s IP: %v ≠ %v\n", from, ip.IP, ipValue)
+	}
+	if !ip.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isIPNull(t *testing.T, ip IP, from string) {
+	if ip.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestParseIP(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out netip.Addr
+	}{
+		{"192.168.0.1", netip.AddrFrom4([4]byte{192, 168, 0, 1})},
+		{"192.168.0.1/32", netip.AddrFrom4([4]byte{192, 168, 0, 1})},
+		{"2001:DB8::1", ipValue},
+		{"2001:db8::1/128", ipValue},
+		{"fe80::1%eth0", netip.MustParseAddr("fe80::1%eth0")},
+	}
+
+	for _, test := range tests {
+		ip, err := ParseIP(test.In)
+		errorPanic(err)
+		if ip != test.Out {
+			t.Errorf("ParseIP(%q) is %v, but should be %v", test.In, ip, test.Out)
+		}
+	}
+	for _, in := range []string{"", "192.168.0", "192.168.0.256", "192.168.0.1/33", "192.168.0.1/24", "2001:db8::1/64", "2001:db8::g", "host"} {
+		if _, err := ParseIP(in); err == nil {
+			t.Errorf("ParseIP(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestNewIP(t *testing.T) {
+	v1 := NewIP()
+	isIPNull(t, v1, "NewIP()")
+
+	v2 := NewIPValue(ipValue)
+	isIPValid(t, v2, "NewIPValue()")
+
+	v3 := NewIPPointerValue(nil)
+	isIPNull(t, v3, "NewIPPointerValue()")
+
+	v4 := NewIPPointerValue(v2.Pointer())
+	isIPValid(t, v4, "NewIPPointerValue()")
+}
+
+func TestIPSetValidReset(t *testing.T) {
+	v1 := NewIP()
+	if v1.Pointer() != nil || v1.MustValue().IsValid() {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(ipValue)
+	isIPValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isIPValid(t, v1, "NullIfDefault()")
+	v1.SetValid(netip.Addr{})
+	v1.NullIfDefault()
+	isIPNull(t, v1, "NullIfDefault()")
+}
+
+func TestIPScanValue(t *testing.T) {
+	v1 := NewIP()
+	errorPanic(v1.Scan([]byte("2001:db8::1/128")))
+	isIPValid(t, v1, "Scan([]byte)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != ipString {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewIP()
+	errorPanic(v2.Scan(ipString))
+	isIPValid(t, v2, "Scan(string)")
+
+	v3 := NewIPValue(ipValue)
+	errorPanic(v3.Scan(nil))
+	isIPNull(t, v3, "Scan(nil)")
+	dv, err = v3.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v4 := NewIP()
+	if err = v4.Scan(int64(1)); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v4.Scan("localhost"); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v4.Scan("192.168.1.5/24"); err == nil {
+		t.Error("Scan(192.168.1.5/24)", "is nil, but should be not nil")
+	}
+	isIPNull(t, v4, "Scan()")
+}
+
+func TestIPUnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewIP()
+	errorPanic(json.Unmarshal(ipJSON, &v1))
+	isIPValid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewIPValue(ipValue)
+	errorPanic(json.Unmarshal(boolNullJSON, &v2))
+	isIPNull(t, v2, "UnmarshalJSON(null)")
+
+	v3 := NewIP()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v3))
+	isIPNull(t, v3, "UnmarshalJSON(blank)")
+
+	v4 := NewIP()
+	if err = json.Unmarshal(int64JSON, &v4); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = v4.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isIPNull(t, v4, "UnmarshalJSON()")
+}
+
+func TestIPMarshalJSON(t *testing.T) {
+	v1 := NewIPValue(ipValue)
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(ipJSON), "MarshalJSON()")
+
+	v2 := NewIP()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+}
+
+func TestIPText(t *testing.T) {
+	v1 := NewIP()
+	errorPanic(v1.UnmarshalText([]byte(ipString)))
+	isIPValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, ipString, "MarshalText()")
+
+	v2 := NewIP()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || v2.IP.IsValid() {
+		t.Errorf("Value should be valid")
+	}
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "", "MarshalText(empty)")
+
+	v3 := NewIPValue(ipValue)
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isIPNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewIP()
+	if err = v4.UnmarshalText([]byte("::g")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isIPNull(t, v4, "UnmarshalText()")
+}
+
+func TestIPBinary(t *testing.T) {
+	v1 := NewIPValue(ipValue)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewIP()
+	errorPanic(v2.UnmarshalBinary(data))
+	isIPValid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewIP()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewIPValue(ipValue)
+	errorPanic(v4.UnmarshalBinary(data))
+	isIPNull(t, v4, "UnmarshalBinary()")
+}
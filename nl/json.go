@@ -0,0 +1,336 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+var jsonNull = json.RawMessage(`null`)
+
+// JSON is an nullable raw JSON document object
+// Значение SQL NULL (Valid=false) отличается от документа JSON null (Valid=true, JSON="null")
+type JSON struct {
+	JSON  json.RawMessage // Value of object
+	Valid bool            // Valid is true if value is not NULL
+}
+
+// NewJSON Создание нового объекта JSON
+func NewJSON() JSON {
+	return JSON{
+		JSON:  nil,
+		Valid: false,
+	}
+}
+
+// NewJSONValue Создание нового действительного объекта JSON из значения, значение копируется
+// Значение не проверяется, для проверки используйте функцию json.Valid
+func NewJSONValue(value json.RawMessage) JSON {
+	return JSON{
+		JSON:  append(json.RawMessage{}, value...),
+		Valid: true,
+	}
+}
+
+// NewJSONPointerValue Создание нового действительного объекта JSON из ссылки на значение
+func NewJSONPointerValue(ptr *json.RawMessage) JSON {
+	if ptr == nil {
+		return NewJSON()
+	}
+	return NewJSONValue(*ptr)
+}
+
+// NewJSONMarshal Создание нового действительного объекта JSON из JSON представления значения v
+func NewJSONMarshal(v interface{}) (ret JSON, err error) {
+	var buf []byte
+
+	if buf, err = json.Marshal(v); err != nil {
+		return
+	}
+	ret = JSON{JSON: buf, Valid: true}
+
+	return
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (j *JSON) SetValid(value json.RawMessage) {
+	j.JSON, j.Valid = append(json.RawMessage{}, value...), true
+}
+
+// Reset Сброс значения и установка флага не действительного значения
+func (j *JSON) Reset() { j.JSON, j.Valid = nil, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (j *JSON) NullIfDefault() JSON {
+	if len(j.JSON) == 0 {
+		j.Reset()
+	}
+	return *j
+}
+
+// MustValue Возвращает значение в любом случае
+func (j *JSON) MustValue() json.RawMessage {
+	if !j.Valid {
+		return json.RawMessage{}
+	}
+	return j.JSON
+}
+
+// Pointer Возвращает ссылку на значение
+func (j *JSON) Pointer() *json.RawMessage {
+	if !j.Valid {
+		return nil
+	}
+	return &j.JSON
+}
+
+// IsNull Возвращает истину, если значение является документом JSON null
+func (j JSON) IsNull() bool { return j.Valid && bytes.Equal(bytes.TrimSpace(j.JSON), jsonNull) }
+
+// Decode Декодирование документа в значение v
+// Значение SQL NULL декодируется как документ JSON null
+func (j JSON) Decode(v interface{}) error {
+	if !j.Valid || len(j.JSON) == 0 {
+		return json.Unmarshal(jsonNull, v)
+	}
+	return json.Unmarshal(j.JSON, v)
+}
+
+// Проверка и копирование документа
+func (j *JSON) parse(data []byte) (err error) {
+	if !json.Valid(data) {
+		err = fmt.Errorf("can't parse %q as JSON: invalid document", data)
+		return
+	}
+	j.JSON = append(json.RawMessage{}, data...)
+
+	return
+}
+
+// Scan Реализация интерфейса Scanner
+func (j *JSON) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		j.Reset()
+		return
+	case []byte:
+		err = j.parse(x)
+	case string:
+		err = j.parse([]byte(x))
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.JSON: %v", x, value)
+	}
+	if j.Valid = err == nil; !j.Valid {
+		j.JSON = nil
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+// Документ передаётся строкой, так как часть драйверов передаёт []byte как bytea
+func (j JSON) Value() (driver.Value, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	if len(j.JSON) == 0 {
+		return string(jsonNull), nil
+	}
+	return string(j.JSON), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Документ null сохраняется как действительное значение JSON null
+func (j *JSON) UnmarshalJSON(data []byte) (err error) {
+	if err = j.parse(data); err != nil {
+		j.Reset()
+		return
+	}
+	j.Valid = true
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+// Значение SQL NULL и пустой документ представляются как null
+func (j JSON) MarshalJSON() (data []byte, err error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		data = []byte(jsonNull)
+		return
+	}
+	data = j.JSON
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+// Пустой текст соответствует значению SQL NULL
+func (j *JSON) UnmarshalText(text []byte) (err error) {
+	if len(text) == 0 {
+		j.Reset()
+		return
+	}
+	if err = j.parse(text); err != nil {
+		j.Reset()
+		return
+	}
+	j.Valid = true
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (j JSON) MarshalText() (text []byte, err error) {
+	const emptyString = ""
+
+	if !j.Valid {
+		text = []byte(emptyString)
+		return
+	}
+	if len(j.JSON) == 0 {
+		text = []byte(jsonNull)
+		return
+	}
+	text = j.JSON
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (j *JSON) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return j.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagJSON, j)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (j JSON) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagJSON, j) }
+
+// Запись значения в компактном двоичном формате
+func (j JSON) encodeBinary(w *binaryWriter) {
+	if w.flags(j.Valid); !j.Valid {
+		return
+	}
+	w.bytes(j.JSON)
+}
+
+// Чтение значения в компактном двоичном формате
+func (j *JSON) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		value json.RawMessage
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			j.Reset()
+		}
+		return
+	}
+	if value = r.bytes(); r.err == nil {
+		j.JSON, j.Valid = value, valid
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (j *JSON) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.JSONWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.JSONWrapper)
+	if err = dec.Decode(item); err == nil {
+		j.JSON, j.Valid = item.Value, item.Valid
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значения MessagePack преобразуются в документ JSON, карты в объекты, массивы в массивы
+func (j *JSON) UnmarshalMsgpack(data []byte) (err error) {
+	var buf []byte
+
+	if msgpack.IsNil(data) {
+		j.Reset()
+		return msgpackEnd(msgpack.ReadNil(data))
+	}
+	if buf, err = msgpackJSON(data); err == nil {
+		j.JSON, j.Valid = buf, true
+	}
+
+	return
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Документ JSON кодируется значениями MessagePack, объекты картами, массивы массивами
+func (j JSON) MarshalMsgpack() ([]byte, error) {
+	if !j.Valid {
+		return msgpack.AppendNil(nil), nil
+	}
+	return appendMsgpackJSON(nil, j.JSON)
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значения CBOR преобразуются в документ JSON, карты в объекты, массивы в массивы
+func (j *JSON) UnmarshalCBOR(data []byte) (err error) {
+	var buf []byte
+
+	if cbor.IsNull(data) {
+		j.Reset()
+		return cborEnd(cbor.ReadNull(data))
+	}
+	if buf, err = cborJSON(data); err == nil {
+		j.JSON, j.Valid = buf, true
+	}
+
+	return
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Документ JSON кодируется значениями CBOR, объекты картами, массивы массивами
+func (j JSON) MarshalCBOR() ([]byte, error) {
+	if !j.Valid {
+		return cbor.AppendNull(nil), nil
+	}
+	return appendCBORJSON(nil, j.JSON)
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение YAML преобразуется в документ JSON, карты в объекты, последовательности в массивы
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (j *JSON) UnmarshalYAML(unmarshal func(interface{}) error) (err error) {
+	var (
+		data []byte
+		null bool
+	)
+
+	if data, null, err = yamlJSON(unmarshal); err == nil {
+		j.JSON, j.Valid = data, !null
+	}
+
+	return
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Документ JSON кодируется значениями YAML, объекты картами, массивы последовательностями
+func (j JSON) MarshalYAML() (interface{}, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	return yamlFromJSON(j.JSON)
+}
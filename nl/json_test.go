@@ -0,0 +1,241 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"testing"
+)
+
+var jsonDocument = json.RawMessage(`{"name":"test","items":[1,2,3],"nested":{"ok":true}}`)
+
+type jsonTestDocument struct {
+	Name   string `json:"name"`
+	Items  []int  `json:"items"`
+	Nested struct {
+		Ok bool `json:"ok"`
+	} `json:"nested"`
+}
+
+func isJSONValid(t *testing.T, j JSON, value json.RawMessage, from string) {
+	if string(j.JSON) != string(value) {
+		t.Errorf("Bad %s JSON: %s ≠ %s\n", from, j.JSON, value)
+	}
+	if !j.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isJSONNull(t *testing.T, j JSON, from string) {
+	if j.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewJSON(t *testing.T) {
+	v1 := NewJSON()
+	isJSONNull(t, v1, "NewJSON()")
+
+	v2 := NewJSONValue(jsonDocument)
+	isJSONValid(t, v2, jsonDocument, "NewJSONValue()")
+	v2.JSON[0] = '['
+	if jsonDocument[0] != '{' {
+		t.Error("NewJSONValue()", "shares memory with the source")
+	}
+
+	v3 := NewJSONPointerValue(nil)
+	isJSONNull(t, v3, "NewJSONPointerValue()")
+
+	v4 := NewJSONPointerValue(&jsonDocument)
+	isJSONValid(t, v4, jsonDocument, "NewJSONPointerValue()")
+
+	v5, err := NewJSONMarshal(map[string]int{"a": 1})
+	errorPanic(err)
+	isJSONValid(t, v5, json.RawMessage(`{"a":1}`), "NewJSONMarshal()")
+
+	if _, err = NewJSONMarshal(func() {}); err == nil {
+		t.Error("NewJSONMarshal()", "is nil, but should be not nil")
+	}
+}
+
+func TestJSONSetValidReset(t *testing.T) {
+	v1 := NewJSON()
+	if v1.Pointer() != nil || len(v1.MustValue()) != 0 {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(jsonDocument)
+	isJSONValid(t, v1, jsonDocument, "SetValid()")
+	if p := v1.Pointer(); p == nil || string(*p) != string(jsonDocument) {
+		t.Error("Pointer()", "is wrong")
+	}
+	v1.NullIfDefault()
+	isJSONValid(t, v1, jsonDocument, "NullIfDefault()")
+	v1.SetValid(nil)
+	v1.NullIfDefault()
+	isJSONNull(t, v1, "NullIfDefault()")
+}
+
+func TestJSONDecode(t *testing.T) {
+	var (
+		doc *jsonTestDocument
+		m   map[string]interface{}
+	)
+
+	v1 := NewJSONValue(jsonDocument)
+	errorPanic(v1.Decode(&doc))
+	if doc == nil || doc.Name != "test" || len(doc.Items) != 3 || !doc.Nested.Ok {
+		t.Error("Decode()", "is wrong")
+	}
+
+	v2 := NewJSON()
+	errorPanic(v2.Decode(&doc))
+	if doc != nil {
+		t.Error("Decode(SQL NULL)", "is wrong")
+	}
+
+	v3 := NewJSONValue(json.RawMessage(`[1]`))
+	if err := v3.Decode(&m); err == nil {
+		t.Error("Decode()", "is nil, but should be not nil")
+	}
+}
+
+func TestJSONScanValue(t *testing.T) {
+	v1 := NewJSON()
+	errorPanic(v1.Scan([]byte(jsonDocument)))
+	isJSONValid(t, v1, jsonDocument, "Scan([]byte)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != string(jsonDocument) {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewJSON()
+	errorPanic(v2.Scan(string(jsonDocument)))
+	isJSONValid(t, v2, jsonDocument, "Scan(string)")
+
+	v3 := NewJSON()
+	errorPanic(v3.Scan("null"))
+	if !v3.Valid || !v3.IsNull() {
+		t.Error("Scan(JSON null)", "is wrong")
+	}
+	dv, err = v3.Value()
+	errorPanic(err)
+	if dv.(string) != "null" {
+		t.Error("Value(JSON null)", "is wrong")
+	}
+
+	v4 := NewJSONValue(jsonDocument)
+	errorPanic(v4.Scan(nil))
+	isJSONNull(t, v4, "Scan(nil)")
+	if v4.IsNull() {
+		t.Error("IsNull()", "is true, but should be false")
+	}
+	dv, err = v4.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v5 := NewJSON()
+	if err = v5.Scan(invalidJSON); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v5.Scan(int64(1)); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isJSONNull(t, v5, "Scan()")
+
+	buf := []byte(`[1,2]`)
+	v6 := NewJSON()
+	errorPanic(v6.Scan(buf))
+	buf[1] = '3'
+	isJSONValid(t, v6, json.RawMessage(`[1,2]`), "Scan([]byte)")
+}
+
+func TestJSONUnmarshalJSON(t *testing.T) {
+	var (
+		err error
+		doc struct {
+			Payload JSON `json:"payload"`
+			Missing JSON `json:"missing"`
+		}
+	)
+
+	errorPanic(json.Unmarshal([]byte(`{"payload":`+string(jsonDocument)+`}`), &doc))
+	isJSONValid(t, doc.Payload, jsonDocument, "UnmarshalJSON()")
+	isJSONNull(t, doc.Missing, "UnmarshalJSON(missing)")
+
+	errorPanic(json.Unmarshal([]byte(`{"payload":null}`), &doc))
+	if !doc.Payload.Valid || !doc.Payload.IsNull() {
+		t.Error("UnmarshalJSON(null)", "is wrong")
+	}
+
+	v1 := NewJSONValue(jsonDocument)
+	if err = v1.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isJSONNull(t, v1, "UnmarshalJSON()")
+}
+
+func TestJSONMarshalJSON(t *testing.T) {
+	v1 := struct {
+		Payload JSON `json:"payload"`
+	}{NewJSONValue(jsonDocument)}
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, `{"payload":`+string(jsonDocument)+`}`, "MarshalJSON()")
+
+	v2 := NewJSON()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+
+	v3 := NewJSONValue(nil)
+	data, err = json.Marshal(v3)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(empty)")
+}
+
+func TestJSONText(t *testing.T) {
+	v1 := NewJSON()
+	errorPanic(v1.UnmarshalText(jsonDocument))
+	isJSONValid(t, v1, jsonDocument, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(jsonDocument), "MarshalText()")
+
+	v2 := NewJSONValue(jsonDocument)
+	errorPanic(v2.UnmarshalText([]byte("")))
+	isJSONNull(t, v2, "UnmarshalText(empty)")
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "", "MarshalText(SQL NULL)")
+
+	v3 := NewJSON()
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	if !v3.IsNull() {
+		t.Error("UnmarshalText(null)", "is wrong")
+	}
+
+	v4 := NewJSON()
+	if err = v4.UnmarshalText(invalidJSON); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isJSONNull(t, v4, "UnmarshalText()")
+}
+
+func TestJSONBinary(t *testing.T) {
+	v1 := NewJSONValue(jsonDocument)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewJSON()
+	errorPanic(v2.UnmarshalBinary(data))
+	isJSONValid(t, v2, jsonDocument, "UnmarshalBinary()")
+
+	v3 := NewJSON()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewJSONValue(jsonDocument)
+	errorPanic(v4.UnmarshalBinary(data))
+	isJSONNull(t, v4, "UnmarshalBinary()")
+}
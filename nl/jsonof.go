@@ -0,0 +1,389 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// JSONOf is an nullable object of any type stored as JSON document, for json, jsonb and JSON columns
+// Значение SQL NULL (Valid=false) отличается от документа JSON null, который декодируется в нулевое значение T
+type JSONOf[T any] struct {
+	V     T    // Value of object
+	Valid bool // Valid is true if value is not NULL
+}
+
+// NewJSONOf Создание нового не действительного объекта JSONOf
+func NewJSONOf[T any]() JSONOf[T] {
+	return JSONOf[T]{
+		Valid: false,
+	}
+}
+
+// NewJSONOfValue Создание нового действительного объекта JSONOf из значения
+func NewJSONOfValue[T any](value T) JSONOf[T] {
+	return JSONOf[T]{
+		V:     value,
+		Valid: true,
+	}
+}
+
+// NewJSONOfPointerValue Создание нового действительного объекта JSONOf из ссылки на значение
+func NewJSONOfPointerValue[T any](ptr *T) JSONOf[T] {
+	if ptr == nil {
+		return NewJSONOf[T]()
+	}
+	return NewJSONOfValue(*ptr)
+}
+
+// NewJSONOfNull Создание нового объекта JSONOf из обобщённого объекта Null
+func NewJSONOfNull[T any](n Null[T]) JSONOf[T] {
+	return JSONOf[T]{V: n.V, Valid: n.Valid}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (j JSONOf[T]) Null() Null[T] { return Null[T]{V: j.V, Valid: j.Valid} }
+
+// SetValid Изменение значения и установка флага действительного значения
+func (j *JSONOf[T]) SetValid(value T) { j.V, j.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (j *JSONOf[T]) Reset() {
+	var zero T
+	j.V, j.Valid = zero, false
+}
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (j *JSONOf[T]) NullIfDefault() JSONOf[T] {
+	if isDefaultValue(reflect.ValueOf(&j.V).Elem()) {
+		j.Reset()
+	}
+	return *j
+}
+
+// MustValue Возвращает значение в любом случае
+func (j *JSONOf[T]) MustValue() T {
+	var zero T
+	if !j.Valid {
+		return zero
+	}
+	return j.V
+}
+
+// Pointer Возвращает ссылку на значение
+func (j *JSONOf[T]) Pointer() *T {
+	if !j.Valid {
+		return nil
+	}
+	return &j.V
+}
+
+// Декодирование документа в новое значение
+func (j *JSONOf[T]) decode(data []byte) (err error) {
+	var value T
+
+	if err = json.Unmarshal(data, &value); err != nil {
+		err = fmt.Errorf("can't decode JSON into nul.JSONOf[%s]: %s", reflect.TypeOf(&value).Elem(), err)
+		return
+	}
+	j.V = value
+
+	return
+}
+
+// Scan Реализация интерфейса Scanner
+func (j *JSONOf[T]) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		j.Reset()
+		return
+	case []byte:
+		err = j.decode(x)
+	case string:
+		err = j.decode([]byte(x))
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.JSONOf[%s]: %v", x, reflect.TypeOf(&j.V).Elem(), value)
+	}
+	if j.Valid = err == nil; !j.Valid {
+		j.Reset()
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+// Документ передаётся строкой, так как часть драйверов передаёт []byte как bytea
+func (j JSONOf[T]) Value() (driver.Value, error) {
+	var (
+		buf []byte
+		err error
+	)
+
+	if !j.Valid {
+		return nil, nil
+	}
+	if buf, err = json.Marshal(j.V); err != nil {
+		return nil, err
+	}
+
+	return string(buf), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (j *JSONOf[T]) UnmarshalJSON(data []byte) (err error) {
+	const nullString = "null"
+
+	if string(bytes.TrimSpace(data)) == nullString {
+		j.Reset()
+		return
+	}
+	if err = j.decode(data); err != nil {
+		j.Reset()
+		return
+	}
+	j.Valid = true
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (j JSONOf[T]) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !j.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data, err = json.Marshal(j.V)
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (j *JSONOf[T]) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	switch string(text) {
+	case nullString:
+		j.Reset()
+		return
+	case emptyString:
+		j.Reset()
+		j.Valid = true
+		return
+	default:
+		err = j.decode(text)
+	}
+	if j.Valid = err == nil; !j.Valid {
+		j.Reset()
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (j JSONOf[T]) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !j.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text, err = json.Marshal(j.V)
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (j *JSONOf[T]) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return j.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagJSONOf, j)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+// Значение сохраняется в виде JSON документа, поэтому T не обязан поддерживать двоичное кодирование
+func (j JSONOf[T]) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagJSONOf, j) }
+
+// Запись значения в компактном двоичном формате
+func (j JSONOf[T]) encodeBinary(w *binaryWriter) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if j.Valid {
+		data, err = json.Marshal(j.V)
+		w.fail(err)
+	}
+	if w.flags(j.Valid); !j.Valid {
+		return
+	}
+	w.bytes(data)
+}
+
+// Чтение значения в компактном двоичном формате
+func (j *JSONOf[T]) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		data  []byte
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			j.Reset()
+		}
+		return
+	}
+	if data = r.bytes(); r.err != nil {
+		return
+	}
+	if r.fail(j.decode(data)); r.err == nil {
+		j.Valid = true
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (j *JSONOf[T]) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.JSONWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.JSONWrapper)
+	if err = dec.Decode(item); err != nil {
+		return
+	}
+	if !item.Valid {
+		j.Reset()
+		return
+	}
+	if err = j.decode(item.Value); err == nil {
+		j.Valid = true
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значения MessagePack преобразуются в документ JSON, который декодируется в значение
+func (j *JSONOf[T]) UnmarshalMsgpack(data []byte) (err error) {
+	var buf []byte
+
+	if msgpack.IsNil(data) {
+		j.Reset()
+		return msgpackEnd(msgpack.ReadNil(data))
+	}
+	if buf, err = msgpackJSON(data); err == nil {
+		if err = j.decode(buf); err == nil {
+			j.Valid = true
+		}
+	}
+
+	return
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Документ JSON значения кодируется значениями MessagePack, объекты картами, массивы массивами
+func (j JSONOf[T]) MarshalMsgpack() (data []byte, err error) {
+	var buf []byte
+
+	if !j.Valid {
+		data = msgpack.AppendNil(nil)
+		return
+	}
+	if buf, err = json.Marshal(j.V); err == nil {
+		data, err = appendMsgpackJSON(nil, buf)
+	}
+
+	return
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значения CBOR преобразуются в документ JSON, который декодируется в значение
+func (j *JSONOf[T]) UnmarshalCBOR(data []byte) (err error) {
+	var buf []byte
+
+	if cbor.IsNull(data) {
+		j.Reset()
+		return cborEnd(cbor.ReadNull(data))
+	}
+	if buf, err = cborJSON(data); err == nil {
+		if err = j.decode(buf); err == nil {
+			j.Valid = true
+		}
+	}
+
+	return
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Документ JSON значения кодируется значениями CBOR, объекты картами, массивы массивами
+func (j JSONOf[T]) MarshalCBOR() (data []byte, err error) {
+	var buf []byte
+
+	if !j.Valid {
+		data = cbor.AppendNull(nil)
+		return
+	}
+	if buf, err = json.Marshal(j.V); err == nil {
+		data, err = appendCBORJSON(nil, buf)
+	}
+
+	return
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение YAML преобразуется в документ JSON, который декодируется в значение
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (j *JSONOf[T]) UnmarshalYAML(unmarshal func(interface{}) error) (err error) {
+	var (
+		data []byte
+		null bool
+	)
+
+	if data, null, err = yamlJSON(unmarshal); err != nil {
+		return
+	}
+	if null {
+		j.Reset()
+		return
+	}
+	if err = j.decode(data); err == nil {
+		j.Valid = true
+	}
+
+	return
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Документ JSON значения кодируется значениями YAML, объекты картами, массивы последовательностями
+func (j JSONOf[T]) MarshalYAML() (ret interface{}, err error) {
+	var data []byte
+
+	if !j.Valid {
+		return
+	}
+	if data, err = json.Marshal(j.V); err == nil {
+		ret, err = yamlFromJSON(data)
+	}
+
+	return
+}
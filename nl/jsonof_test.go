@@ -0,0 +1,198 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"testing"
+)
+
+func jsonOfTestValue() jsonTestDocument {
+	var ret jsonTestDocument
+
+	errorPanic(json.Unmarshal(jsonDocument, &ret))
+
+	return ret
+}
+
+func isJSONOfValid(t *testing.T, j JSONOf[jsonTestDocument], from string) {
+	var value = jsonOfTestValue()
+
+	if j.V.Name != value.Name || len(j.V.Items) != len(value.Items) || j.V.Nested != value.Nested {
+		t.Errorf("Bad %s value: %v ≠ %v\n", from, j.V, value)
+	}
+	if !j.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isJSONOfNull[T any](t *testing.T, j JSONOf[T], from string) {
+	if j.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewJSONOf(t *testing.T) {
+	v1 := NewJSONOf[jsonTestDocument]()
+	isJSONOfNull(t, v1, "NewJSONOf()")
+
+	v2 := NewJSONOfValue(jsonOfTestValue())
+	isJSONOfValid(t, v2, "NewJSONOfValue()")
+
+	v3 := NewJSONOfPointerValue[jsonTestDocument](nil)
+	isJSONOfNull(t, v3, "NewJSONOfPointerValue()")
+
+	v4 := NewJSONOfPointerValue(v2.Pointer())
+	isJSONOfValid(t, v4, "NewJSONOfPointerValue()")
+
+	v5 := NewJSONOfNull(v2.Null())
+	isJSONOfValid(t, v5, "NewJSONOfNull()")
+}
+
+func TestJSONOfSetValidReset(t *testing.T) {
+	v1 := NewJSONOf[[]int]()
+	if v1.Pointer() != nil || v1.MustValue() != nil {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid([]int{1})
+	if !v1.Valid || len(v1.MustValue()) != 1 {
+		t.Error("SetValid()", "is wrong")
+	}
+	v1.NullIfDefault()
+	if !v1.Valid {
+		t.Error("NullIfDefault()", "is invalid, but should be valid")
+	}
+	v1.SetValid([]int{})
+	v1.NullIfDefault()
+	isJSONOfNull(t, v1, "NullIfDefault()")
+	if v1.V != nil {
+		t.Error("Reset()", "value is not zero")
+	}
+}
+
+func TestJSONOfScanValue(t *testing.T) {
+	v1 := NewJSONOf[jsonTestDocument]()
+	errorPanic(v1.Scan([]byte(jsonDocument)))
+	isJSONOfValid(t, v1, "Scan([]byte)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != string(jsonDocument) {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewJSONOf[jsonTestDocument]()
+	errorPanic(v2.Scan(string(jsonDocument)))
+	isJSONOfValid(t, v2, "Scan(string)")
+
+	v3 := NewJSONOfValue([]string{"a"})
+	errorPanic(v3.Scan("null"))
+	if !v3.Valid || v3.V != nil {
+		t.Error("Scan(JSON null)", "is wrong")
+	}
+
+	v4 := NewJSONOfValue(jsonOfTestValue())
+	errorPanic(v4.Scan(nil))
+	isJSONOfNull(t, v4, "Scan(nil)")
+	dv, err = v4.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v5 := NewJSONOfValue(jsonOfTestValue())
+	if err = v5.Scan(`[1,2,3]`); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isJSONOfNull(t, v5, "Scan(array)")
+	if v5.V.Name != "" {
+		t.Error("Scan()", "value is not zero")
+	}
+	if err = v5.Scan(invalidJSON); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v5.Scan(int64(1)); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+
+	v6 := NewJSONOfValue(func() {})
+	if _, err = v6.Value(); err == nil {
+		t.Error("Value()", "is nil, but should be not nil")
+	}
+}
+
+func TestJSONOfUnmarshalJSON(t *testing.T) {
+	var (
+		err error
+		doc struct {
+			Payload JSONOf[jsonTestDocument] `json:"payload"`
+			Tags    JSONOf[[]string]         `json:"tags"`
+		}
+	)
+
+	errorPanic(json.Unmarshal([]byte(`{"payload":`+string(jsonDocument)+`,"tags":null}`), &doc))
+	isJSONOfValid(t, doc.Payload, "UnmarshalJSON()")
+	isJSONOfNull(t, doc.Tags, "UnmarshalJSON(null)")
+
+	v1 := NewJSONOfValue(jsonOfTestValue())
+	if err = json.Unmarshal([]byte(`"text"`), &v1); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isJSONOfNull(t, v1, "UnmarshalJSON(string)")
+	if err = v1.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+}
+
+func TestJSONOfMarshalJSON(t *testing.T) {
+	v1 := struct {
+		Payload JSONOf[jsonTestDocument] `json:"payload"`
+		Tags    JSONOf[[]string]         `json:"tags"`
+	}{Payload: NewJSONOfValue(jsonOfTestValue())}
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, `{"payload":`+string(jsonDocument)+`,"tags":null}`, "MarshalJSON()")
+}
+
+func TestJSONOfText(t *testing.T) {
+	v1 := NewJSONOf[jsonTestDocument]()
+	errorPanic(v1.UnmarshalText(jsonDocument))
+	isJSONOfValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(jsonDocument), "MarshalText()")
+
+	v2 := NewJSONOf[jsonTestDocument]()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || v2.V.Name != "" {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewJSONOfValue(jsonOfTestValue())
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isJSONOfNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewJSONOf[jsonTestDocument]()
+	if err = v4.UnmarshalText(invalidJSON); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isJSONOfNull(t, v4, "UnmarshalText()")
+}
+
+func TestJSONOfBinary(t *testing.T) {
+	v1 := NewJSONOfValue(jsonOfTestValue())
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewJSONOf[jsonTestDocument]()
+	errorPanic(v2.UnmarshalBinary(data))
+	isJSONOfValid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewJSONOf[map[string]interface{}]()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewJSONOfValue(map[string]interface{}{"a": 1})
+	errorPanic(v4.UnmarshalBinary(data))
+	isJSONOfNull(t, v4, "UnmarshalBinary()")
+}
@@ -0,0 +1,309 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"fmt"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// LineString is an nullable geometry line string object, for PostGIS geometry(LineString) and MySQL LINESTRING columns
+type LineString struct {
+	LineString []Coord // Value of object
+	Valid      bool    // Valid is true if value is not NULL
+	SRID       int32   // SRID is spatial reference identifier, Value() writes EWKB with SRID if it isn't zero
+}
+
+// NewLineString Создание нового объекта LineString
+func NewLineString() LineString {
+	return LineString{
+		LineString: nil,
+		Valid:      false,
+	}
+}
+
+// NewLineStringValue Создание нового действительного объекта LineString из значения, значение копируется
+func NewLineStringValue(value []Coord) LineString {
+	return LineString{
+		LineString: append([]Coord{}, value...),
+		Valid:      true,
+	}
+}
+
+// NewLineStringPointerValue Создание нового действительного объекта LineString из ссылки на значение
+func NewLineStringPointerValue(ptr *[]Coord) LineString {
+	if ptr == nil {
+		return NewLineString()
+	}
+	return NewLineStringValue(*ptr)
+}
+
+// SetValid Изменение значения и установка флага действительного значения, значение копируется
+func (ls *LineString) SetValid(value []Coord) {
+	ls.LineString, ls.Valid = append([]Coord{}, value...), true
+}
+
+// Reset Сброс значения и установка флага не действительного значения
+func (ls *LineString) Reset() { ls.LineString, ls.Valid = nil, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (ls *LineString) NullIfDefault() LineString {
+	if len(ls.LineString) == 0 {
+		ls.Reset()
+	}
+	return *ls
+}
+
+// MustValue Возвращает значение в любом случае
+func (ls *LineString) MustValue() []Coord {
+	if !ls.Valid {
+		return []Coord{}
+	}
+	return ls.LineString
+}
+
+// Pointer Возвращает ссылку на значение
+func (ls *LineString) Pointer() *[]Coord {
+	if !ls.Valid {
+		return nil
+	}
+	return &ls.LineString
+}
+
+// Установка значения из колец координат
+func (ls *LineString) fromRings(rings [][]Coord, srid int32, err error) error {
+	if err == nil {
+		ls.LineString, ls.SRID = rings[0], srid
+	}
+	if ls.Valid = err == nil; !ls.Valid {
+		ls.LineString = nil
+	}
+
+	return err
+}
+
+// Кольца координат значения
+func (ls LineString) rings() [][]Coord { return [][]Coord{ls.LineString} }
+
+// Scan Реализация интерфейса Scanner
+// Поддерживаются WKB, EWKB, шестнадцатеричный EWKB PostGIS, внутренний формат MySQL и WKT
+func (ls *LineString) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		ls.Reset()
+		return
+	case []byte:
+		return ls.fromRings(decodeGeometry(x, geometryLineString))
+	case string:
+		return ls.fromRings(decodeGeometry([]byte(x), geometryLineString))
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.LineString: %v", x, value)
+	}
+	ls.Reset()
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+// Значение записывается в формате WKB, в запросе используйте ST_GeomFromWKB(?) или ST_GeomFromEWKB(?)
+func (ls LineString) Value() (driver.Value, error) {
+	if !ls.Valid {
+		return nil, nil
+	}
+	return formatWKB(geometryLineString, ls.SRID, ls.rings()), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение представляется объектом GeoJSON
+func (ls *LineString) UnmarshalJSON(data []byte) (err error) {
+	const nullString = "null"
+
+	if string(bytes.TrimSpace(data)) == nullString {
+		ls.Reset()
+		return
+	}
+	rings, err := parseGeoJSON(data, geometryLineString)
+
+	return ls.fromRings(rings, ls.SRID, err)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (ls LineString) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !ls.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data, err = formatGeoJSON(geometryLineString, ls.rings())
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+// Значение представляется в формате WKT, допускается префикс SRID EWKT
+func (ls *LineString) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	switch string(text) {
+	case nullString:
+		ls.Reset()
+		return
+	case emptyString:
+		ls.LineString, ls.Valid = []Coord{}, true
+		return
+	}
+
+	return ls.fromRings(parseWKT(string(text), geometryLineString))
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (ls LineString) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !ls.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(formatWKT(geometryLineString, ls.rings()))
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (ls *LineString) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return ls.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagLineString, ls)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (ls LineString) MarshalBinary() (data []byte, err error) {
+	return marshalBinary(binaryTagLineString, ls)
+}
+
+// Запись значения в компактном двоичном формате
+func (ls LineString) encodeBinary(w *binaryWriter) {
+	if w.flags(ls.Valid); !ls.Valid {
+		return
+	}
+	w.varint(int64(ls.SRID))
+	w.coords(ls.LineString)
+}
+
+// Чтение значения в компактном двоичном формате
+func (ls *LineString) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		srid  int32
+		value []Coord
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			ls.LineString, ls.Valid, ls.SRID = nil, false, 0
+		}
+		return
+	}
+	srid = int32(r.intN(32))
+	if value = r.coords(); r.err != nil {
+		return
+	}
+	ls.LineString, ls.Valid, ls.SRID = value, valid, srid
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (ls *LineString) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.LineStringWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.LineStringWrapper)
+	if err = dec.Decode(item); err == nil {
+		ls.LineString, ls.Valid, ls.SRID = coordsFromWrapper(item.Value), item.Valid, item.SRID
+		if !ls.Valid {
+			ls.LineString = nil
+		}
+	}
+
+	return
+}
+
+// Координаты в виде пар чисел обёртки
+func coordsToWrapper(coords []Coord) [][2]float64 {
+	var ret = make([][2]float64, len(coords))
+
+	for i, c := range coords {
+		ret[i] = [2]float64{c.X, c.Y}
+	}
+
+	return ret
+}
+
+// Координаты из пар чисел обёртки
+func coordsFromWrapper(items [][2]float64) []Coord {
+	var ret = make([]Coord, len(items))
+
+	for i := range items {
+		ret[i] = Coord{X: items[i][0], Y: items[i][1]}
+	}
+
+	return ret
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки в формате WKT или EWKT
+func (ls *LineString) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, ls) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой в формате WKT, либо EWKT с префиксом SRID, если SRID не равен нулю
+func (ls LineString) MarshalMsgpack() ([]byte, error) {
+	if !ls.Valid {
+		return msgpack.AppendNil(nil), nil
+	}
+	return msgpack.AppendString(nil, formatEWKT(geometryLineString, ls.SRID, ls.rings())), nil
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки в формате WKT или EWKT
+func (ls *LineString) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, ls) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой в формате WKT, либо EWKT с префиксом SRID, если SRID не равен нулю
+func (ls LineString) MarshalCBOR() ([]byte, error) {
+	if !ls.Valid {
+		return cbor.AppendNull(nil), nil
+	}
+	return cbor.AppendString(nil, formatEWKT(geometryLineString, ls.SRID, ls.rings())), nil
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из строки в формате WKT или EWKT
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (ls *LineString) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, ls)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой в формате WKT, либо EWKT с префиксом SRID, если SRID не равен нулю
+func (ls LineString) MarshalYAML() (interface{}, error) {
+	if !ls.Valid {
+		return nil, nil
+	}
+	return formatEWKT(geometryLineString, ls.SRID, ls.rings()), nil
+}
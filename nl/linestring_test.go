@@ -0,0 +1,157 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var (
+	lineStringValue = []Coord{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 5.5, Y: -6}}
+	lineStringWKT   = "LINESTRING(1 2,3 4,5.5 -6)"
+	lineStringJSON  = []byte(`{"type":"LineString","coordinates":[[1,2],[3,4],[5.5,-6]]}`)
+)
+
+func isLineStringValid(t *testing.T, ls LineString, from string) {
+	if !reflect.DeepEqual(ls.LineString, lineStringValue) {
+		t.Errorf("Bad %s line string: %v ≠ %v\n", from, ls.LineString, lineStringValue)
+	}
+	if !ls.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isLineStringNull(t *testing.T, ls LineString, from string) {
+	if ls.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+	if ls.LineString != nil {
+		t.Error(from, "value is not nil, but should be nil")
+	}
+}
+
+func TestNewLineString(t *testing.T) {
+	v1 := NewLineString()
+	isLineStringNull(t, v1, "NewLineString()")
+
+	v2 := NewLineStringValue(lineStringValue)
+	isLineStringValid(t, v2, "NewLineStringValue()")
+	v2.LineString[0].X = 100
+	if lineStringValue[0].X != 1 {
+		t.Error("NewLineStringValue()", "doesn't copy value")
+	}
+	v2.LineString[0].X = 1
+
+	v3 := NewLineStringPointerValue(nil)
+	isLineStringNull(t, v3, "NewLineStringPointerValue()")
+
+	v4 := NewLineStringPointerValue(v2.Pointer())
+	isLineStringValid(t, v4, "NewLineStringPointerValue()")
+}
+
+func TestLineStringSetValidReset(t *testing.T) {
+	v1 := NewLineString()
+	if v1.Pointer() != nil || len(v1.MustValue()) != 0 {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(lineStringValue)
+	isLineStringValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isLineStringValid(t, v1, "NullIfDefault()")
+	v1.SetValid(nil)
+	v1.NullIfDefault()
+	isLineStringNull(t, v1, "NullIfDefault()")
+}
+
+func TestLineStringScanValue(t *testing.T) {
+	var v LineString
+
+	v1 := NewLineStringValue(lineStringValue)
+	v1.SRID = 4326
+	val, err := v1.Value()
+	errorPanic(err)
+	errorPanic(v.Scan(val))
+	isLineStringValid(t, v, "Scan()")
+	if v.SRID != 4326 {
+		t.Errorf("SRID is %d, but should be 4326", v.SRID)
+	}
+	errorPanic(v.Scan(lineStringWKT))
+	isLineStringValid(t, v, "Scan()")
+
+	errorPanic(v.Scan(nil))
+	isLineStringNull(t, v, "Scan(nil)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != nil {
+		t.Errorf("Value() is %v, but should be nil", val)
+	}
+
+	if err = v.Scan(geometryHex(pointWKBHex)); err == nil {
+		t.Error("Scan()", "error is nil, but should be not nil")
+	}
+	isLineStringNull(t, v, "Scan(error)")
+	if err = v.Scan(int64(1)); err == nil {
+		t.Error("Scan(int64)", "error is nil, but should be not nil")
+	}
+}
+
+func TestLineStringJSON(t *testing.T) {
+	var v LineString
+
+	errorPanic(json.Unmarshal(lineStringJSON, &v))
+	isLineStringValid(t, v, "UnmarshalJSON()")
+	data, err := json.Marshal(v)
+	errorPanic(err)
+	jsonEquals(t, data, string(lineStringJSON), "non-empty json marshal")
+
+	errorPanic(json.Unmarshal([]byte(`null`), &v))
+	isLineStringNull(t, v, "UnmarshalJSON(null)")
+	data, err = json.Marshal(v)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestLineStringText(t *testing.T) {
+	var v LineString
+
+	errorPanic(v.UnmarshalText([]byte(lineStringWKT)))
+	isLineStringValid(t, v, "UnmarshalText()")
+	data, err := v.MarshalText()
+	errorPanic(err)
+	if string(data) != lineStringWKT {
+		t.Errorf("MarshalText() is %s, but should be %s", data, lineStringWKT)
+	}
+
+	errorPanic(v.UnmarshalText([]byte("")))
+	if !v.Valid || len(v.LineString) != 0 {
+		t.Error("UnmarshalText()", "of empty text is wrong")
+	}
+
+	errorPanic(v.UnmarshalText([]byte("null")))
+	isLineStringNull(t, v, "UnmarshalText(null)")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != "null" {
+		t.Errorf("MarshalText() is %s, but should be null", data)
+	}
+}
+
+func TestLineStringBinary(t *testing.T) {
+	v1 := NewLineStringValue(lineStringValue)
+	v1.SRID = 3857
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewLineString()
+	errorPanic(v2.UnmarshalBinary(data))
+	isLineStringValid(t, v2, "UnmarshalBinary()")
+	if v2.SRID != 3857 {
+		t.Errorf("SRID is %d, but should be 3857", v2.SRID)
+	}
+
+	data, err = NewLineString().MarshalBinary()
+	errorPanic(err)
+	errorPanic(v2.UnmarshalBinary(data))
+	isLineStringNull(t, v2, "UnmarshalBinary(null)")
+}
@@ -0,0 +1,261 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gopkg.in/webnice/lin.v1/msgpack"
+)
+
+// Формат MessagePack
+// Не действительное значение кодируется nil, логические значения, числа, строки и срезы байт кодируются
+// соответствующими форматами MessagePack, время расширением timestamp, остальные типы текстовым представлением
+// MarshalText в виде строки. Методы MarshalMsgpack и UnmarshalMsgpack вызываются библиотекой
+// github.com/vmihailenco/msgpack и совместимыми библиотеками.
+
+// Кодирование значения в формате MessagePack
+type msgpackMarshaler interface {
+	MarshalMsgpack() ([]byte, error)
+}
+
+// Декодирование значения в формате MessagePack
+type msgpackUnmarshaler interface {
+	UnmarshalMsgpack(data []byte) error
+}
+
+// Значение, декодируемое из текстового представления
+type msgpackTextUnmarshaler interface {
+	encoding.TextUnmarshaler
+	Reset()
+}
+
+var (
+	msgpackMarshalerType   = reflect.TypeOf((*msgpackMarshaler)(nil)).Elem()
+	msgpackUnmarshalerType = reflect.TypeOf((*msgpackUnmarshaler)(nil)).Elem()
+	msgpackTimeType        = reflect.TypeOf(time.Time{})
+)
+
+// Проверка отсутствия данных после значения
+func msgpackEnd(rest []byte, err error) error {
+	if err == nil && len(rest) > 0 {
+		err = fmt.Errorf("msgpack: %d extra bytes after value", len(rest))
+	}
+	return err
+}
+
+// Декодирование значения функцией чтения, null является истиной, если значение является nil
+func unmarshalMsgpack[T any](data []byte, read func([]byte) (T, []byte, error)) (value T, null bool, err error) {
+	var rest []byte
+
+	if null = msgpack.IsNil(data); null {
+		rest, err = msgpack.ReadNil(data)
+	} else {
+		value, rest, err = read(data)
+	}
+	err = msgpackEnd(rest, err)
+
+	return
+}
+
+// Функция чтения целого числа размером bits бит
+func msgpackInt(bits int) func([]byte) (int64, []byte, error) {
+	return func(b []byte) (int64, []byte, error) { return msgpack.ReadInt(b, bits) }
+}
+
+// Кодирование текстового представления значения в виде строки, не действительное значение кодируется nil
+func marshalMsgpackText(valid bool, value encoding.TextMarshaler) (data []byte, err error) {
+	var text []byte
+
+	if !valid {
+		data = msgpack.AppendNil(nil)
+		return
+	}
+	if text, err = value.MarshalText(); err == nil {
+		data = msgpack.AppendString(nil, string(text))
+	}
+
+	return
+}
+
+// Декодирование значения из текстового представления
+// Строки и срезы байт разбираются методом UnmarshalText, числа предварительно форматируются, nil сбрасывает значение
+func unmarshalMsgpackText(data []byte, value msgpackTextUnmarshaler) (err error) {
+	var (
+		item interface{}
+		rest []byte
+	)
+
+	if item, rest, err = msgpack.ReadValue(data); err != nil {
+		return
+	}
+	if err = msgpackEnd(rest, nil); err != nil {
+		return
+	}
+	switch v := item.(type) {
+	case nil:
+		value.Reset()
+	case string:
+		err = value.UnmarshalText([]byte(v))
+	case []byte:
+		err = value.UnmarshalText(v)
+	case int64, uint64, float32, float64:
+		err = value.UnmarshalText([]byte(asString(v)))
+	default:
+		err = fmt.Errorf("can't unmarshal msgpack %s into %T", msgpack.NextType(data), value)
+	}
+
+	return
+}
+
+// Кодирование значения произвольного типа
+// Значения типов, реализующих MarshalMsgpack, кодируются этим методом, логические значения, числа, строки,
+// срезы байт и время соответствующими форматами, значения остальных типов через JSON представление
+func appendMsgpackValue(b []byte, value interface{}) (ret []byte, err error) {
+	var (
+		rv   = reflect.ValueOf(value)
+		data []byte
+	)
+
+	switch {
+	case !rv.IsValid():
+		return msgpack.AppendNil(b), nil
+	case rv.Type().Implements(msgpackMarshalerType):
+		if data, err = value.(msgpackMarshaler).MarshalMsgpack(); err == nil {
+			ret = append(b, data...)
+		}
+		return
+	case rv.Type() == msgpackTimeType:
+		return msgpack.AppendTime(b, value.(time.Time)), nil
+	case isBytesKind(rv):
+		return msgpack.AppendBytes(b, rv.Bytes()), nil
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		ret = msgpack.AppendBool(b, rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ret = msgpack.AppendInt(b, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		ret = msgpack.AppendUint(b, rv.Uint())
+	case reflect.Float32:
+		ret = msgpack.AppendFloat32(b, float32(rv.Float()))
+	case reflect.Float64:
+		ret = msgpack.AppendFloat64(b, rv.Float())
+	case reflect.String:
+		ret = msgpack.AppendString(b, rv.String())
+	default:
+		if data, err = json.Marshal(value); err == nil {
+			ret, err = appendMsgpackJSON(b, data)
+		}
+	}
+
+	return
+}
+
+// Декодирование значения произвольного типа, возвращаются данные после значения
+func readMsgpackValue(data []byte, value interface{}) (rest []byte, err error) {
+	var (
+		rv  = reflect.ValueOf(value).Elem()
+		raw []byte
+		i   int64
+		u   uint64
+		f   float64
+		b   bool
+		str string
+		buf []byte
+		tm  time.Time
+	)
+
+	if rest, err = msgpack.Skip(data); err != nil {
+		return
+	}
+	raw = data[:len(data)-len(rest)]
+	switch {
+	case reflect.PointerTo(rv.Type()).Implements(msgpackUnmarshalerType):
+		err = value.(msgpackUnmarshaler).UnmarshalMsgpack(raw)
+		return
+	case rv.Type() == msgpackTimeType:
+		// Время декодируется из расширения timestamp либо из строки текстового представления
+		if msgpack.NextType(raw) == msgpack.StrType {
+			if str, _, err = msgpack.ReadString(raw); err == nil {
+				err = tm.UnmarshalText([]byte(str))
+			}
+		} else {
+			tm, _, err = msgpack.ReadTime(raw)
+		}
+		if err == nil {
+			rv.Set(reflect.ValueOf(tm))
+		}
+		return
+	case isBytesKind(rv):
+		if buf, _, err = msgpack.ReadBytes(raw); err == nil {
+			rv.SetBytes(buf)
+		}
+		return
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		if b, _, err = msgpack.ReadBool(raw); err == nil {
+			rv.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, _, err = msgpack.ReadInt(raw, rv.Type().Bits()); err == nil {
+			rv.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, _, err = msgpack.ReadUint(raw, rv.Type().Bits()); err == nil {
+			rv.SetUint(u)
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, _, err = msgpack.ReadFloat(raw, rv.Type().Bits()); err == nil {
+			rv.SetFloat(f)
+		}
+	case reflect.String:
+		if str, _, err = msgpack.ReadString(raw); err == nil {
+			rv.SetString(str)
+		}
+	default:
+		if buf, err = msgpackJSON(raw); err == nil {
+			err = json.Unmarshal(buf, value)
+		}
+	}
+
+	return
+}
+
+// Кодирование документа JSON в виде значений MessagePack, объекты кодируются картами, массивы массивами
+func appendMsgpackJSON(b []byte, data []byte) (ret []byte, err error) {
+	var (
+		dec   = json.NewDecoder(bytes.NewReader(data))
+		value interface{}
+	)
+
+	dec.UseNumber()
+	if err = dec.Decode(&value); err == nil {
+		ret, err = msgpack.AppendValue(b, value)
+	}
+
+	return
+}
+
+// Документ JSON из значения MessagePack
+func msgpackJSON(data []byte) (ret []byte, err error) {
+	var (
+		value interface{}
+		rest  []byte
+	)
+
+	if value, rest, err = msgpack.ReadValue(data); err == nil {
+		err = msgpackEnd(rest, nil)
+	}
+	if err == nil {
+		ret, err = json.Marshal(value)
+	}
+
+	return
+}
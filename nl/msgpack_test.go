@@ -0,0 +1,177 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type msgpackTestValue interface {
+	msgpackMarshaler
+	msgpackUnmarshaler
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	for _, item := range binaryTestValues() {
+		value, ok := item.(msgpackTestValue)
+		if !ok {
+			t.Errorf("%T doesn't implement MarshalMsgpack and UnmarshalMsgpack", item)
+			continue
+		}
+		if date, ok := value.(*Date); ok && date.Date.Year < 0 {
+			// Текстовое представление даты не поддерживает годы до нашей эры
+			continue
+		}
+		data, err := value.MarshalMsgpack()
+		errorPanic(err)
+		target := reflect.New(reflect.TypeOf(value).Elem()).Interface().(msgpackTestValue)
+		if err = target.UnmarshalMsgpack(data); err != nil {
+			t.Errorf("UnmarshalMsgpack() of %T error: %s", value, err)
+			continue
+		}
+		again, err := target.MarshalMsgpack()
+		errorPanic(err)
+		if !bytes.Equal(again, data) {
+			t.Errorf("MarshalMsgpack() of decoded %T is %x, but should be %x", value, again, data)
+		}
+		switch value.(type) {
+		case *Decimal, *BigInt, *BigFloat, *Time, *TimeRange, *Duration:
+		case *Optional[string]:
+			// Отсутствующее значение кодируется nil и декодируется как присутствующее значение null
+		default:
+			if !reflect.DeepEqual(target, value) {
+				t.Errorf("UnmarshalMsgpack() of %T is %v, but should be %v", value, target, value)
+			}
+		}
+	}
+}
+
+func TestMsgpackFormat(t *testing.T) {
+	var (
+		array, _ = NewArrayDims([]Int64{NewInt64Value(1), NewInt64(), NewInt64Value(3), NewInt64Value(-4)}, 2, 2)
+		dec, _   = NewDecimalString("1.50")
+		uuid, _  = NewUUIDString("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+		tests    = []struct {
+			Value msgpackMarshaler
+			Hex   string
+		}{
+			{NewInt64(), "c0"},
+			{NewInt64Value(-33), "d0df"},
+			{NewUint8Value(200), "ccc8"},
+			{NewFloat32Value(1.5), "ca3fc00000"},
+			{NewBoolValue(true), "c3"},
+			{NewStringValue("abc"), "a3616263"},
+			{NewBytesValue([]byte{1, 2}), "c4020102"},
+			{NewTimeValue(time.Unix(1, 0)), "d6ff00000001"},
+			{NewDurationValue(time.Second), "ce3b9aca00"},
+			{dec, "a4312e3530"},
+			{NewBigIntValue(nil), "00"},
+			{NewDateValue(CivilDate{Year: 2020, Month: time.February, Day: 29}), "aa323032302d30322d3239"},
+			{uuid, "d924" + hex.EncodeToString([]byte("f81d4fae-7dec-11d0-a765-00a0c91e6bf6"))},
+			{array, "92920" + "1c09203fc"},
+			{NewArrayValue([]Int64{}), "90"},
+			{NewStringMapValue(map[string]String{"b": NewString(), "a": NewStringValue("1")}), "82a161a131a162c0"},
+			{NewJSONValue([]byte(`{"a":[1,2.5,null]}`)), "81a16193" + "01cb4004000000000000c0"},
+			{NewJSONOfValue(binaryTestStruct{Name: "a", Count: 2}), "82a5436f756e7402a44e616d65a161"},
+			{NewNullValue[int16](-7), "f9"},
+			{NewNullValue(binaryTestStruct{Name: "a", Count: 2}), "82a5436f756e7402a44e616d65a161"},
+			{NewNullValue(NewStringValue("a")), "a161"},
+			{NewOptional[int](), "c0"},
+		}
+	)
+
+	for _, test := range tests {
+		data, err := test.Value.MarshalMsgpack()
+		errorPanic(err)
+		if hex.EncodeToString(data) != test.Hex {
+			t.Errorf("MarshalMsgpack() of %T is %x, but should be %s", test.Value, data, test.Hex)
+		}
+	}
+}
+
+func TestMsgpackUnmarshal(t *testing.T) {
+	var (
+		i8  Int8
+		u64 Uint64
+		f32 Float32
+		s   String
+		tm  Time
+		d   Duration
+		dec Decimal
+		u   UUID
+		o   Optional[int]
+	)
+
+	errorPanic(i8.UnmarshalMsgpack([]byte{0x7f}))
+	if !i8.Valid || i8.Int8 != 127 {
+		t.Errorf("UnmarshalMsgpack() is %v, but should be 127", i8)
+	}
+	errorPanic(i8.UnmarshalMsgpack([]byte{0xc0}))
+	if i8.Valid || i8.Int8 != 0 {
+		t.Error("UnmarshalMsgpack()", "of nil is valid, but should be invalid")
+	}
+	errorPanic(u64.UnmarshalMsgpack([]byte{0xd0, 0x01}))
+	if !u64.Valid || u64.Uint64 != 1 {
+		t.Errorf("UnmarshalMsgpack() is %v, but should be 1", u64)
+	}
+	errorPanic(f32.UnmarshalMsgpack([]byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}))
+	if !f32.Valid || f32.Float32 != 1.5 {
+		t.Errorf("UnmarshalMsgpack() is %v, but should be 1.5", f32)
+	}
+	errorPanic(s.UnmarshalMsgpack([]byte{0xc4, 0x01, 'a'}))
+	if !s.Valid || s.String != "a" {
+		t.Errorf("UnmarshalMsgpack() is %v, but should be a", s)
+	}
+	errorPanic(tm.UnmarshalMsgpack(append([]byte{0xb4}, "2020-01-02T03:04:05Z"...)))
+	if !tm.Valid || !tm.Time.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("UnmarshalMsgpack() is %v, but should be 2020-01-02T03:04:05Z", tm)
+	}
+	errorPanic(d.UnmarshalMsgpack([]byte{0xa2, '1', 'h'}))
+	if !d.Valid || d.Duration != time.Hour {
+		t.Errorf("UnmarshalMsgpack() is %v, but should be 1h", d)
+	}
+	errorPanic(dec.UnmarshalMsgpack([]byte{0xd0, 0xf9}))
+	if text, _ := dec.MarshalText(); string(text) != "-7" {
+		t.Errorf("UnmarshalMsgpack() is %s, but should be -7", text)
+	}
+	errorPanic(u.UnmarshalMsgpack(append([]byte{0xc4, 0x10}, make([]byte, 16)...)))
+	if !u.Valid || !u.Binary {
+		t.Error("UnmarshalMsgpack()", "of bin doesn't set Binary")
+	}
+	errorPanic(o.UnmarshalMsgpack([]byte{0xc0}))
+	isOptionalNull(t, o, "UnmarshalMsgpack(nil)")
+
+	var errors = []struct {
+		Value msgpackUnmarshaler
+		Hex   string
+	}{
+		{&i8, "ccc8"},
+		{&i8, "a0"},
+		{&i8, "0101"},
+		{&i8, "c0c0"},
+		{&i8, ""},
+		{&s, "01"},
+		{&tm, "d605000000000000"},
+		{&dec, "c3"},
+		{&Int64Array{}, "929201c09103"},
+		{&Int64Array{}, "929101920102"},
+		{&Int64Array{}, "92a0"},
+		{&StringMap{}, "8101c0"},
+		{&StringMap{}, "81a16101"},
+		{&JSON{}, "81a161"},
+		{&JSONOf[binaryTestStruct]{}, "81a44e616d6501"},
+		{&Null[int8]{}, "cc80"},
+		{&Null[binaryTestStruct]{}, "81a44e616d6501"},
+	}
+	for _, test := range errors {
+		data, err := hex.DecodeString(test.Hex)
+		errorPanic(err)
+		if err = test.Value.UnmarshalMsgpack(data); err == nil {
+			t.Errorf("UnmarshalMsgpack(%s) into %T error is nil, but should be not nil", test.Hex, test.Value)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package nul // import "gopkg.in/webnice/lin.v1/nl"
 //import "gopkg.in/webnice/debug.v1"
 //import "gopkg.in/webnice/log.v2"
 import (
+	"encoding/base64"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -34,3 +35,70 @@ func asString(src interface{}) string {
 
 	return fmt.Sprintf("%v", src)
 }
+
+// Разбор строки в значение по виду (kind) значения, с проверкой диапазона
+func parseKind(rv reflect.Value, str string) (err error) {
+	var (
+		i   int64
+		u   uint64
+		f   float64
+		b   bool
+		buf []byte
+	)
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(str)
+	case reflect.Bool:
+		if b, err = strconv.ParseBool(str); err == nil {
+			rv.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err = strconv.ParseInt(str, 10, rv.Type().Bits()); err == nil {
+			rv.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, err = strconv.ParseUint(str, 10, rv.Type().Bits()); err == nil {
+			rv.SetUint(u)
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err = strconv.ParseFloat(str, rv.Type().Bits()); err == nil {
+			rv.SetFloat(f)
+		}
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			err = fmt.Errorf("can't parse string into go value of type %s", rv.Type())
+			return
+		}
+		if buf, err = base64.StdEncoding.DecodeString(str); err == nil {
+			rv.SetBytes(buf)
+		}
+	default:
+		err = fmt.Errorf("can't parse string into go value of type %s", rv.Type())
+	}
+
+	return
+}
+
+// Форматирование значения в строку по виду (kind) значения
+func formatKind(rv reflect.Value) (ret string, err error) {
+	switch rv.Kind() {
+	case reflect.String:
+		ret = rv.String()
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			err = fmt.Errorf("can't format go value of type %s as string", rv.Type())
+			return
+		}
+		ret = base64.StdEncoding.EncodeToString(rv.Bytes())
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		ret = asString(rv.Interface())
+	default:
+		err = fmt.Errorf("can't format go value of type %s as string", rv.Type())
+	}
+
+	return
+}
@@ -23,32 +23,46 @@ var (
 	boolTrueValidGob        = `0aff81060102ff840000003aff8200362dff850301010b426f6f6c5772617070657201ff86000102010556616c7565010200010556616c6964010200000007ff860101010100`
 	boolFalseValidGob       = `0aff81060102ff8400000038ff8200342dff850301010b426f6f6c5772617070657201ff86000102010556616c7565010200010556616c6964010200000005ff86020100`
 	boolNullInvalidGob      = `0aff81060102ff8400000036ff8200322dff850301010b426f6f6c5772617070657201ff86000102010556616c7565010200010556616c6964010200000003ff8600`
+	boolTrueValidBinary     = `81010101`
+	boolFalseValidBinary    = `81010100`
+	boolNullInvalidBinary   = `810100`
 	bytesTestValidJSON      = []byte(`{"Bytes":"VGVzdCBkYXRhIDFwSHVPeEFEWmtlaDhZOVd2TDc1","Valid":true}`)
 	bytesTestTextBase64     = []byte(`VGVzdCBkYXRhIDFwSHVPeEFEWmtlaDhZOVd2TDc1`)
 	bytesNullInvalidGob     = `0aff87060102ff8a00000012ff8b0301010642756666657201ff8c00000037ff8800332eff8d0301010c42797465735772617070657201ff8e000102010556616c7565010a00010556616c6964010200000003ff8e00`
 	bytesOkValidGob         = `0aff87060102ff8a00000012ff8b0301010642756666657201ff8c00000059ff8800552eff8d0301010c42797465735772617070657201ff8e000102010556616c7565010a00010556616c6964010200000025ff8e011e54657374206461746120317048754f7841445a6b656838593957764c3735010100`
 	bytesZeroValidGob       = `0aff87060102ff8a00000012ff8b0301010642756666657201ff8c00000039ff8800352eff8d0301010c42797465735772617070657201ff8e000102010556616c7565010a00010556616c6964010200000005ff8e020100`
+	bytesNullInvalidBinary  = `810200`
+	bytesOkValidBinary      = `8102011e54657374206461746120317048754f7841445a6b656838593957764c3735`
+	bytesZeroValidBinary    = `81020100`
 	bytesNullJSON           = []byte(`null`)
 	float64JSON             = []byte(`179769313486231570814527423731704356798070567525844996598917476803157260780028538760589558632766878171540458953514382464234321326889464182768467546703537516986049910576551282076245490090389328944075868508455133942304583236903222948165808559332123348274797826204144723168738177180919299881250404026184124858368.000000`)
 	float64StringJSON       = []byte(`"179769313486231570814527423731704356798070567525844996598917476803157260780028538760589558632766878171540458953514382464234321326889464182768467546703537516986049910576551282076245490090389328944075868508455133942304583236903222948165808559332123348274797826204144723168738177180919299881250404026184124858368.000000"`)
 	float64BlankJSON        = []byte(`""`)
 	floatNullInvalidGob     = `0aff8f060102ff9200000039ff90003530ff930301010e466c6f617436345772617070657201ff94000102010556616c7565010800010556616c6964010200000003ff9400`
 	floatOkValidGob         = `0aff8f060102ff9200000045ff90004130ff930301010e466c6f617436345772617070657201ff94000102010556616c7565010800010556616c696401020000000fff9401f8ffffffffffffef7f010100`
+	floatNullInvalidBinary  = `810300`
+	floatOkValidBinary      = `810301ffffffffffffef7f`
 	int64JSON               = []byte(`9223372036854775807`)
 	int64StringJSON         = []byte(`"9223372036854775807"`)
 	int64BlankJSON          = []byte(`""`)
 	intNullInvalidGob       = `0aff95060102ff9800000037ff9600332eff990301010c496e7436345772617070657201ff9a000102010556616c7565010400010556616c6964010200000003ff9a00`
 	intOkValidGob           = `0aff95060102ff9800000043ff96003f2eff990301010c496e7436345772617070657201ff9a000102010556616c7565010400010556616c696401020000000fff9a01f8fffffffffffffffe010100`
+	intNullInvalidBinary    = `810400`
+	intOkValidBinary        = `810401feffffffffffffffff01`
 	stringTestBody          = `3LbOVMltCjj1Mg6sSRYLzS5j64DDNEVax29ypIGxwEx9mnbFnT9FY0sZqP11`
 	stringJSON              = []byte(`"3LbOVMltCjj1Mg6sSRYLzS5j64DDNEVax29ypIGxwEx9mnbFnT9FY0sZqP11"`)
 	stringNullInvalidGob    = `0aff9b060102ff9e00000038ff9c00342fff9f0301010d537472696e675772617070657201ffa0000102010556616c7565010c00010556616c6964010200000003ffa000`
 	stringOkValidGob        = `0aff9b060102ff9e00000078ff9c00742fff9f0301010d537472696e675772617070657201ffa0000102010556616c7565010c00010556616c6964010200000043ffa0013c334c624f564d6c74436a6a314d6736735352594c7a53356a363444444e4556617832397970494778774578396d6e62466e5439465930735a71503131010100`
+	stringNullInvalidBinary = `810500`
+	stringOkValidBinary     = `8105013c334c624f564d6c74436a6a314d6736735352594c7a53356a363444444e4556617832397970494778774578396d6e62466e5439465930735a71503131`
 	timeStringValue         = `2018-05-17T17:17:17.171717+03:00`
 	timeStringValueJSON     = []byte(`"` + timeStringValue + `"`)
 	timeOkValidValue, _     = time.Parse(time.RFC3339, timeStringValue)
 	timeTestValidJSON       = []byte(`{"Time":"` + timeStringValue + `","Valid":true}`)
 	timeNullInvalidGob      = `0affa1060102ffa400000010ffa50501010454696d6501ffa600000048ffa200442effa70301010b54696d655772617070657201ffa8000102010556616c756501ffa600010556616c6964010200000010ffa50501010454696d6501ffa600000003ffa800`
 	timeOkValidGob          = `0affa1060102ffa400000010ffa50501010454696d6501ffa60000005bffa200572effa70301010b54696d655772617070657201ffa8000102010556616c756501ffa600010556616c6964010200000010ffa50501010454696d6501ffa600000016ffa8010f010000000ed28f85ed0a3c318800b4010100`
+	timeNullInvalidBinary   = `810600`
+	timeOkValidBinary       = `8106010f010000000ed28f85ed0a3c318800b4`
 	uint64String            = fmt.Sprintf("%d", uint64(math.MaxUint64))
 	uint64JSON              = []byte(uint64String)
 	uint64StringJSON        = []byte(`"` + uint64String + `"`)
@@ -56,6 +70,8 @@ var (
 	uint64MaxValueValidJSON = []byte(`{"Uint64":` + uint64String + `,"Valid":true}`)
 	uint64NullInvalidGob    = `0affa9060102ffac00000038ffaa00342fffad0301010d55696e7436345772617070657201ffae000102010556616c7565010600010556616c6964010200000003ffae00`
 	uint64OkValidGob        = `0affa9060102ffac00000044ffaa00402fffad0301010d55696e7436345772617070657201ffae000102010556616c7565010600010556616c696401020000000fffae01f8ffffffffffffffff010100`
+	uint64NullInvalidBinary = `810700`
+	uint64OkValidBinary     = `810701ffffffffffffffffff01`
 )
 
 // Основной интерфейс который должны удовлетворять все типы
@@ -99,6 +115,146 @@ type uint64Interface interface {
 	NullIfDefault() Uint64
 }
 
+type int32Interface interface {
+	mainInterface
+	NullIfDefault() Int32
+}
+
+type int16Interface interface {
+	mainInterface
+	NullIfDefault() Int16
+}
+
+type int8Interface interface {
+	mainInterface
+	NullIfDefault() Int8
+}
+
+type uint32Interface interface {
+	mainInterface
+	NullIfDefault() Uint32
+}
+
+type uint16Interface interface {
+	mainInterface
+	NullIfDefault() Uint16
+}
+
+type uint8Interface interface {
+	mainInterface
+	NullIfDefault() Uint8
+}
+
+type float32Interface interface {
+	mainInterface
+	NullIfDefault() Float32
+}
+
+type decimalInterface interface {
+	mainInterface
+	NullIfDefault() Decimal
+}
+
+type uuidInterface interface {
+	mainInterface
+	NullIfDefault() UUID
+}
+
+type durationInterface interface {
+	mainInterface
+	NullIfDefault() Duration
+}
+
+type dateInterface interface {
+	mainInterface
+	NullIfDefault() Date
+}
+
+type timeOfDayInterface interface {
+	mainInterface
+	NullIfDefault() TimeOfDay
+}
+
+type jsonInterface interface {
+	mainInterface
+	NullIfDefault() JSON
+}
+
+type ipInterface interface {
+	mainInterface
+	NullIfDefault() IP
+}
+
+type prefixInterface interface {
+	mainInterface
+	NullIfDefault() Prefix
+}
+
+type hardwareAddrInterface interface {
+	mainInterface
+	NullIfDefault() HardwareAddr
+}
+
+type bigIntInterface interface {
+	mainInterface
+	NullIfDefault() BigInt
+}
+
+type bigFloatInterface interface {
+	mainInterface
+	NullIfDefault() BigFloat
+}
+
+type stringMapInterface interface {
+	mainInterface
+	NullIfDefault() StringMap
+}
+
+type intervalInterface interface {
+	mainInterface
+	NullIfDefault() Interval
+}
+
+type pointInterface interface {
+	mainInterface
+	NullIfDefault() Point
+}
+
+type lineStringInterface interface {
+	mainInterface
+	NullIfDefault() LineString
+}
+
+type polygonInterface interface {
+	mainInterface
+	NullIfDefault() Polygon
+}
+
+type nullInterface[T any] interface {
+	mainInterface
+	NullIfDefault() Null[T]
+}
+
+type optionalInterface[T any] interface {
+	mainInterface
+	NullIfDefault() Optional[T]
+}
+
+type jsonOfInterface[T any] interface {
+	mainInterface
+	NullIfDefault() JSONOf[T]
+}
+
+type arrayInterface[T any] interface {
+	mainInterface
+	NullIfDefault() Array[T]
+}
+
+type rangeInterface[T rangeElement] interface {
+	mainInterface
+	NullIfDefault() Range[T]
+}
+
 func errorPanic(err error) {
 	if err != nil {
 		panic(err)
@@ -144,6 +300,34 @@ func TestMainInterface(t *testing.T) {
 	_ = stringInterface(&String{})
 	_ = timeInterface(&Time{})
 	_ = uint64Interface(&Uint64{})
+	_ = nullInterface[int64](&Null[int64]{})
+	_ = optionalInterface[int64](&Optional[int64]{})
+	_ = int32Interface(&Int32{})
+	_ = int16Interface(&Int16{})
+	_ = int8Interface(&Int8{})
+	_ = uint32Interface(&Uint32{})
+	_ = uint16Interface(&Uint16{})
+	_ = uint8Interface(&Uint8{})
+	_ = float32Interface(&Float32{})
+	_ = decimalInterface(&Decimal{})
+	_ = uuidInterface(&UUID{})
+	_ = durationInterface(&Duration{})
+	_ = dateInterface(&Date{})
+	_ = timeOfDayInterface(&TimeOfDay{})
+	_ = jsonInterface(&JSON{})
+	_ = jsonOfInterface[jsonTestDocument](&JSONOf[jsonTestDocument]{})
+	_ = ipInterface(&IP{})
+	_ = prefixInterface(&Prefix{})
+	_ = hardwareAddrInterface(&HardwareAddr{})
+	_ = bigIntInterface(&BigInt{})
+	_ = bigFloatInterface(&BigFloat{})
+	_ = arrayInterface[Int64](&Int64Array{})
+	_ = rangeInterface[Int64](&Int64Range{})
+	_ = stringMapInterface(&StringMap{})
+	_ = intervalInterface(&Interval{})
+	_ = pointInterface(&Point{})
+	_ = lineStringInterface(&LineString{})
+	_ = polygonInterface(&Polygon{})
 }
 
 func TestEncodingBinaryInterface(t *testing.T) {
@@ -154,6 +338,34 @@ func TestEncodingBinaryInterface(t *testing.T) {
 	_ = encoding.BinaryMarshaler(&String{})
 	_ = encoding.BinaryMarshaler(&Time{})
 	_ = encoding.BinaryMarshaler(&Uint64{})
+	_ = encoding.BinaryMarshaler(&Null[int64]{})
+	_ = encoding.BinaryMarshaler(&Optional[int64]{})
+	_ = encoding.BinaryMarshaler(&Int32{})
+	_ = encoding.BinaryMarshaler(&Int16{})
+	_ = encoding.BinaryMarshaler(&Int8{})
+	_ = encoding.BinaryMarshaler(&Uint32{})
+	_ = encoding.BinaryMarshaler(&Uint16{})
+	_ = encoding.BinaryMarshaler(&Uint8{})
+	_ = encoding.BinaryMarshaler(&Float32{})
+	_ = encoding.BinaryMarshaler(&Decimal{})
+	_ = encoding.BinaryMarshaler(&UUID{})
+	_ = encoding.BinaryMarshaler(&Duration{})
+	_ = encoding.BinaryMarshaler(&Date{})
+	_ = encoding.BinaryMarshaler(&TimeOfDay{})
+	_ = encoding.BinaryMarshaler(&JSON{})
+	_ = encoding.BinaryMarshaler(&JSONOf[jsonTestDocument]{})
+	_ = encoding.BinaryMarshaler(&IP{})
+	_ = encoding.BinaryMarshaler(&Prefix{})
+	_ = encoding.BinaryMarshaler(&HardwareAddr{})
+	_ = encoding.BinaryMarshaler(&BigInt{})
+	_ = encoding.BinaryMarshaler(&BigFloat{})
+	_ = encoding.BinaryMarshaler(&Int64Array{})
+	_ = encoding.BinaryMarshaler(&Int64Range{})
+	_ = encoding.BinaryMarshaler(&StringMap{})
+	_ = encoding.BinaryMarshaler(&Interval{})
+	_ = encoding.BinaryMarshaler(&Point{})
+	_ = encoding.BinaryMarshaler(&LineString{})
+	_ = encoding.BinaryMarshaler(&Polygon{})
 
 	_ = encoding.BinaryUnmarshaler(&Bool{})
 	_ = encoding.BinaryUnmarshaler(&Bytes{})
@@ -162,6 +374,34 @@ func TestEncodingBinaryInterface(t *testing.T) {
 	_ = encoding.BinaryUnmarshaler(&String{})
 	_ = encoding.BinaryUnmarshaler(&Time{})
 	_ = encoding.BinaryUnmarshaler(&Uint64{})
+	_ = encoding.BinaryUnmarshaler(&Null[int64]{})
+	_ = encoding.BinaryUnmarshaler(&Optional[int64]{})
+	_ = encoding.BinaryUnmarshaler(&Int32{})
+	_ = encoding.BinaryUnmarshaler(&Int16{})
+	_ = encoding.BinaryUnmarshaler(&Int8{})
+	_ = encoding.BinaryUnmarshaler(&Uint32{})
+	_ = encoding.BinaryUnmarshaler(&Uint16{})
+	_ = encoding.BinaryUnmarshaler(&Uint8{})
+	_ = encoding.BinaryUnmarshaler(&Float32{})
+	_ = encoding.BinaryUnmarshaler(&Decimal{})
+	_ = encoding.BinaryUnmarshaler(&UUID{})
+	_ = encoding.BinaryUnmarshaler(&Duration{})
+	_ = encoding.BinaryUnmarshaler(&Date{})
+	_ = encoding.BinaryUnmarshaler(&TimeOfDay{})
+	_ = encoding.BinaryUnmarshaler(&JSON{})
+	_ = encoding.BinaryUnmarshaler(&JSONOf[jsonTestDocument]{})
+	_ = encoding.BinaryUnmarshaler(&IP{})
+	_ = encoding.BinaryUnmarshaler(&Prefix{})
+	_ = encoding.BinaryUnmarshaler(&HardwareAddr{})
+	_ = encoding.BinaryUnmarshaler(&BigInt{})
+	_ = encoding.BinaryUnmarshaler(&BigFloat{})
+	_ = encoding.BinaryUnmarshaler(&Int64Array{})
+	_ = encoding.BinaryUnmarshaler(&Int64Range{})
+	_ = encoding.BinaryUnmarshaler(&StringMap{})
+	_ = encoding.BinaryUnmarshaler(&Interval{})
+	_ = encoding.BinaryUnmarshaler(&Point{})
+	_ = encoding.BinaryUnmarshaler(&LineString{})
+	_ = encoding.BinaryUnmarshaler(&Polygon{})
 }
 
 func TestEncodingTextInterface(t *testing.T) {
@@ -172,6 +412,34 @@ func TestEncodingTextInterface(t *testing.T) {
 	_ = encoding.TextMarshaler(&String{})
 	_ = encoding.TextMarshaler(&Time{})
 	_ = encoding.TextMarshaler(&Uint64{})
+	_ = encoding.TextMarshaler(&Null[int64]{})
+	_ = encoding.TextMarshaler(&Optional[int64]{})
+	_ = encoding.TextMarshaler(&Int32{})
+	_ = encoding.TextMarshaler(&Int16{})
+	_ = encoding.TextMarshaler(&Int8{})
+	_ = encoding.TextMarshaler(&Uint32{})
+	_ = encoding.TextMarshaler(&Uint16{})
+	_ = encoding.TextMarshaler(&Uint8{})
+	_ = encoding.TextMarshaler(&Float32{})
+	_ = encoding.TextMarshaler(&Decimal{})
+	_ = encoding.TextMarshaler(&UUID{})
+	_ = encoding.TextMarshaler(&Duration{})
+	_ = encoding.TextMarshaler(&Date{})
+	_ = encoding.TextMarshaler(&TimeOfDay{})
+	_ = encoding.TextMarshaler(&JSON{})
+	_ = encoding.TextMarshaler(&JSONOf[jsonTestDocument]{})
+	_ = encoding.TextMarshaler(&IP{})
+	_ = encoding.TextMarshaler(&Prefix{})
+	_ = encoding.TextMarshaler(&HardwareAddr{})
+	_ = encoding.TextMarshaler(&BigInt{})
+	_ = encoding.TextMarshaler(&BigFloat{})
+	_ = encoding.TextMarshaler(&Int64Array{})
+	_ = encoding.TextMarshaler(&Int64Range{})
+	_ = encoding.TextMarshaler(&StringMap{})
+	_ = encoding.TextMarshaler(&Interval{})
+	_ = encoding.TextMarshaler(&Point{})
+	_ = encoding.TextMarshaler(&LineString{})
+	_ = encoding.TextMarshaler(&Polygon{})
 
 	_ = encoding.TextUnmarshaler(&Bool{})
 	_ = encoding.TextUnmarshaler(&Bytes{})
@@ -180,6 +448,34 @@ func TestEncodingTextInterface(t *testing.T) {
 	_ = encoding.TextUnmarshaler(&String{})
 	_ = encoding.TextUnmarshaler(&Time{})
 	_ = encoding.TextUnmarshaler(&Uint64{})
+	_ = encoding.TextUnmarshaler(&Null[int64]{})
+	_ = encoding.TextUnmarshaler(&Optional[int64]{})
+	_ = encoding.TextUnmarshaler(&Int32{})
+	_ = encoding.TextUnmarshaler(&Int16{})
+	_ = encoding.TextUnmarshaler(&Int8{})
+	_ = encoding.TextUnmarshaler(&Uint32{})
+	_ = encoding.TextUnmarshaler(&Uint16{})
+	_ = encoding.TextUnmarshaler(&Uint8{})
+	_ = encoding.TextUnmarshaler(&Float32{})
+	_ = encoding.TextUnmarshaler(&Decimal{})
+	_ = encoding.TextUnmarshaler(&UUID{})
+	_ = encoding.TextUnmarshaler(&Duration{})
+	_ = encoding.TextUnmarshaler(&Date{})
+	_ = encoding.TextUnmarshaler(&TimeOfDay{})
+	_ = encoding.TextUnmarshaler(&JSON{})
+	_ = encoding.TextUnmarshaler(&JSONOf[jsonTestDocument]{})
+	_ = encoding.TextUnmarshaler(&IP{})
+	_ = encoding.TextUnmarshaler(&Prefix{})
+	_ = encoding.TextUnmarshaler(&HardwareAddr{})
+	_ = encoding.TextUnmarshaler(&BigInt{})
+	_ = encoding.TextUnmarshaler(&BigFloat{})
+	_ = encoding.TextUnmarshaler(&Int64Array{})
+	_ = encoding.TextUnmarshaler(&Int64Range{})
+	_ = encoding.TextUnmarshaler(&StringMap{})
+	_ = encoding.TextUnmarshaler(&Interval{})
+	_ = encoding.TextUnmarshaler(&Point{})
+	_ = encoding.TextUnmarshaler(&LineString{})
+	_ = encoding.TextUnmarshaler(&Polygon{})
 }
 
 func TestEncodingJsonInterface(t *testing.T) {
@@ -190,6 +486,34 @@ func TestEncodingJsonInterface(t *testing.T) {
 	_ = json.Marshaler(&String{})
 	_ = json.Marshaler(&Time{})
 	_ = json.Marshaler(&Uint64{})
+	_ = json.Marshaler(&Null[int64]{})
+	_ = json.Marshaler(&Optional[int64]{})
+	_ = json.Marshaler(&Int32{})
+	_ = json.Marshaler(&Int16{})
+	_ = json.Marshaler(&Int8{})
+	_ = json.Marshaler(&Uint32{})
+	_ = json.Marshaler(&Uint16{})
+	_ = json.Marshaler(&Uint8{})
+	_ = json.Marshaler(&Float32{})
+	_ = json.Marshaler(&Decimal{})
+	_ = json.Marshaler(&UUID{})
+	_ = json.Marshaler(&Duration{})
+	_ = json.Marshaler(&Date{})
+	_ = json.Marshaler(&TimeOfDay{})
+	_ = json.Marshaler(&JSON{})
+	_ = json.Marshaler(&JSONOf[jsonTestDocument]{})
+	_ = json.Marshaler(&IP{})
+	_ = json.Marshaler(&Prefix{})
+	_ = json.Marshaler(&HardwareAddr{})
+	_ = json.Marshaler(&BigInt{})
+	_ = json.Marshaler(&BigFloat{})
+	_ = json.Marshaler(&Int64Array{})
+	_ = json.Marshaler(&Int64Range{})
+	_ = json.Marshaler(&StringMap{})
+	_ = json.Marshaler(&Interval{})
+	_ = json.Marshaler(&Point{})
+	_ = json.Marshaler(&LineString{})
+	_ = json.Marshaler(&Polygon{})
 
 	_ = json.Unmarshaler(&Bool{})
 	_ = json.Unmarshaler(&Bytes{})
@@ -198,6 +522,34 @@ func TestEncodingJsonInterface(t *testing.T) {
 	_ = json.Unmarshaler(&String{})
 	_ = json.Unmarshaler(&Time{})
 	_ = json.Unmarshaler(&Uint64{})
+	_ = json.Unmarshaler(&Null[int64]{})
+	_ = json.Unmarshaler(&Optional[int64]{})
+	_ = json.Unmarshaler(&Int32{})
+	_ = json.Unmarshaler(&Int16{})
+	_ = json.Unmarshaler(&Int8{})
+	_ = json.Unmarshaler(&Uint32{})
+	_ = json.Unmarshaler(&Uint16{})
+	_ = json.Unmarshaler(&Uint8{})
+	_ = json.Unmarshaler(&Float32{})
+	_ = json.Unmarshaler(&Decimal{})
+	_ = json.Unmarshaler(&UUID{})
+	_ = json.Unmarshaler(&Duration{})
+	_ = json.Unmarshaler(&Date{})
+	_ = json.Unmarshaler(&TimeOfDay{})
+	_ = json.Unmarshaler(&JSON{})
+	_ = json.Unmarshaler(&JSONOf[jsonTestDocument]{})
+	_ = json.Unmarshaler(&IP{})
+	_ = json.Unmarshaler(&Prefix{})
+	_ = json.Unmarshaler(&HardwareAddr{})
+	_ = json.Unmarshaler(&BigInt{})
+	_ = json.Unmarshaler(&BigFloat{})
+	_ = json.Unmarshaler(&Int64Array{})
+	_ = json.Unmarshaler(&Int64Range{})
+	_ = json.Unmarshaler(&StringMap{})
+	_ = json.Unmarshaler(&Interval{})
+	_ = json.Unmarshaler(&Point{})
+	_ = json.Unmarshaler(&LineString{})
+	_ = json.Unmarshaler(&Polygon{})
 }
 
 func TestSqlDriverValuerInterface(t *testing.T) {
@@ -208,6 +560,34 @@ func TestSqlDriverValuerInterface(t *testing.T) {
 	_ = driver.Valuer(&String{})
 	_ = driver.Valuer(&Time{})
 	_ = driver.Valuer(&Uint64{})
+	_ = driver.Valuer(&Null[int64]{})
+	_ = driver.Valuer(&Optional[int64]{})
+	_ = driver.Valuer(&Int32{})
+	_ = driver.Valuer(&Int16{})
+	_ = driver.Valuer(&Int8{})
+	_ = driver.Valuer(&Uint32{})
+	_ = driver.Valuer(&Uint16{})
+	_ = driver.Valuer(&Uint8{})
+	_ = driver.Valuer(&Float32{})
+	_ = driver.Valuer(&Decimal{})
+	_ = driver.Valuer(&UUID{})
+	_ = driver.Valuer(&Duration{})
+	_ = driver.Valuer(&Date{})
+	_ = driver.Valuer(&TimeOfDay{})
+	_ = driver.Valuer(&JSON{})
+	_ = driver.Valuer(&JSONOf[jsonTestDocument]{})
+	_ = driver.Valuer(&IP{})
+	_ = driver.Valuer(&Prefix{})
+	_ = driver.Valuer(&HardwareAddr{})
+	_ = driver.Valuer(&BigInt{})
+	_ = driver.Valuer(&BigFloat{})
+	_ = driver.Valuer(&Int64Array{})
+	_ = driver.Valuer(&Int64Range{})
+	_ = driver.Valuer(&StringMap{})
+	_ = driver.Valuer(&Interval{})
+	_ = driver.Valuer(&Point{})
+	_ = driver.Valuer(&LineString{})
+	_ = driver.Valuer(&Polygon{})
 }
 
 func TestSqlScannerInterface(t *testing.T) {
@@ -218,4 +598,32 @@ func TestSqlScannerInterface(t *testing.T) {
 	_ = sql.Scanner(&String{})
 	_ = sql.Scanner(&Time{})
 	_ = sql.Scanner(&Uint64{})
+	_ = sql.Scanner(&Null[int64]{})
+	_ = sql.Scanner(&Optional[int64]{})
+	_ = sql.Scanner(&Int32{})
+	_ = sql.Scanner(&Int16{})
+	_ = sql.Scanner(&Int8{})
+	_ = sql.Scanner(&Uint32{})
+	_ = sql.Scanner(&Uint16{})
+	_ = sql.Scanner(&Uint8{})
+	_ = sql.Scanner(&Float32{})
+	_ = sql.Scanner(&Decimal{})
+	_ = sql.Scanner(&UUID{})
+	_ = sql.Scanner(&Duration{})
+	_ = sql.Scanner(&Date{})
+	_ = sql.Scanner(&TimeOfDay{})
+	_ = sql.Scanner(&JSON{})
+	_ = sql.Scanner(&JSONOf[jsonTestDocument]{})
+	_ = sql.Scanner(&IP{})
+	_ = sql.Scanner(&Prefix{})
+	_ = sql.Scanner(&HardwareAddr{})
+	_ = sql.Scanner(&BigInt{})
+	_ = sql.Scanner(&BigFloat{})
+	_ = sql.Scanner(&Int64Array{})
+	_ = sql.Scanner(&Int64Range{})
+	_ = sql.Scanner(&StringMap{})
+	_ = sql.Scanner(&Interval{})
+	_ = sql.Scanner(&Point{})
+	_ = sql.Scanner(&LineString{})
+	_ = sql.Scanner(&Polygon{})
 }
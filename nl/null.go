@@ -0,0 +1,542 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Null is an nullable object of any type
+type Null[T any] struct {
+	V     T    // Value of object
+	Valid bool // Valid is true if value is not NULL
+}
+
+// NewNull Создание нового не действительного объекта Null
+func NewNull[T any]() Null[T] {
+	return Null[T]{
+		Valid: false,
+	}
+}
+
+// NewNullValue Создание нового действительного объекта Null из значения
+func NewNullValue[T any](value T) Null[T] {
+	return Null[T]{
+		V:     value,
+		Valid: true,
+	}
+}
+
+// NewNullPointerValue Создание нового действительного объекта Null из ссылки на значение
+func NewNullPointerValue[T any](ptr *T) Null[T] {
+	if ptr == nil {
+		return NewNull[T]()
+	}
+	return NewNullValue(*ptr)
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (n *Null[T]) SetValid(value T) { n.V, n.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (n *Null[T]) Reset() {
+	var zero T
+	n.V, n.Valid = zero, false
+}
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (n *Null[T]) NullIfDefault() Null[T] {
+	if isDefaultValue(reflect.ValueOf(&n.V).Elem()) {
+		n.Reset()
+	}
+	return *n
+}
+
+// MustValue Возвращает значение в любом случае
+func (n *Null[T]) MustValue() T {
+	var zero T
+	if !n.Valid {
+		return zero
+	}
+	return n.V
+}
+
+// Pointer Возвращает ссылку на значение
+func (n *Null[T]) Pointer() *T {
+	if !n.Valid {
+		return nil
+	}
+	return &n.V
+}
+
+// Scan Реализация интерфейса Scanner
+func (n *Null[T]) Scan(value interface{}) (err error) {
+	var (
+		rv  reflect.Value
+		src reflect.Value
+	)
+
+	if value == nil {
+		n.Reset()
+		return
+	}
+	if scanner, ok := interface{}(&n.V).(sql.Scanner); ok {
+		err = scanner.Scan(value)
+		n.Valid = err == nil
+		return
+	}
+	rv, src = reflect.ValueOf(&n.V).Elem(), reflect.ValueOf(value)
+	switch x := value.(type) {
+	case []byte:
+		if isBytesKind(rv) {
+			rv.SetBytes(append([]byte{}, x...))
+			break
+		}
+		err = n.scanString(rv, string(x))
+	case string:
+		if isBytesKind(rv) {
+			rv.SetBytes([]byte(x))
+			break
+		}
+		err = n.scanString(rv, x)
+	case T:
+		n.V = x
+	default:
+		switch {
+		case isBasicKind(rv) && isBasicKind(src):
+			err = parseKind(rv, asString(value))
+		case src.Type().ConvertibleTo(rv.Type()):
+			rv.Set(src.Convert(rv.Type()))
+		default:
+			err = fmt.Errorf("can't scan type %T into nul.Null[%s]: %v", value, rv.Type(), value)
+		}
+	}
+	if n.Valid = err == nil; !n.Valid {
+		n.Reset()
+	}
+
+	return
+}
+
+// Разбор строки через encoding.TextUnmarshaler значения, либо по виду значения
+func (n *Null[T]) scanString(rv reflect.Value, str string) (err error) {
+	if unmarshaler, ok := interface{}(&n.V).(encoding.TextUnmarshaler); ok {
+		err = unmarshaler.UnmarshalText([]byte(str))
+		return
+	}
+	err = parseKind(rv, str)
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (n Null[T]) Value() (driver.Value, error) {
+	const maxInt64 = uint64(1<<63 - 1)
+	var rv reflect.Value
+
+	if !n.Valid {
+		return nil, nil
+	}
+	if valuer, ok := interface{}(n.V).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	rv = reflect.ValueOf(n.V)
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > maxInt64 {
+			return []byte(strconv.FormatUint(rv.Uint(), 10)), nil
+		}
+		return int64(rv.Uint()), nil
+	}
+
+	return driver.DefaultParameterConverter.ConvertValue(n.V)
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (n *Null[T]) UnmarshalJSON(data []byte) (err error) {
+	const nullString = "null"
+	var (
+		rv  reflect.Value
+		str string
+	)
+
+	if string(bytes.TrimSpace(data)) == nullString {
+		n.Reset()
+		return
+	}
+	if err = json.Unmarshal(data, &n.V); err != nil {
+		// Числовые и логические значения допускаются в виде строки
+		rv = reflect.ValueOf(&n.V).Elem()
+		if !isBasicKind(rv) || rv.Kind() == reflect.String || json.Unmarshal(data, &str) != nil {
+			n.Reset()
+			return
+		}
+		if len(str) == 0 {
+			n.Reset()
+			err = nil
+			return
+		}
+		err = parseKind(rv, str)
+	}
+	if n.Valid = err == nil; !n.Valid {
+		n.Reset()
+	}
+
+	return
+}
+
+// Функция разбора значения JSON, допускающая также объект вида {"<name>": значение, "Valid": флаг},
+// в котором значение является представлением JSON значения, а флаг действительного значения логическим значением
+func unmarshalNullJSONObject[T any](name string) func(*Null[T], []byte) error {
+	const validKey = "Valid"
+	return func(n *Null[T], data []byte) (err error) {
+		var (
+			object map[string]json.RawMessage
+			valid  bool
+		)
+
+		if data = bytes.TrimSpace(data); len(data) == 0 || data[0] != '{' {
+			return n.UnmarshalJSON(data)
+		}
+		if err = json.Unmarshal(data, &object); err != nil {
+			return
+		}
+		if _, ok := object[name]; !ok || json.Unmarshal(object[validKey], &valid) != nil {
+			return fmt.Errorf("unmarshalling object into go value of type nul.%s requires key %q "+
+				"and key %q to be of type bool", name, name, validKey)
+		}
+		if err = n.UnmarshalJSON(object[name]); err == nil && !valid {
+			n.Reset()
+		}
+
+		return
+	}
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (n Null[T]) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !n.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data, err = json.Marshal(n.V)
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (n *Null[T]) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		n.Reset()
+		return
+	case emptyString:
+		n.Reset()
+		n.Valid = true
+		return
+	default:
+		err = n.scanString(reflect.ValueOf(&n.V).Elem(), str)
+	}
+	if n.Valid = err == nil; !n.Valid {
+		n.Reset()
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (n Null[T]) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+	var str string
+
+	if !n.Valid {
+		text = []byte(nullString)
+		return
+	}
+	if marshaler, ok := interface{}(n.V).(encoding.TextMarshaler); ok {
+		text, err = marshaler.MarshalText()
+		return
+	}
+	if str, err = formatKind(reflect.ValueOf(n.V)); err == nil {
+		text = []byte(str)
+	}
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (n *Null[T]) UnmarshalBinary(data []byte) error {
+	return unmarshalNullBinary[T](binaryTagNull)(n, data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+// Значения типов библиотеки, логические значения, числа и строки кодируются компактно,
+// значения типов, реализующих encoding.BinaryMarshaler, этим интерфейсом, значения остальных типов кодируются gob
+func (n Null[T]) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagNull, n) }
+
+// Запись значения в компактном двоичном формате
+func (n Null[T]) encodeBinary(w *binaryWriter) {
+	if w.flags(n.Valid); !n.Valid {
+		return
+	}
+	writeBinaryValue(w, n.V)
+}
+
+// Чтение значения в компактном двоичном формате
+func (n *Null[T]) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		value T
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			n.Reset()
+		}
+		return
+	}
+	if readBinaryValue(r, &value); r.err == nil {
+		n.V, n.Valid = value, valid
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (n *Null[T]) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.NullWrapper[T]
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.NullWrapper[T])
+	if err = dec.Decode(item); err == nil {
+		n.V, n.Valid = item.Value, item.Valid
+	}
+
+	return
+}
+
+// Изменение значения конкретного типа методом обобщённого объекта Null
+// Конкретные типы хранят значение и флаг действительного значения в собственных полях, поэтому метод вызывается
+// для объекта Null с теми же значениями, а результат записывается обратно в поля
+func setNull[T any, A any](value *T, valid *bool, method func(*Null[T], A) error, arg A) (err error) {
+	var n = Null[T]{V: *value, Valid: *valid}
+
+	err = method(&n, arg)
+	*value, *valid = n.V, n.Valid
+
+	return
+}
+
+// Функция разбора текстового представления значения для методов обобщённого объекта Null
+// Используется конкретными типами, текстовое представление которых разбирается иначе, чем методом UnmarshalText T
+type nullParser[T any] func(string) (T, error)
+
+// Разбор значения Scan, строка и срез байт разбираются функцией, остальные значения методом Scan объекта Null
+func (p nullParser[T]) scan(n *Null[T], value interface{}) (err error) {
+	var str string
+
+	switch x := value.(type) {
+	case []byte:
+		str = string(x)
+	case string:
+		str = x
+	default:
+		return n.Scan(value)
+	}
+	if n.V, err = p(str); err != nil {
+		n.Reset()
+		return
+	}
+	n.Valid = true
+
+	return
+}
+
+// Разбор значения UnmarshalText, текст "null" и пустой текст обрабатываются методом UnmarshalText объекта Null
+func (p nullParser[T]) unmarshalText(n *Null[T], text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	switch string(text) {
+	case nullString, emptyString:
+		return n.UnmarshalText(text)
+	}
+
+	return p.scan(n, string(text))
+}
+
+// Разбор значения UnmarshalJSON, значение допускается только в виде строки, пустая строка является null
+func (p nullParser[T]) unmarshalJSON(n *Null[T], data []byte) (err error) {
+	var v interface{}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		n.Reset()
+	case string:
+		if len(x) == 0 {
+			n.Reset()
+			return
+		}
+		err = p.scan(n, x)
+	default:
+		n.Reset()
+		err = fmt.Errorf("can't unmarshal %s into go value of type %T", reflect.TypeOf(v).Kind(), n.V)
+	}
+
+	return
+}
+
+// Функция разбора значения в компактном двоичном формате с тегом типа tag либо в формате gob предыдущих версий
+func unmarshalNullBinary[T any](tag byte) func(*Null[T], []byte) error {
+	return func(n *Null[T], data []byte) error {
+		if isGobBinary(data) {
+			return n.unmarshalGob(data)
+		}
+		return unmarshalBinary(data, tag, n)
+	}
+}
+
+// Значение является дефолтовым, пустые срезы и карты считаются дефолтовыми
+func isDefaultValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+// Значение является срезом байт
+func isBytesKind(rv reflect.Value) bool {
+	return rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8
+}
+
+// Значение является строкой, числом или логическим значением
+func isBasicKind(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (n *Null[T]) UnmarshalMsgpack(data []byte) (err error) {
+	var (
+		value T
+		rest  []byte
+	)
+
+	if msgpack.IsNil(data) {
+		n.Reset()
+		return msgpackEnd(msgpack.ReadNil(data))
+	}
+	if rest, err = readMsgpackValue(data, &value); err == nil {
+		err = msgpackEnd(rest, nil)
+	}
+	if err == nil {
+		n.V, n.Valid = value, true
+	}
+
+	return
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значения типов, реализующих MarshalMsgpack, кодируются этим методом, логические значения, числа, строки,
+// срезы байт и время соответствующими форматами MessagePack, значения остальных типов через JSON представление
+func (n Null[T]) MarshalMsgpack() ([]byte, error) {
+	if !n.Valid {
+		return msgpack.AppendNil(nil), nil
+	}
+	return appendMsgpackValue(nil, n.V)
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (n *Null[T]) UnmarshalCBOR(data []byte) (err error) {
+	var (
+		value T
+		rest  []byte
+	)
+
+	if cbor.IsNull(data) {
+		n.Reset()
+		return cborEnd(cbor.ReadNull(data))
+	}
+	if rest, err = readCBORValue(data, &value); err == nil {
+		err = cborEnd(rest, nil)
+	}
+	if err == nil {
+		n.V, n.Valid = value, true
+	}
+
+	return
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значения типов, реализующих MarshalCBOR, кодируются этим методом, логические значения, числа, строки,
+// срезы байт и время соответствующими форматами CBOR, значения остальных типов через JSON представление
+func (n Null[T]) MarshalCBOR() ([]byte, error) {
+	if !n.Valid {
+		return cbor.AppendNull(nil), nil
+	}
+	return appendCBORValue(nil, n.V)
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется библиотекой YAML в значение типа T, числовые и логические значения допускаются в виде строки.
+// Значение null библиотека yaml.v3 обрабатывает без вызова метода и не изменяет поле, поэтому действительное
+// значение остаётся действительным, библиотека yaml.v2 присваивает полю null
+func (n *Null[T]) UnmarshalYAML(unmarshal func(interface{}) error) (err error) {
+	var (
+		value T
+		null  bool
+	)
+
+	if value, null, err = unmarshalYAML[T](unmarshal); err == nil {
+		n.V, n.Valid = value, !null
+	}
+
+	return
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется библиотекой YAML, срезы байт строкой, которая записывается с тегом !!binary,
+// если она не является текстом UTF-8, значения типов, реализующих MarshalYAML или MarshalText, этими методами
+func (n Null[T]) MarshalYAML() (interface{}, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return yamlValue(n.V)
+}
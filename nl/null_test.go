@@ -0,0 +1,315 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+type nullTestStatus uint8
+
+func isNullValid[T comparable](t *testing.T, n Null[T], value T, from string) {
+	if !n.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+	if n.V != value {
+		t.Errorf("Bad %s value: %v ≠ %v\n", from, n.V, value)
+	}
+}
+
+func isNullNull[T any](t *testing.T, n Null[T], from string) {
+	if n.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewNull(t *testing.T) {
+	v1 := NewNull[int64]()
+	isNullNull(t, v1, "NewNull()")
+
+	v2 := NewNullValue(int64(math.MaxInt64))
+	isNullValid(t, v2, int64(math.MaxInt64), "NewNullValue()")
+
+	v3 := NewNullPointerValue[string](nil)
+	isNullNull(t, v3, "NewNullPointerValue(nil)")
+
+	str := stringTestBody
+	v4 := NewNullPointerValue(&str)
+	isNullValid(t, v4, stringTestBody, "NewNullPointerValue()")
+}
+
+func TestNullSetValidReset(t *testing.T) {
+	v1 := NewNull[float64]()
+	v1.SetValid(math.MaxFloat64)
+	isNullValid(t, v1, math.MaxFloat64, "SetValid()")
+	v1.Reset()
+	isNullNull(t, v1, "Reset()")
+	if v1.V != 0 {
+		t.Error("Reset()", "value is not zero")
+	}
+}
+
+func TestNullNullIfDefault(t *testing.T) {
+	v1 := NewNullValue(int64(math.MaxInt64))
+	v1.NullIfDefault()
+	isNullValid(t, v1, int64(math.MaxInt64), "NullIfDefault()")
+	v1.SetValid(0)
+	v1.NullIfDefault()
+	isNullNull(t, v1, "NullIfDefault()")
+
+	v2 := NewNullValue([]byte{})
+	v2.NullIfDefault()
+	isNullNull(t, v2, "NullIfDefault([]byte{})")
+
+	v3 := NewNullValue(time.Time{})
+	v3.NullIfDefault()
+	isNullNull(t, v3, "NullIfDefault(time.Time{})")
+}
+
+func TestNullMustValuePointer(t *testing.T) {
+	v1 := NewNull[string]()
+	if v1.MustValue() != "" {
+		t.Error("MustValue()", "is not empty, but should be empty")
+	}
+	if v1.Pointer() != nil {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(stringTestBody)
+	if v1.MustValue() != stringTestBody {
+		t.Error("MustValue()", "is wrong")
+	}
+	if p := v1.Pointer(); p == nil || *p != stringTestBody {
+		t.Error("Pointer()", "is wrong")
+	}
+}
+
+func TestNullScan(t *testing.T) {
+	v1 := NewNull[int64]()
+	errorPanic(v1.Scan(int64(math.MaxInt64)))
+	isNullValid(t, v1, int64(math.MaxInt64), "Scan(int64)")
+
+	v2 := NewNull[int64]()
+	errorPanic(v2.Scan([]byte("9223372036854775807")))
+	isNullValid(t, v2, int64(math.MaxInt64), "Scan([]byte)")
+
+	v3 := NewNullValue[int64](1)
+	errorPanic(v3.Scan(nil))
+	isNullNull(t, v3, "Scan(nil)")
+
+	v4 := NewNull[int32]()
+	if err := v4.Scan(int64(math.MaxInt64)); err == nil {
+		t.Error("Scan()", "overflow error is nil, but should be not nil")
+	}
+	isNullNull(t, v4, "Scan(overflow)")
+
+	v5 := NewNull[nullTestStatus]()
+	errorPanic(v5.Scan(int64(200)))
+	isNullValid(t, v5, nullTestStatus(200), "Scan(custom)")
+
+	v6 := NewNull[time.Time]()
+	errorPanic(v6.Scan(timeStringValue))
+	if !v6.Valid || !v6.V.Equal(timeOkValidValue) {
+		t.Error("Scan(time string)", "is wrong")
+	}
+
+	buf := []byte(stringTestBody)
+	v7 := NewNull[[]byte]()
+	errorPanic(v7.Scan(buf))
+	buf[0] = 0
+	if !v7.Valid || string(v7.V) != stringTestBody {
+		t.Error("Scan([]byte)", "shares memory with the source")
+	}
+
+	v8 := NewNull[bool]()
+	if err := v8.Scan(struct{}{}); err == nil {
+		t.Error("Scan(struct)", "is nil, but should be not nil")
+	}
+	isNullNull(t, v8, "Scan(struct)")
+
+	v9 := NewNull[Int64]()
+	errorPanic(v9.Scan(int64(math.MaxInt64)))
+	if !v9.Valid || !v9.V.Valid || v9.V.Int64 != math.MaxInt64 {
+		t.Error("Scan(sql.Scanner)", "is wrong")
+	}
+}
+
+func TestNullValue(t *testing.T) {
+	var (
+		err error
+		dv  driver.Value
+	)
+
+	v1 := NewNull[uint8]()
+	dv, err = v1.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v2 := NewNullValue(nullTestStatus(200))
+	dv, err = v2.Value()
+	errorPanic(err)
+	if dv.(int64) != 200 {
+		t.Error("Value()", "is wrong")
+	}
+
+	v3 := NewNullValue(uint64(math.MaxUint64))
+	dv, err = v3.Value()
+	errorPanic(err)
+	if !bytes.Equal(dv.([]byte), []byte(uint64String)) {
+		t.Error("Value()", "is wrong")
+	}
+
+	v4 := NewNullValue(NewStringValue(stringTestBody))
+	dv, err = v4.Value()
+	errorPanic(err)
+	if dv.(string) != stringTestBody {
+		t.Error("Value(driver.Valuer)", "is wrong")
+	}
+}
+
+func TestNullUnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewNull[int64]()
+	errorPanic(json.Unmarshal(int64JSON, &v1))
+	isNullValid(t, v1, int64(math.MaxInt64), "UnmarshalJSON()")
+
+	v2 := NewNull[int64]()
+	errorPanic(json.Unmarshal(int64StringJSON, &v2))
+	isNullValid(t, v2, int64(math.MaxInt64), "UnmarshalJSON(string)")
+
+	v3 := NewNullValue[int64](1)
+	errorPanic(json.Unmarshal(boolNullJSON, &v3))
+	isNullNull(t, v3, "UnmarshalJSON(null)")
+
+	v4 := NewNull[int64]()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v4))
+	isNullNull(t, v4, "UnmarshalJSON(blank)")
+
+	v5 := NewNull[int64]()
+	if err = json.Unmarshal(boolFalseJSON, &v5); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isNullNull(t, v5, "UnmarshalJSON(false)")
+
+	v6 := NewNull[int64]()
+	if err = v6.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+
+	v7 := NewNull[string]()
+	errorPanic(json.Unmarshal(stringJSON, &v7))
+	isNullValid(t, v7, stringTestBody, "UnmarshalJSON(string)")
+
+	v8 := NewNull[string]()
+	if err = json.Unmarshal(int64JSON, &v8); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+}
+
+func TestNullMarshalJSON(t *testing.T) {
+	v1 := NewNullValue(int64(math.MaxInt64))
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(int64JSON), "non-empty json marshal")
+
+	v2 := NewNull[int64]()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestNullUnmarshalText(t *testing.T) {
+	v1 := NewNull[uint64]()
+	errorPanic(v1.UnmarshalText(uint64JSON))
+	isNullValid(t, v1, uint64(math.MaxUint64), "UnmarshalText()")
+
+	v2 := NewNull[uint64]()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	isNullValid(t, v2, 0, "UnmarshalText(empty)")
+
+	v3 := NewNullValue[uint64](1)
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isNullNull(t, v3, "UnmarshalText(null)")
+
+	v4 := NewNull[time.Time]()
+	errorPanic(v4.UnmarshalText([]byte(timeStringValue)))
+	if !v4.Valid || !v4.V.Equal(timeOkValidValue) {
+		t.Error("UnmarshalText(time)", "is wrong")
+	}
+
+	v5 := NewNull[uint8]()
+	if err := v5.UnmarshalText([]byte("256")); err == nil {
+		t.Error("UnmarshalText(overflow)", "is nil, but should be not nil")
+	}
+	isNullNull(t, v5, "UnmarshalText(overflow)")
+}
+
+func TestNullMarshalText(t *testing.T) {
+	v1 := NewNullValue(uint64(math.MaxUint64))
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, uint64String, "Non-empty text marshal")
+
+	v2 := NewNull[uint64]()
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "Null text marshal")
+
+	v3 := NewNullValue([]byte(`Test data 1pHuOxADZkeh8Y9WvL75`))
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(bytesTestTextBase64), "Bytes text marshal")
+
+	v4 := NewNullValue(struct{}{})
+	if _, err = v4.MarshalText(); err == nil {
+		t.Error("MarshalText(struct)", "is nil, but should be not nil")
+	}
+}
+
+func TestNullBinary(t *testing.T) {
+	var data []byte
+
+	v1 := NewNullValue(timeOkValidValue)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewNull[time.Time]()
+	errorPanic(v2.UnmarshalBinary(data))
+	if !v2.Valid || !v2.V.Equal(timeOkValidValue) {
+		t.Error("UnmarshalBinary()", "is wrong")
+	}
+
+	v3 := NewNull[string]()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewNullValue(stringTestBody)
+	errorPanic(v4.UnmarshalBinary(data))
+	isNullNull(t, v4, "UnmarshalBinary()")
+}
+
+func TestNullConvert(t *testing.T) {
+	v1 := NewInt64Value(math.MaxInt64)
+	isNullValid(t, v1.Null(), int64(math.MaxInt64), "Int64.Null()")
+	if v2 := NewInt64Null(v1.Null()); v2 != v1 {
+		t.Error("NewInt64Null()", "is wrong")
+	}
+
+	v3 := NewBytesValue([]byte(stringTestBody))
+	v4 := v3.Null()
+	if !v4.Valid || string(v4.V) != stringTestBody {
+		t.Error("Bytes.Null()", "is wrong")
+	}
+	v5 := NewBytesNull(v4)
+	if !v5.Valid || v5.Bytes.String() != stringTestBody {
+		t.Error("NewBytesNull()", "is wrong")
+	}
+	if v6 := NewBytesNull(NewNull[[]byte]()); v6.Valid || v6.Bytes.Len() != 0 {
+		t.Error("NewBytesNull(null)", "is wrong")
+	}
+}
@@ -0,0 +1,259 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"reflect"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Optional is an nullable object of any type which distinguishes absent, null and value states
+// Объект предназначен для частичного обновления (PATCH): поле, отсутствующее в JSON документе,
+// остаётся в состоянии "отсутствует" (Present=false), явный null устанавливает Present=true и Valid=false.
+// При сериализации отсутствующее значение пропускается тегом `json:",omitzero"` (Go 1.24+) через метод IsZero,
+// тег omitempty к структурам не применяется, для него используйте указатель *Optional[T]
+type Optional[T any] struct {
+	V       T    // Value of object
+	Valid   bool // Valid is true if value is not NULL
+	Present bool // Present is true if value was set, even to NULL
+}
+
+// NewOptional Создание нового отсутствующего объекта Optional
+func NewOptional[T any]() Optional[T] {
+	return Optional[T]{
+		Valid:   false,
+		Present: false,
+	}
+}
+
+// NewOptionalNull Создание нового присутствующего объекта Optional со значением null
+func NewOptionalNull[T any]() Optional[T] {
+	return Optional[T]{
+		Valid:   false,
+		Present: true,
+	}
+}
+
+// NewOptionalValue Создание нового действительного объекта Optional из значения
+func NewOptionalValue[T any](value T) Optional[T] {
+	return Optional[T]{
+		V:       value,
+		Valid:   true,
+		Present: true,
+	}
+}
+
+// NewOptionalPointerValue Создание нового присутствующего объекта Optional из ссылки на значение, nil является null
+func NewOptionalPointerValue[T any](ptr *T) Optional[T] {
+	if ptr == nil {
+		return NewOptionalNull[T]()
+	}
+	return NewOptionalValue(*ptr)
+}
+
+// SetValid Изменение значения и установка флагов действительного и присутствующего значения
+func (o *Optional[T]) SetValid(value T) { o.V, o.Valid, o.Present = value, true, true }
+
+// Reset Сброс значения до null, значение остаётся присутствующим
+func (o *Optional[T]) Reset() {
+	var zero T
+	o.V, o.Valid, o.Present = zero, false, true
+}
+
+// Unset Сброс значения до состояния "отсутствует"
+func (o *Optional[T]) Unset() {
+	var zero T
+	o.V, o.Valid, o.Present = zero, false, false
+}
+
+// IsZero Возвращает истину, если значение отсутствует, используется тегом `json:",omitzero"`
+func (o Optional[T]) IsZero() bool { return !o.Present }
+
+// IsNull Возвращает истину, если значение присутствует и является null
+func (o Optional[T]) IsNull() bool { return o.Present && !o.Valid }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (o *Optional[T]) NullIfDefault() Optional[T] {
+	if o.Valid && isDefaultValue(reflect.ValueOf(&o.V).Elem()) {
+		o.Reset()
+	}
+	return *o
+}
+
+// MustValue Возвращает значение в любом случае
+func (o *Optional[T]) MustValue() T {
+	var zero T
+	if !o.Valid {
+		return zero
+	}
+	return o.V
+}
+
+// Pointer Возвращает ссылку на значение
+func (o *Optional[T]) Pointer() *T {
+	if !o.Valid {
+		return nil
+	}
+	return &o.V
+}
+
+// Null Возвращает значение в виде объекта Null, отсутствующее значение возвращается как null
+func (o Optional[T]) Null() Null[T] { return o.null() }
+
+// Значение в виде объекта Null
+func (o Optional[T]) null() Null[T] { return Null[T]{V: o.V, Valid: o.Valid} }
+
+// Установка значения из объекта Null, значение становится присутствующим
+func (o *Optional[T]) fromNull(n Null[T], err error) error {
+	if err != nil {
+		o.Unset()
+		return err
+	}
+	o.V, o.Valid, o.Present = n.V, n.Valid, true
+
+	return nil
+}
+
+// Scan Реализация интерфейса Scanner
+func (o *Optional[T]) Scan(value interface{}) (err error) {
+	var n Null[T]
+
+	err = n.Scan(value)
+
+	return o.fromNull(n, err)
+}
+
+// Value Реализация интерфейса driver.Valuer
+// Отсутствующее значение записывается как null
+func (o Optional[T]) Value() (driver.Value, error) { return o.null().Value() }
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Метод вызывается только для присутствующих в документе полей, поэтому значение становится присутствующим
+func (o *Optional[T]) UnmarshalJSON(data []byte) (err error) {
+	var n Null[T]
+
+	err = n.UnmarshalJSON(data)
+
+	return o.fromNull(n, err)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (o Optional[T]) MarshalJSON() (data []byte, err error) { return o.null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (o *Optional[T]) UnmarshalText(text []byte) (err error) {
+	var n Null[T]
+
+	err = n.UnmarshalText(text)
+
+	return o.fromNull(n, err)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (o Optional[T]) MarshalText() (text []byte, err error) { return o.null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (o *Optional[T]) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return o.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagOptional, o)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (o Optional[T]) MarshalBinary() (data []byte, err error) {
+	return marshalBinary(binaryTagOptional, o)
+}
+
+// Запись значения в компактном двоичном формате
+func (o Optional[T]) encodeBinary(w *binaryWriter) {
+	if w.flags(o.Valid, o.Present); !o.Valid {
+		return
+	}
+	writeBinaryValue(w, o.V)
+}
+
+// Чтение значения в компактном двоичном формате
+func (o *Optional[T]) decodeBinary(r *binaryReader) {
+	var (
+		valid, present bool
+		value          T
+	)
+
+	if r.flags(&valid, &present); !valid {
+		if r.err == nil {
+			o.Reset()
+			o.Present = present
+		}
+		return
+	}
+	if readBinaryValue(r, &value); r.err == nil {
+		o.V, o.Valid, o.Present = value, valid, present
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (o *Optional[T]) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.OptionalWrapper[T]
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.OptionalWrapper[T])
+	if err = dec.Decode(item); err == nil {
+		o.V, o.Valid, o.Present = item.Value, item.Valid, item.Present
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение становится присутствующим
+func (o *Optional[T]) UnmarshalMsgpack(data []byte) (err error) {
+	var n Null[T]
+
+	err = n.UnmarshalMsgpack(data)
+
+	return o.fromNull(n, err)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Отсутствующее значение кодируется nil
+func (o Optional[T]) MarshalMsgpack() ([]byte, error) { return o.null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение становится присутствующим
+func (o *Optional[T]) UnmarshalCBOR(data []byte) (err error) {
+	var n Null[T]
+
+	err = n.UnmarshalCBOR(data)
+
+	return o.fromNull(n, err)
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Отсутствующее значение кодируется null
+func (o Optional[T]) MarshalCBOR() ([]byte, error) { return o.null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение становится присутствующим. Значение null библиотека YAML обрабатывает без вызова метода:
+// yaml.v2 присваивает полю отсутствующее значение, а yaml.v3 оставляет поле без изменений
+func (o *Optional[T]) UnmarshalYAML(unmarshal func(interface{}) error) (err error) {
+	var n Null[T]
+
+	err = n.UnmarshalYAML(unmarshal)
+
+	return o.fromNull(n, err)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Отсутствующее значение кодируется nil
+func (o Optional[T]) MarshalYAML() (interface{}, error) { return o.null().MarshalYAML() }
@@ -0,0 +1,161 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"testing"
+)
+
+type optionalTestPatch struct {
+	Name  Optional[string] `json:"name"`
+	Age   Optional[int64]  `json:"age"`
+	Email Optional[String] `json:"email"`
+}
+
+func isOptionalAbsent[T any](t *testing.T, o Optional[T], from string) {
+	if o.Present || o.Valid || !o.IsZero() {
+		t.Error(from, "is present, but should be absent")
+	}
+}
+
+func isOptionalNull[T any](t *testing.T, o Optional[T], from string) {
+	if !o.Present || o.Valid || !o.IsNull() || o.IsZero() {
+		t.Error(from, "is not null, but should be null")
+	}
+}
+
+func isOptionalValid[T comparable](t *testing.T, o Optional[T], value T, from string) {
+	if !o.Present || !o.Valid || o.IsNull() || o.IsZero() {
+		t.Error(from, "is invalid, but should be valid")
+	}
+	if o.V != value {
+		t.Errorf("Bad %s value: %v ≠ %v\n", from, o.V, value)
+	}
+}
+
+func TestNewOptional(t *testing.T) {
+	isOptionalAbsent(t, NewOptional[int64](), "NewOptional()")
+	isOptionalNull(t, NewOptionalNull[int64](), "NewOptionalNull()")
+	isOptionalValid(t, NewOptionalValue[int64](12), 12, "NewOptionalValue()")
+	isOptionalNull(t, NewOptionalPointerValue[int64](nil), "NewOptionalPointerValue()")
+
+	v1 := NewOptionalValue[int64](12)
+	isOptionalValid(t, NewOptionalPointerValue(v1.Pointer()), 12, "NewOptionalPointerValue()")
+}
+
+func TestOptionalSetValidReset(t *testing.T) {
+	var v Optional[int64]
+
+	if v.Pointer() != nil || v.MustValue() != 0 {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v.SetValid(12)
+	isOptionalValid(t, v, 12, "SetValid()")
+	v.NullIfDefault()
+	isOptionalValid(t, v, 12, "NullIfDefault()")
+	v.Reset()
+	isOptionalNull(t, v, "Reset()")
+	v.Unset()
+	isOptionalAbsent(t, v, "Unset()")
+	v.SetValid(0)
+	v.NullIfDefault()
+	isOptionalNull(t, v, "NullIfDefault()")
+	v.Unset()
+	v.NullIfDefault()
+	isOptionalAbsent(t, v, "NullIfDefault()")
+}
+
+func TestOptionalUnmarshalJSON(t *testing.T) {
+	var v optionalTestPatch
+
+	errorPanic(json.Unmarshal([]byte(`{"name":"Alice","age":null}`), &v))
+	isOptionalValid(t, v.Name, "Alice", "UnmarshalJSON(name)")
+	isOptionalNull(t, v.Age, "UnmarshalJSON(age)")
+	isOptionalAbsent(t, v.Email, "UnmarshalJSON(email)")
+
+	v = optionalTestPatch{}
+	errorPanic(json.Unmarshal([]byte(`{"age":"42","email":"a@example.com"}`), &v))
+	isOptionalAbsent(t, v.Name, "UnmarshalJSON(name)")
+	isOptionalValid(t, v.Age, 42, "UnmarshalJSON(age)")
+	isOptionalValid(t, v.Email, NewStringValue("a@example.com"), "UnmarshalJSON(email)")
+
+	v = optionalTestPatch{}
+	errorPanic(json.Unmarshal([]byte(`{"email":null}`), &v))
+	isOptionalNull(t, v.Email, "UnmarshalJSON(email)")
+
+	if err := json.Unmarshal([]byte(`{"age":"abc"}`), &v); err == nil {
+		t.Error("UnmarshalJSON()", "error is nil, but should be not nil")
+	}
+}
+
+func TestOptionalMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(optionalTestPatch{Name: NewOptionalValue("Bob"), Age: NewOptionalNull[int64]()})
+	errorPanic(err)
+	jsonEquals(t, data, `{"name":"Bob","age":null,"email":null}`, "json marshal")
+
+	data, err = json.Marshal(struct {
+		Age *Optional[int64] `json:"age,omitempty"`
+	}{})
+	errorPanic(err)
+	jsonEquals(t, data, `{}`, "omitempty json marshal")
+}
+
+func TestOptionalScanValue(t *testing.T) {
+	var v Optional[int64]
+
+	errorPanic(v.Scan("12"))
+	isOptionalValid(t, v, 12, "Scan()")
+	val, err := v.Value()
+	errorPanic(err)
+	if val != int64(12) {
+		t.Errorf("Value() is %v, but should be 12", val)
+	}
+
+	errorPanic(v.Scan(nil))
+	isOptionalNull(t, v, "Scan(nil)")
+	if err = v.Scan("abc"); err == nil {
+		t.Error("Scan()", "error is nil, but should be not nil")
+	}
+	isOptionalAbsent(t, v, "Scan(error)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != nil {
+		t.Errorf("Value() is %v, but should be nil", val)
+	}
+	if n := NewOptionalValue[int64](12).Null(); !n.Valid || n.V != 12 {
+		t.Error("Null()", "is wrong")
+	}
+}
+
+func TestOptionalText(t *testing.T) {
+	var v Optional[int64]
+
+	errorPanic(v.UnmarshalText([]byte("12")))
+	isOptionalValid(t, v, 12, "UnmarshalText()")
+	data, err := v.MarshalText()
+	errorPanic(err)
+	if string(data) != "12" {
+		t.Errorf("MarshalText() is %s, but should be 12", data)
+	}
+
+	errorPanic(v.UnmarshalText([]byte("null")))
+	isOptionalNull(t, v, "UnmarshalText(null)")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != "null" {
+		t.Errorf("MarshalText() is %s, but should be null", data)
+	}
+}
+
+func TestOptionalBinary(t *testing.T) {
+	for _, v1 := range []Optional[int64]{NewOptional[int64](), NewOptionalNull[int64](), NewOptionalValue[int64](12)} {
+		data, err := v1.MarshalBinary()
+		errorPanic(err)
+		var v2 Optional[int64]
+		errorPanic(v2.UnmarshalBinary(data))
+		if v2 != v1 {
+			t.Errorf("UnmarshalBinary() is %v, but should be %v", v2, v1)
+		}
+	}
+}
@@ -0,0 +1,438 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	periodDaysPerMonth    = 30                                    // Количество дней в месяце при переносе дробной части месяцев, как в PostgreSQL
+	periodMicrosPerSecond = int64(time.Second / time.Microsecond) // Количество микросекунд в секунде
+	periodMicrosPerDay    = 24 * 60 * 60 * periodMicrosPerSecond  // Количество микросекунд в сутках
+)
+
+// Множители единиц измерения периода
+var periodUnits = map[string]struct {
+	Months int64 // Количество месяцев в единице
+	Days   int64 // Количество дней в единице
+	Micros int64 // Количество микросекунд в единице
+}{
+	"millennium":  {Months: 12000},
+	"century":     {Months: 1200},
+	"decade":      {Months: 120},
+	"year":        {Months: 12},
+	"month":       {Months: 1},
+	"week":        {Days: 7},
+	"day":         {Days: 1},
+	"hour":        {Micros: 60 * 60 * periodMicrosPerSecond},
+	"minute":      {Micros: 60 * periodMicrosPerSecond},
+	"second":      {Micros: periodMicrosPerSecond},
+	"millisecond": {Micros: 1000},
+	"microsecond": {Micros: 1},
+}
+
+// Синонимы единиц измерения периода
+var periodUnitAliases = map[string]string{
+	"millennia": "millennium", "millenniums": "millennium", "mil": "millennium", "mils": "millennium",
+	"centuries": "century", "c": "century", "cent": "century",
+	"decades": "decade", "dec": "decade", "decs": "decade",
+	"years": "year", "y": "year", "yr": "year", "yrs": "year",
+	"months": "month", "mon": "month", "mons": "month",
+	"weeks": "week", "w": "week",
+	"days": "day", "d": "day",
+	"hours": "hour", "h": "hour", "hr": "hour", "hrs": "hour",
+	"minutes": "minute", "m": "minute", "min": "minute", "mins": "minute",
+	"seconds": "second", "s": "second", "sec": "second", "secs": "second",
+	"milliseconds": "millisecond", "ms": "millisecond", "msec": "millisecond", "msecs": "millisecond",
+	"microseconds": "microsecond", "us": "microsecond", "usec": "microsecond", "usecs": "microsecond",
+}
+
+// Period Интервал времени PostgreSQL из месяцев, дней и микросекунд
+// Компоненты хранятся раздельно, так как длина месяца и дня зависит от даты, к которой прибавляется интервал
+type Period struct {
+	Months       int32 // Количество месяцев
+	Days         int32 // Количество дней
+	Microseconds int64 // Количество микросекунд
+}
+
+// PeriodOf Возвращает период из месяцев, дней и продолжительности, продолжительность округляется до микросекунд
+func PeriodOf(months int, days int, d time.Duration) Period {
+	return Period{Months: int32(months), Days: int32(days), Microseconds: int64(d / time.Microsecond)}
+}
+
+// IsZero Возвращает истину, если все компоненты периода равны нулю
+func (p Period) IsZero() bool { return p.Months == 0 && p.Days == 0 && p.Microseconds == 0 }
+
+// Neg Возвращает период с противоположным знаком всех компонентов
+func (p Period) Neg() Period {
+	return Period{Months: -p.Months, Days: -p.Days, Microseconds: -p.Microseconds}
+}
+
+// Duration Возвращает продолжительность части периода, не зависящей от календаря
+func (p Period) Duration() time.Duration { return time.Duration(p.Microseconds) * time.Microsecond }
+
+// AddTo Прибавление периода к моменту времени так же, как это делает PostgreSQL
+// Сначала прибавляются месяцы с ограничением дня последним днём месяца, затем дни и затем время
+func (p Period) AddTo(t time.Time) time.Time {
+	var (
+		year, month, day = t.Date()
+		hour, min, sec   = t.Clock()
+		months           = int(month) - 1 + int(p.Months)
+	)
+
+	if p.Months != 0 {
+		year += months / 12
+		if months %= 12; months < 0 {
+			year, months = year-1, months+12
+		}
+		month = time.Month(months + 1)
+		if last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > last {
+			day = last
+		}
+		t = time.Date(year, month, day, hour, min, sec, t.Nanosecond(), t.Location())
+	}
+	if p.Days != 0 {
+		t = t.AddDate(0, 0, int(p.Days))
+	}
+
+	return t.Add(p.Duration())
+}
+
+// String Возвращает период в формате ISO 8601, например "P1Y2M3DT4H5M6.5S"
+// Знак указывается у каждого компонента, как в стиле iso_8601 PostgreSQL
+func (p Period) String() string {
+	var (
+		buf    strings.Builder
+		micros = p.Microseconds
+		years  = p.Months / 12
+		months = p.Months % 12
+	)
+
+	if p.IsZero() {
+		return "PT0S"
+	}
+	buf.WriteByte('P')
+	writePeriodField(&buf, int64(years), 'Y')
+	writePeriodField(&buf, int64(months), 'M')
+	writePeriodField(&buf, int64(p.Days), 'D')
+	if micros == 0 {
+		return buf.String()
+	}
+	buf.WriteByte('T')
+	writePeriodField(&buf, micros/(60*60*periodMicrosPerSecond), 'H')
+	micros %= 60 * 60 * periodMicrosPerSecond
+	writePeriodField(&buf, micros/(60*periodMicrosPerSecond), 'M')
+	if micros %= 60 * periodMicrosPerSecond; micros != 0 {
+		if micros < 0 {
+			buf.WriteByte('-')
+			micros = -micros
+		}
+		buf.WriteString(strconv.FormatInt(micros/periodMicrosPerSecond, 10))
+		if frac := micros % periodMicrosPerSecond; frac != 0 {
+			buf.WriteString(strings.TrimRight(fmt.Sprintf(".%06d", frac), "0"))
+		}
+		buf.WriteByte('S')
+	}
+
+	return buf.String()
+}
+
+// Форматирование не нулевого компонента периода в формате ISO 8601
+func writePeriodField(buf *strings.Builder, value int64, designator byte) {
+	if value == 0 {
+		return
+	}
+	buf.WriteString(strconv.FormatInt(value, 10))
+	buf.WriteByte(designator)
+}
+
+// Накопление компонентов периода при разборе с проверкой переполнения
+type periodBuilder struct {
+	Months int64
+	Days   int64
+	Micros int64
+}
+
+// Прибавление значения в единицах измерения с переносом дробной части в меньшие компоненты, как в PostgreSQL
+func (b *periodBuilder) add(value float64, unit string) error {
+	var (
+		u, ok = periodUnits[unit]
+		whole float64
+		frac  float64
+	)
+
+	if !ok {
+		return fmt.Errorf("unknown unit %q", unit)
+	}
+	switch {
+	case u.Months >= 12:
+		b.Months += int64(math.Round(value * float64(u.Months)))
+	case u.Months != 0:
+		whole, frac = math.Modf(value * float64(u.Months))
+		b.Months += int64(whole)
+		whole, frac = math.Modf(frac * periodDaysPerMonth)
+		b.Days += int64(whole)
+		b.Micros += int64(math.Round(frac * float64(periodMicrosPerDay)))
+	case u.Days != 0:
+		days := value * float64(u.Days)
+		whole, frac = math.Modf(days)
+		b.Days += int64(whole)
+		b.Micros += int64(math.Round(frac * float64(periodMicrosPerDay)))
+	default:
+		whole, frac = math.Modf(value)
+		b.Micros += int64(whole)*u.Micros + int64(math.Round(frac*float64(u.Micros)))
+	}
+
+	return nil
+}
+
+// Период из накопленных компонентов
+func (b *periodBuilder) period(neg bool) (ret Period, err error) {
+	if neg {
+		b.Months, b.Days, b.Micros = -b.Months, -b.Days, -b.Micros
+	}
+	if b.Months < math.MinInt32 || b.Months > math.MaxInt32 || b.Days < math.MinInt32 || b.Days > math.MaxInt32 {
+		err = fmt.Errorf("interval out of range")
+		return
+	}
+	ret = Period{Months: int32(b.Months), Days: int32(b.Days), Microseconds: b.Micros}
+
+	return
+}
+
+// ParsePeriod Разбор интервала в формате ISO 8601 или в стилях вывода PostgreSQL
+// Поддерживаются стили postgres "1 year 2 mons -3 days +04:05:06", postgres_verbose "@ 1 year 2 mons ago",
+// sql_standard "1-2 +3 -4:05:06" и iso_8601 "P1Y2M3DT4H5M6S"
+func ParsePeriod(str string) (ret Period, err error) {
+	var src = str
+
+	if str = strings.TrimSpace(str); str == "" {
+		err = fmt.Errorf("can't parse %q as interval: empty string", src)
+		return
+	}
+	if str[0] == 'P' || str[0] == 'p' {
+		ret, err = parseISOPeriod(str[1:])
+	} else {
+		ret, err = parsePostgresPeriod(str)
+	}
+	if err != nil {
+		err = fmt.Errorf("can't parse %q as interval: %s", src, err)
+	}
+
+	return
+}
+
+// Разбор интервала в формате ISO 8601 без начального символа 'P'
+func parseISOPeriod(str string) (ret Period, err error) {
+	var (
+		b      periodBuilder
+		inTime bool
+		value  float64
+		unit   string
+		n      int
+	)
+
+	if str == "" {
+		return ret, fmt.Errorf("empty ISO 8601 interval")
+	}
+	for len(str) > 0 {
+		if str[0] == 'T' || str[0] == 't' {
+			if inTime || len(str) == 1 {
+				return ret, fmt.Errorf("unexpected 'T'")
+			}
+			inTime, str = true, str[1:]
+			continue
+		}
+		for n = 0; n < len(str) && (str[n] == '+' || str[n] == '-' || str[n] == '.' || str[n] == ',' || (str[n] >= '0' && str[n] <= '9')); n++ {
+		}
+		if n == 0 || n == len(str) {
+			return ret, fmt.Errorf("invalid ISO 8601 interval")
+		}
+		if value, err = strconv.ParseFloat(strings.Replace(str[:n], ",", ".", 1), 64); err != nil {
+			return ret, fmt.Errorf("invalid number %q", str[:n])
+		}
+		switch d := str[n] | 0x20; {
+		case !inTime && d == 'y':
+			unit = "year"
+		case !inTime && d == 'm':
+			unit = "month"
+		case !inTime && d == 'w':
+			unit = "week"
+		case !inTime && d == 'd':
+			unit = "day"
+		case inTime && d == 'h':
+			unit = "hour"
+		case inTime && d == 'm':
+			unit = "minute"
+		case inTime && d == 's':
+			unit = "second"
+		default:
+			return ret, fmt.Errorf("unexpected %q", str[n])
+		}
+		if err = b.add(value, unit); err != nil {
+			return
+		}
+		str = str[n+1:]
+	}
+
+	return b.period(false)
+}
+
+// Разбор интервала в стилях postgres, postgres_verbose и sql_standard
+func parsePostgresPeriod(str string) (ret Period, err error) {
+	var (
+		b       periodBuilder
+		fields  = strings.Fields(strings.ToLower(str))
+		sign    = 1.0
+		neg     bool
+		value   float64
+		unit    string
+		isUnit  bool
+		hasUnit bool
+	)
+
+	if fields[0] == "@" {
+		fields = fields[1:]
+	}
+	if n := len(fields); n > 0 && fields[n-1] == "ago" {
+		fields, neg = fields[:n-1], true
+	}
+	if len(fields) == 0 {
+		return ret, fmt.Errorf("invalid interval")
+	}
+	// В стиле sql_standard знак первого поля относится ко всем полям без явного знака
+	for i := range fields {
+		if _, isUnit = periodUnit(fields[i]); isUnit {
+			hasUnit = true
+		}
+	}
+	if !hasUnit && strings.HasPrefix(fields[0], "-") {
+		sign = -1
+		for _, field := range fields[1:] {
+			if strings.HasPrefix(field, "-") || strings.HasPrefix(field, "+") {
+				sign = 1
+			}
+		}
+	}
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if i > 0 && !strings.HasPrefix(field, "-") && !strings.HasPrefix(field, "+") {
+			field = signPrefix(sign) + field
+		}
+		switch {
+		case strings.Contains(field, ":"):
+			err = parsePeriodClock(&b, field)
+		case strings.Count(strings.TrimLeft(field, "+-"), "-") == 1:
+			err = parsePeriodYearMonth(&b, field)
+		default:
+			if value, err = strconv.ParseFloat(field, 64); err != nil {
+				return ret, fmt.Errorf("invalid number %q", fields[i])
+			}
+			switch {
+			case i+1 < len(fields) && !isPeriodNumber(fields[i+1]):
+				if unit, isUnit = periodUnit(fields[i+1]); !isUnit {
+					return ret, fmt.Errorf("unknown unit %q", fields[i+1])
+				}
+				i++
+			case i+1 < len(fields) && strings.Contains(fields[i+1], ":"):
+				unit = "day"
+			default:
+				unit = "second"
+			}
+			err = b.add(value, unit)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	return b.period(neg)
+}
+
+// Знак числа в виде префикса
+func signPrefix(sign float64) string {
+	if sign < 0 {
+		return "-"
+	}
+	return ""
+}
+
+// Проверка, что поле является числом или временем, а не единицей измерения
+func isPeriodNumber(field string) bool {
+	field = strings.TrimLeft(field, "+-")
+	return len(field) > 0 && (field[0] >= '0' && field[0] <= '9' || field[0] == '.')
+}
+
+// Нормализованное название единицы измерения
+func periodUnit(field string) (ret string, ok bool) {
+	if _, ok = periodUnits[field]; ok {
+		return field, true
+	}
+	ret, ok = periodUnitAliases[field]
+
+	return
+}
+
+// Разбор времени вида [+-]hh:mm[:ss[.frac]]
+func parsePeriodClock(b *periodBuilder, field string) (err error) {
+	var (
+		sign  = 1.0
+		parts []string
+		value float64
+		units = []string{"hour", "minute", "second"}
+	)
+
+	switch field[0] {
+	case '-':
+		sign, field = -1, field[1:]
+	case '+':
+		field = field[1:]
+	}
+	if parts = strings.Split(field, ":"); len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("invalid time %q", field)
+	}
+	for i := range parts {
+		if value, err = strconv.ParseFloat(parts[i], 64); err != nil || value < 0 || strings.ContainsAny(parts[i], "+-") ||
+			(i < len(parts)-1 && strings.Contains(parts[i], ".")) {
+			return fmt.Errorf("invalid time %q", field)
+		}
+		if err = b.add(sign*value, units[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Разбор лет и месяцев вида [+-]y-m стиля sql_standard
+func parsePeriodYearMonth(b *periodBuilder, field string) (err error) {
+	var (
+		sign          = 1.0
+		years, months uint64
+		n             int
+	)
+
+	switch field[0] {
+	case '-':
+		sign, field = -1, field[1:]
+	case '+':
+		field = field[1:]
+	}
+	n = strings.IndexByte(field, '-')
+	if years, err = strconv.ParseUint(field[:n], 10, 31); err != nil {
+		return fmt.Errorf("invalid year-month %q", field)
+	}
+	if months, err = strconv.ParseUint(field[n+1:], 10, 31); err != nil {
+		return fmt.Errorf("invalid year-month %q", field)
+	}
+	if err = b.add(sign*float64(years), "year"); err != nil {
+		return
+	}
+
+	return b.add(sign*float64(months), "month")
+}
@@ -0,0 +1,117 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out Period
+	}{
+		// iso_8601
+		{"P1Y2M3DT4H5M6S", Period{Months: 14, Days: 3, Microseconds: 14706000000}},
+		{"P-1Y-2M3DT-4H-5M-6S", Period{Months: -14, Days: 3, Microseconds: -14706000000}},
+		{"PT0S", Period{}},
+		{"P2W", Period{Days: 14}},
+		{"PT6.5S", Period{Microseconds: 6500000}},
+		{"PT0,000001S", Period{Microseconds: 1}},
+		{"P0.5M", Period{Days: 15}},
+		// postgres
+		{"1 year 2 mons 3 days 04:05:06", Period{Months: 14, Days: 3, Microseconds: 14706000000}},
+		{"-1 years -2 mons +3 days -04:05:06", Period{Months: -14, Days: 3, Microseconds: -14706000000}},
+		{"00:00:00", Period{}},
+		{"1 mon", Period{Months: 1}},
+		{"-1 days +23:59:59.999999", Period{Days: -1, Microseconds: 86399999999}},
+		{"1 day -00:00:01", Period{Days: 1, Microseconds: -1000000}},
+		{"100:00:00", Period{Microseconds: 360000000000}},
+		// postgres_verbose
+		{"@ 1 year 2 mons 3 days 4 hours 5 mins 6 secs", Period{Months: 14, Days: 3, Microseconds: 14706000000}},
+		{"@ 1 year 2 mons -3 days 4 hours 5 mins 6 secs ago", Period{Months: -14, Days: 3, Microseconds: -14706000000}},
+		{"@ 1.5 years", Period{Months: 18}},
+		{"2 weeks 10 milliseconds", Period{Days: 14, Microseconds: 10000}},
+		// sql_standard
+		{"1-2", Period{Months: 14}},
+		{"3 4:05:06", Period{Days: 3, Microseconds: 14706000000}},
+		{"1-2 3 4:05:06", Period{Months: 14, Days: 3, Microseconds: 14706000000}},
+		{"-1-2 +3 -4:05:06", Period{Months: -14, Days: 3, Microseconds: -14706000000}},
+		{"-1-2 3 4:05:06", Period{Months: -14, Days: -3, Microseconds: -14706000000}},
+		{"-0-0 -3 4:05:06", Period{Days: -3, Microseconds: 14706000000}},
+		{"0", Period{}},
+	}
+
+	for _, test := range tests {
+		out, err := ParsePeriod(test.In)
+		errorPanic(err)
+		if out != test.Out {
+			t.Errorf("ParsePeriod(%q) is %+v, but should be %+v", test.In, out, test.Out)
+		}
+	}
+	for _, in := range []string{"", "P", "PT", "P1", "P1H", "PT1D", "P1YT", "1 fortnight", "1:2:3:4", "1:-2", "ago", "@", "1-x", "one day"} {
+		if _, err := ParsePeriod(in); err == nil {
+			t.Errorf("ParsePeriod(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestPeriodString(t *testing.T) {
+	var tests = []struct {
+		In  Period
+		Out string
+	}{
+		{Period{}, "PT0S"},
+		{Period{Months: 14, Days: 3, Microseconds: 14706000000}, "P1Y2M3DT4H5M6S"},
+		{Period{Months: -14, Days: 3, Microseconds: -14706500000}, "P-1Y-2M3DT-4H-5M-6.5S"},
+		{Period{Months: 1}, "P1M"},
+		{Period{Microseconds: 1}, "PT0.000001S"},
+		{Period{Microseconds: -60000000}, "PT-1M"},
+		{PeriodOf(0, 1, time.Hour+time.Nanosecond), "P1DT1H"},
+	}
+
+	for _, test := range tests {
+		if out := test.In.String(); out != test.Out {
+			t.Errorf("String() of %+v is %q, but should be %q", test.In, out, test.Out)
+		}
+		if back, err := ParsePeriod(test.Out); err != nil || back != test.In {
+			t.Errorf("ParsePeriod(%q) is %+v, but should be %+v", test.Out, back, test.In)
+		}
+	}
+}
+
+func TestPeriodAddTo(t *testing.T) {
+	var tests = []struct {
+		Period Period
+		In     time.Time
+		Out    time.Time
+	}{
+		{
+			Period{Months: 1},
+			time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC),
+			time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Period{Months: 1, Days: 1},
+			time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Period{Months: -13},
+			time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Period{Days: -1, Microseconds: 1500000},
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 29, 0, 0, 1, 500000000, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		if out := test.Period.AddTo(test.In); !out.Equal(test.Out) {
+			t.Errorf("AddTo(%v) of %v is %v, but should be %v", test.In, test.Period, out, test.Out)
+		}
+	}
+}
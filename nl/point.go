@@ -0,0 +1,280 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"fmt"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Point is an nullable geometry point object, for PostGIS geometry(Point) and MySQL POINT columns
+type Point struct {
+	Point Coord // Value of object
+	Valid bool  // Valid is true if value is not NULL
+	SRID  int32 // SRID is spatial reference identifier, Value() writes EWKB with SRID if it isn't zero
+}
+
+// NewPoint Создание нового объекта Point
+func NewPoint() Point {
+	return Point{
+		Point: Coord{},
+		Valid: false,
+	}
+}
+
+// NewPointValue Создание нового действительного объекта Point из значения
+func NewPointValue(value Coord) Point {
+	return Point{
+		Point: value,
+		Valid: true,
+	}
+}
+
+// NewPointPointerValue Создание нового действительного объекта Point из ссылки на значение
+func NewPointPointerValue(ptr *Coord) Point {
+	if ptr == nil {
+		return NewPoint()
+	}
+	return NewPointValue(*ptr)
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (p *Point) SetValid(value Coord) { p.Point, p.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (p *Point) Reset() { p.Point, p.Valid = Coord{}, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (p *Point) NullIfDefault() Point {
+	if p.Point == (Coord{}) {
+		p.Reset()
+	}
+	return *p
+}
+
+// MustValue Возвращает значение в любом случае
+func (p *Point) MustValue() Coord {
+	if !p.Valid {
+		return Coord{}
+	}
+	return p.Point
+}
+
+// Pointer Возвращает ссылку на значение
+func (p *Point) Pointer() *Coord {
+	if !p.Valid {
+		return nil
+	}
+	return &p.Point
+}
+
+// Установка значения из колец координат
+func (p *Point) fromRings(rings [][]Coord, srid int32, err error) error {
+	if err == nil {
+		p.Point, p.SRID = rings[0][0], srid
+	}
+	if p.Valid = err == nil; !p.Valid {
+		p.Point = Coord{}
+	}
+
+	return err
+}
+
+// Кольца координат значения
+func (p Point) rings() [][]Coord { return [][]Coord{{p.Point}} }
+
+// Scan Реализация интерфейса Scanner
+// Поддерживаются WKB, EWKB, шестнадцатеричный EWKB PostGIS, внутренний формат MySQL и WKT
+func (p *Point) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		p.Reset()
+		return
+	case []byte:
+		return p.fromRings(decodeGeometry(x, geometryPoint))
+	case string:
+		return p.fromRings(decodeGeometry([]byte(x), geometryPoint))
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.Point: %v", x, value)
+	}
+	p.Reset()
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+// Значение записывается в формате WKB, в запросе используйте ST_GeomFromWKB(?) или ST_GeomFromEWKB(?)
+func (p Point) Value() (driver.Value, error) {
+	if !p.Valid {
+		return nil, nil
+	}
+	return formatWKB(geometryPoint, p.SRID, p.rings()), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение представляется объектом GeoJSON
+func (p *Point) UnmarshalJSON(data []byte) (err error) {
+	const nullString = "null"
+
+	if string(bytes.TrimSpace(data)) == nullString {
+		p.Reset()
+		return
+	}
+	rings, err := parseGeoJSON(data, geometryPoint)
+
+	return p.fromRings(rings, p.SRID, err)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (p Point) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !p.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data, err = formatGeoJSON(geometryPoint, p.rings())
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+// Значение представляется в формате WKT, допускается префикс SRID EWKT
+func (p *Point) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	switch string(text) {
+	case nullString:
+		p.Reset()
+		return
+	case emptyString:
+		p.Point, p.Valid = Coord{}, true
+		return
+	}
+
+	return p.fromRings(parseWKT(string(text), geometryPoint))
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (p Point) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !p.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(formatWKT(geometryPoint, p.rings()))
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (p *Point) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return p.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagPoint, p)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (p Point) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagPoint, p) }
+
+// Запись значения в компактном двоичном формате
+func (p Point) encodeBinary(w *binaryWriter) {
+	if w.flags(p.Valid); !p.Valid {
+		return
+	}
+	w.float64(p.Point.X)
+	w.float64(p.Point.Y)
+	w.varint(int64(p.SRID))
+}
+
+// Чтение значения в компактном двоичном формате
+func (p *Point) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		value Coord
+		srid  int32
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			p.Point, p.Valid, p.SRID = Coord{}, false, 0
+		}
+		return
+	}
+	value.X, value.Y = r.float64(), r.float64()
+	if srid = int32(r.intN(32)); r.err == nil {
+		p.Point, p.Valid, p.SRID = value, valid, srid
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (p *Point) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.PointWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.PointWrapper)
+	if err = dec.Decode(item); err == nil {
+		p.Point, p.Valid, p.SRID = Coord{X: item.X, Y: item.Y}, item.Valid, item.SRID
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки в формате WKT или EWKT
+func (p *Point) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, p) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой в формате WKT, либо EWKT с префиксом SRID, если SRID не равен нулю
+func (p Point) MarshalMsgpack() ([]byte, error) {
+	if !p.Valid {
+		return msgpack.AppendNil(nil), nil
+	}
+	return msgpack.AppendString(nil, formatEWKT(geometryPoint, p.SRID, p.rings())), nil
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки в формате WKT или EWKT
+func (p *Point) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, p) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой в формате WKT, либо EWKT с префиксом SRID, если SRID не равен нулю
+func (p Point) MarshalCBOR() ([]byte, error) {
+	if !p.Valid {
+		return cbor.AppendNull(nil), nil
+	}
+	return cbor.AppendString(nil, formatEWKT(geometryPoint, p.SRID, p.rings())), nil
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из строки в формате WKT или EWKT
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (p *Point) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, p)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой в формате WKT, либо EWKT с префиксом SRID, если SRID не равен нулю
+func (p Point) MarshalYAML() (interface{}, error) {
+	if !p.Valid {
+		return nil, nil
+	}
+	return formatEWKT(geometryPoint, p.SRID, p.rings()), nil
+}
@@ -0,0 +1,157 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+var (
+	pointValue = Coord{X: 1, Y: 2}
+	pointWKT   = "POINT(1 2)"
+	pointJSON  = []byte(`{"type":"Point","coordinates":[1,2]}`)
+)
+
+func isPointValid(t *testing.T, p Point, from string) {
+	if p.Point != pointValue {
+		t.Errorf("Bad %s point: %v ≠ %v\n", from, p.Point, pointValue)
+	}
+	if !p.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isPointNull(t *testing.T, p Point, from string) {
+	if p.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewPoint(t *testing.T) {
+	v1 := NewPoint()
+	isPointNull(t, v1, "NewPoint()")
+
+	v2 := NewPointValue(pointValue)
+	isPointValid(t, v2, "NewPointValue()")
+
+	v3 := NewPointPointerValue(nil)
+	isPointNull(t, v3, "NewPointPointerValue()")
+
+	v4 := NewPointPointerValue(v2.Pointer())
+	isPointValid(t, v4, "NewPointPointerValue()")
+}
+
+func TestPointSetValidReset(t *testing.T) {
+	v1 := NewPoint()
+	if v1.Pointer() != nil || v1.MustValue() != (Coord{}) {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(pointValue)
+	isPointValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isPointValid(t, v1, "NullIfDefault()")
+	v1.SetValid(Coord{})
+	v1.NullIfDefault()
+	isPointNull(t, v1, "NullIfDefault()")
+}
+
+func TestPointScanValue(t *testing.T) {
+	var v Point
+
+	for _, in := range []interface{}{geometryHex(pointWKBHex), pointEWKBHex, geometryHex(pointMySQLHex), pointWKT} {
+		errorPanic(v.Scan(in))
+		isPointValid(t, v, "Scan()")
+	}
+	if v.SRID != 0 {
+		t.Errorf("SRID is %d, but should be 0", v.SRID)
+	}
+	errorPanic(v.Scan(geometryHex(pointEWKBHex)))
+	if v.SRID != 4326 {
+		t.Errorf("SRID is %d, but should be 4326", v.SRID)
+	}
+	val, err := v.Value()
+	errorPanic(err)
+	if !bytes.Equal(val.([]byte), geometryHex(pointEWKBHex)) {
+		t.Errorf("Value() is %x, but should be %s", val, pointEWKBHex)
+	}
+
+	errorPanic(v.Scan(nil))
+	isPointNull(t, v, "Scan(nil)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != nil {
+		t.Errorf("Value() is %v, but should be nil", val)
+	}
+
+	if err = v.Scan(geometryHex(pointZWKBHex)); err == nil {
+		t.Error("Scan()", "error is nil, but should be not nil")
+	}
+	isPointNull(t, v, "Scan(error)")
+	if err = v.Scan(int64(1)); err == nil {
+		t.Error("Scan(int64)", "error is nil, but should be not nil")
+	}
+}
+
+func TestPointJSON(t *testing.T) {
+	var v Point
+
+	errorPanic(json.Unmarshal(pointJSON, &v))
+	isPointValid(t, v, "UnmarshalJSON()")
+	data, err := json.Marshal(v)
+	errorPanic(err)
+	jsonEquals(t, data, string(pointJSON), "non-empty json marshal")
+
+	errorPanic(json.Unmarshal([]byte(`null`), &v))
+	isPointNull(t, v, "UnmarshalJSON(null)")
+	data, err = json.Marshal(v)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+
+	if err = json.Unmarshal([]byte(`{"type":"Polygon","coordinates":[]}`), &v); err == nil {
+		t.Error("UnmarshalJSON()", "error is nil, but should be not nil")
+	}
+	isPointNull(t, v, "UnmarshalJSON(error)")
+}
+
+func TestPointText(t *testing.T) {
+	var v Point
+
+	errorPanic(v.UnmarshalText([]byte("SRID=4326;" + pointWKT)))
+	isPointValid(t, v, "UnmarshalText()")
+	if v.SRID != 4326 {
+		t.Errorf("SRID is %d, but should be 4326", v.SRID)
+	}
+	data, err := v.MarshalText()
+	errorPanic(err)
+	if string(data) != pointWKT {
+		t.Errorf("MarshalText() is %s, but should be %s", data, pointWKT)
+	}
+
+	errorPanic(v.UnmarshalText([]byte("")))
+	if !v.Valid || v.Point != (Coord{}) {
+		t.Error("UnmarshalText()", "of empty text is wrong")
+	}
+
+	errorPanic(v.UnmarshalText([]byte("null")))
+	isPointNull(t, v, "UnmarshalText(null)")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != "null" {
+		t.Errorf("MarshalText() is %s, but should be null", data)
+	}
+}
+
+func TestPointBinary(t *testing.T) {
+	v1 := NewPointValue(pointValue)
+	v1.SRID = 4326
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewPoint()
+	errorPanic(v2.UnmarshalBinary(data))
+	isPointValid(t, v2, "UnmarshalBinary()")
+	if v2.SRID != 4326 {
+		t.Errorf("SRID is %d, but should be 4326", v2.SRID)
+	}
+}
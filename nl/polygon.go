@@ -0,0 +1,306 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"fmt"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Polygon is an nullable geometry polygon object, for PostGIS geometry(Polygon) and MySQL POLYGON columns
+// Первое кольцо является внешней границей, остальные кольца являются отверстиями
+type Polygon struct {
+	Polygon [][]Coord // Value of object
+	Valid   bool      // Valid is true if value is not NULL
+	SRID    int32     // SRID is spatial reference identifier, Value() writes EWKB with SRID if it isn't zero
+}
+
+// NewPolygon Создание нового объекта Polygon
+func NewPolygon() Polygon {
+	return Polygon{
+		Polygon: nil,
+		Valid:   false,
+	}
+}
+
+// NewPolygonValue Создание нового действительного объекта Polygon из значения, значение копируется
+func NewPolygonValue(value [][]Coord) Polygon {
+	return Polygon{
+		Polygon: copyPolygonRings(value),
+		Valid:   true,
+	}
+}
+
+// NewPolygonPointerValue Создание нового действительного объекта Polygon из ссылки на значение
+func NewPolygonPointerValue(ptr *[][]Coord) Polygon {
+	if ptr == nil {
+		return NewPolygon()
+	}
+	return NewPolygonValue(*ptr)
+}
+
+// Копия колец многоугольника
+func copyPolygonRings(rings [][]Coord) [][]Coord {
+	var ret = make([][]Coord, len(rings))
+
+	for i := range rings {
+		ret[i] = append([]Coord{}, rings[i]...)
+	}
+
+	return ret
+}
+
+// SetValid Изменение значения и установка флага действительного значения, значение копируется
+func (pg *Polygon) SetValid(value [][]Coord) { pg.Polygon, pg.Valid = copyPolygonRings(value), true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (pg *Polygon) Reset() { pg.Polygon, pg.Valid = nil, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (pg *Polygon) NullIfDefault() Polygon {
+	if len(pg.Polygon) == 0 {
+		pg.Reset()
+	}
+	return *pg
+}
+
+// MustValue Возвращает значение в любом случае
+func (pg *Polygon) MustValue() [][]Coord {
+	if !pg.Valid {
+		return [][]Coord{}
+	}
+	return pg.Polygon
+}
+
+// Pointer Возвращает ссылку на значение
+func (pg *Polygon) Pointer() *[][]Coord {
+	if !pg.Valid {
+		return nil
+	}
+	return &pg.Polygon
+}
+
+// Установка значения из колец координат
+func (pg *Polygon) fromRings(rings [][]Coord, srid int32, err error) error {
+	if err == nil {
+		pg.Polygon, pg.SRID = rings, srid
+	}
+	if pg.Valid = err == nil; !pg.Valid {
+		pg.Polygon = nil
+	}
+
+	return err
+}
+
+// Scan Реализация интерфейса Scanner
+// Поддерживаются WKB, EWKB, шестнадцатеричный EWKB PostGIS, внутренний формат MySQL и WKT
+func (pg *Polygon) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		pg.Reset()
+		return
+	case []byte:
+		return pg.fromRings(decodeGeometry(x, geometryPolygon))
+	case string:
+		return pg.fromRings(decodeGeometry([]byte(x), geometryPolygon))
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.Polygon: %v", x, value)
+	}
+	pg.Reset()
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+// Значение записывается в формате WKB, в запросе используйте ST_GeomFromWKB(?) или ST_GeomFromEWKB(?)
+func (pg Polygon) Value() (driver.Value, error) {
+	if !pg.Valid {
+		return nil, nil
+	}
+	return formatWKB(geometryPolygon, pg.SRID, pg.Polygon), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение представляется объектом GeoJSON
+func (pg *Polygon) UnmarshalJSON(data []byte) (err error) {
+	const nullString = "null"
+
+	if string(bytes.TrimSpace(data)) == nullString {
+		pg.Reset()
+		return
+	}
+	rings, err := parseGeoJSON(data, geometryPolygon)
+
+	return pg.fromRings(rings, pg.SRID, err)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (pg Polygon) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !pg.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data, err = formatGeoJSON(geometryPolygon, pg.Polygon)
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+// Значение представляется в формате WKT, допускается префикс SRID EWKT
+func (pg *Polygon) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	switch string(text) {
+	case nullString:
+		pg.Reset()
+		return
+	case emptyString:
+		pg.Polygon, pg.Valid = [][]Coord{}, true
+		return
+	}
+
+	return pg.fromRings(parseWKT(string(text), geometryPolygon))
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (pg Polygon) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !pg.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(formatWKT(geometryPolygon, pg.Polygon))
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (pg *Polygon) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return pg.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagPolygon, pg)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (pg Polygon) MarshalBinary() (data []byte, err error) {
+	return marshalBinary(binaryTagPolygon, pg)
+}
+
+// Запись значения в компактном двоичном формате
+func (pg Polygon) encodeBinary(w *binaryWriter) {
+	if w.flags(pg.Valid); !pg.Valid {
+		return
+	}
+	w.varint(int64(pg.SRID))
+	w.uvarint(uint64(len(pg.Polygon)))
+	for i := range pg.Polygon {
+		w.coords(pg.Polygon[i])
+	}
+}
+
+// Чтение значения в компактном двоичном формате
+func (pg *Polygon) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		srid  int32
+		value [][]Coord
+	)
+
+	if r.flags(&valid); !valid {
+		if r.err == nil {
+			pg.Polygon, pg.Valid, pg.SRID = nil, false, 0
+		}
+		return
+	}
+	srid = int32(r.intN(32))
+	value = make([][]Coord, r.count())
+	for i := 0; i < len(value) && r.err == nil; i++ {
+		value[i] = r.coords()
+	}
+	if r.err != nil {
+		return
+	}
+	pg.Polygon, pg.Valid, pg.SRID = value, valid, srid
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (pg *Polygon) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.PolygonWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.PolygonWrapper)
+	if err = dec.Decode(item); err != nil {
+		return
+	}
+	pg.Polygon, pg.Valid, pg.SRID = nil, item.Valid, item.SRID
+	if !pg.Valid {
+		return
+	}
+	pg.Polygon = make([][]Coord, len(item.Value))
+	for i := range item.Value {
+		pg.Polygon[i] = coordsFromWrapper(item.Value[i])
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки в формате WKT или EWKT
+func (pg *Polygon) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, pg) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой в формате WKT, либо EWKT с префиксом SRID, если SRID не равен нулю
+func (pg Polygon) MarshalMsgpack() ([]byte, error) {
+	if !pg.Valid {
+		return msgpack.AppendNil(nil), nil
+	}
+	return msgpack.AppendString(nil, formatEWKT(geometryPolygon, pg.SRID, pg.Polygon)), nil
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки в формате WKT или EWKT
+func (pg *Polygon) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, pg) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой в формате WKT, либо EWKT с префиксом SRID, если SRID не равен нулю
+func (pg Polygon) MarshalCBOR() ([]byte, error) {
+	if !pg.Valid {
+		return cbor.AppendNull(nil), nil
+	}
+	return cbor.AppendString(nil, formatEWKT(geometryPolygon, pg.SRID, pg.Polygon)), nil
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из строки в формате WKT или EWKT
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (pg *Polygon) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, pg)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой в формате WKT, либо EWKT с префиксом SRID, если SRID не равен нулю
+func (pg Polygon) MarshalYAML() (interface{}, error) {
+	if !pg.Valid {
+		return nil, nil
+	}
+	return formatEWKT(geometryPolygon, pg.SRID, pg.Polygon), nil
+}
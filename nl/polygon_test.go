@@ -0,0 +1,156 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var (
+	polygonValue = [][]Coord{
+		{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 0, Y: 0}},
+		{{X: 2, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 4}, {X: 2, Y: 2}},
+	}
+	polygonWKT  = "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,4 2,4 4,2 2))"
+	polygonJSON = []byte(`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[4,2],[4,4],[2,2]]]}`)
+)
+
+func isPolygonValid(t *testing.T, pg Polygon, from string) {
+	if !reflect.DeepEqual(pg.Polygon, polygonValue) {
+		t.Errorf("Bad %s polygon: %v ≠ %v\n", from, pg.Polygon, polygonValue)
+	}
+	if !pg.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isPolygonNull(t *testing.T, pg Polygon, from string) {
+	if pg.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+	if pg.Polygon != nil {
+		t.Error(from, "value is not nil, but should be nil")
+	}
+}
+
+func TestNewPolygon(t *testing.T) {
+	v1 := NewPolygon()
+	isPolygonNull(t, v1, "NewPolygon()")
+
+	v2 := NewPolygonValue(polygonValue)
+	isPolygonValid(t, v2, "NewPolygonValue()")
+	v2.Polygon[1][0].X = 100
+	if polygonValue[1][0].X != 2 {
+		t.Error("NewPolygonValue()", "doesn't copy value")
+	}
+	v2.Polygon[1][0].X = 2
+
+	v3 := NewPolygonPointerValue(nil)
+	isPolygonNull(t, v3, "NewPolygonPointerValue()")
+
+	v4 := NewPolygonPointerValue(v2.Pointer())
+	isPolygonValid(t, v4, "NewPolygonPointerValue()")
+}
+
+func TestPolygonSetValidReset(t *testing.T) {
+	v1 := NewPolygon()
+	if v1.Pointer() != nil || len(v1.MustValue()) != 0 {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(polygonValue)
+	isPolygonValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isPolygonValid(t, v1, "NullIfDefault()")
+	v1.SetValid(nil)
+	v1.NullIfDefault()
+	isPolygonNull(t, v1, "NullIfDefault()")
+}
+
+func TestPolygonScanValue(t *testing.T) {
+	var v Polygon
+
+	v1 := NewPolygonValue(polygonValue)
+	val, err := v1.Value()
+	errorPanic(err)
+	errorPanic(v.Scan(val))
+	isPolygonValid(t, v, "Scan()")
+	errorPanic(v.Scan(append([]byte{0xe6, 0x10, 0x00, 0x00}, val.([]byte)...)))
+	isPolygonValid(t, v, "Scan(mysql)")
+	if v.SRID != 4326 {
+		t.Errorf("SRID is %d, but should be 4326", v.SRID)
+	}
+	errorPanic(v.Scan(polygonWKT))
+	isPolygonValid(t, v, "Scan()")
+
+	errorPanic(v.Scan(nil))
+	isPolygonNull(t, v, "Scan(nil)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != nil {
+		t.Errorf("Value() is %v, but should be nil", val)
+	}
+
+	if err = v.Scan("POLYGON((0 0,1 1)"); err == nil {
+		t.Error("Scan()", "error is nil, but should be not nil")
+	}
+	isPolygonNull(t, v, "Scan(error)")
+	if err = v.Scan(int64(1)); err == nil {
+		t.Error("Scan(int64)", "error is nil, but should be not nil")
+	}
+}
+
+func TestPolygonJSON(t *testing.T) {
+	var v Polygon
+
+	errorPanic(json.Unmarshal(polygonJSON, &v))
+	isPolygonValid(t, v, "UnmarshalJSON()")
+	data, err := json.Marshal(v)
+	errorPanic(err)
+	jsonEquals(t, data, string(polygonJSON), "non-empty json marshal")
+
+	errorPanic(json.Unmarshal([]byte(`null`), &v))
+	isPolygonNull(t, v, "UnmarshalJSON(null)")
+	data, err = json.Marshal(v)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestPolygonText(t *testing.T) {
+	var v Polygon
+
+	errorPanic(v.UnmarshalText([]byte(polygonWKT)))
+	isPolygonValid(t, v, "UnmarshalText()")
+	data, err := v.MarshalText()
+	errorPanic(err)
+	if string(data) != polygonWKT {
+		t.Errorf("MarshalText() is %s, but should be %s", data, polygonWKT)
+	}
+
+	errorPanic(v.UnmarshalText([]byte("")))
+	if !v.Valid || len(v.Polygon) != 0 {
+		t.Error("UnmarshalText()", "of empty text is wrong")
+	}
+
+	errorPanic(v.UnmarshalText([]byte("null")))
+	isPolygonNull(t, v, "UnmarshalText(null)")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != "null" {
+		t.Errorf("MarshalText() is %s, but should be null", data)
+	}
+}
+
+func TestPolygonBinary(t *testing.T) {
+	v1 := NewPolygonValue(polygonValue)
+	v1.SRID = 4326
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewPolygon()
+	errorPanic(v2.UnmarshalBinary(data))
+	isPolygonValid(t, v2, "UnmarshalBinary()")
+	if v2.SRID != 4326 {
+		t.Errorf("SRID is %d, but should be 4326", v2.SRID)
+	}
+}
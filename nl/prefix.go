@@ -0,0 +1,197 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"net/netip"
+	"strings"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Prefix is an nullable netip.Prefix object, for cidr and inet columns
+type Prefix struct {
+	Prefix netip.Prefix // Value of object
+	Valid  bool         // Valid is true if value is not NULL
+}
+
+// NewPrefix Создание нового объекта Prefix
+func NewPrefix() Prefix {
+	return Prefix{
+		Prefix: netip.Prefix{},
+		Valid:  false,
+	}
+}
+
+// NewPrefixValue Создание нового действительного объекта Prefix из значения
+func NewPrefixValue(value netip.Prefix) Prefix {
+	return Prefix{
+		Prefix: value,
+		Valid:  true,
+	}
+}
+
+// NewPrefixPointerValue Создание нового действительного объекта Prefix из ссылки на значение
+func NewPrefixPointerValue(ptr *netip.Prefix) Prefix {
+	if ptr == nil {
+		return NewPrefix()
+	}
+	return NewPrefixValue(*ptr)
+}
+
+// NewPrefixNull Создание нового объекта Prefix из обобщённого объекта Null
+func NewPrefixNull(n Null[netip.Prefix]) Prefix {
+	return Prefix{
+		Prefix: n.V,
+		Valid:  n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (p Prefix) Null() Null[netip.Prefix] { return Null[netip.Prefix]{V: p.Prefix, Valid: p.Valid} }
+
+// ParsePrefix Разбор префикса сети "192.168.0.0/24", адрес без длины префикса рассматривается как префикс из одного адреса
+func ParsePrefix(str string) (ret netip.Prefix, err error) {
+	var addr netip.Addr
+
+	if strings.IndexByte(str, '/') < 0 {
+		if addr, err = netip.ParseAddr(str); err == nil {
+			ret = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		return
+	}
+	ret, err = netip.ParsePrefix(str)
+
+	return
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (p *Prefix) SetValid(value netip.Prefix) { p.Prefix, p.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (p *Prefix) Reset() { p.Prefix, p.Valid = netip.Prefix{}, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (p *Prefix) NullIfDefault() Prefix {
+	if !p.Prefix.IsValid() {
+		p.Reset()
+	}
+	return *p
+}
+
+// MustValue Возвращает значение в любом случае
+func (p *Prefix) MustValue() netip.Prefix {
+	if !p.Valid {
+		return netip.Prefix{}
+	}
+	return p.Prefix
+}
+
+// Pointer Возвращает ссылку на значение
+func (p *Prefix) Pointer() *netip.Prefix {
+	if !p.Valid {
+		return nil
+	}
+	return &p.Prefix
+}
+
+// Scan Реализация интерфейса Scanner
+func (p *Prefix) Scan(value interface{}) error {
+	return setNull(&p.Prefix, &p.Valid, nullParser[netip.Prefix](ParsePrefix).scan, value)
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (p Prefix) Value() (driver.Value, error) {
+	if !p.Valid {
+		return nil, nil
+	}
+	return p.Prefix.String(), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение допускается только в виде строки, пустая строка является null
+func (p *Prefix) UnmarshalJSON(data []byte) error {
+	return setNull(&p.Prefix, &p.Valid, nullParser[netip.Prefix](ParsePrefix).unmarshalJSON, data)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (p Prefix) MarshalJSON() ([]byte, error) { return p.Null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (p *Prefix) UnmarshalText(text []byte) error {
+	return setNull(&p.Prefix, &p.Valid, nullParser[netip.Prefix](ParsePrefix).unmarshalText, text)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (p Prefix) MarshalText() ([]byte, error) { return p.Null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (p *Prefix) UnmarshalBinary(data []byte) error {
+	if isGobBinary(data) {
+		return p.unmarshalGob(data)
+	}
+	return setNull(&p.Prefix, &p.Valid, unmarshalNullBinary[netip.Prefix](binaryTagPrefix), data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (p Prefix) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagPrefix, p.Null()) }
+
+// Запись значения в компактном двоичном формате
+func (p Prefix) encodeBinary(w *binaryWriter) { p.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (p *Prefix) decodeBinary(r *binaryReader) {
+	var n = p.Null()
+
+	n.decodeBinary(r)
+	*p = NewPrefixNull(n)
+}
+
+// Разбор значения в формате gob предыдущих версий, значение хранится в обёртке в двоичном формате netip.Prefix
+func (p *Prefix) unmarshalGob(data []byte) (err error) {
+	var (
+		item  *wrapper.PrefixWrapper
+		value netip.Prefix
+	)
+
+	item = new(wrapper.PrefixWrapper)
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(item); err != nil {
+		return
+	}
+	if err = value.UnmarshalBinary(item.Value); err == nil {
+		p.Prefix, p.Valid = value, item.Valid
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (p *Prefix) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, p) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой текстового представления
+func (p Prefix) MarshalMsgpack() ([]byte, error) { return p.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (p *Prefix) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, p) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой текстового представления
+func (p Prefix) MarshalCBOR() ([]byte, error) { return p.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (p *Prefix) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, p)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (p Prefix) MarshalYAML() (interface{}, error) { return marshalYAMLText(p.Valid, p) }
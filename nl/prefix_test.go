@@ -0,0 +1,201 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+)
+
+var (
+	prefixValue  = netip.MustParsePrefix("2001:db8::/32")
+	prefixString = "2001:db8::/32"
+	prefixJSON   = []byte(`"2001:db8::/32"`)
+)
+
+func isPrefixValid(t *testing.T, p Prefix, from string) {
+	if p.Prefix != prefixValue {
+		t.Errorf("Bad %s prefix: %v ≠ %v\n", from, p.Prefix, prefixValue)
+	}
+	if !p.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isPrefixNull(t *testing.T, p Prefix, from string) {
+	if p.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out netip.Prefix
+	}{
+		{"192.168.0.0/24", netip.MustParsePrefix("192.168.0.0/24")},
+		{"192.168.0.1/24", netip.MustParsePrefix("192.168.0.1/24")},
+		{"192.168.0.1", netip.MustParsePrefix("192.168.0.1/32")},
+		{"2001:DB8::/32", prefixValue},
+		{"2001:db8::1", netip.MustParsePrefix("2001:db8::1/128")},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePrefix(test.In)
+		errorPanic(err)
+		if p != test.Out {
+			t.Errorf("ParsePrefix(%q) is %v, but should be %v", test.In, p, test.Out)
+		}
+	}
+	for _, in := range []string{"", "192.168.0/24", "192.168.0.0/33", "2001:db8::/129", "192.168.0.0/", "net"} {
+		if _, err := ParsePrefix(in); err == nil {
+			t.Errorf("ParsePrefix(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestNewPrefix(t *testing.T) {
+	v1 := NewPrefix()
+	isPrefixNull(t, v1, "NewPrefix()")
+
+	v2 := NewPrefixValue(prefixValue)
+	isPrefixValid(t, v2, "NewPrefixValue()")
+
+	v3 := NewPrefixPointerValue(nil)
+	isPrefixNull(t, v3, "NewPrefixPointerValue()")
+
+	v4 := NewPrefixPointerValue(v2.Pointer())
+	isPrefixValid(t, v4, "NewPrefixPointerValue()")
+}
+
+func TestPrefixSetValidReset(t *testing.T) {
+	v1 := NewPrefix()
+	if v1.Pointer() != nil || v1.MustValue().IsValid() {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(prefixValue)
+	isPrefixValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isPrefixValid(t, v1, "NullIfDefault()")
+	v1.SetValid(netip.Prefix{})
+	v1.NullIfDefault()
+	isPrefixNull(t, v1, "NullIfDefault()")
+}
+
+func TestPrefixScanValue(t *testing.T) {
+	v1 := NewPrefix()
+	errorPanic(v1.Scan([]byte("2001:db8::/32")))
+	isPrefixValid(t, v1, "Scan([]byte)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != prefixString {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewPrefix()
+	errorPanic(v2.Scan(prefixString))
+	isPrefixValid(t, v2, "Scan(string)")
+
+	v3 := NewPrefixValue(prefixValue)
+	errorPanic(v3.Scan(nil))
+	isPrefixNull(t, v3, "Scan(nil)")
+	dv, err = v3.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v4 := NewPrefix()
+	if err = v4.Scan(int64(1)); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v4.Scan("2001:db8::/200"); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isPrefixNull(t, v4, "Scan()")
+}
+
+func TestPrefixUnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewPrefix()
+	errorPanic(json.Unmarshal(prefixJSON, &v1))
+	isPrefixValid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewPrefixValue(prefixValue)
+	errorPanic(json.Unmarshal(boolNullJSON, &v2))
+	isPrefixNull(t, v2, "UnmarshalJSON(null)")
+
+	v3 := NewPrefix()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v3))
+	isPrefixNull(t, v3, "UnmarshalJSON(blank)")
+
+	v4 := NewPrefix()
+	if err = json.Unmarshal(int64JSON, &v4); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = v4.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isPrefixNull(t, v4, "UnmarshalJSON()")
+}
+
+func TestPrefixMarshalJSON(t *testing.T) {
+	v1 := NewPrefixValue(prefixValue)
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(prefixJSON), "MarshalJSON()")
+
+	v2 := NewPrefix()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+}
+
+func TestPrefixText(t *testing.T) {
+	v1 := NewPrefix()
+	errorPanic(v1.UnmarshalText([]byte(prefixString)))
+	isPrefixValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, prefixString, "MarshalText()")
+
+	v2 := NewPrefix()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || v2.Prefix.IsValid() {
+		t.Errorf("Value should be valid")
+	}
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "", "MarshalText(empty)")
+
+	v3 := NewPrefixValue(prefixValue)
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isPrefixNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewPrefix()
+	if err = v4.UnmarshalText([]byte("::/g")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isPrefixNull(t, v4, "UnmarshalText()")
+}
+
+func TestPrefixBinary(t *testing.T) {
+	v1 := NewPrefixValue(prefixValue)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewPrefix()
+	errorPanic(v2.UnmarshalBinary(data))
+	isPrefixValid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewPrefix()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewPrefixValue(prefixValue)
+	errorPanic(v4.UnmarshalBinary(data))
+	isPrefixNull(t, v4, "UnmarshalBinary()")
+}
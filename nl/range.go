@@ -0,0 +1,641 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// Типы границ диапазона
+type rangeElement interface {
+	Int64 | Time | Date
+}
+
+// Bounds Границы диапазона PostgreSQL, граница со значением null означает отсутствие границы
+// Диапазоны дискретных типов Int64 и Date приводятся к каноническому виду [), как в PostgreSQL
+type Bounds[T rangeElement] struct {
+	Lower    T    // Lower bound, null is unbounded
+	Upper    T    // Upper bound, null is unbounded
+	LowerInc bool // LowerInc is true if lower bound is included in range
+	UpperInc bool // UpperInc is true if upper bound is included in range
+	Empty    bool // Empty is true if range contains no values
+}
+
+// Range is an nullable PostgreSQL range object
+type Range[T rangeElement] struct {
+	Range Bounds[T] // Value of object
+	Valid bool      // Valid is true if value is not NULL
+}
+
+// Диапазоны для типов пакета
+type (
+	Int64Range = Range[Int64] // Int64Range is an nullable range of Int64, for int8range and int4range columns
+	TimeRange  = Range[Time]  // TimeRange is an nullable range of Time, for tstzrange and tsrange columns
+	DateRange  = Range[Date]  // DateRange is an nullable range of Date, for daterange columns
+)
+
+// Граница диапазона для сравнения
+type rangeBound[T rangeElement] struct {
+	Value T    // Значение границы, null означает бесконечность
+	Inc   bool // Граница включается в диапазон
+	Lower bool // Граница является нижней
+}
+
+// NewBounds Создание границ диапазона, границы приводятся к каноническому виду
+// Если нижняя граница больше верхней, возвращается ошибка
+func NewBounds[T rangeElement](lower T, upper T, lowerInc bool, upperInc bool) (Bounds[T], error) {
+	return Bounds[T]{Lower: lower, Upper: upper, LowerInc: lowerInc, UpperInc: upperInc}.normalize()
+}
+
+// EmptyBounds Возвращает границы пустого диапазона
+func EmptyBounds[T rangeElement]() Bounds[T] { return Bounds[T]{Empty: true} }
+
+// ParseBounds Разбор литерала диапазона PostgreSQL вида [1,5), (,"2010-01-01 14:30:00+00"] или empty
+func ParseBounds[T rangeElement](str string) (ret Bounds[T], err error) {
+	var lit rangeLiteral
+
+	if lit, err = parseRangeLiteral(str); err != nil {
+		return
+	}
+	if lit.Empty {
+		ret = EmptyBounds[T]()
+		return
+	}
+	if ret.Lower, err = parseRangeValue[T](lit.Lower); err != nil {
+		err = fmt.Errorf("can't parse %q as range: %s", str, err)
+		return
+	}
+	if ret.Upper, err = parseRangeValue[T](lit.Upper); err != nil {
+		err = fmt.Errorf("can't parse %q as range: %s", str, err)
+		return
+	}
+	ret.LowerInc, ret.UpperInc = lit.LowerInc, lit.UpperInc
+
+	return ret.normalize()
+}
+
+// Разбор значения границы, nil означает отсутствие границы
+func parseRangeValue[T rangeElement](str *string) (ret T, err error) {
+	if str == nil {
+		return
+	}
+	switch x := interface{}(&ret).(type) {
+	case *Time:
+		err = scanTimestamp(x, *str)
+	case sql.Scanner:
+		err = x.Scan(*str)
+	}
+
+	return
+}
+
+// Текстовое представление значения границы, nil означает отсутствие границы
+func formatRangeValue[T rangeElement](v T) (ret *string, err error) {
+	var (
+		value driver.Value
+		item  arrayItem
+	)
+
+	if !isRangeValueValid(v) {
+		return
+	}
+	if value, err = interface{}(v).(driver.Valuer).Value(); err != nil {
+		return
+	}
+	if item, err = formatArrayValue(value); err == nil {
+		ret = &item.Value
+	}
+
+	return
+}
+
+// Значение границы не равно null
+func isRangeValueValid[T rangeElement](v T) bool {
+	switch x := interface{}(v).(type) {
+	case Int64:
+		return x.Valid
+	case Time:
+		return x.Valid
+	case Date:
+		return x.Valid
+	}
+	return false
+}
+
+// Сравнение значений границ, значения должны быть не равны null
+func compareRangeValues[T rangeElement](a T, b T) int {
+	var less, greater bool
+
+	switch x := interface{}(a).(type) {
+	case Int64:
+		y := interface{}(b).(Int64)
+		less, greater = x.Int64 < y.Int64, x.Int64 > y.Int64
+	case Time:
+		y := interface{}(b).(Time)
+		less, greater = x.Time.Before(y.Time), x.Time.After(y.Time)
+	case Date:
+		y := interface{}(b).(Date)
+		less, greater = x.Date.Before(y.Date), x.Date.After(y.Date)
+	}
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Следующее значение дискретного типа, для непрерывного типа discrete равен false
+func nextRangeValue[T rangeElement](v T) (ret T, discrete bool, err error) {
+	switch x := interface{}(v).(type) {
+	case Int64:
+		if x.Int64 == math.MaxInt64 {
+			err = fmt.Errorf("range bound %d is out of range", x.Int64)
+			return
+		}
+		ret, discrete = interface{}(NewInt64Value(x.Int64+1)).(T), true
+	case Date:
+		ret, discrete = interface{}(NewDateValue(x.Date.AddDays(1))).(T), true
+	}
+
+	return
+}
+
+// Приведение границ к каноническому виду
+func (b Bounds[T]) normalize() (ret Bounds[T], err error) {
+	var (
+		zero     T
+		discrete bool
+		next     T
+	)
+
+	if b.Empty {
+		return EmptyBounds[T](), nil
+	}
+	if !isRangeValueValid(b.Lower) {
+		b.Lower, b.LowerInc = zero, false
+	}
+	if !isRangeValueValid(b.Upper) {
+		b.Upper, b.UpperInc = zero, false
+	}
+	if isRangeValueValid(b.Lower) && !b.LowerInc {
+		if next, discrete, err = nextRangeValue(b.Lower); err != nil {
+			return
+		} else if discrete {
+			b.Lower, b.LowerInc = next, true
+		}
+	}
+	if isRangeValueValid(b.Upper) && b.UpperInc {
+		if next, discrete, err = nextRangeValue(b.Upper); err != nil {
+			return
+		} else if discrete {
+			b.Upper, b.UpperInc = next, false
+		}
+	}
+	if isRangeValueValid(b.Lower) && isRangeValueValid(b.Upper) {
+		switch c := compareRangeValues(b.Lower, b.Upper); {
+		case c > 0:
+			err = fmt.Errorf("range lower bound must be less than or equal to range upper bound")
+			return
+		case c == 0 && !(b.LowerInc && b.UpperInc):
+			return EmptyBounds[T](), nil
+		}
+	}
+	ret = b
+
+	return
+}
+
+// Нижняя граница для сравнения
+func (b Bounds[T]) lower() rangeBound[T] {
+	return rangeBound[T]{Value: b.Lower, Inc: b.LowerInc, Lower: true}
+}
+
+// Верхняя граница для сравнения
+func (b Bounds[T]) upper() rangeBound[T] {
+	return rangeBound[T]{Value: b.Upper, Inc: b.UpperInc, Lower: false}
+}
+
+// Сравнение границ диапазонов с учётом бесконечности и включения границ, так же, как в PostgreSQL
+func compareRangeBounds[T rangeElement](a rangeBound[T], b rangeBound[T]) int {
+	var aInf, bInf = !isRangeValueValid(a.Value), !isRangeValueValid(b.Value)
+
+	switch {
+	case aInf && bInf && a.Lower == b.Lower:
+		return 0
+	case aInf && a.Lower, bInf && !b.Lower:
+		return -1
+	case aInf, bInf:
+		return 1
+	}
+	if c := compareRangeValues(a.Value, b.Value); c != 0 {
+		return c
+	}
+	switch {
+	case !a.Inc && !b.Inc && a.Lower == b.Lower, a.Inc && b.Inc:
+		return 0
+	case !a.Inc && a.Lower, !b.Inc && !b.Lower:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// IsEmpty Возвращает истину, если диапазон не содержит значений
+func (b Bounds[T]) IsEmpty() bool { return b.Empty }
+
+// IsUnbounded Возвращает истину, если у диапазона нет ни нижней, ни верхней границы
+func (b Bounds[T]) IsUnbounded() bool {
+	return !b.Empty && !isRangeValueValid(b.Lower) && !isRangeValueValid(b.Upper)
+}
+
+// Contains Возвращает истину, если значение входит в диапазон, значение null не входит ни в один диапазон
+func (b Bounds[T]) Contains(v T) bool {
+	var point = rangeBound[T]{Value: v, Inc: true}
+
+	if b.Empty || !isRangeValueValid(v) {
+		return false
+	}
+	point.Lower = true
+	if compareRangeBounds(b.lower(), point) > 0 {
+		return false
+	}
+	point.Lower = false
+
+	return compareRangeBounds(b.upper(), point) >= 0
+}
+
+// Overlaps Возвращает истину, если диапазоны имеют общие значения
+func (b Bounds[T]) Overlaps(o Bounds[T]) bool {
+	if b.Empty || o.Empty {
+		return false
+	}
+	if compareRangeBounds(b.lower(), o.lower()) >= 0 && compareRangeBounds(b.lower(), o.upper()) <= 0 {
+		return true
+	}
+
+	return compareRangeBounds(o.lower(), b.lower()) >= 0 && compareRangeBounds(o.lower(), b.upper()) <= 0
+}
+
+// Intersect Возвращает пересечение диапазонов, диапазоны без общих значений дают пустой диапазон
+func (b Bounds[T]) Intersect(o Bounds[T]) Bounds[T] {
+	var ret = b
+
+	if !b.Overlaps(o) {
+		return EmptyBounds[T]()
+	}
+	if compareRangeBounds(o.lower(), b.lower()) > 0 {
+		ret.Lower, ret.LowerInc = o.Lower, o.LowerInc
+	}
+	if compareRangeBounds(o.upper(), b.upper()) < 0 {
+		ret.Upper, ret.UpperInc = o.Upper, o.UpperInc
+	}
+	if normalized, err := ret.normalize(); err == nil {
+		ret = normalized
+	}
+
+	return ret
+}
+
+// Литерал диапазона
+func (b Bounds[T]) literal() (ret rangeLiteral, err error) {
+	if b.Empty {
+		ret.Empty = true
+		return
+	}
+	ret.LowerInc, ret.UpperInc = b.LowerInc, b.UpperInc
+	if ret.Lower, err = formatRangeValue(b.Lower); err != nil {
+		return
+	}
+	ret.Upper, err = formatRangeValue(b.Upper)
+
+	return
+}
+
+// String Возвращает литерал диапазона PostgreSQL
+func (b Bounds[T]) String() string {
+	var lit, err = b.literal()
+
+	if err != nil {
+		return err.Error()
+	}
+
+	return formatRangeLiteral(lit)
+}
+
+// NewRange Создание нового не действительного объекта Range
+func NewRange[T rangeElement]() Range[T] {
+	return Range[T]{
+		Range: Bounds[T]{},
+		Valid: false,
+	}
+}
+
+// NewRangeValue Создание нового действительного объекта Range из значения
+func NewRangeValue[T rangeElement](value Bounds[T]) Range[T] {
+	return Range[T]{
+		Range: value,
+		Valid: true,
+	}
+}
+
+// NewRangePointerValue Создание нового действительного объекта Range из ссылки на значение
+func NewRangePointerValue[T rangeElement](ptr *Bounds[T]) Range[T] {
+	if ptr == nil {
+		return NewRange[T]()
+	}
+	return NewRangeValue(*ptr)
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (r *Range[T]) SetValid(value Bounds[T]) { r.Range, r.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (r *Range[T]) Reset() { r.Range, r.Valid = Bounds[T]{}, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+// Дефолтовым значением является диапазон без границ
+func (r *Range[T]) NullIfDefault() Range[T] {
+	if r.Range.IsUnbounded() {
+		r.Reset()
+	}
+	return *r
+}
+
+// MustValue Возвращает значение в любом случае
+func (r *Range[T]) MustValue() Bounds[T] {
+	if !r.Valid {
+		return Bounds[T]{}
+	}
+	return r.Range
+}
+
+// Pointer Возвращает ссылку на значение
+func (r *Range[T]) Pointer() *Bounds[T] {
+	if !r.Valid {
+		return nil
+	}
+	return &r.Range
+}
+
+// Contains Проверка вхождения значения в диапазон, если диапазон или значение равны null, результат равен null
+func (r Range[T]) Contains(v T) Bool {
+	if !r.Valid || !isRangeValueValid(v) {
+		return NewBool()
+	}
+	return NewBoolValue(r.Range.Contains(v))
+}
+
+// Overlaps Проверка наличия общих значений диапазонов, если один из диапазонов равен null, результат равен null
+func (r Range[T]) Overlaps(o Range[T]) Bool {
+	if !r.Valid || !o.Valid {
+		return NewBool()
+	}
+	return NewBoolValue(r.Range.Overlaps(o.Range))
+}
+
+// Intersect Пересечение диапазонов, если один из диапазонов равен null, результат равен null
+func (r Range[T]) Intersect(o Range[T]) Range[T] {
+	if !r.Valid || !o.Valid {
+		return NewRange[T]()
+	}
+	return NewRangeValue(r.Range.Intersect(o.Range))
+}
+
+// Scan Реализация интерфейса Scanner
+func (r *Range[T]) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		r.Reset()
+		return
+	case []byte:
+		r.Range, err = ParseBounds[T](string(x))
+	case string:
+		r.Range, err = ParseBounds[T](x)
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.Range: %v", x, value)
+	}
+	if r.Valid = err == nil; !r.Valid {
+		r.Range = Bounds[T]{}
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (r Range[T]) Value() (driver.Value, error) {
+	var (
+		lit rangeLiteral
+		err error
+	)
+
+	if !r.Valid {
+		return nil, nil
+	}
+	if lit, err = r.Range.literal(); err != nil {
+		return nil, err
+	}
+
+	return formatRangeLiteral(lit), nil
+}
+
+// Представление границ диапазона в JSON
+type boundsJSON[T rangeElement] struct {
+	Lower    T    `json:"lower"`
+	Upper    T    `json:"upper"`
+	LowerInc bool `json:"lower_inc"`
+	UpperInc bool `json:"upper_inc"`
+	Empty    bool `json:"empty,omitempty"`
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Диапазон представляется объектом с границами или строкой с литералом диапазона PostgreSQL
+func (r *Range[T]) UnmarshalJSON(data []byte) (err error) {
+	var (
+		v    interface{}
+		item boundsJSON[T]
+	)
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		r.Reset()
+		return
+	case string:
+		if len(x) == 0 {
+			r.Reset()
+			return
+		}
+		r.Range, err = ParseBounds[T](x)
+	case map[string]interface{}:
+		if err = json.Unmarshal(data, &item); err == nil {
+			r.Range, err = Bounds[T](item).normalize()
+		}
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.Range", reflect.TypeOf(v).Kind())
+	}
+	if r.Valid = err == nil; !r.Valid {
+		r.Range = Bounds[T]{}
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (r Range[T]) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !r.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data, err = json.Marshal(boundsJSON[T](r.Range))
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (r *Range[T]) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		r.Reset()
+		return
+	case emptyString:
+		r.Range, r.Valid = Bounds[T]{}, true
+		return
+	default:
+		r.Range, err = ParseBounds[T](str)
+	}
+	if r.Valid = err == nil; !r.Valid {
+		r.Range = Bounds[T]{}
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (r Range[T]) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+	var lit rangeLiteral
+
+	if !r.Valid {
+		text = []byte(nullString)
+		return
+	}
+	if lit, err = r.Range.literal(); err == nil {
+		text = []byte(formatRangeLiteral(lit))
+	}
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (r *Range[T]) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return r.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagRange, r)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (r Range[T]) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagRange, r) }
+
+// Запись значения в компактном двоичном формате
+func (r Range[T]) encodeBinary(w *binaryWriter) {
+	if w.flags(r.Valid, r.Range.LowerInc, r.Range.UpperInc, r.Range.Empty); !r.Valid {
+		return
+	}
+	writeBinaryValue(w, r.Range.Lower)
+	writeBinaryValue(w, r.Range.Upper)
+}
+
+// Чтение значения в компактном двоичном формате
+func (r *Range[T]) decodeBinary(rd *binaryReader) {
+	var (
+		valid bool
+		value Bounds[T]
+	)
+
+	if rd.flags(&valid, &value.LowerInc, &value.UpperInc, &value.Empty); !valid {
+		if rd.err == nil {
+			r.Reset()
+		}
+		return
+	}
+	readBinaryValue(rd, &value.Lower)
+	if readBinaryValue(rd, &value.Upper); rd.err == nil {
+		r.Range, r.Valid = value, valid
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (r *Range[T]) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.RangeWrapper[T]
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.RangeWrapper[T])
+	if err = dec.Decode(item); err == nil {
+		r.Range = Bounds[T]{
+			Lower:    item.Lower,
+			Upper:    item.Upper,
+			LowerInc: item.LowerInc,
+			UpperInc: item.UpperInc,
+			Empty:    item.Empty,
+		}
+		r.Valid = item.Valid
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (r *Range[T]) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, r) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой текстового представления
+func (r Range[T]) MarshalMsgpack() ([]byte, error) { return marshalMsgpackText(r.Valid, r) }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (r *Range[T]) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, r) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой текстового представления
+func (r Range[T]) MarshalCBOR() ([]byte, error) { return marshalCBORText(r.Valid, r) }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (r *Range[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, r)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (r Range[T]) MarshalYAML() (interface{}, error) { return marshalYAMLText(r.Valid, r) }
@@ -0,0 +1,329 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	int64RangeLiteral = `[1,5)`
+	int64RangeJSON    = []byte(`{"lower":1,"upper":5,"lower_inc":true,"upper_inc":false}`)
+)
+
+func int64Bounds(t *testing.T, lower Int64, upper Int64, lowerInc bool, upperInc bool) Bounds[Int64] {
+	ret, err := NewBounds(lower, upper, lowerInc, upperInc)
+	if err != nil {
+		t.Fatalf("NewBounds() error: %s", err)
+	}
+	return ret
+}
+
+func isInt64RangeValid(t *testing.T, r Int64Range, from string) {
+	expected := Bounds[Int64]{Lower: NewInt64Value(1), Upper: NewInt64Value(5), LowerInc: true}
+	if r.Range != expected {
+		t.Errorf("Bad %s range: %v ≠ %v\n", from, r.Range, expected)
+	}
+	if !r.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isRangeNull[T rangeElement](t *testing.T, r Range[T], from string) {
+	if r.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestParseBounds(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out string
+	}{
+		{`[1,5)`, `[1,5)`},
+		{`(0,4]`, `[1,5)`},
+		{`[1,4]`, `[1,5)`},
+		{`[,5]`, `(,6)`},
+		{`(,)`, `(,)`},
+		{`[3,3)`, `empty`},
+		{`(3,4)`, `empty`},
+		{`[3,3]`, `[3,4)`},
+		{`empty`, `empty`},
+	}
+
+	for _, test := range tests {
+		b, err := ParseBounds[Int64](test.In)
+		errorPanic(err)
+		if out := b.String(); out != test.Out {
+			t.Errorf("ParseBounds(%q) is %q, but should be %q", test.In, out, test.Out)
+		}
+	}
+	for _, in := range []string{`[5,1)`, `[a,5)`, `[1,9223372036854775807]`, `1`} {
+		if _, err := ParseBounds[Int64](in); err == nil {
+			t.Errorf("ParseBounds(%q) error is nil, but should be not nil", in)
+		}
+	}
+
+	d, err := ParseBounds[Date](`[2019-03-01,2019-03-31]`)
+	errorPanic(err)
+	if out := d.String(); out != `[2019-03-01,2019-04-01)` {
+		t.Errorf("ParseBounds() is %q, but should be %q", out, `[2019-03-01,2019-04-01)`)
+	}
+
+	tm, err := ParseBounds[Time](`["2010-01-01 14:30:00+00","2010-01-01 15:30:00+00"]`)
+	errorPanic(err)
+	if out := tm.String(); out != `["2010-01-01 14:30:00Z","2010-01-01 15:30:00Z"]` {
+		t.Errorf("ParseBounds() is %q, but should be %q", out, `["2010-01-01 14:30:00Z","2010-01-01 15:30:00Z"]`)
+	}
+}
+
+func TestBoundsContains(t *testing.T) {
+	b := int64Bounds(t, NewInt64Value(1), NewInt64Value(5), true, false)
+	for v, expected := range map[int64]bool{0: false, 1: true, 4: true, 5: false} {
+		if b.Contains(NewInt64Value(v)) != expected {
+			t.Errorf("Contains(%d) of %v is %t, but should be %t", v, b, !expected, expected)
+		}
+	}
+	if b.Contains(NewInt64()) {
+		t.Error("Contains(null)", "is true, but should be false")
+	}
+	if unbounded := (Bounds[Int64]{}); !unbounded.Contains(NewInt64Value(-100)) {
+		t.Error("Contains()", "of unbounded range is false, but should be true")
+	}
+	if EmptyBounds[Int64]().Contains(NewInt64Value(1)) {
+		t.Error("Contains()", "of empty range is true, but should be false")
+	}
+
+	from := time.Date(2010, 1, 1, 14, 30, 0, 0, time.UTC)
+	tb, err := NewBounds(NewTimeValue(from), NewTimeValue(from.Add(time.Hour)), false, true)
+	errorPanic(err)
+	if tb.Contains(NewTimeValue(from)) || !tb.Contains(NewTimeValue(from.Add(time.Hour))) {
+		t.Errorf("Contains() of %v is wrong", tb)
+	}
+}
+
+func TestBoundsOverlapsIntersect(t *testing.T) {
+	var tests = []struct {
+		A, B      string
+		Overlaps  bool
+		Intersect string
+	}{
+		{`[1,5)`, `[3,8)`, true, `[3,5)`},
+		{`[1,5)`, `[5,8)`, false, `empty`},
+		{`[1,5]`, `[5,8)`, true, `[5,6)`},
+		{`(,5)`, `[3,)`, true, `[3,5)`},
+		{`(,)`, `[3,4)`, true, `[3,4)`},
+		{`(,)`, `(,)`, true, `(,)`},
+		{`[1,2)`, `empty`, false, `empty`},
+	}
+
+	for _, test := range tests {
+		a, err := ParseBounds[Int64](test.A)
+		errorPanic(err)
+		b, err := ParseBounds[Int64](test.B)
+		errorPanic(err)
+		if a.Overlaps(b) != test.Overlaps || b.Overlaps(a) != test.Overlaps {
+			t.Errorf("Overlaps() of %s and %s should be %t", test.A, test.B, test.Overlaps)
+		}
+		if out := a.Intersect(b).String(); out != test.Intersect {
+			t.Errorf("Intersect() of %s and %s is %s, but should be %s", test.A, test.B, out, test.Intersect)
+		}
+		if out := b.Intersect(a).String(); out != test.Intersect {
+			t.Errorf("Intersect() of %s and %s is %s, but should be %s", test.B, test.A, out, test.Intersect)
+		}
+	}
+
+	from := time.Date(2010, 1, 1, 14, 30, 0, 0, time.UTC)
+	ta, err := NewBounds(NewTimeValue(from), NewTimeValue(from.Add(time.Hour)), true, false)
+	errorPanic(err)
+	tb, err := NewBounds(NewTimeValue(from.Add(time.Hour)), NewTime(), true, false)
+	errorPanic(err)
+	if ta.Overlaps(tb) || !ta.Intersect(tb).IsEmpty() {
+		t.Errorf("Overlaps() of %v and %v is true, but should be false", ta, tb)
+	}
+}
+
+func TestNewRange(t *testing.T) {
+	v1 := NewRange[Int64]()
+	isRangeNull(t, v1, "NewRange()")
+
+	v2 := NewRangeValue(int64Bounds(t, NewInt64Value(1), NewInt64Value(4), true, true))
+	isInt64RangeValid(t, v2, "NewRangeValue()")
+
+	v3 := NewRangePointerValue[Int64](nil)
+	isRangeNull(t, v3, "NewRangePointerValue()")
+
+	v4 := NewRangePointerValue(v2.Pointer())
+	isInt64RangeValid(t, v4, "NewRangePointerValue()")
+}
+
+func TestRangeSetValidReset(t *testing.T) {
+	v1 := NewRange[Int64]()
+	if v1.Pointer() != nil || !v1.MustValue().IsUnbounded() {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(int64Bounds(t, NewInt64Value(1), NewInt64Value(5), true, false))
+	isInt64RangeValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isInt64RangeValid(t, v1, "NullIfDefault()")
+	v1.SetValid(Bounds[Int64]{})
+	v1.NullIfDefault()
+	isRangeNull(t, v1, "NullIfDefault()")
+}
+
+func TestRangeOperations(t *testing.T) {
+	a := NewRangeValue(int64Bounds(t, NewInt64Value(1), NewInt64Value(5), true, false))
+	b := NewRangeValue(int64Bounds(t, NewInt64Value(3), NewInt64(), true, false))
+
+	if r := a.Contains(NewInt64Value(3)); !r.Valid || !r.Bool {
+		t.Error("Contains()", "is wrong")
+	}
+	if r := a.Contains(NewInt64()); r.Valid {
+		t.Error("Contains(null)", "is valid, but should be invalid")
+	}
+	if r := NewRange[Int64]().Contains(NewInt64Value(3)); r.Valid {
+		t.Error("Contains()", "of null range is valid, but should be invalid")
+	}
+	if r := a.Overlaps(b); !r.Valid || !r.Bool {
+		t.Error("Overlaps()", "is wrong")
+	}
+	if r := a.Overlaps(NewRange[Int64]()); r.Valid {
+		t.Error("Overlaps()", "with null range is valid, but should be invalid")
+	}
+	if r := a.Intersect(b); !r.Valid || r.Range.String() != `[3,5)` {
+		t.Errorf("Intersect() is %v, but should be [3,5)", r.Range)
+	}
+	if r := NewRange[Int64]().Intersect(b); r.Valid {
+		t.Error("Intersect()", "with null range is valid, but should be invalid")
+	}
+}
+
+func TestRangeScanValue(t *testing.T) {
+	var v Int64Range
+
+	errorPanic(v.Scan(int64RangeLiteral))
+	isInt64RangeValid(t, v, "Scan()")
+	errorPanic(v.Scan([]byte(`[1,4]`)))
+	isInt64RangeValid(t, v, "Scan()")
+	val, err := v.Value()
+	errorPanic(err)
+	if val != int64RangeLiteral {
+		t.Errorf("Value() is %v, but should be %v", val, int64RangeLiteral)
+	}
+
+	errorPanic(v.Scan(nil))
+	isRangeNull(t, v, "Scan(nil)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != nil {
+		t.Errorf("Value() is %v, but should be nil", val)
+	}
+
+	if err = v.Scan(`[5,1)`); err == nil {
+		t.Error("Scan()", "error is nil, but should be not nil")
+	}
+	isRangeNull(t, v, "Scan(error)")
+	if err = v.Scan(int64(1)); err == nil {
+		t.Error("Scan(int64)", "error is nil, but should be not nil")
+	}
+
+	var tr TimeRange
+	errorPanic(tr.Scan(`["2010-01-01 14:30:00+00",)`))
+	if !tr.Valid || !tr.Range.Lower.Time.Equal(time.Date(2010, 1, 1, 14, 30, 0, 0, time.UTC)) || tr.Range.Upper.Valid {
+		t.Errorf("Scan() is %v, but should be [2010-01-01 14:30:00Z,)", tr.Range)
+	}
+	val, err = tr.Value()
+	errorPanic(err)
+	if val != `["2010-01-01 14:30:00Z",)` {
+		t.Errorf("Value() is %v, but should be %v", val, `["2010-01-01 14:30:00Z",)`)
+	}
+}
+
+func TestRangeUnmarshalJSON(t *testing.T) {
+	var v Int64Range
+
+	errorPanic(json.Unmarshal(int64RangeJSON, &v))
+	isInt64RangeValid(t, v, "UnmarshalJSON()")
+
+	errorPanic(json.Unmarshal([]byte(`{"lower":0,"upper":4,"upper_inc":true}`), &v))
+	isInt64RangeValid(t, v, "UnmarshalJSON()")
+
+	errorPanic(json.Unmarshal([]byte(`"[1,5)"`), &v))
+	isInt64RangeValid(t, v, "UnmarshalJSON(string)")
+
+	errorPanic(json.Unmarshal([]byte(`{"lower":null,"upper":null}`), &v))
+	if !v.Valid || !v.Range.IsUnbounded() {
+		t.Error("UnmarshalJSON()", "of unbounded range is wrong")
+	}
+
+	errorPanic(json.Unmarshal([]byte(`{"empty":true}`), &v))
+	if !v.Valid || !v.Range.IsEmpty() {
+		t.Error("UnmarshalJSON()", "of empty range is wrong")
+	}
+
+	errorPanic(json.Unmarshal([]byte(`null`), &v))
+	isRangeNull(t, v, "UnmarshalJSON(null)")
+
+	for _, in := range []string{`1`, `[1,5]`, `"[5,1)"`, `{"lower":5,"upper":1}`, `{"lower":"a"}`} {
+		if err := json.Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("UnmarshalJSON(%s) error is nil, but should be not nil", in)
+		}
+		isRangeNull(t, v, "UnmarshalJSON(error)")
+	}
+}
+
+func TestRangeMarshalJSON(t *testing.T) {
+	v1 := NewRangeValue(int64Bounds(t, NewInt64Value(1), NewInt64Value(5), true, false))
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(int64RangeJSON), "non-empty json marshal")
+
+	v2 := NewRangeValue(Bounds[Int64]{Upper: NewInt64Value(5)})
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, `{"lower":null,"upper":5,"lower_inc":false,"upper_inc":false}`, "unbounded json marshal")
+
+	v3 := NewRange[Int64]()
+	data, err = json.Marshal(v3)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestRangeText(t *testing.T) {
+	var v Int64Range
+
+	errorPanic(v.UnmarshalText([]byte(int64RangeLiteral)))
+	isInt64RangeValid(t, v, "UnmarshalText()")
+	data, err := v.MarshalText()
+	errorPanic(err)
+	if string(data) != int64RangeLiteral {
+		t.Errorf("MarshalText() is %s, but should be %s", data, int64RangeLiteral)
+	}
+
+	errorPanic(v.UnmarshalText([]byte("null")))
+	isRangeNull(t, v, "UnmarshalText(null)")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != "null" {
+		t.Errorf("MarshalText() is %s, but should be null", data)
+	}
+}
+
+func TestRangeBinary(t *testing.T) {
+	v1 := NewRangeValue(int64Bounds(t, NewInt64Value(1), NewInt64Value(5), true, false))
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewRange[Int64]()
+	errorPanic(v2.UnmarshalBinary(data))
+	isInt64RangeValid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewRangeValue(int64Bounds(t, NewInt64(), NewInt64Value(5), false, false))
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	errorPanic(v2.UnmarshalBinary(data))
+	if !v2.Valid || v2.Range != v3.Range {
+		t.Errorf("UnmarshalBinary() is %v, but should be %v", v2.Range, v3.Range)
+	}
+}
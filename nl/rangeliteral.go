@@ -0,0 +1,157 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	rangeEmptyString = "empty"          // Литерал пустого диапазона
+	rangeQuoteChars  = "()[],\"\\ \t\n" // Символы, требующие заключения границы в кавычки
+)
+
+// Литерал диапазона PostgreSQL
+type rangeLiteral struct {
+	Lower    *string // Нижняя граница, nil если граница отсутствует
+	Upper    *string // Верхняя граница, nil если граница отсутствует
+	LowerInc bool    // Нижняя граница включается в диапазон
+	UpperInc bool    // Верхняя граница включается в диапазон
+	Empty    bool    // Диапазон пуст
+}
+
+// Разбор литерала диапазона PostgreSQL вида [1,5), (,"2010-01-01 14:30:00+00"] и empty
+func parseRangeLiteral(str string) (ret rangeLiteral, err error) {
+	var (
+		src = str
+		pos int
+	)
+
+	if str = strings.TrimSpace(str); strings.EqualFold(str, rangeEmptyString) {
+		ret.Empty = true
+		return
+	}
+	if len(str) < 3 {
+		err = fmt.Errorf("can't parse %q as range: too short", src)
+		return
+	}
+	switch str[0] {
+	case '[':
+		ret.LowerInc = true
+	case '(':
+	default:
+		err = fmt.Errorf("can't parse %q as range: expected '[' or '('", src)
+		return
+	}
+	switch str[len(str)-1] {
+	case ']':
+		ret.UpperInc = true
+	case ')':
+	default:
+		err = fmt.Errorf("can't parse %q as range: expected ']' or ')'", src)
+		return
+	}
+	if ret.Lower, pos, err = parseRangeBoundLiteral(str, 1); err != nil {
+		err = fmt.Errorf("can't parse %q as range: %s", src, err)
+		return
+	}
+	if pos >= len(str) || str[pos] != ',' {
+		err = fmt.Errorf("can't parse %q as range: expected ',' at position %d", src, pos)
+		return
+	}
+	if ret.Upper, pos, err = parseRangeBoundLiteral(str, pos+1); err != nil {
+		err = fmt.Errorf("can't parse %q as range: %s", src, err)
+		return
+	}
+	if pos != len(str)-1 {
+		err = fmt.Errorf("can't parse %q as range: unexpected %q at position %d", src, str[pos], pos)
+	}
+
+	return
+}
+
+// Разбор границы диапазона до запятой или закрывающей скобки
+// Часть границы может быть заключена в кавычки, удвоенная кавычка внутри кавычек означает символ кавычки
+func parseRangeBoundLiteral(str string, pos int) (ret *string, end int, err error) {
+	var (
+		buf    strings.Builder
+		quoted bool
+		empty  = true
+	)
+
+	for end = pos; end < len(str); end++ {
+		c := str[end]
+		switch {
+		case c == '\\':
+			if end++; end >= len(str) {
+				err = fmt.Errorf("unexpected end of range")
+				return
+			}
+			buf.WriteByte(str[end])
+		case c == '"' && quoted && end+1 < len(str) && str[end+1] == '"':
+			buf.WriteByte(c)
+			end++
+		case c == '"':
+			quoted = !quoted
+		case !quoted && (c == ',' || c == ')' || c == ']'):
+			if !empty {
+				value := buf.String()
+				ret = &value
+			}
+			return
+		case !quoted && (c == '(' || c == '['):
+			err = fmt.Errorf("unexpected %q at position %d", c, end)
+			return
+		default:
+			buf.WriteByte(c)
+		}
+		empty = false
+	}
+	err = fmt.Errorf("unexpected end of range")
+
+	return
+}
+
+// Форматирование литерала диапазона PostgreSQL
+func formatRangeLiteral(lit rangeLiteral) string {
+	var buf strings.Builder
+
+	if lit.Empty {
+		return rangeEmptyString
+	}
+	if lit.LowerInc {
+		buf.WriteByte('[')
+	} else {
+		buf.WriteByte('(')
+	}
+	writeRangeBoundLiteral(&buf, lit.Lower)
+	buf.WriteByte(',')
+	writeRangeBoundLiteral(&buf, lit.Upper)
+	if lit.UpperInc {
+		buf.WriteByte(']')
+	} else {
+		buf.WriteByte(')')
+	}
+
+	return buf.String()
+}
+
+// Форматирование границы диапазона с заключением в кавычки при необходимости
+func writeRangeBoundLiteral(buf *strings.Builder, bound *string) {
+	if bound == nil {
+		return
+	}
+	if *bound != "" && !strings.ContainsAny(*bound, rangeQuoteChars) {
+		buf.WriteString(*bound)
+		return
+	}
+	buf.WriteByte('"')
+	for i := 0; i < len(*bound); i++ {
+		if (*bound)[i] == '"' || (*bound)[i] == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte((*bound)[i])
+	}
+	buf.WriteByte('"')
+}
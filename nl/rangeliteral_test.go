@@ -0,0 +1,66 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"reflect"
+	"testing"
+)
+
+func rangeLiteralString(s string) *string { return &s }
+
+func TestParseRangeLiteral(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out rangeLiteral
+	}{
+		{`empty`, rangeLiteral{Empty: true}},
+		{` EMPTY `, rangeLiteral{Empty: true}},
+		{`[1,5)`, rangeLiteral{Lower: rangeLiteralString("1"), Upper: rangeLiteralString("5"), LowerInc: true}},
+		{`(,5]`, rangeLiteral{Upper: rangeLiteralString("5"), UpperInc: true}},
+		{`(,)`, rangeLiteral{}},
+		{`["",""]`, rangeLiteral{Lower: rangeLiteralString(""), Upper: rangeLiteralString(""), LowerInc: true, UpperInc: true}},
+		{
+			`["2010-01-01 14:30:00+00","2010-01-01 15:30:00+00")`,
+			rangeLiteral{Lower: rangeLiteralString("2010-01-01 14:30:00+00"), Upper: rangeLiteralString("2010-01-01 15:30:00+00"), LowerInc: true},
+		},
+		{`("a""b","c\,d")`, rangeLiteral{Lower: rangeLiteralString(`a"b`), Upper: rangeLiteralString("c,d")}},
+	}
+
+	for _, test := range tests {
+		out, err := parseRangeLiteral(test.In)
+		errorPanic(err)
+		if !reflect.DeepEqual(out, test.Out) {
+			t.Errorf("parseRangeLiteral(%q) is %+v, but should be %+v", test.In, out, test.Out)
+		}
+	}
+	for _, in := range []string{``, `[]`, `1,5`, `{1,5)`, `[1,5}`, `[1)`, `[1,5,6)`, `["1,5)`, `[(1,5)`, `[1,5)x`} {
+		if _, err := parseRangeLiteral(in); err == nil {
+			t.Errorf("parseRangeLiteral(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestFormatRangeLiteral(t *testing.T) {
+	var tests = []struct {
+		In  rangeLiteral
+		Out string
+	}{
+		{rangeLiteral{Empty: true}, `empty`},
+		{rangeLiteral{Lower: rangeLiteralString("1"), Upper: rangeLiteralString("5"), LowerInc: true}, `[1,5)`},
+		{rangeLiteral{Upper: rangeLiteralString("5"), UpperInc: true}, `(,5]`},
+		{rangeLiteral{}, `(,)`},
+		{rangeLiteral{Lower: rangeLiteralString(""), Upper: rangeLiteralString(`a "b\`)}, `("","a \"b\\")`},
+	}
+
+	for _, test := range tests {
+		if out := formatRangeLiteral(test.In); out != test.Out {
+			t.Errorf("formatRangeLiteral(%+v) is %q, but should be %q", test.In, out, test.Out)
+		}
+		back, err := parseRangeLiteral(test.Out)
+		errorPanic(err)
+		if !reflect.DeepEqual(back, test.In) {
+			t.Errorf("parseRangeLiteral(%q) is %+v, but should be %+v", test.Out, back, test.In)
+		}
+	}
+}
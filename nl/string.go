@@ -3,14 +3,7 @@ package nul // import "gopkg.in/webnice/lin.v1/nl"
 //import "gopkg.in/webnice/debug.v1"
 //import "gopkg.in/webnice/log.v2"
 import (
-	"bytes"
 	"database/sql/driver"
-	"encoding/gob"
-	"encoding/json"
-	"fmt"
-	"reflect"
-
-	"gopkg.in/webnice/lin.v1/wrapper"
 )
 
 // String is an nullable string object
@@ -43,6 +36,17 @@ func NewStringPointerValue(ptr *string) String {
 	return NewStringValue(*ptr)
 }
 
+// NewStringNull Создание нового объекта String из обобщённого объекта Null
+func NewStringNull(n Null[string]) String {
+	return String{
+		String: n.V,
+		Valid:  n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (s String) Null() Null[string] { return Null[string]{V: s.String, Valid: s.Valid} }
+
 // SetValid Изменение значения и установка флага действительного значения
 func (s *String) SetValid(value string) { s.String, s.Valid = value, true }
 
@@ -78,128 +82,77 @@ func (s *String) Pointer() *string {
 }
 
 // Scan Реализация интерфейса Scanner
-func (s *String) Scan(value interface{}) (err error) {
+// Значение любого типа преобразуется в строку
+func (s *String) Scan(value interface{}) error {
 	if value == nil {
 		s.Reset()
-		return
+		return nil
 	}
-	s.String = asString(value)
-	s.Valid = err == nil
+	s.String, s.Valid = asString(value), true
 
-	return
+	return nil
 }
 
 // Value Реализация интерфейса driver.Valuer
-func (s String) Value() (driver.Value, error) {
-	if !s.Valid {
-		return nil, nil
-	}
-	return s.String, nil
-}
+func (s String) Value() (driver.Value, error) { return s.Null().Value() }
 
 // UnmarshalJSON Реализация интерфейса json.Unmarshaler
-func (s *String) UnmarshalJSON(data []byte) (err error) {
-	var v interface{}
-
-	if err = json.Unmarshal(data, &v); err != nil {
-		return
-	}
-	switch x := v.(type) {
-	case nil:
-		s.Valid = false
-		return
-	case string:
-		s.String = x
-	case map[string]interface{}:
-		err = json.Unmarshal(data, &s.String)
-	default:
-		err = fmt.Errorf("can't unmarshal %q into go value of type nul.String", reflect.TypeOf(v).Name())
-	}
-	s.Valid = err == nil
-
-	return
+func (s *String) UnmarshalJSON(data []byte) error {
+	return setNull(&s.String, &s.Valid, (*Null[string]).UnmarshalJSON, data)
 }
 
 // MarshalJSON Реализация интерфейса json.Marshaler
-func (s String) MarshalJSON() (data []byte, err error) {
-	const nullString = "null"
-
-	if !s.Valid {
-		data = []byte(nullString)
-		return
-	}
-	data, err = json.Marshal(s.String)
+func (s String) MarshalJSON() ([]byte, error) { return s.Null().MarshalJSON() }
 
-	return
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (s *String) UnmarshalText(text []byte) error {
+	return setNull(&s.String, &s.Valid, (*Null[string]).UnmarshalText, text)
 }
 
-// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
-func (s *String) UnmarshalText(text []byte) (err error) {
-	const (
-		emptyString = ""
-		nullString  = "null"
-	)
-	var str string
-
-	switch str = string(text); str {
-	case nullString:
-		s.String, s.Valid = emptyString, false
-		return
-	case emptyString:
-		s.String, s.Valid = emptyString, true
-		return
-	default:
-		s.String = str
-		s.Valid = err == nil
-	}
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (s String) MarshalText() ([]byte, error) { return s.Null().MarshalText() }
 
-	return
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (s *String) UnmarshalBinary(data []byte) error {
+	return setNull(&s.String, &s.Valid, unmarshalNullBinary[string](binaryTagString), data)
 }
 
-// MarshalText Реализация интерфейса encoding.TextMarshaler
-func (s String) MarshalText() (text []byte, err error) {
-	const nullString = "null"
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (s String) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagString, s.Null()) }
 
-	if !s.Valid {
-		text = []byte(nullString)
-		return
-	}
-	text = []byte(s.String)
+// Запись значения в компактном двоичном формате
+func (s String) encodeBinary(w *binaryWriter) { s.Null().encodeBinary(w) }
 
-	return
-}
+// Чтение значения в компактном двоичном формате
+func (s *String) decodeBinary(r *binaryReader) {
+	var n = s.Null()
 
-// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
-func (s *String) UnmarshalBinary(data []byte) (err error) {
-	var (
-		reader *bytes.Reader
-		dec    *gob.Decoder
-		item   *wrapper.StringWrapper
-	)
-
-	reader = bytes.NewReader(data)
-	dec = gob.NewDecoder(reader)
-	item = new(wrapper.StringWrapper)
-	if err = dec.Decode(item); err == nil {
-		s.String, s.Valid = item.Value, item.Valid
-	}
+	n.decodeBinary(r)
+	*s = NewStringNull(n)
+}
 
-	return
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (s *String) UnmarshalMsgpack(data []byte) error {
+	return setNull(&s.String, &s.Valid, (*Null[string]).UnmarshalMsgpack, data)
 }
 
-// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
-func (s String) MarshalBinary() (data []byte, err error) {
-	var (
-		buf  *bytes.Buffer
-		enc  *gob.Encoder
-		item *wrapper.StringWrapper
-	)
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (s String) MarshalMsgpack() ([]byte, error) { return s.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (s *String) UnmarshalCBOR(data []byte) error {
+	return setNull(&s.String, &s.Valid, (*Null[string]).UnmarshalCBOR, data)
+}
 
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-	item = &wrapper.StringWrapper{Value: s.String, Valid: s.Valid}
-	err = enc.Encode(item)
-	data = buf.Bytes()
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (s String) MarshalCBOR() ([]byte, error) { return s.Null().MarshalCBOR() }
 
-	return
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (s *String) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&s.String, &s.Valid, (*Null[string]).UnmarshalYAML, unmarshal)
 }
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (s String) MarshalYAML() (interface{}, error) { return s.Null().MarshalYAML() }
@@ -263,24 +263,13 @@ func TestStringUnmarshalBinary(t *testing.T) {
 }
 
 func TestStringMarshalBinary(t *testing.T) {
-	var err error
-	var buf *bytes.Buffer
-	var enc *gob.Encoder
-
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-
 	v1 := NewString()
-	err = enc.Encode(&v1)
+	data, err := v1.MarshalBinary()
 	errorPanic(err)
-	v1h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v1h), stringNullInvalidGob, "NewBytes() -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), stringNullInvalidBinary, "NewString() -> MarshalBinary()")
 
-	buf.Reset()
-	enc = gob.NewEncoder(buf)
 	v2 := NewStringValue(stringTestBody)
-	err = enc.Encode(&v2)
+	data, err = v2.MarshalBinary()
 	errorPanic(err)
-	v2h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v2h), stringOkValidGob, "NewBoolValue(false) -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), stringOkValidBinary, "NewStringValue() -> MarshalBinary()")
 }
@@ -0,0 +1,661 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+const hstoreNullString = "NULL" // Значение NULL в текстовом представлении hstore
+
+// StringMap is an nullable map of nullable strings, for hstore columns and JSON objects with string values
+type StringMap struct {
+	Map   map[string]String // Value of object
+	Valid bool              // Valid is true if value is not NULL
+	JSON  bool              // JSON is true if Value() writes JSON object instead of hstore form
+}
+
+// NewStringMap Создание нового объекта StringMap
+func NewStringMap() StringMap {
+	return StringMap{
+		Map:   nil,
+		Valid: false,
+	}
+}
+
+// NewStringMapValue Создание нового действительного объекта StringMap из значения, значение копируется
+func NewStringMapValue(value map[string]String) StringMap {
+	return StringMap{
+		Map:   copyStringMap(value),
+		Valid: true,
+	}
+}
+
+// NewStringMapPointerValue Создание нового действительного объекта StringMap из ссылки на значение
+func NewStringMapPointerValue(ptr *map[string]String) StringMap {
+	if ptr == nil {
+		return NewStringMap()
+	}
+	return NewStringMapValue(*ptr)
+}
+
+// NewStringMapStrings Создание нового действительного объекта StringMap из карты строк без значений null
+func NewStringMapStrings(value map[string]string) StringMap {
+	var ret = StringMap{Map: make(map[string]String, len(value)), Valid: true}
+
+	for k, v := range value {
+		ret.Map[k] = NewStringValue(v)
+	}
+
+	return ret
+}
+
+// Копия карты, nil копируется как пустая карта
+func copyStringMap(value map[string]String) map[string]String {
+	var ret = make(map[string]String, len(value))
+
+	for k, v := range value {
+		ret[k] = v
+	}
+
+	return ret
+}
+
+// SetValid Изменение значения и установка флага действительного значения, значение копируется
+func (sm *StringMap) SetValid(value map[string]String) { sm.Map, sm.Valid = copyStringMap(value), true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (sm *StringMap) Reset() { sm.Map, sm.Valid = nil, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (sm *StringMap) NullIfDefault() StringMap {
+	if len(sm.Map) == 0 {
+		sm.Reset()
+	}
+	return *sm
+}
+
+// MustValue Возвращает значение в любом случае
+func (sm *StringMap) MustValue() map[string]String {
+	if !sm.Valid || sm.Map == nil {
+		return map[string]String{}
+	}
+	return sm.Map
+}
+
+// Pointer Возвращает ссылку на значение
+func (sm *StringMap) Pointer() *map[string]String {
+	if !sm.Valid {
+		return nil
+	}
+	return &sm.Map
+}
+
+// Get Возвращает значение по ключу, отсутствующий ключ возвращается как null
+func (sm StringMap) Get(key string) String { return sm.Map[key] }
+
+// Set Установка значения по ключу, объект становится действительным
+func (sm *StringMap) Set(key string, value String) {
+	if sm.Map == nil {
+		sm.Map = make(map[string]String)
+	}
+	sm.Map[key], sm.Valid = value, true
+}
+
+// Strings Возвращает карту строк, ключи со значением null пропускаются
+func (sm StringMap) Strings() map[string]string {
+	var ret = make(map[string]string, len(sm.Map))
+
+	for k, v := range sm.Map {
+		if v.Valid {
+			ret[k] = v.String
+		}
+	}
+
+	return ret
+}
+
+// Ключи карты в порядке сортировки
+func (sm StringMap) keys() []string {
+	var ret = make([]string, 0, len(sm.Map))
+
+	for k := range sm.Map {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+// Разбор значения в форме JSON объекта или в текстовом представлении hstore
+func (sm *StringMap) parse(data []byte) (err error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		sm.Map, err = parseStringMapJSON(trimmed)
+		sm.JSON = true
+		return
+	}
+	sm.Map, err = ParseHstore(string(data))
+	sm.JSON = false
+
+	return
+}
+
+// Разбор JSON объекта, значениями которого являются строки или null
+func parseStringMapJSON(data []byte) (ret map[string]String, err error) {
+	var items map[string]*string
+
+	if err = json.Unmarshal(data, &items); err != nil {
+		err = fmt.Errorf("can't parse %q as JSON object of strings: %s", data, err)
+		return
+	}
+	if items == nil {
+		err = fmt.Errorf("can't parse %q as JSON object of strings", data)
+		return
+	}
+	ret = make(map[string]String, len(items))
+	for k, v := range items {
+		ret[k] = NewStringPointerValue(v)
+	}
+
+	return
+}
+
+// ParseHstore Разбор текстового представления hstore вида "a"=>"1", "b"=>NULL
+func ParseHstore(str string) (ret map[string]String, err error) {
+	var (
+		pos        int
+		key, value string
+		null       bool
+	)
+
+	ret = make(map[string]String)
+	for pos = skipHstoreSpace(str, 0); pos < len(str); {
+		if key, null, pos, err = parseHstoreItem(str, pos); err != nil {
+			break
+		}
+		if null {
+			err = fmt.Errorf("unexpected NULL key at position %d", pos)
+			break
+		}
+		if pos = skipHstoreSpace(str, pos); !strings.HasPrefix(str[pos:], "=>") {
+			err = fmt.Errorf("expected '=>' at position %d", pos)
+			break
+		}
+		if value, null, pos, err = parseHstoreItem(str, skipHstoreSpace(str, pos+2)); err != nil {
+			break
+		}
+		if ret[key] = NewStringValue(value); null {
+			ret[key] = NewString()
+		}
+		if pos = skipHstoreSpace(str, pos); pos < len(str) {
+			if str[pos] != ',' {
+				err = fmt.Errorf("expected ',' at position %d", pos)
+				break
+			}
+			if pos = skipHstoreSpace(str, pos+1); pos == len(str) {
+				err = fmt.Errorf("unexpected end of hstore")
+			}
+		}
+	}
+	if err != nil {
+		ret, err = nil, fmt.Errorf("can't parse %q as hstore: %s", str, err)
+	}
+
+	return
+}
+
+// Пропуск пробельных символов
+func skipHstoreSpace(str string, pos int) int {
+	for pos < len(str) && isArraySpace(str[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// Разбор ключа или значения hstore в кавычках или без кавычек
+func parseHstoreItem(str string, pos int) (ret string, null bool, end int, err error) {
+	var (
+		buf     strings.Builder
+		quoted  bool
+		escaped bool
+	)
+
+	if quoted = pos < len(str) && str[pos] == '"'; quoted {
+		pos++
+	}
+	for ; pos < len(str); pos++ {
+		c := str[pos]
+		switch {
+		case c == '\\':
+			if pos++; pos >= len(str) {
+				err = fmt.Errorf("unexpected end of hstore")
+				return
+			}
+			buf.WriteByte(str[pos])
+			escaped = true
+			continue
+		case quoted && c == '"':
+			ret, end = buf.String(), pos+1
+			return
+		case !quoted && (c == ',' || c == '=' || c == '"' || isArraySpace(c)):
+			if buf.Len() == 0 {
+				err = fmt.Errorf("empty item at position %d", pos)
+				return
+			}
+			ret, end = buf.String(), pos
+			null = !escaped && strings.EqualFold(ret, hstoreNullString)
+			return
+		}
+		buf.WriteByte(c)
+	}
+	if quoted || buf.Len() == 0 {
+		err = fmt.Errorf("unexpected end of hstore")
+		return
+	}
+	ret, end = buf.String(), pos
+	null = !escaped && strings.EqualFold(ret, hstoreNullString)
+
+	return
+}
+
+// FormatHstore Форматирование карты в текстовое представление hstore, ключи сортируются
+func (sm StringMap) FormatHstore() string {
+	var buf strings.Builder
+
+	for i, k := range sm.keys() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		writeHstoreString(&buf, k)
+		buf.WriteString("=>")
+		if v := sm.Map[k]; v.Valid {
+			writeHstoreString(&buf, v.String)
+			continue
+		}
+		buf.WriteString(hstoreNullString)
+	}
+
+	return buf.String()
+}
+
+// Форматирование строки hstore в кавычках
+func writeHstoreString(buf *strings.Builder, str string) {
+	buf.WriteByte('"')
+	for i := 0; i < len(str); i++ {
+		if str[i] == '"' || str[i] == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(str[i])
+	}
+	buf.WriteByte('"')
+}
+
+// Форматирование карты в JSON объект
+func (sm StringMap) formatJSON() (data []byte, err error) {
+	if sm.Map == nil {
+		data = []byte("{}")
+		return
+	}
+	data, err = json.Marshal(sm.Map)
+
+	return
+}
+
+// Scan Реализация интерфейса Scanner
+// Сканирование JSON объекта устанавливает флаг JSON, чтобы Value() записывал значение в той же форме,
+// при ошибке флаг сбрасывается
+func (sm *StringMap) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		sm.Reset()
+		return
+	case []byte:
+		err = sm.parse(x)
+	case string:
+		err = sm.parse([]byte(x))
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.StringMap: %v", x, value)
+	}
+	if sm.Valid = err == nil; !sm.Valid {
+		sm.Map, sm.JSON = nil, false
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (sm StringMap) Value() (driver.Value, error) {
+	var (
+		buf []byte
+		err error
+	)
+
+	if !sm.Valid {
+		return nil, nil
+	}
+	if !sm.JSON {
+		return sm.FormatHstore(), nil
+	}
+	if buf, err = sm.formatJSON(); err != nil {
+		return nil, err
+	}
+
+	return string(buf), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (sm *StringMap) UnmarshalJSON(data []byte) (err error) {
+	const nullString = "null"
+
+	if string(bytes.TrimSpace(data)) == nullString {
+		sm.Reset()
+		return
+	}
+	sm.Map, err = parseStringMapJSON(data)
+	if sm.Valid = err == nil; !sm.Valid {
+		sm.Map, sm.JSON = nil, false
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+// Ключи со значением null сохраняются в объекте со значением null
+func (sm StringMap) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !sm.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data, err = sm.formatJSON()
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (sm *StringMap) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	switch string(text) {
+	case nullString:
+		sm.Reset()
+		return
+	case emptyString:
+		sm.Map, sm.Valid = map[string]String{}, true
+		return
+	default:
+		err = sm.parse(text)
+	}
+	if sm.Valid = err == nil; !sm.Valid {
+		sm.Map, sm.JSON = nil, false
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (sm StringMap) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !sm.Valid {
+		text = []byte(nullString)
+		return
+	}
+	if sm.JSON {
+		text, err = sm.formatJSON()
+		return
+	}
+	text = []byte(sm.FormatHstore())
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (sm *StringMap) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return sm.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagStringMap, sm)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+// Ключи записываются в порядке сортировки
+func (sm StringMap) MarshalBinary() (data []byte, err error) {
+	return marshalBinary(binaryTagStringMap, sm)
+}
+
+// Запись значения в компактном двоичном формате
+func (sm StringMap) encodeBinary(w *binaryWriter) {
+	var keys = sm.keys()
+
+	if w.flags(sm.Valid, sm.JSON); !sm.Valid {
+		return
+	}
+	w.uvarint(uint64(len(keys)))
+	for _, k := range keys {
+		w.string(k)
+		sm.Map[k].encodeBinary(w)
+	}
+}
+
+// Чтение значения в компактном двоичном формате
+func (sm *StringMap) decodeBinary(r *binaryReader) {
+	var (
+		valid, isJSON bool
+		value         map[string]String
+		key           string
+		item          String
+	)
+
+	if r.flags(&valid, &isJSON); !valid {
+		if r.err == nil {
+			sm.Map, sm.Valid, sm.JSON = nil, false, isJSON
+		}
+		return
+	}
+	value = make(map[string]String)
+	for n := r.count(); n > 0 && r.err == nil; n-- {
+		key = r.string()
+		item.decodeBinary(r)
+		value[key] = item
+	}
+	if r.err != nil {
+		return
+	}
+	sm.Map, sm.Valid, sm.JSON = value, valid, isJSON
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (sm *StringMap) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.StringMapWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.StringMapWrapper)
+	if err = dec.Decode(item); err != nil {
+		return
+	}
+	sm.Map, sm.Valid, sm.JSON = nil, item.Valid, item.JSON
+	if !sm.Valid {
+		return
+	}
+	sm.Map = make(map[string]String, len(item.Value)+len(item.Null))
+	for k, v := range item.Value {
+		sm.Map[k] = NewStringValue(v)
+	}
+	for _, k := range item.Null {
+		sm.Map[k] = NewString()
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значением является карта строк, значения которой являются строками или nil
+func (sm *StringMap) UnmarshalMsgpack(data []byte) (err error) {
+	var (
+		n     int
+		rest  []byte
+		key   string
+		item  String
+		value map[string]String
+	)
+
+	if msgpack.IsNil(data) {
+		sm.Reset()
+		return msgpackEnd(msgpack.ReadNil(data))
+	}
+	if n, rest, err = msgpack.ReadMapHeader(data); err != nil {
+		return
+	}
+	value = make(map[string]String, n)
+	for i := 0; i < n && err == nil; i++ {
+		if key, rest, err = msgpack.ReadString(rest); err != nil {
+			break
+		}
+		if rest, err = readMsgpackValue(rest, &item); err == nil {
+			value[key] = item
+		}
+	}
+	if err = msgpackEnd(rest, err); err == nil {
+		sm.Map, sm.Valid = value, true
+	}
+
+	return
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется картой строк, ключи записываются в порядке сортировки
+func (sm StringMap) MarshalMsgpack() (data []byte, err error) {
+	if !sm.Valid {
+		data = msgpack.AppendNil(nil)
+		return
+	}
+	data = msgpack.AppendMapHeader(nil, len(sm.Map))
+	for _, key := range sm.keys() {
+		if data, err = appendMsgpackValue(msgpack.AppendString(data, key), sm.Map[key]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значением является карта строк, значения которой являются строками или null
+func (sm *StringMap) UnmarshalCBOR(data []byte) (err error) {
+	var (
+		n     int
+		rest  []byte
+		key   string
+		item  String
+		value map[string]String
+	)
+
+	if cbor.IsNull(data) {
+		sm.Reset()
+		return cborEnd(cbor.ReadNull(data))
+	}
+	if n, rest, err = cbor.ReadMapHeader(data); err != nil {
+		return
+	}
+	value = make(map[string]String, n)
+	for i := 0; i < n && err == nil; i++ {
+		if key, rest, err = cbor.ReadString(rest); err != nil {
+			break
+		}
+		if rest, err = readCBORValue(rest, &item); err == nil {
+			value[key] = item
+		}
+	}
+	if err = cborEnd(rest, err); err == nil {
+		sm.Map, sm.Valid = value, true
+	}
+
+	return
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется картой строк, ключи записываются в порядке детерминированного кодирования CBOR
+func (sm StringMap) MarshalCBOR() (data []byte, err error) {
+	var keys []string
+
+	if !sm.Valid {
+		data = cbor.AppendNull(nil)
+		return
+	}
+	keys = sm.keys()
+	cbor.SortKeys(keys)
+	data = cbor.AppendMapHeader(nil, len(sm.Map))
+	for _, key := range keys {
+		if data, err = appendCBORValue(cbor.AppendString(data, key), sm.Map[key]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значением является карта строк, значения которой являются строками или null
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (sm *StringMap) UnmarshalYAML(unmarshal func(interface{}) error) (err error) {
+	var (
+		value map[string]*string
+		null  bool
+	)
+
+	if value, null, err = unmarshalYAML[map[string]*string](unmarshal); err != nil {
+		return
+	}
+	if null || value == nil {
+		sm.Reset()
+		return
+	}
+	sm.Map, sm.Valid = make(map[string]String, len(value)), true
+	for key := range value {
+		sm.Map[key] = NewString()
+		if value[key] != nil {
+			sm.Map[key] = NewStringValue(*value[key])
+		}
+	}
+
+	return
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется картой строк, значение null кодируется nil
+func (sm StringMap) MarshalYAML() (interface{}, error) {
+	var ret map[string]interface{}
+
+	if !sm.Valid {
+		return nil, nil
+	}
+	ret = make(map[string]interface{}, len(sm.Map))
+	for key := range sm.Map {
+		if ret[key] = nil; sm.Map[key].Valid {
+			ret[key] = sm.Map[key].String
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,268 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var (
+	stringMapHstore = `"a"=>"1", "b"=>NULL, "c d"=>"x\"y\\z"`
+	stringMapJSON   = []byte(`{"a":"1","b":null,"c d":"x\"y\\z"}`)
+)
+
+func stringMapTestValue() map[string]String {
+	return map[string]String{
+		"a":   NewStringValue("1"),
+		"b":   NewString(),
+		"c d": NewStringValue(`x"y\z`),
+	}
+}
+
+func isStringMapValid(t *testing.T, sm StringMap, from string) {
+	if !reflect.DeepEqual(sm.Map, stringMapTestValue()) {
+		t.Errorf("Bad %s map: %v ≠ %v\n", from, sm.Map, stringMapTestValue())
+	}
+	if !sm.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isStringMapNull(t *testing.T, sm StringMap, from string) {
+	if sm.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+	if sm.Map != nil {
+		t.Errorf("Bad %s map: %v, but should be nil", from, sm.Map)
+	}
+}
+
+func TestParseHstore(t *testing.T) {
+	var tests = []struct {
+		In  string
+		Out map[string]String
+	}{
+		{``, map[string]String{}},
+		{`  `, map[string]String{}},
+		{stringMapHstore, stringMapTestValue()},
+		{`a=>1,b=>null , "NULL" => \NULL`, map[string]String{"a": NewStringValue("1"), "b": NewString(), "NULL": NewStringValue("NULL")}},
+		{`"a"=>""`, map[string]String{"a": NewStringValue("")}},
+	}
+
+	for _, test := range tests {
+		out, err := ParseHstore(test.In)
+		errorPanic(err)
+		if !reflect.DeepEqual(out, test.Out) {
+			t.Errorf("ParseHstore(%q) is %v, but should be %v", test.In, out, test.Out)
+		}
+	}
+	for _, in := range []string{`a`, `a=>`, `a=>1,`, `a=1`, `"a=>1`, `a=>"1`, `NULL=>1`, `a=>1 b=>2`, `a=>1\`} {
+		if _, err := ParseHstore(in); err == nil {
+			t.Errorf("ParseHstore(%q) error is nil, but should be not nil", in)
+		}
+	}
+}
+
+func TestNewStringMap(t *testing.T) {
+	v1 := NewStringMap()
+	isStringMapNull(t, v1, "NewStringMap()")
+
+	value := stringMapTestValue()
+	v2 := NewStringMapValue(value)
+	isStringMapValid(t, v2, "NewStringMapValue()")
+	value["a"] = NewString()
+	isStringMapValid(t, v2, "NewStringMapValue(copy)")
+
+	v3 := NewStringMapPointerValue(nil)
+	isStringMapNull(t, v3, "NewStringMapPointerValue(nil)")
+
+	value = stringMapTestValue()
+	v4 := NewStringMapPointerValue(&value)
+	isStringMapValid(t, v4, "NewStringMapPointerValue()")
+
+	v5 := NewStringMapStrings(map[string]string{"a": "1"})
+	if !v5.Valid || v5.Get("a") != NewStringValue("1") || v5.Get("b").Valid {
+		t.Errorf("NewStringMapStrings() is %v, but should be {a:1}", v5.Map)
+	}
+}
+
+func TestStringMapSetValidReset(t *testing.T) {
+	var v StringMap
+
+	v.Set("a", NewStringValue("1"))
+	if !v.Valid || v.Get("a").String != "1" {
+		t.Errorf("Set() is %v, but should be {a:1}", v.Map)
+	}
+	v.SetValid(stringMapTestValue())
+	isStringMapValid(t, v, "SetValid()")
+	if !reflect.DeepEqual(v.Strings(), map[string]string{"a": "1", "c d": `x"y\z`}) {
+		t.Errorf("Strings() is %v, but shouldn't contain null values", v.Strings())
+	}
+	v.Reset()
+	isStringMapNull(t, v, "Reset()")
+	if v.Pointer() != nil || v.MustValue() == nil {
+		t.Error("Pointer() or MustValue() of null returns wrong value")
+	}
+	v.SetValid(nil)
+	v.NullIfDefault()
+	isStringMapNull(t, v, "NullIfDefault()")
+}
+
+func TestStringMapScanValue(t *testing.T) {
+	var (
+		v   StringMap
+		val interface{}
+		err error
+	)
+
+	errorPanic(v.Scan(stringMapHstore))
+	isStringMapValid(t, v, "Scan(hstore)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != stringMapHstore {
+		t.Errorf("Value() is %v, but should be %v", val, stringMapHstore)
+	}
+
+	errorPanic(v.Scan(stringMapJSON))
+	isStringMapValid(t, v, "Scan(JSON)")
+	if !v.JSON {
+		t.Error("Scan(JSON) doesn't set JSON flag")
+	}
+	val, err = v.Value()
+	errorPanic(err)
+	if val != string(stringMapJSON) {
+		t.Errorf("Value() is %v, but should be %s", val, stringMapJSON)
+	}
+
+	errorPanic(v.Scan(""))
+	if !v.Valid || len(v.Map) != 0 || v.JSON {
+		t.Errorf("Scan() of empty hstore is %v %v", v.Map, v.Valid)
+	}
+
+	errorPanic(v.Scan(nil))
+	isStringMapNull(t, v, "Scan(nil)")
+	val, err = v.Value()
+	errorPanic(err)
+	if val != nil {
+		t.Errorf("Value() is %v, but should be nil", val)
+	}
+
+	for _, in := range []interface{}{`a=>`, `{"a":1}`, `{"a"`, int64(1)} {
+		v.JSON = true
+		if err = v.Scan(in); err == nil {
+			t.Errorf("Scan(%v) error is nil, but should be not nil", in)
+		}
+		isStringMapNull(t, v, "Scan(error)")
+		if v.JSON {
+			t.Errorf("Scan(%v) error keeps JSON flag", in)
+		}
+	}
+}
+
+func TestStringMapUnmarshalJSON(t *testing.T) {
+	var (
+		v   StringMap
+		err error
+	)
+
+	errorPanic(json.Unmarshal(stringMapJSON, &v))
+	isStringMapValid(t, v, "UnmarshalJSON()")
+
+	errorPanic(json.Unmarshal([]byte(`null`), &v))
+	isStringMapNull(t, v, "UnmarshalJSON(null)")
+
+	for _, in := range []string{`[]`, `"a"`, `{"a":1}`, `{"a":{}}`} {
+		if err = json.Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("UnmarshalJSON(%s) error is nil, but should be not nil", in)
+		}
+		isStringMapNull(t, v, "UnmarshalJSON(error)")
+	}
+}
+
+func TestStringMapMarshalJSON(t *testing.T) {
+	var (
+		data []byte
+		err  error
+	)
+
+	v1 := NewStringMapValue(stringMapTestValue())
+	data, err = json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(stringMapJSON), "non-empty json marshal")
+
+	v2 := StringMap{Valid: true}
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "{}", "empty json marshal")
+
+	v3 := NewStringMap()
+	data, err = json.Marshal(v3)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestStringMapText(t *testing.T) {
+	var (
+		v    StringMap
+		data []byte
+		err  error
+	)
+
+	errorPanic(v.UnmarshalText([]byte(stringMapHstore)))
+	isStringMapValid(t, v, "UnmarshalText()")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != stringMapHstore {
+		t.Errorf("MarshalText() is %s, but should be %s", data, stringMapHstore)
+	}
+
+	errorPanic(v.UnmarshalText(stringMapJSON))
+	isStringMapValid(t, v, "UnmarshalText(JSON)")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != string(stringMapJSON) {
+		t.Errorf("MarshalText() is %s, but should be %s", data, stringMapJSON)
+	}
+
+	errorPanic(v.UnmarshalText([]byte("null")))
+	isStringMapNull(t, v, "UnmarshalText(null)")
+	data, err = v.MarshalText()
+	errorPanic(err)
+	if string(data) != "null" {
+		t.Errorf("MarshalText() is %s, but should be null", data)
+	}
+
+	v.JSON = true
+	if err = v.UnmarshalText([]byte(`{"a"`)); err == nil {
+		t.Error("UnmarshalText() error is nil, but should be not nil")
+	}
+	if isStringMapNull(t, v, "UnmarshalText(error)"); v.JSON {
+		t.Error("UnmarshalText() error keeps JSON flag")
+	}
+}
+
+func TestStringMapBinary(t *testing.T) {
+	var (
+		v2   StringMap
+		data []byte
+		err  error
+	)
+
+	v1 := NewStringMapValue(stringMapTestValue())
+	v1.JSON = true
+	data, err = v1.MarshalBinary()
+	errorPanic(err)
+	errorPanic(v2.UnmarshalBinary(data))
+	isStringMapValid(t, v2, "UnmarshalBinary()")
+	if !v2.JSON {
+		t.Error("UnmarshalBinary()", "lost JSON")
+	}
+
+	v1 = NewStringMap()
+	data, err = v1.MarshalBinary()
+	errorPanic(err)
+	errorPanic(v2.UnmarshalBinary(data))
+	isStringMapNull(t, v2, "UnmarshalBinary(null)")
+}
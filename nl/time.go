@@ -3,15 +3,8 @@ package nul // import "gopkg.in/webnice/lin.v1/nl"
 //import "gopkg.in/webnice/debug.v1"
 //import "gopkg.in/webnice/log.v2"
 import (
-	"bytes"
 	"database/sql/driver"
-	"encoding/gob"
-	"encoding/json"
-	"fmt"
-	"reflect"
 	"time"
-
-	"gopkg.in/webnice/lin.v1/wrapper"
 )
 
 // Time is an nullable time.Time object
@@ -44,6 +37,17 @@ func NewTimePointerValue(ptr *time.Time) Time {
 	return NewTimeValue(*ptr)
 }
 
+// NewTimeNull Создание нового объекта Time из обобщённого объекта Null
+func NewTimeNull(n Null[time.Time]) Time {
+	return Time{
+		Time:  n.V,
+		Valid: n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (t Time) Null() Null[time.Time] { return Null[time.Time]{V: t.Time, Valid: t.Valid} }
+
 // SetValid Изменение значения и установка флага действительного значения
 func (t *Time) SetValid(value time.Time) { t.Time, t.Valid = value, true }
 
@@ -75,152 +79,83 @@ func (t *Time) Pointer() *time.Time {
 }
 
 // Scan Реализация интерфейса Scanner
-func (t *Time) Scan(value interface{}) (err error) {
-	switch x := value.(type) {
-	case nil:
-		t.Time, t.Valid = time.Time{}, false
-		return
-	case string:
-		t.Time, err = time.Parse(time.RFC3339Nano, x)
-		t.Valid = err == nil
-	case time.Time:
-		t.Time, t.Valid = x, true
-	default:
-		buf := asString(x)
-		t.Time, err = time.Parse(time.RFC3339Nano, buf)
-		t.Valid = err == nil
-	}
-
-	return
+func (t *Time) Scan(value interface{}) error {
+	return setNull(&t.Time, &t.Valid, (*Null[time.Time]).Scan, value)
 }
 
 // Value Реализация интерфейса driver.Valuer
-func (t Time) Value() (driver.Value, error) {
-	if !t.Valid {
-		return nil, nil
-	}
-	return t.Time, nil
-}
+func (t Time) Value() (driver.Value, error) { return t.Null().Value() }
 
 // UnmarshalJSON Реализация интерфейса json.Unmarshaler
-func (t *Time) UnmarshalJSON(data []byte) (err error) {
-	var v interface{}
-
-	if err = json.Unmarshal(data, &v); err != nil {
-		return
-	}
-	switch x := v.(type) {
-	case nil:
-		t.Time, t.Valid = time.Time{}, false
-		return
-	case string:
-		err = t.Time.UnmarshalJSON(data)
-	case map[string]interface{}:
-		ti, tiOK := x["Time"].(string)
-		valid, validOK := x["Valid"].(bool)
-		if !tiOK || !validOK {
-			return fmt.Errorf(`unmarshalling object into Go value of type nul.Time requires key "Time" to be of type `+
-				`string and key "Valid" to be of type bool; `+
-				`found %T and %T, respectively`, x["Time"], x["Valid"])
-		}
-		err = t.Time.UnmarshalText([]byte(ti))
-		t.Valid = valid
-		return
-	default:
-		err = fmt.Errorf("Can't unmarshal %s into Go value of type nul.Time", reflect.TypeOf(v).Name())
-	}
-	t.Valid = err == nil
-
-	return
+// Допускается также объект вида {"Time": значение, "Valid": флаг}
+func (t *Time) UnmarshalJSON(data []byte) error {
+	return setNull(&t.Time, &t.Valid, unmarshalNullJSONObject[time.Time]("Time"), data)
 }
 
 // MarshalJSON Реализация интерфейса json.Marshaler
-func (t Time) MarshalJSON() (data []byte, err error) {
-	const nullString = "null"
+func (t Time) MarshalJSON() ([]byte, error) { return t.Null().MarshalJSON() }
 
-	if !t.Valid {
-		data = []byte(nullString)
-		return
-	}
-	data, err = t.Time.MarshalJSON()
-
-	return
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (t *Time) UnmarshalText(text []byte) error {
+	return setNull(&t.Time, &t.Valid, (*Null[time.Time]).UnmarshalText, text)
 }
 
-// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
-func (t *Time) UnmarshalText(text []byte) (err error) {
-	const (
-		emptyString = ""
-		nullString  = "null"
-	)
-	var str string
-
-	switch str = string(text); str {
-	case nullString:
-		t.Time, t.Valid = time.Time{}, false
-		return
-	case emptyString:
-		t.Time, t.Valid = time.Time{}, true
-		return
-	default:
-		err = t.Time.UnmarshalText(text)
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+// Нулевое время записывается пустой строкой
+func (t Time) MarshalText() ([]byte, error) {
+	if t.Valid && t.Time.IsZero() {
+		return []byte{}, nil
 	}
-	t.Valid = err == nil
+	return t.Null().MarshalText()
+}
 
-	return
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (t *Time) UnmarshalBinary(data []byte) error {
+	return setNull(&t.Time, &t.Valid, unmarshalNullBinary[time.Time](binaryTagTime), data)
 }
 
-// MarshalText Реализация интерфейса encoding.TextMarshaler
-func (t Time) MarshalText() (text []byte, err error) {
-	const (
-		emptyString = ""
-		nullString  = "null"
-	)
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (t Time) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagTime, t.Null()) }
 
-	if !t.Valid {
-		text = []byte(nullString)
-		return
-	}
-	if t.Time.IsZero() {
-		text = []byte(emptyString)
-		return
-	}
-	text, err = t.Time.MarshalText()
+// Запись значения в компактном двоичном формате
+func (t Time) encodeBinary(w *binaryWriter) { t.Null().encodeBinary(w) }
 
-	return
-}
+// Чтение значения в компактном двоичном формате
+func (t *Time) decodeBinary(r *binaryReader) {
+	var n = t.Null()
 
-// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
-func (t *Time) UnmarshalBinary(data []byte) (err error) {
-	var (
-		reader *bytes.Reader
-		dec    *gob.Decoder
-		item   *wrapper.TimeWrapper
-	)
-
-	reader = bytes.NewReader(data)
-	dec = gob.NewDecoder(reader)
-	item = new(wrapper.TimeWrapper)
-	if err = dec.Decode(item); err == nil {
-		t.Time, t.Valid = item.Value, item.Valid
-	}
+	n.decodeBinary(r)
+	*t = NewTimeNull(n)
+}
 
-	return
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из расширения timestamp либо из строки текстового представления
+func (t *Time) UnmarshalMsgpack(data []byte) error {
+	return setNull(&t.Time, &t.Valid, (*Null[time.Time]).UnmarshalMsgpack, data)
 }
 
-// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
-func (t Time) MarshalBinary() (data []byte, err error) {
-	var (
-		buf  *bytes.Buffer
-		enc  *gob.Encoder
-		item *wrapper.TimeWrapper
-	)
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется расширением timestamp, часовой пояс не сохраняется
+func (t Time) MarshalMsgpack() ([]byte, error) { return t.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из текстовой строки с тегом 0 либо из количества секунд с тегом 1
+func (t *Time) UnmarshalCBOR(data []byte) error {
+	return setNull(&t.Time, &t.Valid, (*Null[time.Time]).UnmarshalCBOR, data)
+}
 
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-	item = &wrapper.TimeWrapper{Value: t.Time, Valid: t.Valid}
-	err = enc.Encode(item)
-	data = buf.Bytes()
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется текстовой строкой в формате RFC 3339 с тегом 0, часовой пояс и наносекунды сохраняются
+func (t Time) MarshalCBOR() ([]byte, error) { return t.Null().MarshalCBOR() }
 
-	return
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из метки времени YAML либо из строки в формате RFC 3339
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (t *Time) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&t.Time, &t.Valid, (*Null[time.Time]).UnmarshalYAML, unmarshal)
 }
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется меткой времени YAML
+func (t Time) MarshalYAML() (interface{}, error) { return t.Null().MarshalYAML() }
@@ -285,24 +285,13 @@ func TestTimeUnmarshalBinary(t *testing.T) {
 }
 
 func TestTimeMarshalBinary(t *testing.T) {
-	var err error
-	var buf *bytes.Buffer
-	var enc *gob.Encoder
-
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-
 	v1 := NewTime()
-	err = enc.Encode(&v1)
+	data, err := v1.MarshalBinary()
 	errorPanic(err)
-	v1h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v1h), timeNullInvalidGob, "NewBytes() -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), timeNullInvalidBinary, "NewTime() -> MarshalBinary()")
 
-	buf.Reset()
-	enc = gob.NewEncoder(buf)
 	v2 := NewTimeValue(timeOkValidValue)
-	err = enc.Encode(&v2)
+	data, err = v2.MarshalBinary()
 	errorPanic(err)
-	v2h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v2h), timeOkValidGob, "NewBoolValue(false) -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), timeOkValidBinary, "NewTimeValue() -> MarshalBinary()")
 }
@@ -0,0 +1,322 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+// TimeOfDay is an nullable time of day object without date
+type TimeOfDay struct {
+	Time  CivilTime // Value of object
+	Valid bool      // Valid is true if value is not NULL
+}
+
+// NewTimeOfDay Создание нового объекта TimeOfDay
+func NewTimeOfDay() TimeOfDay {
+	return TimeOfDay{
+		Time:  CivilTime{},
+		Valid: false,
+	}
+}
+
+// NewTimeOfDayValue Создание нового действительного объекта TimeOfDay из значения
+func NewTimeOfDayValue(value CivilTime) TimeOfDay {
+	return TimeOfDay{
+		Time:  value,
+		Valid: true,
+	}
+}
+
+// NewTimeOfDayPointerValue Создание нового действительного объекта TimeOfDay из ссылки на значение
+func NewTimeOfDayPointerValue(ptr *CivilTime) TimeOfDay {
+	if ptr == nil {
+		return NewTimeOfDay()
+	}
+	return NewTimeOfDayValue(*ptr)
+}
+
+// On Возвращает объект Time с моментом времени в дату d, результат равен null если одно из значений равно null
+// Если время имеет смещение часового пояса, используется смещение, иначе часовой пояс loc
+func (t TimeOfDay) On(d Date, loc *time.Location) Time {
+	if !t.Valid || !d.Valid {
+		return NewTime()
+	}
+	return NewTimeValue(t.Time.On(d.Date, loc))
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (t *TimeOfDay) SetValid(value CivilTime) { t.Time, t.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (t *TimeOfDay) Reset() { t.Time, t.Valid = CivilTime{}, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (t *TimeOfDay) NullIfDefault() TimeOfDay {
+	if t.Time.IsZero() {
+		t.Reset()
+	}
+	return *t
+}
+
+// MustValue Возвращает значение в любом случае
+func (t *TimeOfDay) MustValue() CivilTime {
+	if !t.Valid {
+		return CivilTime{}
+	}
+	return t.Time
+}
+
+// Pointer Возвращает ссылку на значение
+func (t *TimeOfDay) Pointer() *CivilTime {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// Scan Реализация интерфейса Scanner
+// Время time.Time сканируется со смещением только в фиксированном часовом поясе, отличном от UTC и time.Local,
+// такой пояс драйверы создают для типа TIME WITH TIME ZONE
+func (t *TimeOfDay) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		t.Reset()
+		return
+	case time.Time:
+		if !isFixedZone(x) {
+			t.Time = CivilTimeOf(x)
+			break
+		}
+		t.Time = CivilTimeZoneOf(x)
+	case []byte:
+		t.Time, err = ParseCivilTime(string(x))
+	case string:
+		t.Time, err = ParseCivilTime(x)
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.TimeOfDay: %v", x, value)
+	}
+	if t.Valid = err == nil; !t.Valid {
+		t.Time = CivilTime{}
+	}
+
+	return
+}
+
+// Проверка, что часовой пояс момента времени является фиксированным смещением, отличным от UTC и time.Local
+// Часовой пояс считается фиксированным, если смещение в январе и июле года момента времени совпадает с его смещением
+func isFixedZone(t time.Time) bool {
+	var (
+		loc       = t.Location()
+		_, offset = t.Zone()
+	)
+
+	if loc == time.UTC || loc == time.Local {
+		return false
+	}
+	for _, month := range []time.Month{time.January, time.July} {
+		if _, o := time.Date(t.Year(), month, 1, 0, 0, 0, 0, loc).Zone(); o != offset {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (t TimeOfDay) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time.String(), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+func (t *TimeOfDay) UnmarshalJSON(data []byte) (err error) {
+	var v interface{}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		t.Reset()
+		return
+	case string:
+		if len(x) == 0 {
+			t.Reset()
+			return
+		}
+		t.Time, err = ParseCivilTime(x)
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.TimeOfDay", reflect.TypeOf(v).Kind())
+	}
+	if t.Valid = err == nil; !t.Valid {
+		t.Time = CivilTime{}
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (t TimeOfDay) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !t.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data = []byte(`"` + t.Time.String() + `"`)
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (t *TimeOfDay) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+	var str string
+
+	switch str = string(text); str {
+	case nullString:
+		t.Reset()
+		return
+	case emptyString:
+		t.Time, t.Valid = CivilTime{}, true
+		return
+	default:
+		t.Time, err = ParseCivilTime(str)
+	}
+	if t.Valid = err == nil; !t.Valid {
+		t.Time = CivilTime{}
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (t TimeOfDay) MarshalText() (text []byte, err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	if !t.Valid {
+		text = []byte(nullString)
+		return
+	}
+	if t.Time.IsZero() {
+		text = []byte(emptyString)
+		return
+	}
+	text = []byte(t.Time.String())
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (t *TimeOfDay) UnmarshalBinary(data []byte) (err error) {
+	if isGobBinary(data) {
+		return t.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagTimeOfDay, t)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (t TimeOfDay) MarshalBinary() (data []byte, err error) {
+	return marshalBinary(binaryTagTimeOfDay, t)
+}
+
+// Запись значения в компактном двоичном формате
+func (t TimeOfDay) encodeBinary(w *binaryWriter) {
+	if w.flags(t.Valid, t.Time.HasOffset); !t.Valid {
+		return
+	}
+	w.varint(int64(t.Time.Hour))
+	w.varint(int64(t.Time.Minute))
+	w.varint(int64(t.Time.Second))
+	w.varint(int64(t.Time.Nanosecond))
+	w.varint(int64(t.Time.Offset))
+}
+
+// Чтение значения в компактном двоичном формате
+func (t *TimeOfDay) decodeBinary(r *binaryReader) {
+	var (
+		valid bool
+		value CivilTime
+	)
+
+	if r.flags(&valid, &value.HasOffset); !valid {
+		if r.err == nil {
+			t.Reset()
+		}
+		return
+	}
+	value.Hour, value.Minute, value.Second = r.int(), r.int(), r.int()
+	if value.Nanosecond, value.Offset = r.int(), r.int(); r.err == nil {
+		t.Time, t.Valid = value, valid
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (t *TimeOfDay) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.TimeOfDayWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.TimeOfDayWrapper)
+	if err = dec.Decode(item); err == nil {
+		t.Time, t.Valid = CivilTime{
+			Hour:       item.Hour,
+			Minute:     item.Minute,
+			Second:     item.Second,
+			Nanosecond: item.Nanosecond,
+			Offset:     item.Offset,
+			HasOffset:  item.HasOffset,
+		}, item.Valid
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (t *TimeOfDay) UnmarshalMsgpack(data []byte) error { return unmarshalMsgpackText(data, t) }
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется строкой текстового представления
+func (t TimeOfDay) MarshalMsgpack() ([]byte, error) { return marshalMsgpackText(t.Valid, t) }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из строки текстового представления
+func (t *TimeOfDay) UnmarshalCBOR(data []byte) error { return unmarshalCBORText(data, t) }
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется строкой текстового представления
+func (t TimeOfDay) MarshalCBOR() ([]byte, error) { return marshalCBORText(t.Valid, t) }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (t *TimeOfDay) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, t)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (t TimeOfDay) MarshalYAML() (interface{}, error) { return marshalYAMLText(t.Valid, t) }
@@ -0,0 +1,213 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	timeOfDayValue  = CivilTime{Hour: 15, Minute: 4, Second: 5, Nanosecond: 999999000, Offset: 3 * 3600, HasOffset: true}
+	timeOfDayString = "15:04:05.999999+03:00"
+	timeOfDayJSON   = []byte(`"15:04:05.999999+03:00"`)
+)
+
+func isTimeOfDayValid(t *testing.T, v TimeOfDay, from string) {
+	if v.Time != timeOfDayValue {
+		t.Errorf("Bad %s time of day: %v ≠ %v\n", from, v.Time, timeOfDayValue)
+	}
+	if !v.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isTimeOfDayNull(t *testing.T, v TimeOfDay, from string) {
+	if v.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewTimeOfDay(t *testing.T) {
+	v1 := NewTimeOfDay()
+	isTimeOfDayNull(t, v1, "NewTimeOfDay()")
+
+	v2 := NewTimeOfDayValue(timeOfDayValue)
+	isTimeOfDayValid(t, v2, "NewTimeOfDayValue()")
+
+	v3 := NewTimeOfDayPointerValue(nil)
+	isTimeOfDayNull(t, v3, "NewTimeOfDayPointerValue()")
+
+	v4 := NewTimeOfDayPointerValue(v2.Pointer())
+	isTimeOfDayValid(t, v4, "NewTimeOfDayPointerValue()")
+}
+
+func TestTimeOfDaySetValidReset(t *testing.T) {
+	v1 := NewTimeOfDay()
+	if v1.Pointer() != nil || !v1.MustValue().IsZero() {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(timeOfDayValue)
+	isTimeOfDayValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isTimeOfDayValid(t, v1, "NullIfDefault()")
+	v1.SetValid(CivilTime{})
+	v1.NullIfDefault()
+	isTimeOfDayNull(t, v1, "NullIfDefault()")
+}
+
+func TestTimeOfDayOn(t *testing.T) {
+	v1 := NewTimeOfDayValue(timeOfDayValue)
+	tm := v1.On(NewDateValue(dateValue), time.UTC)
+	if !tm.Valid || !tm.Time.Equal(time.Date(2019, time.March, 31, 12, 4, 5, 999999000, time.UTC)) {
+		t.Error("On()", "is wrong")
+	}
+	if v2 := v1.On(NewDate(), time.UTC); v2.Valid {
+		t.Error("On()", "is valid, but should be invalid")
+	}
+	if v3 := NewTimeOfDay().On(NewDateValue(dateValue), time.UTC); v3.Valid {
+		t.Error("On()", "is valid, but should be invalid")
+	}
+}
+
+func TestTimeOfDayScanValue(t *testing.T) {
+	v1 := NewTimeOfDay()
+	errorPanic(v1.Scan(timeOfDayString))
+	isTimeOfDayValid(t, v1, "Scan(string)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != timeOfDayString {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewTimeOfDay()
+	errorPanic(v2.Scan([]byte("15:04:05.999999")))
+	if !v2.Valid || v2.Time.HasOffset || v2.Time.Nanosecond != 999999000 {
+		t.Error("Scan([]byte)", "is wrong")
+	}
+
+	v3 := NewTimeOfDay()
+	errorPanic(v3.Scan(time.Date(0, time.January, 1, 15, 4, 5, 999999000, time.UTC)))
+	if !v3.Valid || v3.Time != v2.Time {
+		t.Error("Scan(time.Time)", "is wrong")
+	}
+	errorPanic(v3.Scan(time.Date(0, time.January, 1, 15, 4, 5, 0, time.FixedZone("", 3*3600))))
+	if !v3.Valid || v3.Time != (CivilTime{Hour: 15, Minute: 4, Second: 5, Offset: 3 * 3600, HasOffset: true}) {
+		t.Errorf("Scan(time.Time) is %v, but should be 15:04:05+03:00", v3.Time)
+	}
+	dv, err = v3.Value()
+	errorPanic(err)
+	if dv.(string) != "15:04:05+03:00" {
+		t.Errorf("Value() is %v, but should be 15:04:05+03:00", dv)
+	}
+
+	errorPanic(v3.Scan(time.Date(2020, time.July, 1, 15, 4, 5, 0, time.Local)))
+	if !v3.Valid || v3.Time != (CivilTime{Hour: 15, Minute: 4, Second: 5}) {
+		t.Errorf("Scan(time.Time) in time.Local is %v, but should be 15:04:05", v3.Time)
+	}
+	if loc, e := time.LoadLocation("America/New_York"); e == nil {
+		errorPanic(v3.Scan(time.Date(2020, time.July, 1, 15, 4, 5, 0, loc)))
+		if !v3.Valid || v3.Time != (CivilTime{Hour: 15, Minute: 4, Second: 5}) {
+			t.Errorf("Scan(time.Time) in America/New_York is %v, but should be 15:04:05", v3.Time)
+		}
+	}
+
+	v4 := NewTimeOfDayValue(timeOfDayValue)
+	errorPanic(v4.Scan(nil))
+	isTimeOfDayNull(t, v4, "Scan(nil)")
+	dv, err = v4.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v5 := NewTimeOfDay()
+	if err = v5.Scan(int64(1)); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	if err = v5.Scan("25:00:00"); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isTimeOfDayNull(t, v5, "Scan()")
+}
+
+func TestTimeOfDayUnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewTimeOfDay()
+	errorPanic(json.Unmarshal(timeOfDayJSON, &v1))
+	isTimeOfDayValid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewTimeOfDayValue(timeOfDayValue)
+	errorPanic(json.Unmarshal(boolNullJSON, &v2))
+	isTimeOfDayNull(t, v2, "UnmarshalJSON(null)")
+
+	v3 := NewTimeOfDay()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v3))
+	isTimeOfDayNull(t, v3, "UnmarshalJSON(blank)")
+
+	v4 := NewTimeOfDay()
+	if err = json.Unmarshal(int64JSON, &v4); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = json.Unmarshal([]byte(`"3pm"`), &v4); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = v4.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	isTimeOfDayNull(t, v4, "UnmarshalJSON()")
+}
+
+func TestTimeOfDayMarshalJSON(t *testing.T) {
+	v1 := NewTimeOfDayValue(timeOfDayValue)
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(timeOfDayJSON), "MarshalJSON()")
+
+	v2 := NewTimeOfDay()
+	data, err = json.Marshal(v2)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+}
+
+func TestTimeOfDayText(t *testing.T) {
+	v1 := NewTimeOfDay()
+	errorPanic(v1.UnmarshalText([]byte(timeOfDayString)))
+	isTimeOfDayValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, timeOfDayString, "MarshalText()")
+
+	v2 := NewTimeOfDay()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if !v2.Valid || !v2.Time.IsZero() {
+		t.Errorf("Value should be valid")
+	}
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "", "MarshalText(empty)")
+
+	v3 := NewTimeOfDayValue(timeOfDayValue)
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isTimeOfDayNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewTimeOfDay()
+	if err = v4.UnmarshalText([]byte("15:04:05+99")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isTimeOfDayNull(t, v4, "UnmarshalText()")
+}
+
+func TestTimeOfDayBinary(t *testing.T) {
+	v1 := NewTimeOfDayValue(timeOfDayValue)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewTimeOfDay()
+	errorPanic(v2.UnmarshalBinary(data))
+	isTimeOfDayValid(t, v2, "UnmarshalBinary()")
+}
@@ -0,0 +1,148 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+)
+
+// Uint16 is an nullable uint16 object
+type Uint16 struct {
+	Uint16 uint16 // Value of object
+	Valid  bool   // Valid is true if value is not NULL
+}
+
+// NewUint16 Создание нового объекта Uint16
+func NewUint16() Uint16 {
+	return Uint16{
+		Uint16: 0,
+		Valid:  false,
+	}
+}
+
+// NewUint16Value Создание нового действительного объекта Uint16 из значения
+func NewUint16Value(value uint16) Uint16 {
+	return Uint16{
+		Uint16: value,
+		Valid:  true,
+	}
+}
+
+// NewUint16PointerValue Создание нового действительного объекта Uint16 из ссылки на значение
+func NewUint16PointerValue(ptr *uint16) Uint16 {
+	if ptr == nil {
+		return NewUint16()
+	}
+	return NewUint16Value(*ptr)
+}
+
+// NewUint16Null Создание нового объекта Uint16 из обобщённого объекта Null
+func NewUint16Null(n Null[uint16]) Uint16 {
+	return Uint16{
+		Uint16: n.V,
+		Valid:  n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (u Uint16) Null() Null[uint16] { return Null[uint16]{V: u.Uint16, Valid: u.Valid} }
+
+// SetValid Изменение значения и установка флага действительного значения
+func (u *Uint16) SetValid(value uint16) { u.Uint16, u.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (u *Uint16) Reset() { u.Uint16, u.Valid = 0, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (u *Uint16) NullIfDefault() Uint16 {
+	if u.Uint16 == 0 {
+		u.Reset()
+	}
+	return *u
+}
+
+// MustValue Возвращает значение в любом случае
+func (u *Uint16) MustValue() uint16 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint16
+}
+
+// Pointer Возвращает ссылку на значение
+func (u *Uint16) Pointer() *uint16 {
+	if !u.Valid {
+		return nil
+	}
+	return &u.Uint16
+}
+
+// Scan Реализация интерфейса Scanner
+func (u *Uint16) Scan(value interface{}) error {
+	return setNull(&u.Uint16, &u.Valid, (*Null[uint16]).Scan, value)
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (u Uint16) Value() (driver.Value, error) { return u.Null().Value() }
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение допускается в виде числа либо строки, пустая строка является null
+func (u *Uint16) UnmarshalJSON(data []byte) error {
+	return setNull(&u.Uint16, &u.Valid, (*Null[uint16]).UnmarshalJSON, data)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (u Uint16) MarshalJSON() ([]byte, error) { return u.Null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (u *Uint16) UnmarshalText(text []byte) error {
+	return setNull(&u.Uint16, &u.Valid, (*Null[uint16]).UnmarshalText, text)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (u Uint16) MarshalText() ([]byte, error) { return u.Null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (u *Uint16) UnmarshalBinary(data []byte) error {
+	return setNull(&u.Uint16, &u.Valid, unmarshalNullBinary[uint16](binaryTagUint16), data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (u Uint16) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagUint16, u.Null()) }
+
+// Запись значения в компактном двоичном формате
+func (u Uint16) encodeBinary(w *binaryWriter) { u.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (u *Uint16) decodeBinary(r *binaryReader) {
+	var n = u.Null()
+
+	n.decodeBinary(r)
+	*u = NewUint16Null(n)
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (u *Uint16) UnmarshalMsgpack(data []byte) error {
+	return setNull(&u.Uint16, &u.Valid, (*Null[uint16]).UnmarshalMsgpack, data)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (u Uint16) MarshalMsgpack() ([]byte, error) { return u.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (u *Uint16) UnmarshalCBOR(data []byte) error {
+	return setNull(&u.Uint16, &u.Valid, (*Null[uint16]).UnmarshalCBOR, data)
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (u Uint16) MarshalCBOR() ([]byte, error) { return u.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (u *Uint16) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&u.Uint16, &u.Valid, (*Null[uint16]).UnmarshalYAML, unmarshal)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (u Uint16) MarshalYAML() (interface{}, error) { return u.Null().MarshalYAML() }
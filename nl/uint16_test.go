@@ -0,0 +1,248 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+)
+
+var (
+	uint16JSON         = []byte(strconv.FormatUint(math.MaxUint16, 10))
+	uint16StringJSON   = []byte(`"` + strconv.FormatUint(math.MaxUint16, 10) + `"`)
+	uint16ValidJSON    = []byte(`{"Uint16":` + strconv.FormatUint(math.MaxUint16, 10) + `,"Valid":true}`)
+	uint16OverflowJSON = []byte(strconv.FormatUint(math.MaxUint16+1, 10))
+	uint16NegativeJSON = []byte(`-1`)
+)
+
+func isUint16Valid(t *testing.T, u Uint16, from string) {
+	dv, _ := u.Value()
+	if dv.(int64) != math.MaxUint16 {
+		t.Errorf("Bad %s uint16: \"%d\" ≠ \"%d\"\n", from, dv, uint16(math.MaxUint16))
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isUint16Null(t *testing.T, u Uint16, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewUint16(t *testing.T) {
+	v1 := NewUint16()
+	isUint16Null(t, v1, "NewUint16()")
+
+	v2 := NewUint16Value(math.MaxUint16)
+	isUint16Valid(t, v2, "NewUint16Value()")
+
+	var bv = uint16(math.MaxUint16)
+	v3 := NewUint16PointerValue(&bv)
+	isUint16Valid(t, v3, "NewUint16PointerValue()")
+
+	v4 := NewUint16PointerValue(nil)
+	isUint16Null(t, v4, "NewUint16PointerValue()")
+}
+
+func TestUint16SetValidReset(t *testing.T) {
+	v1 := NewUint16()
+	v1.SetValid(math.MaxUint16)
+	isUint16Valid(t, v1, "SetValid()")
+	v1.Reset()
+	isUint16Null(t, v1, "Reset()")
+}
+
+func TestUint16NullIfDefault(t *testing.T) {
+	v1 := NewUint16Value(math.MaxUint16)
+	v1.NullIfDefault()
+	isUint16Valid(t, v1, "NullIfDefault()")
+
+	v1.SetValid(0)
+	v1.NullIfDefault()
+	isUint16Null(t, v1, "NullIfDefault()")
+}
+
+func TestUint16MustValuePointer(t *testing.T) {
+	v1 := NewUint16()
+	if v1.MustValue() != 0 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if v1.Pointer() != nil {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(math.MaxUint16)
+	if v1.MustValue() != math.MaxUint16 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if pb := v1.Pointer(); pb == nil || *pb != math.MaxUint16 {
+		t.Error("Pointer()", "is wrong")
+	}
+}
+
+func TestUint16Scan(t *testing.T) {
+	v1 := NewUint16()
+	errorPanic(v1.Scan(int64(math.MaxUint16)))
+	isUint16Valid(t, v1, "Scan()")
+
+	v2 := NewUint16()
+	errorPanic(v2.Scan(uint16JSON))
+	isUint16Valid(t, v2, "Scan()")
+
+	v3 := NewUint16Value(1)
+	errorPanic(v3.Scan(nil))
+	isUint16Null(t, v3, "Scan()")
+
+	v4 := NewUint16()
+	if err := v4.Scan(false); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+
+	v5 := NewUint16()
+	if err := v5.Scan(int64(math.MaxUint16 + 1)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isUint16Null(t, v5, "Scan(overflow)")
+
+	v6 := NewUint16()
+	if err := v6.Scan(int64(-1)); err == nil {
+		t.Error("Scan(negative)", "is nil, but should be not nil")
+	}
+	isUint16Null(t, v6, "Scan(negative)")
+}
+
+func TestUint16Value(t *testing.T) {
+	v1 := NewUint16Value(math.MaxUint16)
+	dv, err := v1.Value()
+	errorPanic(err)
+	if !driver.IsValue(dv) {
+		t.Error("Value()", "is not a driver.Value")
+	}
+
+	v2 := NewUint16()
+	dv, err = v2.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+}
+
+func TestUint16UnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewUint16()
+	errorPanic(json.Unmarshal(uint16JSON, &v1))
+	isUint16Valid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewUint16()
+	errorPanic(json.Unmarshal(uint16StringJSON, &v2))
+	isUint16Valid(t, v2, "UnmarshalJSON()")
+
+	v3 := NewUint16()
+	if err = json.Unmarshal(uint16ValidJSON, &v3); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint16Null(t, v3, "UnmarshalJSON(object)")
+
+	v4 := NewUint16()
+	errorPanic(json.Unmarshal(boolNullJSON, &v4))
+	isUint16Null(t, v4, "UnmarshalJSON(null)")
+
+	v5 := NewUint16()
+	if err = json.Unmarshal(uint16OverflowJSON, &v5); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint16Null(t, v5, "UnmarshalJSON(overflow)")
+
+	v6 := NewUint16()
+	if err = json.Unmarshal(uint16NegativeJSON, &v6); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint16Null(t, v6, "UnmarshalJSON(negative)")
+
+	v7 := NewUint16()
+	if err = v7.UnmarshalJSON(invalidJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+}
+
+func TestUint16MarshalJSON(t *testing.T) {
+	v1 := NewUint16Value(math.MaxUint16)
+	data, err := v1.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, string(uint16JSON), "non-empty json marshal")
+
+	v2 := NewUint16()
+	data, err = v2.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestUint16UnmarshalText(t *testing.T) {
+	v1 := NewUint16()
+	errorPanic(v1.UnmarshalText(uint16JSON))
+	isUint16Valid(t, v1, "UnmarshalText()")
+
+	v2 := NewUint16()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if v2.Uint16 != 0 || !v2.Valid {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewUint16()
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isUint16Null(t, v3, "UnmarshalText()")
+
+	v4 := NewUint16()
+	if err := v4.UnmarshalText(uint16OverflowJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint16Null(t, v4, "UnmarshalText(overflow)")
+
+	v5 := NewUint16()
+	if err := v5.UnmarshalText(uint16NegativeJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint16Null(t, v5, "UnmarshalText(negative)")
+}
+
+func TestUint16MarshalText(t *testing.T) {
+	v1 := NewUint16Value(math.MaxUint16)
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(uint16JSON), "Non-empty text marshal")
+
+	v2 := NewUint16()
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "Null text marshal")
+}
+
+func TestUint16Binary(t *testing.T) {
+	v1 := NewUint16Value(math.MaxUint16)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewUint16()
+	errorPanic(v2.UnmarshalBinary(data))
+	isUint16Valid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewUint16()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewUint16Value(1)
+	errorPanic(v4.UnmarshalBinary(data))
+	isUint16Null(t, v4, "UnmarshalBinary()")
+
+	v5 := NewUint64Value(math.MaxUint16 + 1)
+	data, err = v5.MarshalBinary()
+	errorPanic(err)
+	v6 := NewUint16()
+	if err = v6.UnmarshalBinary(data); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint16Null(t, v6, "UnmarshalBinary(overflow)")
+}
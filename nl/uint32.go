@@ -0,0 +1,148 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+)
+
+// Uint32 is an nullable uint32 object
+type Uint32 struct {
+	Uint32 uint32 // Value of object
+	Valid  bool   // Valid is true if value is not NULL
+}
+
+// NewUint32 Создание нового объекта Uint32
+func NewUint32() Uint32 {
+	return Uint32{
+		Uint32: 0,
+		Valid:  false,
+	}
+}
+
+// NewUint32Value Создание нового действительного объекта Uint32 из значения
+func NewUint32Value(value uint32) Uint32 {
+	return Uint32{
+		Uint32: value,
+		Valid:  true,
+	}
+}
+
+// NewUint32PointerValue Создание нового действительного объекта Uint32 из ссылки на значение
+func NewUint32PointerValue(ptr *uint32) Uint32 {
+	if ptr == nil {
+		return NewUint32()
+	}
+	return NewUint32Value(*ptr)
+}
+
+// NewUint32Null Создание нового объекта Uint32 из обобщённого объекта Null
+func NewUint32Null(n Null[uint32]) Uint32 {
+	return Uint32{
+		Uint32: n.V,
+		Valid:  n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (u Uint32) Null() Null[uint32] { return Null[uint32]{V: u.Uint32, Valid: u.Valid} }
+
+// SetValid Изменение значения и установка флага действительного значения
+func (u *Uint32) SetValid(value uint32) { u.Uint32, u.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (u *Uint32) Reset() { u.Uint32, u.Valid = 0, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (u *Uint32) NullIfDefault() Uint32 {
+	if u.Uint32 == 0 {
+		u.Reset()
+	}
+	return *u
+}
+
+// MustValue Возвращает значение в любом случае
+func (u *Uint32) MustValue() uint32 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint32
+}
+
+// Pointer Возвращает ссылку на значение
+func (u *Uint32) Pointer() *uint32 {
+	if !u.Valid {
+		return nil
+	}
+	return &u.Uint32
+}
+
+// Scan Реализация интерфейса Scanner
+func (u *Uint32) Scan(value interface{}) error {
+	return setNull(&u.Uint32, &u.Valid, (*Null[uint32]).Scan, value)
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (u Uint32) Value() (driver.Value, error) { return u.Null().Value() }
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение допускается в виде числа либо строки, пустая строка является null
+func (u *Uint32) UnmarshalJSON(data []byte) error {
+	return setNull(&u.Uint32, &u.Valid, (*Null[uint32]).UnmarshalJSON, data)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (u Uint32) MarshalJSON() ([]byte, error) { return u.Null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (u *Uint32) UnmarshalText(text []byte) error {
+	return setNull(&u.Uint32, &u.Valid, (*Null[uint32]).UnmarshalText, text)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (u Uint32) MarshalText() ([]byte, error) { return u.Null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (u *Uint32) UnmarshalBinary(data []byte) error {
+	return setNull(&u.Uint32, &u.Valid, unmarshalNullBinary[uint32](binaryTagUint32), data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (u Uint32) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagUint32, u.Null()) }
+
+// Запись значения в компактном двоичном формате
+func (u Uint32) encodeBinary(w *binaryWriter) { u.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (u *Uint32) decodeBinary(r *binaryReader) {
+	var n = u.Null()
+
+	n.decodeBinary(r)
+	*u = NewUint32Null(n)
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (u *Uint32) UnmarshalMsgpack(data []byte) error {
+	return setNull(&u.Uint32, &u.Valid, (*Null[uint32]).UnmarshalMsgpack, data)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (u Uint32) MarshalMsgpack() ([]byte, error) { return u.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (u *Uint32) UnmarshalCBOR(data []byte) error {
+	return setNull(&u.Uint32, &u.Valid, (*Null[uint32]).UnmarshalCBOR, data)
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (u Uint32) MarshalCBOR() ([]byte, error) { return u.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (u *Uint32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&u.Uint32, &u.Valid, (*Null[uint32]).UnmarshalYAML, unmarshal)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (u Uint32) MarshalYAML() (interface{}, error) { return u.Null().MarshalYAML() }
@@ -0,0 +1,248 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+)
+
+var (
+	uint32JSON         = []byte(strconv.FormatUint(math.MaxUint32, 10))
+	uint32StringJSON   = []byte(`"` + strconv.FormatUint(math.MaxUint32, 10) + `"`)
+	uint32ValidJSON    = []byte(`{"Uint32":` + strconv.FormatUint(math.MaxUint32, 10) + `,"Valid":true}`)
+	uint32OverflowJSON = []byte(strconv.FormatUint(math.MaxUint32+1, 10))
+	uint32NegativeJSON = []byte(`-1`)
+)
+
+func isUint32Valid(t *testing.T, u Uint32, from string) {
+	dv, _ := u.Value()
+	if dv.(int64) != math.MaxUint32 {
+		t.Errorf("Bad %s uint32: \"%d\" ≠ \"%d\"\n", from, dv, uint32(math.MaxUint32))
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isUint32Null(t *testing.T, u Uint32, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewUint32(t *testing.T) {
+	v1 := NewUint32()
+	isUint32Null(t, v1, "NewUint32()")
+
+	v2 := NewUint32Value(math.MaxUint32)
+	isUint32Valid(t, v2, "NewUint32Value()")
+
+	var bv = uint32(math.MaxUint32)
+	v3 := NewUint32PointerValue(&bv)
+	isUint32Valid(t, v3, "NewUint32PointerValue()")
+
+	v4 := NewUint32PointerValue(nil)
+	isUint32Null(t, v4, "NewUint32PointerValue()")
+}
+
+func TestUint32SetValidReset(t *testing.T) {
+	v1 := NewUint32()
+	v1.SetValid(math.MaxUint32)
+	isUint32Valid(t, v1, "SetValid()")
+	v1.Reset()
+	isUint32Null(t, v1, "Reset()")
+}
+
+func TestUint32NullIfDefault(t *testing.T) {
+	v1 := NewUint32Value(math.MaxUint32)
+	v1.NullIfDefault()
+	isUint32Valid(t, v1, "NullIfDefault()")
+
+	v1.SetValid(0)
+	v1.NullIfDefault()
+	isUint32Null(t, v1, "NullIfDefault()")
+}
+
+func TestUint32MustValuePointer(t *testing.T) {
+	v1 := NewUint32()
+	if v1.MustValue() != 0 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if v1.Pointer() != nil {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(math.MaxUint32)
+	if v1.MustValue() != math.MaxUint32 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if pb := v1.Pointer(); pb == nil || *pb != math.MaxUint32 {
+		t.Error("Pointer()", "is wrong")
+	}
+}
+
+func TestUint32Scan(t *testing.T) {
+	v1 := NewUint32()
+	errorPanic(v1.Scan(int64(math.MaxUint32)))
+	isUint32Valid(t, v1, "Scan()")
+
+	v2 := NewUint32()
+	errorPanic(v2.Scan(uint32JSON))
+	isUint32Valid(t, v2, "Scan()")
+
+	v3 := NewUint32Value(1)
+	errorPanic(v3.Scan(nil))
+	isUint32Null(t, v3, "Scan()")
+
+	v4 := NewUint32()
+	if err := v4.Scan(false); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+
+	v5 := NewUint32()
+	if err := v5.Scan(int64(math.MaxUint32 + 1)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isUint32Null(t, v5, "Scan(overflow)")
+
+	v6 := NewUint32()
+	if err := v6.Scan(int64(-1)); err == nil {
+		t.Error("Scan(negative)", "is nil, but should be not nil")
+	}
+	isUint32Null(t, v6, "Scan(negative)")
+}
+
+func TestUint32Value(t *testing.T) {
+	v1 := NewUint32Value(math.MaxUint32)
+	dv, err := v1.Value()
+	errorPanic(err)
+	if !driver.IsValue(dv) {
+		t.Error("Value()", "is not a driver.Value")
+	}
+
+	v2 := NewUint32()
+	dv, err = v2.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+}
+
+func TestUint32UnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewUint32()
+	errorPanic(json.Unmarshal(uint32JSON, &v1))
+	isUint32Valid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewUint32()
+	errorPanic(json.Unmarshal(uint32StringJSON, &v2))
+	isUint32Valid(t, v2, "UnmarshalJSON()")
+
+	v3 := NewUint32()
+	if err = json.Unmarshal(uint32ValidJSON, &v3); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint32Null(t, v3, "UnmarshalJSON(object)")
+
+	v4 := NewUint32()
+	errorPanic(json.Unmarshal(boolNullJSON, &v4))
+	isUint32Null(t, v4, "UnmarshalJSON(null)")
+
+	v5 := NewUint32()
+	if err = json.Unmarshal(uint32OverflowJSON, &v5); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint32Null(t, v5, "UnmarshalJSON(overflow)")
+
+	v6 := NewUint32()
+	if err = json.Unmarshal(uint32NegativeJSON, &v6); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint32Null(t, v6, "UnmarshalJSON(negative)")
+
+	v7 := NewUint32()
+	if err = v7.UnmarshalJSON(invalidJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+}
+
+func TestUint32MarshalJSON(t *testing.T) {
+	v1 := NewUint32Value(math.MaxUint32)
+	data, err := v1.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, string(uint32JSON), "non-empty json marshal")
+
+	v2 := NewUint32()
+	data, err = v2.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestUint32UnmarshalText(t *testing.T) {
+	v1 := NewUint32()
+	errorPanic(v1.UnmarshalText(uint32JSON))
+	isUint32Valid(t, v1, "UnmarshalText()")
+
+	v2 := NewUint32()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if v2.Uint32 != 0 || !v2.Valid {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewUint32()
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isUint32Null(t, v3, "UnmarshalText()")
+
+	v4 := NewUint32()
+	if err := v4.UnmarshalText(uint32OverflowJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint32Null(t, v4, "UnmarshalText(overflow)")
+
+	v5 := NewUint32()
+	if err := v5.UnmarshalText(uint32NegativeJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint32Null(t, v5, "UnmarshalText(negative)")
+}
+
+func TestUint32MarshalText(t *testing.T) {
+	v1 := NewUint32Value(math.MaxUint32)
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(uint32JSON), "Non-empty text marshal")
+
+	v2 := NewUint32()
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "Null text marshal")
+}
+
+func TestUint32Binary(t *testing.T) {
+	v1 := NewUint32Value(math.MaxUint32)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewUint32()
+	errorPanic(v2.UnmarshalBinary(data))
+	isUint32Valid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewUint32()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewUint32Value(1)
+	errorPanic(v4.UnmarshalBinary(data))
+	isUint32Null(t, v4, "UnmarshalBinary()")
+
+	v5 := NewUint64Value(math.MaxUint32 + 1)
+	data, err = v5.MarshalBinary()
+	errorPanic(err)
+	v6 := NewUint32()
+	if err = v6.UnmarshalBinary(data); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint32Null(t, v6, "UnmarshalBinary(overflow)")
+}
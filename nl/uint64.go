@@ -3,16 +3,8 @@ package nul // import "gopkg.in/webnice/lin.v1/nl"
 //import "gopkg.in/webnice/debug.v1"
 //import "gopkg.in/webnice/log.v2"
 import (
-	"bytes"
 	"database/sql/driver"
-	"encoding/gob"
-	"encoding/json"
-	"fmt"
-	"reflect"
-	"regexp"
 	"strconv"
-
-	"gopkg.in/webnice/lin.v1/wrapper"
 )
 
 // Uint64 is an nullable uint64 object
@@ -45,6 +37,17 @@ func NewUint64PointerValue(ptr *uint64) Uint64 {
 	return NewUint64Value(*ptr)
 }
 
+// NewUint64Null Создание нового объекта Uint64 из обобщённого объекта Null
+func NewUint64Null(n Null[uint64]) Uint64 {
+	return Uint64{
+		Uint64: n.V,
+		Valid:  n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (u Uint64) Null() Null[uint64] { return Null[uint64]{V: u.Uint64, Valid: u.Valid} }
+
 // SetValid Изменение значения и установка флага действительного значения
 func (u *Uint64) SetValid(value uint64) { u.Uint64, u.Valid = value, true }
 
@@ -76,23 +79,12 @@ func (u *Uint64) Pointer() *uint64 {
 }
 
 // Scan Реализация интерфейса Scanner
-func (u *Uint64) Scan(value interface{}) (err error) {
-	switch x := value.(type) {
-	case nil:
-		u.Uint64, u.Valid = 0, false
-		return
-	case uint64:
-		u.Uint64 = value.(uint64)
-	default:
-		buf := asString(x)
-		u.Uint64, err = strconv.ParseUint(buf, 10, 64)
-	}
-	u.Valid = err == nil
-
-	return
+func (u *Uint64) Scan(value interface{}) error {
+	return setNull(&u.Uint64, &u.Valid, (*Null[uint64]).Scan, value)
 }
 
 // Value Реализация интерфейса driver.Valuer
+// Значение записывается строкой, так как driver.Value не допускает значения uint64
 func (u Uint64) Value() (driver.Value, error) {
 	if !u.Valid {
 		return nil, nil
@@ -101,130 +93,63 @@ func (u Uint64) Value() (driver.Value, error) {
 }
 
 // UnmarshalJSON Реализация интерфейса json.Unmarshaler
-func (u *Uint64) UnmarshalJSON(data []byte) (err error) {
-	var v interface{}
-
-	if err = json.Unmarshal(data, &v); err != nil {
-		return
-	}
-	switch x := v.(type) {
-	case nil:
-		u.Uint64, u.Valid = 0, false
-		return
-	case float64:
-		err = json.Unmarshal(data, &u.Uint64)
-	case string:
-		var str = x
-		if len(str) == 0 {
-			u.Valid = false
-			return
-		}
-		u.Uint64, err = strconv.ParseUint(str, 10, 64)
-	case map[string]interface{}:
-		var rex *regexp.Regexp
-		_, uiOK := x["Uint64"].(float64)
-		valid, validOK := x["Valid"].(bool)
-		if !uiOK || !validOK {
-			return fmt.Errorf(`unmarshalling object into Go value of type nul.Uint64 requires key "Uint64" to be of type `+
-				`string and key "Valid" to be of type bool; `+
-				`found %T and %T, respectively`, x["Uint64"], x["Valid"])
-		}
-		// TODO
-		// Надо подумать как сделать лучше...
-		rex = regexp.MustCompile(`\"Uint64\"\s*\:\s*([0-9]+)`)
-		if arr := rex.FindStringSubmatch(string(data)); len(arr) == 2 {
-			err = u.UnmarshalText([]byte(arr[1]))
-			u.Valid = valid
-		}
-		return
-	default:
-		err = fmt.Errorf("Can't unmarshal %v into Go value of type nul.Uint64", reflect.TypeOf(v).Name())
-	}
-	u.Valid = err == nil
-
-	return
+// Допускается также объект вида {"Uint64": значение, "Valid": флаг}
+func (u *Uint64) UnmarshalJSON(data []byte) error {
+	return setNull(&u.Uint64, &u.Valid, unmarshalNullJSONObject[uint64]("Uint64"), data)
 }
 
 // MarshalJSON Реализация интерфейса json.Marshaler
-func (u Uint64) MarshalJSON() (data []byte, err error) {
-	const nullString = "null"
+func (u Uint64) MarshalJSON() ([]byte, error) { return u.Null().MarshalJSON() }
 
-	if !u.Valid {
-		data = []byte(nullString)
-		return
-	}
-	data = []byte(strconv.FormatUint(u.Uint64, 10))
-
-	return
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (u *Uint64) UnmarshalText(text []byte) error {
+	return setNull(&u.Uint64, &u.Valid, (*Null[uint64]).UnmarshalText, text)
 }
 
-// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
-func (u *Uint64) UnmarshalText(text []byte) (err error) {
-	const (
-		emptyString = ""
-		nullString  = "null"
-	)
-	var str string
-
-	switch str = string(text); str {
-	case nullString:
-		u.Uint64, u.Valid = 0, false
-		return
-	case emptyString:
-		u.Uint64, u.Valid = 0, true
-		return
-	default:
-		u.Uint64, err = strconv.ParseUint(str, 10, 64)
-	}
-	u.Valid = err == nil
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (u Uint64) MarshalText() ([]byte, error) { return u.Null().MarshalText() }
 
-	return
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (u *Uint64) UnmarshalBinary(data []byte) error {
+	return setNull(&u.Uint64, &u.Valid, unmarshalNullBinary[uint64](binaryTagUint64), data)
 }
 
-// MarshalText Реализация интерфейса encoding.TextMarshaler
-func (u Uint64) MarshalText() (text []byte, err error) {
-	const nullString = "null"
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (u Uint64) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagUint64, u.Null()) }
 
-	if !u.Valid {
-		text = []byte(nullString)
-		return
-	}
-	text = []byte(strconv.FormatUint(u.Uint64, 10))
+// Запись значения в компактном двоичном формате
+func (u Uint64) encodeBinary(w *binaryWriter) { u.Null().encodeBinary(w) }
 
-	return
-}
+// Чтение значения в компактном двоичном формате
+func (u *Uint64) decodeBinary(r *binaryReader) {
+	var n = u.Null()
 
-// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
-func (u *Uint64) UnmarshalBinary(data []byte) (err error) {
-	var (
-		reader *bytes.Reader
-		dec    *gob.Decoder
-		item   *wrapper.Uint64Wrapper
-	)
-
-	reader = bytes.NewReader(data)
-	dec = gob.NewDecoder(reader)
-	item = new(wrapper.Uint64Wrapper)
-	if err = dec.Decode(item); err == nil {
-		u.Uint64, u.Valid = item.Value, item.Valid
-	}
+	n.decodeBinary(r)
+	*u = NewUint64Null(n)
+}
 
-	return
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (u *Uint64) UnmarshalMsgpack(data []byte) error {
+	return setNull(&u.Uint64, &u.Valid, (*Null[uint64]).UnmarshalMsgpack, data)
 }
 
-// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
-func (u Uint64) MarshalBinary() (data []byte, err error) {
-	var (
-		buf  *bytes.Buffer
-		enc  *gob.Encoder
-		item *wrapper.Uint64Wrapper
-	)
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (u Uint64) MarshalMsgpack() ([]byte, error) { return u.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (u *Uint64) UnmarshalCBOR(data []byte) error {
+	return setNull(&u.Uint64, &u.Valid, (*Null[uint64]).UnmarshalCBOR, data)
+}
 
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-	item = &wrapper.Uint64Wrapper{Value: u.Uint64, Valid: u.Valid}
-	err = enc.Encode(item)
-	data = buf.Bytes()
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (u Uint64) MarshalCBOR() ([]byte, error) { return u.Null().MarshalCBOR() }
 
-	return
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (u *Uint64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&u.Uint64, &u.Valid, (*Null[uint64]).UnmarshalYAML, unmarshal)
 }
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (u Uint64) MarshalYAML() (interface{}, error) { return u.Null().MarshalYAML() }
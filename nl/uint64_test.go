@@ -283,24 +283,13 @@ func TestUint64UnmarshalBinary(t *testing.T) {
 }
 
 func TestUint64MarshalBinary(t *testing.T) {
-	var err error
-	var buf *bytes.Buffer
-	var enc *gob.Encoder
-
-	buf = &bytes.Buffer{}
-	enc = gob.NewEncoder(buf)
-
 	v1 := NewUint64()
-	err = enc.Encode(&v1)
+	data, err := v1.MarshalBinary()
 	errorPanic(err)
-	v1h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v1h), uint64NullInvalidGob, "NewBytes() -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), uint64NullInvalidBinary, "NewUint64() -> MarshalBinary()")
 
-	buf.Reset()
-	enc = gob.NewEncoder(buf)
 	v2 := NewUint64Value(uint64(math.MaxUint64))
-	err = enc.Encode(&v2)
+	data, err = v2.MarshalBinary()
 	errorPanic(err)
-	v2h := hex.EncodeToString(buf.Bytes())
-	jsonEquals(t, []byte(v2h), uint64OkValidGob, "NewBoolValue(false) -> MarshalBinary()")
+	jsonEquals(t, []byte(hex.EncodeToString(data)), uint64OkValidBinary, "NewUint64Value(max) -> MarshalBinary()")
 }
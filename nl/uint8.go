@@ -0,0 +1,148 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+)
+
+// Uint8 is an nullable uint8 object
+type Uint8 struct {
+	Uint8 uint8 // Value of object
+	Valid bool  // Valid is true if value is not NULL
+}
+
+// NewUint8 Создание нового объекта Uint8
+func NewUint8() Uint8 {
+	return Uint8{
+		Uint8: 0,
+		Valid: false,
+	}
+}
+
+// NewUint8Value Создание нового действительного объекта Uint8 из значения
+func NewUint8Value(value uint8) Uint8 {
+	return Uint8{
+		Uint8: value,
+		Valid: true,
+	}
+}
+
+// NewUint8PointerValue Создание нового действительного объекта Uint8 из ссылки на значение
+func NewUint8PointerValue(ptr *uint8) Uint8 {
+	if ptr == nil {
+		return NewUint8()
+	}
+	return NewUint8Value(*ptr)
+}
+
+// NewUint8Null Создание нового объекта Uint8 из обобщённого объекта Null
+func NewUint8Null(n Null[uint8]) Uint8 {
+	return Uint8{
+		Uint8: n.V,
+		Valid: n.Valid,
+	}
+}
+
+// Null Возвращает значение в виде обобщённого объекта Null
+func (u Uint8) Null() Null[uint8] { return Null[uint8]{V: u.Uint8, Valid: u.Valid} }
+
+// SetValid Изменение значения и установка флага действительного значения
+func (u *Uint8) SetValid(value uint8) { u.Uint8, u.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (u *Uint8) Reset() { u.Uint8, u.Valid = 0, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (u *Uint8) NullIfDefault() Uint8 {
+	if u.Uint8 == 0 {
+		u.Reset()
+	}
+	return *u
+}
+
+// MustValue Возвращает значение в любом случае
+func (u *Uint8) MustValue() uint8 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint8
+}
+
+// Pointer Возвращает ссылку на значение
+func (u *Uint8) Pointer() *uint8 {
+	if !u.Valid {
+		return nil
+	}
+	return &u.Uint8
+}
+
+// Scan Реализация интерфейса Scanner
+func (u *Uint8) Scan(value interface{}) error {
+	return setNull(&u.Uint8, &u.Valid, (*Null[uint8]).Scan, value)
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (u Uint8) Value() (driver.Value, error) { return u.Null().Value() }
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Значение допускается в виде числа либо строки, пустая строка является null
+func (u *Uint8) UnmarshalJSON(data []byte) error {
+	return setNull(&u.Uint8, &u.Valid, (*Null[uint8]).UnmarshalJSON, data)
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (u Uint8) MarshalJSON() ([]byte, error) { return u.Null().MarshalJSON() }
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+func (u *Uint8) UnmarshalText(text []byte) error {
+	return setNull(&u.Uint8, &u.Valid, (*Null[uint8]).UnmarshalText, text)
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (u Uint8) MarshalText() ([]byte, error) { return u.Null().MarshalText() }
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат и формат gob предыдущих версий
+func (u *Uint8) UnmarshalBinary(data []byte) error {
+	return setNull(&u.Uint8, &u.Valid, unmarshalNullBinary[uint8](binaryTagUint8), data)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (u Uint8) MarshalBinary() ([]byte, error) { return marshalBinary(binaryTagUint8, u.Null()) }
+
+// Запись значения в компактном двоичном формате
+func (u Uint8) encodeBinary(w *binaryWriter) { u.Null().encodeBinary(w) }
+
+// Чтение значения в компактном двоичном формате
+func (u *Uint8) decodeBinary(r *binaryReader) {
+	var n = u.Null()
+
+	n.decodeBinary(r)
+	*u = NewUint8Null(n)
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+func (u *Uint8) UnmarshalMsgpack(data []byte) error {
+	return setNull(&u.Uint8, &u.Valid, (*Null[uint8]).UnmarshalMsgpack, data)
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+func (u Uint8) MarshalMsgpack() ([]byte, error) { return u.Null().MarshalMsgpack() }
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+func (u *Uint8) UnmarshalCBOR(data []byte) error {
+	return setNull(&u.Uint8, &u.Valid, (*Null[uint8]).UnmarshalCBOR, data)
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+func (u Uint8) MarshalCBOR() ([]byte, error) { return u.Null().MarshalCBOR() }
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (u *Uint8) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return setNull(&u.Uint8, &u.Valid, (*Null[uint8]).UnmarshalYAML, unmarshal)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+func (u Uint8) MarshalYAML() (interface{}, error) { return u.Null().MarshalYAML() }
@@ -0,0 +1,248 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+)
+
+var (
+	uint8JSON         = []byte(strconv.FormatUint(math.MaxUint8, 10))
+	uint8StringJSON   = []byte(`"` + strconv.FormatUint(math.MaxUint8, 10) + `"`)
+	uint8ValidJSON    = []byte(`{"Uint8":` + strconv.FormatUint(math.MaxUint8, 10) + `,"Valid":true}`)
+	uint8OverflowJSON = []byte(strconv.FormatUint(math.MaxUint8+1, 10))
+	uint8NegativeJSON = []byte(`-1`)
+)
+
+func isUint8Valid(t *testing.T, u Uint8, from string) {
+	dv, _ := u.Value()
+	if dv.(int64) != math.MaxUint8 {
+		t.Errorf("Bad %s uint8: \"%d\" ≠ \"%d\"\n", from, dv, uint8(math.MaxUint8))
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isUint8Null(t *testing.T, u Uint8, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestNewUint8(t *testing.T) {
+	v1 := NewUint8()
+	isUint8Null(t, v1, "NewUint8()")
+
+	v2 := NewUint8Value(math.MaxUint8)
+	isUint8Valid(t, v2, "NewUint8Value()")
+
+	var bv = uint8(math.MaxUint8)
+	v3 := NewUint8PointerValue(&bv)
+	isUint8Valid(t, v3, "NewUint8PointerValue()")
+
+	v4 := NewUint8PointerValue(nil)
+	isUint8Null(t, v4, "NewUint8PointerValue()")
+}
+
+func TestUint8SetValidReset(t *testing.T) {
+	v1 := NewUint8()
+	v1.SetValid(math.MaxUint8)
+	isUint8Valid(t, v1, "SetValid()")
+	v1.Reset()
+	isUint8Null(t, v1, "Reset()")
+}
+
+func TestUint8NullIfDefault(t *testing.T) {
+	v1 := NewUint8Value(math.MaxUint8)
+	v1.NullIfDefault()
+	isUint8Valid(t, v1, "NullIfDefault()")
+
+	v1.SetValid(0)
+	v1.NullIfDefault()
+	isUint8Null(t, v1, "NullIfDefault()")
+}
+
+func TestUint8MustValuePointer(t *testing.T) {
+	v1 := NewUint8()
+	if v1.MustValue() != 0 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if v1.Pointer() != nil {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(math.MaxUint8)
+	if v1.MustValue() != math.MaxUint8 {
+		t.Error("MustValue()", "is wrong")
+	}
+	if pb := v1.Pointer(); pb == nil || *pb != math.MaxUint8 {
+		t.Error("Pointer()", "is wrong")
+	}
+}
+
+func TestUint8Scan(t *testing.T) {
+	v1 := NewUint8()
+	errorPanic(v1.Scan(int64(math.MaxUint8)))
+	isUint8Valid(t, v1, "Scan()")
+
+	v2 := NewUint8()
+	errorPanic(v2.Scan(uint8JSON))
+	isUint8Valid(t, v2, "Scan()")
+
+	v3 := NewUint8Value(1)
+	errorPanic(v3.Scan(nil))
+	isUint8Null(t, v3, "Scan()")
+
+	v4 := NewUint8()
+	if err := v4.Scan(false); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+
+	v5 := NewUint8()
+	if err := v5.Scan(int64(math.MaxUint8 + 1)); err == nil {
+		t.Error("Scan(overflow)", "is nil, but should be not nil")
+	}
+	isUint8Null(t, v5, "Scan(overflow)")
+
+	v6 := NewUint8()
+	if err := v6.Scan(int64(-1)); err == nil {
+		t.Error("Scan(negative)", "is nil, but should be not nil")
+	}
+	isUint8Null(t, v6, "Scan(negative)")
+}
+
+func TestUint8Value(t *testing.T) {
+	v1 := NewUint8Value(math.MaxUint8)
+	dv, err := v1.Value()
+	errorPanic(err)
+	if !driver.IsValue(dv) {
+		t.Error("Value()", "is not a driver.Value")
+	}
+
+	v2 := NewUint8()
+	dv, err = v2.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+}
+
+func TestUint8UnmarshalJSON(t *testing.T) {
+	var err error
+
+	v1 := NewUint8()
+	errorPanic(json.Unmarshal(uint8JSON, &v1))
+	isUint8Valid(t, v1, "UnmarshalJSON()")
+
+	v2 := NewUint8()
+	errorPanic(json.Unmarshal(uint8StringJSON, &v2))
+	isUint8Valid(t, v2, "UnmarshalJSON()")
+
+	v3 := NewUint8()
+	if err = json.Unmarshal(uint8ValidJSON, &v3); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint8Null(t, v3, "UnmarshalJSON(object)")
+
+	v4 := NewUint8()
+	errorPanic(json.Unmarshal(boolNullJSON, &v4))
+	isUint8Null(t, v4, "UnmarshalJSON(null)")
+
+	v5 := NewUint8()
+	if err = json.Unmarshal(uint8OverflowJSON, &v5); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint8Null(t, v5, "UnmarshalJSON(overflow)")
+
+	v6 := NewUint8()
+	if err = json.Unmarshal(uint8NegativeJSON, &v6); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint8Null(t, v6, "UnmarshalJSON(negative)")
+
+	v7 := NewUint8()
+	if err = v7.UnmarshalJSON(invalidJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+}
+
+func TestUint8MarshalJSON(t *testing.T) {
+	v1 := NewUint8Value(math.MaxUint8)
+	data, err := v1.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, string(uint8JSON), "non-empty json marshal")
+
+	v2 := NewUint8()
+	data, err = v2.MarshalJSON()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "null json marshal")
+}
+
+func TestUint8UnmarshalText(t *testing.T) {
+	v1 := NewUint8()
+	errorPanic(v1.UnmarshalText(uint8JSON))
+	isUint8Valid(t, v1, "UnmarshalText()")
+
+	v2 := NewUint8()
+	errorPanic(v2.UnmarshalText([]byte("")))
+	if v2.Uint8 != 0 || !v2.Valid {
+		t.Errorf("Value should be valid")
+	}
+
+	v3 := NewUint8()
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isUint8Null(t, v3, "UnmarshalText()")
+
+	v4 := NewUint8()
+	if err := v4.UnmarshalText(uint8OverflowJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint8Null(t, v4, "UnmarshalText(overflow)")
+
+	v5 := NewUint8()
+	if err := v5.UnmarshalText(uint8NegativeJSON); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint8Null(t, v5, "UnmarshalText(negative)")
+}
+
+func TestUint8MarshalText(t *testing.T) {
+	v1 := NewUint8Value(math.MaxUint8)
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, string(uint8JSON), "Non-empty text marshal")
+
+	v2 := NewUint8()
+	data, err = v2.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "Null text marshal")
+}
+
+func TestUint8Binary(t *testing.T) {
+	v1 := NewUint8Value(math.MaxUint8)
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewUint8()
+	errorPanic(v2.UnmarshalBinary(data))
+	isUint8Valid(t, v2, "UnmarshalBinary()")
+
+	v3 := NewUint8()
+	data, err = v3.MarshalBinary()
+	errorPanic(err)
+	v4 := NewUint8Value(1)
+	errorPanic(v4.UnmarshalBinary(data))
+	isUint8Null(t, v4, "UnmarshalBinary()")
+
+	v5 := NewUint64Value(math.MaxUint8 + 1)
+	data, err = v5.MarshalBinary()
+	errorPanic(err)
+	v6 := NewUint8()
+	if err = v6.UnmarshalBinary(data); err == nil {
+		t.Errorf("Error should not be nil")
+	}
+	isUint8Null(t, v6, "UnmarshalBinary(overflow)")
+}
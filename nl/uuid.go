@@ -0,0 +1,450 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/webnice/lin.v1/cbor"
+	"gopkg.in/webnice/lin.v1/msgpack"
+	"gopkg.in/webnice/lin.v1/wrapper"
+)
+
+const (
+	uuidSize      = 16          // Размер UUID в байтах
+	uuidURNPrefix = "urn:uuid:" // Префикс URN формы UUID
+)
+
+// UUID is an nullable UUID object
+type UUID struct {
+	UUID   [uuidSize]byte // Value of object
+	Valid  bool           // Valid is true if value is not NULL
+	Binary bool           // Binary is true if Value() writes 16-byte form instead of text form, Scan doesn't change it
+}
+
+// NewUUID Создание нового объекта UUID
+func NewUUID() UUID {
+	return UUID{
+		UUID:  [uuidSize]byte{},
+		Valid: false,
+	}
+}
+
+// NewUUIDValue Создание нового действительного объекта UUID из значения
+func NewUUIDValue(value [uuidSize]byte) UUID {
+	return UUID{
+		UUID:  value,
+		Valid: true,
+	}
+}
+
+// NewUUIDPointerValue Создание нового действительного объекта UUID из ссылки на значение
+func NewUUIDPointerValue(ptr *[uuidSize]byte) UUID {
+	if ptr == nil {
+		return NewUUID()
+	}
+	return NewUUIDValue(*ptr)
+}
+
+// NewUUIDString Создание нового действительного объекта UUID из строкового представления
+func NewUUIDString(str string) (ret UUID, err error) {
+	var value [uuidSize]byte
+
+	if value, err = ParseUUID(str); err != nil {
+		return
+	}
+	ret = NewUUIDValue(value)
+
+	return
+}
+
+// ParseUUID Разбор UUID в канонической форме, в фигурных скобках, в форме URN или в виде 32 шестнадцатеричных цифр
+func ParseUUID(str string) (ret [uuidSize]byte, err error) {
+	const (
+		lengthCanonical = 36
+		lengthHex       = 32
+	)
+	var src = str
+
+	switch {
+	case len(str) == lengthCanonical+2 && str[0] == '{' && str[len(str)-1] == '}':
+		str = str[1 : len(str)-1]
+	case len(str) == lengthCanonical+len(uuidURNPrefix) && strings.EqualFold(str[:len(uuidURNPrefix)], uuidURNPrefix):
+		str = str[len(uuidURNPrefix):]
+	}
+	switch len(str) {
+	case lengthCanonical:
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			err = fmt.Errorf("can't parse %q as UUID: invalid format", src)
+			return
+		}
+		str = str[0:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	case lengthHex:
+	default:
+		err = fmt.Errorf("can't parse %q as UUID: invalid length", src)
+		return
+	}
+	if _, err = hex.Decode(ret[:], []byte(str)); err != nil {
+		err = fmt.Errorf("can't parse %q as UUID: %s", src, err)
+	}
+
+	return
+}
+
+// FormatUUID Форматирование UUID в каноническую форму xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+func FormatUUID(value [uuidSize]byte) string {
+	var buf [36]byte
+
+	hex.Encode(buf[0:8], value[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], value[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], value[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], value[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], value[10:])
+
+	return string(buf[:])
+}
+
+// SetValid Изменение значения и установка флага действительного значения
+func (u *UUID) SetValid(value [uuidSize]byte) { u.UUID, u.Valid = value, true }
+
+// Reset Сброс значения и установка флага не действительного значения
+func (u *UUID) Reset() { u.UUID, u.Valid = [uuidSize]byte{}, false }
+
+// NullIfDefault Выполняет сброс значения до null, если значение переменной явзяется дефолтовым
+func (u *UUID) NullIfDefault() UUID {
+	if u.UUID == [uuidSize]byte{} {
+		u.Reset()
+	}
+	return *u
+}
+
+// MustValue Возвращает значение в любом случае
+func (u *UUID) MustValue() [uuidSize]byte {
+	if !u.Valid {
+		return [uuidSize]byte{}
+	}
+	return u.UUID
+}
+
+// Pointer Возвращает ссылку на значение
+func (u *UUID) Pointer() *[uuidSize]byte {
+	if !u.Valid {
+		return nil
+	}
+	return &u.UUID
+}
+
+// Разбор UUID из 16 байт двоичной формы либо из текстовой формы
+func (u *UUID) parse(buf []byte) (err error) {
+	if len(buf) == uuidSize {
+		copy(u.UUID[:], buf)
+		return
+	}
+	u.UUID, err = ParseUUID(string(buf))
+
+	return
+}
+
+// Scan Реализация интерфейса Scanner
+// Значение из 16 байт рассматривается как двоичная форма UUID, иначе как текстовая форма.
+// Флаг Binary, выбранный пользователем, не изменяется, поэтому Value() записывает значение в той же форме
+// независимо от типа колонки, из которой оно было прочитано
+func (u *UUID) Scan(value interface{}) (err error) {
+	switch x := value.(type) {
+	case nil:
+		u.Reset()
+		return
+	case []byte:
+		err = u.parse(x)
+	case string:
+		err = u.parse([]byte(x))
+	default:
+		err = fmt.Errorf("can't scan type %T into nul.UUID: %v", x, value)
+	}
+	if u.Valid = err == nil; !u.Valid {
+		u.UUID = [uuidSize]byte{}
+	}
+
+	return
+}
+
+// Value Реализация интерфейса driver.Valuer
+func (u UUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	if u.Binary {
+		return u.UUID[:], nil
+	}
+	return FormatUUID(u.UUID), nil
+}
+
+// UnmarshalJSON Реализация интерфейса json.Unmarshaler
+// Строка из 24 символов рассматривается как 16 байт UUID в кодировке base64, строка из 16 байт как двоичная форма
+func (u *UUID) UnmarshalJSON(data []byte) (err error) {
+	const base64Length = 24
+	var (
+		v   interface{}
+		buf []byte
+	)
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		u.Reset()
+		return
+	case string:
+		if len(x) == 0 {
+			u.Reset()
+			return
+		}
+		if len(x) == base64Length {
+			if buf, err = base64.StdEncoding.DecodeString(x); err != nil {
+				break
+			}
+			if len(buf) != uuidSize {
+				err = fmt.Errorf("can't parse %q as UUID: invalid length %d", x, len(buf))
+				break
+			}
+			copy(u.UUID[:], buf)
+			break
+		}
+		err = u.parse([]byte(x))
+	default:
+		err = fmt.Errorf("can't unmarshal %q into go value of type nul.UUID", reflect.TypeOf(v).Kind())
+	}
+	if u.Valid = err == nil; !u.Valid {
+		u.UUID = [uuidSize]byte{}
+	}
+
+	return
+}
+
+// MarshalJSON Реализация интерфейса json.Marshaler
+func (u UUID) MarshalJSON() (data []byte, err error) {
+	const nullString = "null"
+
+	if !u.Valid {
+		data = []byte(nullString)
+		return
+	}
+	data = []byte(`"` + FormatUUID(u.UUID) + `"`)
+
+	return
+}
+
+// UnmarshalText Реализация интерфейса encoding.TextUnmarshaler
+// Текст из 16 байт рассматривается как двоичная форма UUID
+func (u *UUID) UnmarshalText(text []byte) (err error) {
+	const (
+		emptyString = ""
+		nullString  = "null"
+	)
+
+	switch string(text) {
+	case nullString:
+		u.Reset()
+		return
+	case emptyString:
+		u.UUID, u.Valid = [uuidSize]byte{}, true
+		return
+	default:
+		err = u.parse(text)
+	}
+	if u.Valid = err == nil; !u.Valid {
+		u.UUID = [uuidSize]byte{}
+	}
+
+	return
+}
+
+// MarshalText Реализация интерфейса encoding.TextMarshaler
+func (u UUID) MarshalText() (text []byte, err error) {
+	const nullString = "null"
+
+	if !u.Valid {
+		text = []byte(nullString)
+		return
+	}
+	text = []byte(FormatUUID(u.UUID))
+
+	return
+}
+
+// UnmarshalBinary Реализация интерфейса encoding.BinaryUnmarshaler
+// Поддерживается компактный двоичный формат, формат gob предыдущих версий и 16 байт UUID
+func (u *UUID) UnmarshalBinary(data []byte) (err error) {
+	if len(data) == uuidSize {
+		return u.Scan(data)
+	}
+	if isGobBinary(data) {
+		return u.unmarshalGob(data)
+	}
+	return unmarshalBinary(data, binaryTagUUID, u)
+}
+
+// MarshalBinary Реализация интерфейса encoding.BinaryMarshaler
+func (u UUID) MarshalBinary() (data []byte, err error) { return marshalBinary(binaryTagUUID, u) }
+
+// Запись значения в компактном двоичном формате
+func (u UUID) encodeBinary(w *binaryWriter) {
+	if w.flags(u.Valid, u.Binary); !u.Valid {
+		return
+	}
+	w.fixed(u.UUID[:])
+}
+
+// Чтение значения в компактном двоичном формате
+func (u *UUID) decodeBinary(r *binaryReader) {
+	var (
+		valid, bin bool
+		value      [uuidSize]byte
+	)
+
+	if r.flags(&valid, &bin); !valid {
+		if r.err == nil {
+			u.UUID, u.Valid, u.Binary = [uuidSize]byte{}, false, bin
+		}
+		return
+	}
+	if r.fixed(value[:]); r.err == nil {
+		u.UUID, u.Valid, u.Binary = value, valid, bin
+	}
+}
+
+// Разбор значения в формате gob предыдущих версий
+func (u *UUID) unmarshalGob(data []byte) (err error) {
+	var (
+		reader *bytes.Reader
+		dec    *gob.Decoder
+		item   *wrapper.UUIDWrapper
+	)
+
+	reader = bytes.NewReader(data)
+	dec = gob.NewDecoder(reader)
+	item = new(wrapper.UUIDWrapper)
+	if err = dec.Decode(item); err == nil {
+		u.UUID, u.Valid, u.Binary = item.Value, item.Valid, item.Binary
+	}
+
+	return
+}
+
+// UnmarshalMsgpack Реализация интерфейса msgpack.Unmarshaler
+// Значение декодируется из 16 байт в формате bin, что устанавливает флаг Binary, либо из строки
+func (u *UUID) UnmarshalMsgpack(data []byte) (err error) {
+	var (
+		value []byte
+		rest  []byte
+	)
+
+	if msgpack.NextType(data) != msgpack.BinType {
+		if err = unmarshalMsgpackText(data, u); err == nil && u.Valid {
+			u.Binary = false
+		}
+		return
+	}
+	if value, rest, err = msgpack.ReadBytes(data); err != nil {
+		return
+	}
+	if err = msgpackEnd(rest, nil); err != nil {
+		return
+	}
+	if len(value) != uuidSize {
+		return fmt.Errorf("can't unmarshal msgpack bin of length %d into nul.UUID", len(value))
+	}
+	copy(u.UUID[:], value)
+	u.Valid, u.Binary = true, true
+
+	return
+}
+
+// MarshalMsgpack Реализация интерфейса msgpack.Marshaler
+// Значение кодируется 16 байтами в формате bin, если установлен флаг Binary, иначе строкой
+func (u UUID) MarshalMsgpack() ([]byte, error) {
+	switch {
+	case !u.Valid:
+		return msgpack.AppendNil(nil), nil
+	case u.Binary:
+		return msgpack.AppendBytes(nil, u.UUID[:]), nil
+	default:
+		return msgpack.AppendString(nil, FormatUUID(u.UUID)), nil
+	}
+}
+
+// UnmarshalCBOR Реализация интерфейса cbor.Unmarshaler
+// Значение декодируется из 16 байт строки байт, в том числе с тегом 37, что устанавливает флаг Binary,
+// либо из текстовой строки
+func (u *UUID) UnmarshalCBOR(data []byte) (err error) {
+	var (
+		tag   uint64
+		value []byte
+		rest  []byte
+	)
+
+	if cbor.NextType(data) == cbor.TagType {
+		if tag, data, err = cbor.ReadTag(data); err != nil {
+			return
+		}
+		if tag != cbor.TagUUID || cbor.NextType(data) != cbor.BytesType {
+			return fmt.Errorf("can't unmarshal cbor tag %d of %s into nul.UUID", tag, cbor.NextType(data))
+		}
+	}
+	if cbor.NextType(data) != cbor.BytesType {
+		if err = unmarshalCBORText(data, u); err == nil && u.Valid {
+			u.Binary = false
+		}
+		return
+	}
+	if value, rest, err = cbor.ReadBytes(data); err != nil {
+		return
+	}
+	if err = cborEnd(rest, nil); err != nil {
+		return
+	}
+	if len(value) != uuidSize {
+		return fmt.Errorf("can't unmarshal cbor byte string of length %d into nul.UUID", len(value))
+	}
+	copy(u.UUID[:], value)
+	u.Valid, u.Binary = true, true
+
+	return
+}
+
+// MarshalCBOR Реализация интерфейса cbor.Marshaler
+// Значение кодируется 16 байтами строки байт с тегом 37, если установлен флаг Binary, иначе текстовой строкой
+func (u UUID) MarshalCBOR() ([]byte, error) {
+	switch {
+	case !u.Valid:
+		return cbor.AppendNull(nil), nil
+	case u.Binary:
+		return cbor.AppendBytes(cbor.AppendTag(nil, cbor.TagUUID), u.UUID[:]), nil
+	default:
+		return cbor.AppendString(nil, FormatUUID(u.UUID)), nil
+	}
+}
+
+// UnmarshalYAML Реализация интерфейса yaml.Unmarshaler
+// Значение декодируется из скаляра текстового представления
+// Значение null библиотека yaml.v3 не передаёт методу и не изменяет поле, см. Null.UnmarshalYAML
+func (u *UUID) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, u)
+}
+
+// MarshalYAML Реализация интерфейса yaml.Marshaler
+// Значение кодируется строкой текстового представления
+func (u UUID) MarshalYAML() (interface{}, error) { return marshalYAMLText(u.Valid, u) }
@@ -0,0 +1,261 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+var (
+	uuidString     = `6ba7b810-9dad-11d1-80b4-00c04fd430c8`
+	uuidJSON       = []byte(`"` + uuidString + `"`)
+	uuidBase64JSON = []byte(`"a6e4EJ2tEdGAtADAT9QwyA=="`)
+	uuidValue      = [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	uuidRaw        = uuidValue[:]
+)
+
+func isUUIDValid(t *testing.T, u UUID, from string) {
+	if !bytes.Equal(u.UUID[:], uuidRaw) {
+		t.Errorf("Bad %s UUID: %q ≠ %q\n", from, FormatUUID(u.UUID), uuidString)
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func isUUIDNull(t *testing.T, u UUID, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	for _, in := range []string{
+		uuidString,
+		`6BA7B810-9DAD-11D1-80B4-00C04FD430C8`,
+		`{6ba7b810-9dad-11d1-80b4-00c04fd430c8}`,
+		`urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8`,
+		`URN:UUID:6ba7b810-9dad-11d1-80b4-00c04fd430c8`,
+		`6ba7b8109dad11d180b400c04fd430c8`,
+	} {
+		v, err := ParseUUID(in)
+		errorPanic(err)
+		if !bytes.Equal(v[:], uuidRaw) {
+			t.Errorf("ParseUUID(%q) is wrong", in)
+		}
+	}
+	for _, in := range []string{
+		``,
+		`6ba7b810-9dad-11d1-80b4-00c04fd430c`,
+		`6ba7b810x9dad-11d1-80b4-00c04fd430c8`,
+		`6ba7b810-9dad-11d1-80b4-00c04fd430cz`,
+		`{6ba7b810-9dad-11d1-80b4-00c04fd430c8`,
+		`urn:uid:6ba7b810-9dad-11d1-80b4-00c04fd430c8`,
+	} {
+		if _, err := ParseUUID(in); err == nil {
+			t.Errorf("ParseUUID(%q) error is nil, but should be not nil", in)
+		}
+	}
+	jsonEquals(t, []byte(FormatUUID(uuidValue)), uuidString, "FormatUUID()")
+}
+
+func TestNewUUID(t *testing.T) {
+	v1 := NewUUID()
+	isUUIDNull(t, v1, "NewUUID()")
+
+	v2 := NewUUIDValue(uuidValue)
+	isUUIDValid(t, v2, "NewUUIDValue()")
+
+	v3 := NewUUIDPointerValue(nil)
+	isUUIDNull(t, v3, "NewUUIDPointerValue()")
+
+	v4 := NewUUIDPointerValue(v2.Pointer())
+	isUUIDValid(t, v4, "NewUUIDPointerValue()")
+
+	v5, err := NewUUIDString(uuidString)
+	errorPanic(err)
+	isUUIDValid(t, v5, "NewUUIDString()")
+}
+
+func TestUUIDSetValidReset(t *testing.T) {
+	v1 := NewUUID()
+	if v1.Pointer() != nil || v1.MustValue() != [16]byte{} {
+		t.Error("Pointer()", "is not nil, but should be nil")
+	}
+	v1.SetValid(uuidValue)
+	isUUIDValid(t, v1, "SetValid()")
+	v1.NullIfDefault()
+	isUUIDValid(t, v1, "NullIfDefault()")
+	v1.Reset()
+	isUUIDNull(t, v1, "Reset()")
+	if v1.UUID != [16]byte{} {
+		t.Errorf("Reset() is %#v, but should be zero value", v1)
+	}
+
+	v2 := NewUUIDValue([16]byte{})
+	v2.NullIfDefault()
+	isUUIDNull(t, v2, "NullIfDefault()")
+}
+
+func TestUUIDScanValue(t *testing.T) {
+	v1 := NewUUID()
+	errorPanic(v1.Scan(uuidString))
+	isUUIDValid(t, v1, "Scan(string)")
+	dv, err := v1.Value()
+	errorPanic(err)
+	if dv.(string) != uuidString {
+		t.Error("Value()", "is wrong")
+	}
+
+	v2 := NewUUID()
+	errorPanic(v2.Scan(uuidRaw))
+	isUUIDValid(t, v2, "Scan([]byte)")
+	if v2.Binary {
+		t.Error("Scan([]byte)", "should keep Binary")
+	}
+	dv, err = v2.Value()
+	errorPanic(err)
+	if dv.(string) != uuidString {
+		t.Error("Value()", "is wrong")
+	}
+	v2.Binary = true
+	dv, err = v2.Value()
+	errorPanic(err)
+	if !bytes.Equal(dv.([]byte), uuidRaw) {
+		t.Error("Value()", "is wrong")
+	}
+
+	v3 := NewUUID()
+	errorPanic(v3.Scan([]byte(`{` + uuidString + `}`)))
+	isUUIDValid(t, v3, "Scan([]byte)")
+	v3.Binary = true
+	errorPanic(v3.Scan(uuidString))
+	if !v3.Binary {
+		t.Error("Scan(string)", "should keep Binary")
+	}
+
+	v4 := NewUUIDValue(uuidValue)
+	errorPanic(v4.Scan(nil))
+	isUUIDNull(t, v4, "Scan(nil)")
+	dv, err = v4.Value()
+	errorPanic(err)
+	if dv != nil {
+		t.Error("Value()", "returns not nil, but should be nil")
+	}
+
+	v5 := NewUUID()
+	if err = v5.Scan(int64(1)); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	v6 := NewUUID()
+	if err = v6.Scan("bad"); err == nil {
+		t.Error("Scan()", "is nil, but should be not nil")
+	}
+	isUUIDNull(t, v6, "Scan(bad)")
+}
+
+func TestUUIDJSON(t *testing.T) {
+	v1 := NewUUID()
+	errorPanic(json.Unmarshal(uuidJSON, &v1))
+	isUUIDValid(t, v1, "UnmarshalJSON()")
+	data, err := json.Marshal(v1)
+	errorPanic(err)
+	jsonEquals(t, data, string(uuidJSON), "MarshalJSON()")
+
+	v2 := NewUUID()
+	errorPanic(json.Unmarshal(uuidBase64JSON, &v2))
+	isUUIDValid(t, v2, "UnmarshalJSON(base64)")
+
+	v3 := NewUUIDValue(uuidValue)
+	errorPanic(json.Unmarshal(boolNullJSON, &v3))
+	isUUIDNull(t, v3, "UnmarshalJSON(null)")
+	data, err = json.Marshal(v3)
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalJSON(null)")
+
+	v4 := NewUUID()
+	errorPanic(json.Unmarshal(int64BlankJSON, &v4))
+	isUUIDNull(t, v4, "UnmarshalJSON(blank)")
+
+	v5 := NewUUID()
+	if err = json.Unmarshal(int64JSON, &v5); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+	if err = v5.UnmarshalJSON(invalidJSON); err == nil {
+		t.Error("UnmarshalJSON()", "is nil, but should be not nil")
+	}
+}
+
+func TestUUIDText(t *testing.T) {
+	v1 := NewUUID()
+	errorPanic(v1.UnmarshalText([]byte(`urn:uuid:` + uuidString)))
+	isUUIDValid(t, v1, "UnmarshalText()")
+	data, err := v1.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, uuidString, "MarshalText()")
+
+	v2 := NewUUID()
+	errorPanic(v2.UnmarshalText(uuidRaw))
+	isUUIDValid(t, v2, "UnmarshalText(raw)")
+
+	v3 := NewUUID()
+	errorPanic(v3.UnmarshalText(boolNullJSON))
+	isUUIDNull(t, v3, "UnmarshalText(null)")
+	data, err = v3.MarshalText()
+	errorPanic(err)
+	jsonEquals(t, data, "null", "MarshalText(null)")
+
+	v4 := NewUUID()
+	if err = v4.UnmarshalText([]byte("bad")); err == nil {
+		t.Error("UnmarshalText()", "is nil, but should be not nil")
+	}
+	isUUIDNull(t, v4, "UnmarshalText(bad)")
+}
+
+func TestUUIDBinary(t *testing.T) {
+	v1 := NewUUIDValue(uuidValue)
+	v1.Binary = true
+	data, err := v1.MarshalBinary()
+	errorPanic(err)
+	v2 := NewUUID()
+	errorPanic(v2.UnmarshalBinary(data))
+	isUUIDValid(t, v2, "UnmarshalBinary()")
+	if !v2.Binary {
+		t.Error("UnmarshalBinary()", "lost Binary flag")
+	}
+}
+
+func TestUUIDRawText(t *testing.T) {
+	const raw = "0123456789abcdef"
+	var v UUID
+
+	errorPanic(v.UnmarshalText([]byte(raw)))
+	if !v.Valid || v.Binary || string(v.UUID[:]) != raw {
+		t.Errorf("UnmarshalText(%s) is %#v, but should be raw UUID", raw, v)
+	}
+	v = UUID{}
+	errorPanic(v.UnmarshalJSON([]byte(`"` + raw + `"`)))
+	if !v.Valid || v.Binary || string(v.UUID[:]) != raw {
+		t.Errorf("UnmarshalJSON(%s) is %#v, but should be raw UUID", raw, v)
+	}
+	v = UUID{}
+	errorPanic(v.Scan(raw))
+	if !v.Valid || v.Binary || string(v.UUID[:]) != raw {
+		t.Errorf("Scan(%s) is %#v, but should be raw UUID", raw, v)
+	}
+	v = UUID{}
+	errorPanic(v.Scan([]byte(raw)))
+	if !v.Valid || v.Binary || string(v.UUID[:]) != raw {
+		t.Errorf("Scan([]byte) is %#v, but should be raw UUID", v)
+	}
+	v = UUID{}
+	errorPanic(v.UnmarshalBinary([]byte(raw)))
+	if !v.Valid || string(v.UUID[:]) != raw {
+		t.Errorf("UnmarshalBinary() is %#v, but should be raw UUID", v)
+	}
+	if err := v.UnmarshalText([]byte(raw[1:])); err == nil {
+		t.Error("UnmarshalText()", "of 15 bytes is nil, but should be not nil")
+	}
+}
@@ -0,0 +1,234 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Формат YAML
+// Методы MarshalYAML и UnmarshalYAML вызываются библиотеками gopkg.in/yaml.v2, gopkg.in/yaml.v3 и совместимыми,
+// зависимость от библиотеки YAML не требуется. Не действительное значение кодируется значением nil, которое
+// библиотека записывает как null YAML. Логические значения, числа, строки и время записываются скалярами YAML
+// соответствующих типов, срезы байт, не являющиеся текстом UTF-8, библиотека записывает с тегом !!binary,
+// остальные типы записываются строкой текстового представления MarshalText. Числовые и логические значения,
+// как и в JSON, допускаются в виде строки, пустая строка декодируется как null.
+// Значения null YAML (~, null, пустое значение, а в yaml.v3 также Null и NULL) библиотека обрабатывает без вызова
+// UnmarshalYAML. Библиотека yaml.v2 присваивает полю нулевое значение, то есть null, а библиотека yaml.v3 оставляет
+// поле без изменений, поэтому поле, уже содержащее значение, остаётся действительным. При использовании yaml.v3
+// документ с null следует декодировать в новое значение либо в поле-указатель.
+// Библиотека yaml.v2 также обрабатывает как null строку 'null' в кавычках.
+
+// Кодирование значения в формате YAML
+type yamlMarshaler interface {
+	MarshalYAML() (interface{}, error)
+}
+
+// Декодирование значения в формате YAML
+type yamlUnmarshaler interface {
+	UnmarshalYAML(unmarshal func(interface{}) error) error
+}
+
+// Значение, декодируемое из текстового представления
+type yamlTextUnmarshaler interface {
+	encoding.TextUnmarshaler
+	Reset()
+}
+
+var yamlMarshalerType = reflect.TypeOf((*yamlMarshaler)(nil)).Elem()
+
+// Декодирование значения YAML в значение типа T, null является истиной, если значением является null
+// Значение предварительно декодируется в interface{}, так как библиотека yaml.v2 передаёт методу UnmarshalYAML
+// значения Null и NULL, а при декодировании в указатель создаёт нулевое значение. Срез байт декодируется из строки,
+// в том числе с тегом !!binary. Числовые и логические значения допускаются в виде строки, как в JSON
+func unmarshalYAML[T any](unmarshal func(interface{}) error) (value T, null bool, err error) {
+	var (
+		raw interface{}
+		str string
+		rv  = reflect.ValueOf(&value).Elem()
+	)
+
+	if err = unmarshal(&raw); err != nil {
+		return
+	}
+	if null = raw == nil; null {
+		return
+	}
+	if _, ok := raw.([]interface{}); !ok && isBytesKind(rv) {
+		if err = unmarshal(&str); err == nil {
+			rv.SetBytes([]byte(str))
+		}
+		return
+	}
+	if err = unmarshal(&value); err != nil {
+		if str, ok := raw.(string); ok && isBasicKind(rv) && rv.Kind() != reflect.String {
+			if null, err = len(str) == 0, nil; !null {
+				err = parseKind(rv, str)
+			}
+		}
+	}
+
+	return
+}
+
+// Кодирование текстового представления значения строкой, не действительное значение кодируется nil
+func marshalYAMLText(valid bool, value encoding.TextMarshaler) (ret interface{}, err error) {
+	var text []byte
+
+	if !valid {
+		return
+	}
+	if text, err = value.MarshalText(); err == nil {
+		ret = string(text)
+	}
+
+	return
+}
+
+// Декодирование значения из скаляра YAML методом UnmarshalText, null сбрасывает значение
+func unmarshalYAMLText(unmarshal func(interface{}) error, value yamlTextUnmarshaler) (err error) {
+	var (
+		text string
+		null bool
+	)
+
+	if text, null, err = unmarshalYAML[string](unmarshal); err != nil {
+		return
+	}
+	if null {
+		value.Reset()
+		return
+	}
+	err = value.UnmarshalText([]byte(text))
+
+	return
+}
+
+// Значение YAML для значения произвольного типа
+// Библиотека yaml.v2 не вызывает MarshalYAML и MarshalText у значения, возвращённого методом MarshalYAML, поэтому
+// такие значения кодируются здесь, кроме времени, которое библиотека записывает меткой времени YAML.
+// Срез байт кодируется строкой, которую библиотека записывает с тегом !!binary, если она не является текстом UTF-8
+func yamlValue(value interface{}) (ret interface{}, err error) {
+	var (
+		rv   = reflect.ValueOf(value)
+		text []byte
+	)
+
+	if !rv.IsValid() || rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return
+	}
+	switch v := value.(type) {
+	case yamlMarshaler:
+		return v.MarshalYAML()
+	case time.Time:
+		return v, nil
+	case encoding.TextMarshaler:
+		if text, err = v.MarshalText(); err == nil {
+			ret = string(text)
+		}
+		return
+	}
+	if isBytesKind(rv) {
+		return string(rv.Bytes()), nil
+	}
+
+	return value, nil
+}
+
+// Значение YAML из документа JSON, объекты представляются картами, массивы последовательностями
+func yamlFromJSON(data []byte) (ret interface{}, err error) {
+	var dec = json.NewDecoder(bytes.NewReader(data))
+
+	dec.UseNumber()
+	if err = dec.Decode(&ret); err == nil {
+		ret = yamlNumbers(ret)
+	}
+
+	return
+}
+
+// Замена значений json.Number числами int64, uint64 или float64, которые библиотека записывает скалярами YAML
+func yamlNumbers(value interface{}) interface{} {
+	var (
+		i   int64
+		u   uint64
+		f   float64
+		err error
+	)
+
+	switch v := value.(type) {
+	case json.Number:
+		if i, err = v.Int64(); err == nil {
+			return i
+		}
+		if u, err = strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u
+		}
+		if f, err = v.Float64(); err == nil {
+			return f
+		}
+		return string(v)
+	case []interface{}:
+		for n := range v {
+			v[n] = yamlNumbers(v[n])
+		}
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = yamlNumbers(v[key])
+		}
+	}
+
+	return value
+}
+
+// Документ JSON из значения YAML, null является истиной, если значением является null
+func yamlJSON(unmarshal func(interface{}) error) (data []byte, null bool, err error) {
+	var value interface{}
+
+	if err = unmarshal(&value); err != nil {
+		return
+	}
+	if null = value == nil; !null {
+		data, err = json.Marshal(jsonValue(value))
+	}
+
+	return
+}
+
+// Преобразование значения YAML в значение, кодируемое JSON
+// Ключи карт map[interface{}]interface{} библиотеки yaml.v2 форматируются fmt.Sprint
+func jsonValue(value interface{}) interface{} {
+	var (
+		items  []interface{}
+		values map[string]interface{}
+	)
+
+	switch v := value.(type) {
+	case []interface{}:
+		items = make([]interface{}, len(v))
+		for i := range v {
+			items[i] = jsonValue(v[i])
+		}
+		return items
+	case map[interface{}]interface{}:
+		values = make(map[string]interface{}, len(v))
+		for key := range v {
+			values[fmt.Sprint(key)] = jsonValue(v[key])
+		}
+		return values
+	case map[string]interface{}:
+		values = make(map[string]interface{}, len(v))
+		for key := range v {
+			values[key] = jsonValue(v[key])
+		}
+		return values
+	default:
+		return value
+	}
+}
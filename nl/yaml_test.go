@@ -0,0 +1,243 @@
+package nul // import "gopkg.in/webnice/lin.v1/nl"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	yamlv2 "gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type yamlTestValue interface {
+	yamlMarshaler
+	yamlUnmarshaler
+}
+
+// Библиотеки YAML, с которыми проверяется совместимость
+var yamlLibraries = []struct {
+	Name      string
+	Marshal   func(interface{}) ([]byte, error)
+	Unmarshal func([]byte, interface{}) error
+}{
+	{"yaml.v2", yamlv2.Marshal, yamlv2.Unmarshal},
+	{"yaml.v3", yamlv3.Marshal, yamlv3.Unmarshal},
+}
+
+// Декодирование скаляра или потока YAML в поле v структуры, поле предварительно получает значение value
+// Возвращается указатель на декодированное поле
+func yamlDecodeField(unmarshal func([]byte, interface{}) error, doc string, value yamlUnmarshaler) (ret yamlTestValue, err error) {
+	var (
+		typ = reflect.TypeOf(value).Elem()
+		rv  = reflect.New(reflect.StructOf([]reflect.StructField{{Name: "V", Type: typ, Tag: `yaml:"v"`}}))
+	)
+
+	rv.Elem().Field(0).Set(reflect.ValueOf(value).Elem())
+	err = unmarshal([]byte("v: "+doc), rv.Interface())
+	ret = rv.Elem().Field(0).Addr().Interface().(yamlTestValue)
+
+	return
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	for _, item := range binaryTestValues() {
+		value, ok := item.(yamlTestValue)
+		if !ok {
+			t.Errorf("%T doesn't implement MarshalYAML and UnmarshalYAML", item)
+			continue
+		}
+		if date, ok := value.(*Date); ok && date.Date.Year < 0 {
+			// Текстовое представление даты не поддерживает годы до нашей эры
+			continue
+		}
+		if duration, ok := value.(*Duration); ok && duration.Format != DurationFormatGo {
+			// Формат длительности не является частью значения и не восстанавливается при декодировании
+			continue
+		}
+		expected, err := value.MarshalYAML()
+		errorPanic(err)
+		for _, library := range yamlLibraries {
+			data, err := library.Marshal(value)
+			if err != nil {
+				t.Errorf("%s Marshal() of %T error: %s", library.Name, value, err)
+				continue
+			}
+			target := reflect.New(reflect.TypeOf(value).Elem()).Interface().(yamlTestValue)
+			if err = library.Unmarshal(data, target); err != nil {
+				t.Errorf("%s Unmarshal(%q) of %T error: %s", library.Name, data, value, err)
+				continue
+			}
+			again, err := target.MarshalYAML()
+			errorPanic(err)
+			if !reflect.DeepEqual(again, expected) {
+				t.Errorf("%s MarshalYAML() of decoded %T is %#v, but should be %#v", library.Name, value, again, expected)
+			}
+		}
+	}
+}
+
+func TestYAMLFormat(t *testing.T) {
+	var (
+		array, _ = NewArrayDims([]Int64{NewInt64Value(1), NewInt64(), NewInt64Value(3), NewInt64Value(-4)}, 2, 2)
+		dec, _   = NewDecimalString("1.50")
+		big, _   = NewBigIntString("18446744073709551616")
+		point    = NewPointValue(Coord{X: 1, Y: 2})
+		tm       = time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+		tests    []struct {
+			Value    yamlMarshaler
+			Expected interface{}
+		}
+	)
+
+	point.SRID = 4326
+	tests = []struct {
+		Value    yamlMarshaler
+		Expected interface{}
+	}{
+		{NewInt64(), nil},
+		{NewInt8Value(-5), int8(-5)},
+		{NewUint64Value(1 << 63), uint64(1 << 63)},
+		{NewFloat32Value(1.5), float32(1.5)},
+		{NewBoolValue(true), true},
+		{NewStringValue("null"), "null"},
+		{NewBytesValue([]byte{0xff, 0}), "\xff\x00"},
+		{NewTimeValue(tm), tm},
+		{NewDurationValue(90 * time.Minute), "1h30m0s"},
+		{dec, "1.50"},
+		{NewBigIntValue(nil), int64(0)},
+		{big, "18446744073709551616"},
+		{NewDateValue(CivilDate{Year: 2020, Month: time.February, Day: 29}), "2020-02-29"},
+		{point, "SRID=4326;POINT(1 2)"},
+		{array, []interface{}{
+			[]interface{}{NewInt64Value(1), NewInt64()},
+			[]interface{}{NewInt64Value(3), NewInt64Value(-4)},
+		}},
+		{NewArrayValue([]Int64{}), []interface{}{}},
+		{NewStringMapValue(map[string]String{"a": NewStringValue("1"), "b": NewString()}), map[string]interface{}{"a": "1", "b": nil}},
+		{NewJSONValue([]byte(`{"a":[1,2.5,null,18446744073709551615]}`)), map[string]interface{}{
+			"a": []interface{}{int64(1), 2.5, nil, uint64(18446744073709551615)},
+		}},
+		{NewJSONOfValue(binaryTestStruct{Name: "a", Count: 2}), map[string]interface{}{"Name": "a", "Count": int64(2)}},
+		{NewNullValue[int16](-7), int16(-7)},
+		{NewNullValue([]byte{0xfe}), "\xfe"},
+		{NewNullValue(NewStringValue("a")), "a"},
+		{NewNullValue(netip.MustParseAddr("127.0.0.1")), "127.0.0.1"},
+		{NewNullValue(tm), tm},
+		{NewOptional[int](), nil},
+		{NewOptionalNull[int](), nil},
+	}
+	for _, test := range tests {
+		value, err := test.Value.MarshalYAML()
+		errorPanic(err)
+		if !reflect.DeepEqual(value, test.Expected) {
+			t.Errorf("MarshalYAML() of %T is %#v, but should be %#v", test.Value, value, test.Expected)
+		}
+	}
+}
+
+func TestYAMLUnmarshal(t *testing.T) {
+	var array, _ = NewArrayDims([]Int64{NewInt64Value(1), NewInt64(), NewInt64Value(3), NewInt64Value(4)}, 2, 2)
+	var tests = []struct {
+		Doc      string
+		Value    yamlUnmarshaler
+		Expected yamlMarshaler
+	}{
+		{"~", &Bool{}, NewBool()},
+		{"null", &Int64{}, NewInt64()},
+		{"Null", &Int64{}, NewInt64()},
+		{"NULL", &Decimal{}, NewDecimal()},
+		{"", &Time{}, NewTime()},
+		{"'5'", &Int64{}, NewInt64Value(5)},
+		{"'-5'", &Int8{}, NewInt8Value(-5)},
+		{"'18446744073709551615'", &Uint64{}, NewUint64Value(18446744073709551615)},
+		{"'1.5'", &Float64{}, NewFloat64Value(1.5)},
+		{"'true'", &Bool{}, NewBoolValue(true)},
+		{"''", &Int64{}, NewInt64()},
+		{"'7'", &Null[int16]{}, NewNullValue[int16](7)},
+		{"!!binary AQI=", &Bytes{}, NewBytesValue([]byte{1, 2})},
+		{"!!binary /g==", &Null[[]byte]{}, NewNullValue([]byte{0xfe})},
+		{"1.50", &Decimal{}, NewDecimalValue(mustParseDec("1.50"))},
+		{"[[1, ~], [3, 4]]", &Int64Array{}, array},
+		{"[]", &Int64Array{}, NewArrayValue([]Int64{})},
+		{"{a: x, b: ~}", &StringMap{}, NewStringMapValue(map[string]String{"a": NewStringValue("x"), "b": NewString()})},
+		{"{1: [a, ~]}", &JSON{}, NewJSONValue([]byte(`{"1":["a",null]}`))},
+		{"5", &Optional[int]{}, NewOptionalValue(5)},
+	}
+
+	for _, library := range yamlLibraries {
+		for _, test := range tests {
+			value, err := yamlDecodeField(library.Unmarshal, test.Doc, test.Value)
+			if err != nil {
+				t.Errorf("%s Unmarshal(%q) into %T error: %s", library.Name, test.Doc, test.Value, err)
+				continue
+			}
+			actual, err := value.MarshalYAML()
+			errorPanic(err)
+			expected, err := test.Expected.MarshalYAML()
+			errorPanic(err)
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s Unmarshal(%q) into %T is %#v, but should be %#v", library.Name, test.Doc, test.Value, actual, expected)
+			}
+		}
+	}
+}
+
+// Библиотека yaml.v2 декодирует строку 'null' в кавычках как null без вызова UnmarshalYAML
+func TestYAMLUnmarshalQuotedNull(t *testing.T) {
+	value, err := yamlDecodeField(yamlv3.Unmarshal, "'null'", &String{})
+	errorPanic(err)
+	if s := value.(*String); !s.Valid || s.String != "null" {
+		t.Errorf("yaml.v3 Unmarshal(\"'null'\") is %v, but should be \"null\"", s)
+	}
+}
+
+// Библиотека yaml.v2 присваивает null полю, уже содержащему значение, библиотека yaml.v3 оставляет поле без изменений
+func TestYAMLUnmarshalNullIntoValue(t *testing.T) {
+	for _, doc := range []string{"~", "null", "Null", "NULL", ""} {
+		value, err := yamlDecodeField(yamlv2.Unmarshal, doc, ptr(NewInt64Value(9)))
+		errorPanic(err)
+		if i := value.(*Int64); i.Valid {
+			t.Errorf("yaml.v2 Unmarshal(%q) into valid Int64 is %v, but should be null", doc, i)
+		}
+		value, err = yamlDecodeField(yamlv3.Unmarshal, doc, ptr(NewInt64Value(9)))
+		errorPanic(err)
+		if i := value.(*Int64); !i.Valid || i.Int64 != 9 {
+			t.Errorf("yaml.v3 Unmarshal(%q) into valid Int64 is %v, but should be unchanged", doc, i)
+		}
+	}
+}
+
+func TestYAMLUnmarshalErrors(t *testing.T) {
+	var tests = []struct {
+		Doc   string
+		Value yamlUnmarshaler
+	}{
+		{"300", &Int8{}},
+		{"abc", &Int8{}},
+		{"'abc'", &Int64{}},
+		{"'1.5'", &Int64{}},
+		{"-1", &Uint8{}},
+		{"'-1'", &Uint8{}},
+		{"abc", &Time{}},
+		{"abc", &Decimal{}},
+		{"x", &Int64Array{}},
+		{"[[1], 2]", &Int64Array{}},
+		{"[[1], [1, 2]]", &Int64Array{}},
+		{"[[]]", &Int64Array{}},
+		{"[x]", &Int64Array{}},
+		{"[1]", &StringMap{}},
+		{"{Name: 1}", &JSONOf[binaryTestStruct]{}},
+		{"128", &Null[int8]{}},
+	}
+
+	for _, library := range yamlLibraries {
+		for _, test := range tests {
+			if _, err := yamlDecodeField(library.Unmarshal, test.Doc, test.Value); err == nil {
+				t.Errorf("%s Unmarshal(%q) into %T error is nil, but should be not nil", library.Name, test.Doc, test.Value)
+			}
+		}
+	}
+}
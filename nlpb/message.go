@@ -0,0 +1,332 @@
+package nlpb // import "gopkg.in/webnice/lin.v1/nlpb"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	tagName          = "pb"
+	tagSkip          = "-"
+	wrapperValueName = "value"
+	timestampName    = protoreflect.FullName("google.protobuf.Timestamp")
+	timestampSeconds = "seconds"
+	timestampNanos   = "nanos"
+)
+
+// Полные имена сообщений обёрток google.protobuf
+var wrapperNames = map[protoreflect.FullName]bool{
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.UInt64Value": true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.BytesValue":  true,
+}
+
+// UnmarshalStruct Заполнение полей структуры dst из полей сообщения msg
+// Поля структуры сопоставляются с полями сообщения по имени без учёта регистра и символов подчёркивания,
+// либо по имени указанному в теге `pb:"field_name"`, тег `pb:"-"` исключает поле.
+// Заполняются только поля реализующие интерфейс sql.Scanner, то есть все объекты nl.
+// Отсутствующее сообщение обёртки или Timestamp, а так же не установленное поле с признаком присутствия
+// (optional) является null
+func UnmarshalStruct(msg proto.Message, dst interface{}) (err error) {
+	var (
+		rv reflect.Value
+		m  protoreflect.Message
+	)
+
+	rv = reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		err = fmt.Errorf("can't unmarshal protobuf message into %T, pointer to struct is required", dst)
+		return
+	}
+	m = msg.ProtoReflect()
+	err = eachField(rv.Elem(), messageFields(m.Descriptor()), func(fv reflect.Value, fd protoreflect.FieldDescriptor) (err error) {
+		var (
+			scanner sql.Scanner
+			value   interface{}
+			ok      bool
+		)
+
+		if scanner, ok = fv.Addr().Interface().(sql.Scanner); !ok {
+			return
+		}
+		if value, err = fieldValue(m, fd); err == nil {
+			err = scanner.Scan(value)
+		}
+		if err != nil {
+			err = fmt.Errorf("can't unmarshal protobuf field %s into %s: %v", fd.FullName(), fv.Type(), err)
+		}
+
+		return
+	})
+
+	return
+}
+
+// MarshalStruct Заполнение полей сообщения msg из полей структуры src
+// Поля сопоставляются так же как в UnmarshalStruct, используются поля реализующие интерфейс driver.Valuer.
+// Значение null очищает поле сообщения
+func MarshalStruct(src interface{}, msg proto.Message) (err error) {
+	var (
+		rv reflect.Value
+		m  protoreflect.Message
+	)
+
+	rv = reflect.Indirect(reflect.ValueOf(src))
+	if rv.Kind() != reflect.Struct {
+		err = fmt.Errorf("can't marshal %T into protobuf message, struct is required", src)
+		return
+	}
+	m = msg.ProtoReflect()
+	err = eachField(rv, messageFields(m.Descriptor()), func(fv reflect.Value, fd protoreflect.FieldDescriptor) (err error) {
+		var (
+			valuer driver.Valuer
+			value  driver.Value
+			pv     protoreflect.Value
+			ok     bool
+		)
+
+		if valuer, ok = fv.Interface().(driver.Valuer); !ok {
+			return
+		}
+		if value, err = valuer.Value(); err == nil && value == nil {
+			m.Clear(fd)
+			return
+		}
+		if err == nil {
+			pv, err = protoValue(m, fd, value)
+		}
+		if err != nil {
+			err = fmt.Errorf("can't marshal %s into protobuf field %s: %v", fv.Type(), fd.FullName(), err)
+			return
+		}
+		m.Set(fd, pv)
+
+		return
+	})
+
+	return
+}
+
+// Нормализация имени поля для сопоставления полей структуры и сообщения
+func fieldKey(name string) string { return strings.ToLower(strings.ReplaceAll(name, "_", "")) }
+
+// Индекс полей сообщения по нормализованному имени
+func messageFields(md protoreflect.MessageDescriptor) (ret map[string]protoreflect.FieldDescriptor) {
+	var (
+		fields protoreflect.FieldDescriptors
+		n      int
+	)
+
+	fields = md.Fields()
+	ret = make(map[string]protoreflect.FieldDescriptor, fields.Len())
+	for n = 0; n < fields.Len(); n++ {
+		ret[fieldKey(string(fields.Get(n).Name()))] = fields.Get(n)
+	}
+
+	return
+}
+
+// Обход экспортируемых полей структуры, имеющих соответствующее поле в сообщении
+// Поля встроенных структур обходятся рекурсивно
+func eachField(
+	rv reflect.Value,
+	fields map[string]protoreflect.FieldDescriptor,
+	fn func(reflect.Value, protoreflect.FieldDescriptor) error,
+) (err error) {
+	var (
+		sf   reflect.StructField
+		fd   protoreflect.FieldDescriptor
+		name string
+		ok   bool
+		n    int
+	)
+
+	for n = 0; n < rv.NumField() && err == nil; n++ {
+		if sf = rv.Type().Field(n); sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		if name, ok = sf.Tag.Lookup(tagName); !ok || name == "" {
+			name = sf.Name
+		}
+		if name == tagSkip {
+			continue
+		}
+		if fd, ok = fields[fieldKey(name)]; ok && sf.PkgPath == "" {
+			err = fn(rv.Field(n), fd)
+			continue
+		}
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			err = eachField(rv.Field(n), fields, fn)
+		}
+	}
+
+	return
+}
+
+// Значение поля сообщения в виде значения пригодного для sql.Scanner, nil является null
+func fieldValue(m protoreflect.Message, fd protoreflect.FieldDescriptor) (ret interface{}, err error) {
+	var (
+		sub protoreflect.Message
+		sfd protoreflect.FieldDescriptors
+	)
+
+	switch {
+	case fd.IsList() || fd.IsMap():
+		err = fmt.Errorf("repeated and map fields are not supported")
+		return
+	case fd.HasPresence() && !m.Has(fd):
+		return
+	case fd.Kind() == protoreflect.EnumKind:
+		ret = int64(m.Get(fd).Enum())
+		return
+	case fd.Message() == nil:
+		ret = m.Get(fd).Interface()
+		return
+	}
+	sub, sfd = m.Get(fd).Message(), fd.Message().Fields()
+	switch name := fd.Message().FullName(); {
+	case name == timestampName:
+		ret = time.Unix(sub.Get(sfd.ByName(timestampSeconds)).Int(), sub.Get(sfd.ByName(timestampNanos)).Int()).UTC()
+	case wrapperNames[name]:
+		ret = sub.Get(sfd.ByName(wrapperValueName)).Interface()
+	default:
+		err = fmt.Errorf("message %s is not supported", name)
+	}
+
+	return
+}
+
+// Создание значения поля сообщения из значения driver.Valuer
+func protoValue(m protoreflect.Message, fd protoreflect.FieldDescriptor, value driver.Value) (ret protoreflect.Value, err error) {
+	var (
+		sub protoreflect.Message
+		sfd protoreflect.FieldDescriptors
+		tm  time.Time
+		pv  protoreflect.Value
+		ok  bool
+	)
+
+	switch {
+	case fd.IsList() || fd.IsMap():
+		err = fmt.Errorf("repeated and map fields are not supported")
+		return
+	case fd.Message() == nil:
+		ret, err = scalarValue(fd, value)
+		return
+	}
+	sub, sfd = m.NewField(fd).Message(), fd.Message().Fields()
+	switch name := fd.Message().FullName(); {
+	case name == timestampName:
+		if tm, ok = value.(time.Time); !ok {
+			err = fmt.Errorf("can't convert type %T into %s", value, name)
+			return
+		}
+		sub.Set(sfd.ByName(timestampSeconds), protoreflect.ValueOfInt64(tm.Unix()))
+		sub.Set(sfd.ByName(timestampNanos), protoreflect.ValueOfInt32(int32(tm.Nanosecond())))
+	case wrapperNames[name]:
+		if pv, err = scalarValue(sfd.ByName(wrapperValueName), value); err != nil {
+			return
+		}
+		sub.Set(sfd.ByName(wrapperValueName), pv)
+	default:
+		err = fmt.Errorf("message %s is not supported", name)
+		return
+	}
+	ret = protoreflect.ValueOfMessage(sub)
+
+	return
+}
+
+// Создание скалярного значения поля сообщения, с проверкой диапазона
+func scalarValue(fd protoreflect.FieldDescriptor, value driver.Value) (ret protoreflect.Value, err error) {
+	var (
+		i int64
+		u uint64
+		f float64
+		b bool
+	)
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if b, err = strconv.ParseBool(asString(value)); err == nil {
+			ret = protoreflect.ValueOfBool(b)
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if i, err = strconv.ParseInt(asString(value), 10, 32); err == nil {
+			ret = protoreflect.ValueOfInt32(int32(i))
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if i, err = strconv.ParseInt(asString(value), 10, 64); err == nil {
+			ret = protoreflect.ValueOfInt64(i)
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if u, err = strconv.ParseUint(asString(value), 10, 32); err == nil {
+			ret = protoreflect.ValueOfUint32(uint32(u))
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if u, err = strconv.ParseUint(asString(value), 10, 64); err == nil {
+			ret = protoreflect.ValueOfUint64(u)
+		}
+	case protoreflect.FloatKind:
+		if f, err = strconv.ParseFloat(asString(value), 32); err == nil {
+			ret = protoreflect.ValueOfFloat32(float32(f))
+		}
+	case protoreflect.DoubleKind:
+		if f, err = strconv.ParseFloat(asString(value), 64); err == nil {
+			ret = protoreflect.ValueOfFloat64(f)
+		}
+	case protoreflect.EnumKind:
+		if i, err = strconv.ParseInt(asString(value), 10, 32); err == nil {
+			ret = protoreflect.ValueOfEnum(protoreflect.EnumNumber(i))
+		}
+	case protoreflect.StringKind:
+		ret = protoreflect.ValueOfString(asString(value))
+	case protoreflect.BytesKind:
+		switch v := value.(type) {
+		case []byte:
+			ret = protoreflect.ValueOfBytes(append([]byte(nil), v...))
+		default:
+			ret = protoreflect.ValueOfBytes([]byte(asString(value)))
+		}
+	default:
+		err = fmt.Errorf("field kind %s is not supported", fd.Kind())
+	}
+
+	return
+}
+
+// Представление значения driver.Value в виде строки
+func asString(value driver.Value) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,199 @@
+package nlpb // import "gopkg.in/webnice/lin.v1/nlpb"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	nul "gopkg.in/webnice/lin.v1/nl"
+)
+
+type messageTestBase struct {
+	ID nul.Int64
+}
+
+type messageTestStruct struct {
+	messageTestBase
+	UserName  nul.String
+	Active    nul.Bool
+	Score     nul.Float64
+	Counter   nul.Uint64 `pb:"hits"`
+	Avatar    nul.Bytes
+	CreatedAt nul.Time
+	Age       nul.Int32
+	Title     nul.String
+	Note      nul.String
+	Ignored   nul.String `pb:"-"`
+	Unknown   nul.String
+	Plain     string
+}
+
+var messageTestDescriptor protoreflect.MessageDescriptor
+
+func init() {
+	var (
+		file *descriptorpb.FileDescriptorProto
+		fd   protoreflect.FileDescriptor
+		err  error
+	)
+
+	field := func(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		ret := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   kind.Enum(),
+		}
+		if typeName != "" {
+			ret.TypeName = proto.String(typeName)
+		}
+		return ret
+	}
+	message := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	title := field("title", 9, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+	title.Proto3Optional, title.OneofIndex = proto.Bool(true), proto.Int32(0)
+	file = &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("nlpb_test.proto"),
+		Package:    proto.String("nlpb.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/wrappers.proto", "google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", 1, message, ".google.protobuf.Int64Value"),
+				field("user_name", 2, message, ".google.protobuf.StringValue"),
+				field("active", 3, message, ".google.protobuf.BoolValue"),
+				field("score", 4, message, ".google.protobuf.DoubleValue"),
+				field("hits", 5, message, ".google.protobuf.UInt64Value"),
+				field("avatar", 6, message, ".google.protobuf.BytesValue"),
+				field("created_at", 7, message, ".google.protobuf.Timestamp"),
+				field("age", 8, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+				title,
+				field("note", 10, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("ignored", 11, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("plain", 12, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+			},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_title")}},
+		}},
+	}
+	if fd, err = protodesc.NewFile(file, protoregistry.GlobalFiles); err != nil {
+		panic(err)
+	}
+	messageTestDescriptor = fd.Messages().Get(0)
+}
+
+func messageTestSet(msg proto.Message, name protoreflect.Name, value protoreflect.Value) {
+	m := msg.ProtoReflect()
+	m.Set(m.Descriptor().Fields().ByName(name), value)
+}
+
+func messageTestGet(msg proto.Message, name protoreflect.Name) (protoreflect.Value, bool) {
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(name)
+	return m.Get(fd), m.Has(fd)
+}
+
+func TestUnmarshalStruct(t *testing.T) {
+	var (
+		msg = dynamicpb.NewMessage(messageTestDescriptor)
+		tm  = time.Date(2021, time.March, 4, 5, 6, 7, 8, time.UTC)
+		v   messageTestStruct
+	)
+
+	messageTestSet(msg, "id", protoreflect.ValueOfMessage(wrapperspb.Int64(12).ProtoReflect()))
+	messageTestSet(msg, "user_name", protoreflect.ValueOfMessage(wrapperspb.String("alice").ProtoReflect()))
+	messageTestSet(msg, "hits", protoreflect.ValueOfMessage(wrapperspb.UInt64(1<<63).ProtoReflect()))
+	messageTestSet(msg, "created_at", protoreflect.ValueOfMessage(timestamppb.New(tm).ProtoReflect()))
+	messageTestSet(msg, "age", protoreflect.ValueOfInt32(42))
+	messageTestSet(msg, "ignored", protoreflect.ValueOfString("ignored"))
+	v.Active, v.Ignored, v.Plain = nul.NewBoolValue(true), nul.NewStringValue("keep"), "keep"
+	if err := UnmarshalStruct(msg, &v); err != nil {
+		t.Fatalf("UnmarshalStruct() error: %s", err)
+	}
+	if !v.ID.Valid || v.ID.Int64 != 12 || !v.UserName.Valid || v.UserName.String != "alice" {
+		t.Errorf("UnmarshalStruct() of wrappers is wrong: %v, %v", v.ID, v.UserName)
+	}
+	if !v.Counter.Valid || v.Counter.Uint64 != 1<<63 {
+		t.Errorf("UnmarshalStruct() of tagged field is wrong: %v", v.Counter)
+	}
+	if !v.CreatedAt.Valid || !v.CreatedAt.Time.Equal(tm) {
+		t.Errorf("UnmarshalStruct() of timestamp is %v, but should be %v", v.CreatedAt, tm)
+	}
+	if v.Active.Valid || v.Score.Valid || v.Avatar.Valid || v.Title.Valid {
+		t.Error("UnmarshalStruct()", "of absent fields is valid, but should be invalid")
+	}
+	if !v.Age.Valid || v.Age.Int32 != 42 || !v.Note.Valid || v.Note.String != "" {
+		t.Errorf("UnmarshalStruct() of scalar fields is wrong: %v, %v", v.Age, v.Note)
+	}
+	if v.Ignored.String != "keep" || v.Plain != "keep" || v.Unknown.Valid {
+		t.Error("UnmarshalStruct()", "changes skipped fields")
+	}
+
+	if err := UnmarshalStruct(msg, v); err == nil {
+		t.Error("UnmarshalStruct()", "error is nil, but should be not nil")
+	}
+	if err := UnmarshalStruct(msg, &struct{ Age nul.Bool }{}); err == nil {
+		t.Error("UnmarshalStruct()", "error is nil, but should be not nil")
+	}
+}
+
+func TestMarshalStruct(t *testing.T) {
+	var (
+		msg = dynamicpb.NewMessage(messageTestDescriptor)
+		tm  = time.Date(2021, time.March, 4, 5, 6, 7, 8, time.UTC)
+		v   messageTestStruct
+		v2  messageTestStruct
+	)
+
+	v.ID, v.UserName, v.Score = nul.NewInt64Value(-1), nul.NewStringValue("bob"), nul.NewFloat64Value(0.5)
+	v.Counter, v.Avatar, v.CreatedAt = nul.NewUint64Value(7), nul.NewBytesValue([]byte{1, 2}), nul.NewTimeValue(tm)
+	v.Age, v.Title, v.Ignored = nul.NewInt32Value(42), nul.NewStringValue(""), nul.NewStringValue("ignored")
+	messageTestSet(msg, "active", protoreflect.ValueOfMessage(wrapperspb.Bool(true).ProtoReflect()))
+	if err := MarshalStruct(&v, msg); err != nil {
+		t.Fatalf("MarshalStruct() error: %s", err)
+	}
+	if _, ok := messageTestGet(msg, "active"); ok {
+		t.Error("MarshalStruct()", "of null doesn't clear field")
+	}
+	if _, ok := messageTestGet(msg, "ignored"); ok {
+		t.Error("MarshalStruct()", "sets skipped field")
+	}
+	if value, ok := messageTestGet(msg, "title"); !ok || value.String() != "" {
+		t.Error("MarshalStruct()", "of optional field is wrong")
+	}
+	if err := UnmarshalStruct(msg, &v2); err != nil {
+		t.Fatalf("UnmarshalStruct() error: %s", err)
+	}
+	v.Ignored = nul.NewString()
+	if v2.ID != v.ID || v2.UserName != v.UserName || v2.Score != v.Score || v2.Counter != v.Counter ||
+		string(v2.Avatar.Bytes) != string(v.Avatar.Bytes) || !v2.CreatedAt.Time.Equal(tm) || v2.Age != v.Age ||
+		v2.Title != v.Title || v2.Active.Valid || v2.Ignored.Valid {
+		t.Errorf("MarshalStruct() is %v, but should be %v", v2, v)
+	}
+
+	v.Age = nul.NewInt32Value(1)
+	if err := MarshalStruct(v, msg); err != nil {
+		t.Fatalf("MarshalStruct() error: %s", err)
+	}
+	if value, _ := messageTestGet(msg, "age"); value.Int() != 1 {
+		t.Error("MarshalStruct()", "of struct value is wrong")
+	}
+	if err := MarshalStruct(1, msg); err == nil {
+		t.Error("MarshalStruct()", "error is nil, but should be not nil")
+	}
+	if err := MarshalStruct(struct{ CreatedAt nul.String }{nul.NewStringValue("now")}, msg); err == nil {
+		t.Error("MarshalStruct()", "error is nil, but should be not nil")
+	}
+	if err := MarshalStruct(struct{ Age nul.Int64 }{nul.NewInt64Value(1 << 40)}, msg); err == nil {
+		t.Error("MarshalStruct()", "error is nil, but should be not nil")
+	}
+}
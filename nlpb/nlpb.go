@@ -0,0 +1,127 @@
+// Package nlpb converts nullable objects to and from Protocol Buffers well-known types.
+// Nullable fields are expressed by the google.protobuf wrapper messages (Int64Value, StringValue, etc.)
+// and google.protobuf.Timestamp, nil message is null (Valid=false)
+package nlpb // import "gopkg.in/webnice/lin.v1/nlpb"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	nul "gopkg.in/webnice/lin.v1/nl"
+)
+
+// Int64 Создание объекта Int64 из сообщения google.protobuf.Int64Value, nil является null
+func Int64(v *wrapperspb.Int64Value) nul.Int64 {
+	if v == nil {
+		return nul.NewInt64()
+	}
+	return nul.NewInt64Value(v.GetValue())
+}
+
+// Int64Value Создание сообщения google.protobuf.Int64Value из объекта Int64, null является nil
+func Int64Value(v nul.Int64) *wrapperspb.Int64Value {
+	if !v.Valid {
+		return nil
+	}
+	return wrapperspb.Int64(v.Int64)
+}
+
+// Uint64 Создание объекта Uint64 из сообщения google.protobuf.UInt64Value, nil является null
+func Uint64(v *wrapperspb.UInt64Value) nul.Uint64 {
+	if v == nil {
+		return nul.NewUint64()
+	}
+	return nul.NewUint64Value(v.GetValue())
+}
+
+// UInt64Value Создание сообщения google.protobuf.UInt64Value из объекта Uint64, null является nil
+func UInt64Value(v nul.Uint64) *wrapperspb.UInt64Value {
+	if !v.Valid {
+		return nil
+	}
+	return wrapperspb.UInt64(v.Uint64)
+}
+
+// Float64 Создание объекта Float64 из сообщения google.protobuf.DoubleValue, nil является null
+func Float64(v *wrapperspb.DoubleValue) nul.Float64 {
+	if v == nil {
+		return nul.NewFloat64()
+	}
+	return nul.NewFloat64Value(v.GetValue())
+}
+
+// DoubleValue Создание сообщения google.protobuf.DoubleValue из объекта Float64, null является nil
+func DoubleValue(v nul.Float64) *wrapperspb.DoubleValue {
+	if !v.Valid {
+		return nil
+	}
+	return wrapperspb.Double(v.Float64)
+}
+
+// Bool Создание объекта Bool из сообщения google.protobuf.BoolValue, nil является null
+func Bool(v *wrapperspb.BoolValue) nul.Bool {
+	if v == nil {
+		return nul.NewBool()
+	}
+	return nul.NewBoolValue(v.GetValue())
+}
+
+// BoolValue Создание сообщения google.protobuf.BoolValue из объекта Bool, null является nil
+func BoolValue(v nul.Bool) *wrapperspb.BoolValue {
+	if !v.Valid {
+		return nil
+	}
+	return wrapperspb.Bool(v.Bool)
+}
+
+// String Создание объекта String из сообщения google.protobuf.StringValue, nil является null
+func String(v *wrapperspb.StringValue) nul.String {
+	if v == nil {
+		return nul.NewString()
+	}
+	return nul.NewStringValue(v.GetValue())
+}
+
+// StringValue Создание сообщения google.protobuf.StringValue из объекта String, null является nil
+func StringValue(v nul.String) *wrapperspb.StringValue {
+	if !v.Valid {
+		return nil
+	}
+	return wrapperspb.String(v.String)
+}
+
+// Bytes Создание объекта Bytes из сообщения google.protobuf.BytesValue, nil является null
+// Значение копируется
+func Bytes(v *wrapperspb.BytesValue) nul.Bytes {
+	if v == nil {
+		return nul.NewBytes()
+	}
+	return nul.NewBytesValue(v.GetValue())
+}
+
+// BytesValue Создание сообщения google.protobuf.BytesValue из объекта Bytes, null является nil
+// Значение копируется
+func BytesValue(v nul.Bytes) *wrapperspb.BytesValue {
+	if !v.Valid {
+		return nil
+	}
+	return wrapperspb.Bytes(v.MustValue())
+}
+
+// Time Создание объекта Time из сообщения google.protobuf.Timestamp, nil является null
+// Время возвращается в UTC
+func Time(v *timestamppb.Timestamp) nul.Time {
+	if v == nil {
+		return nul.NewTime()
+	}
+	return nul.NewTimeValue(v.AsTime())
+}
+
+// Timestamp Создание сообщения google.protobuf.Timestamp из объекта Time, null является nil
+func Timestamp(v nul.Time) *timestamppb.Timestamp {
+	if !v.Valid {
+		return nil
+	}
+	return timestamppb.New(v.Time)
+}
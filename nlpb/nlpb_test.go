@@ -0,0 +1,91 @@
+package nlpb // import "gopkg.in/webnice/lin.v1/nlpb"
+
+//import "gopkg.in/webnice/debug.v1"
+//import "gopkg.in/webnice/log.v2"
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	nul "gopkg.in/webnice/lin.v1/nl"
+)
+
+func TestNil(t *testing.T) {
+	if Int64(nil).Valid || Uint64(nil).Valid || Float64(nil).Valid || Bool(nil).Valid ||
+		String(nil).Valid || Bytes(nil).Valid || Time(nil).Valid {
+		t.Error("nil", "is valid, but should be invalid")
+	}
+	if Int64Value(nul.NewInt64()) != nil || UInt64Value(nul.NewUint64()) != nil ||
+		DoubleValue(nul.NewFloat64()) != nil || BoolValue(nul.NewBool()) != nil ||
+		StringValue(nul.NewString()) != nil || BytesValue(nul.NewBytes()) != nil ||
+		Timestamp(nul.NewTime()) != nil {
+		t.Error("null", "is not nil, but should be nil")
+	}
+}
+
+func TestInt64(t *testing.T) {
+	v := Int64(Int64Value(nul.NewInt64Value(math.MinInt64)))
+	if !v.Valid || v.Int64 != math.MinInt64 {
+		t.Errorf("Int64() is %v, but should be %d", v, int64(math.MinInt64))
+	}
+	if v = Int64(&wrapperspb.Int64Value{}); !v.Valid || v.Int64 != 0 {
+		t.Error("Int64()", "of zero value is wrong")
+	}
+}
+
+func TestUint64(t *testing.T) {
+	v := Uint64(UInt64Value(nul.NewUint64Value(math.MaxUint64)))
+	if !v.Valid || v.Uint64 != math.MaxUint64 {
+		t.Errorf("Uint64() is %v, but should be %d", v, uint64(math.MaxUint64))
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	v := Float64(DoubleValue(nul.NewFloat64Value(math.Pi)))
+	if !v.Valid || v.Float64 != math.Pi {
+		t.Errorf("Float64() is %v, but should be %v", v, math.Pi)
+	}
+}
+
+func TestBool(t *testing.T) {
+	v := Bool(BoolValue(nul.NewBoolValue(false)))
+	if !v.Valid || v.Bool {
+		t.Errorf("Bool() is %v, but should be false", v)
+	}
+}
+
+func TestString(t *testing.T) {
+	v := String(StringValue(nul.NewStringValue("Привет")))
+	if !v.Valid || v.String != "Привет" {
+		t.Errorf("String() is %v, but should be Привет", v)
+	}
+}
+
+func TestBytes(t *testing.T) {
+	var buf = []byte("abc")
+
+	msg := BytesValue(nul.NewBytesValue(buf))
+	v := Bytes(msg)
+	if !v.Valid || !bytes.Equal(v.Bytes, buf) {
+		t.Errorf("Bytes() is %v, but should be %v", v, buf)
+	}
+	msg.Value[0] = 'x'
+	if v.Bytes[0] != 'a' {
+		t.Error("Bytes()", "doesn't copy value")
+	}
+}
+
+func TestTime(t *testing.T) {
+	var tm = time.Date(2021, time.March, 4, 5, 6, 7, 8, time.FixedZone("MSK", 3*60*60))
+
+	v := Time(Timestamp(nul.NewTimeValue(tm)))
+	if !v.Valid || !v.Time.Equal(tm) || v.Time.Location() != time.UTC {
+		t.Errorf("Time() is %v, but should be %v", v, tm.UTC())
+	}
+	if ts := Timestamp(v); ts.GetSeconds() != tm.Unix() || ts.GetNanos() != 8 {
+		t.Errorf("Timestamp() is %v, but should be %v", ts, timestamppb.New(tm))
+	}
+}
@@ -4,18 +4,56 @@ package wrapper // import "gopkg.in/webnice/lin.v1/wrapper"
 //import "gopkg.in/webnice/log.v2"
 import (
 	"encoding/gob"
+	"math/big"
 	"time"
 )
 
 func init() {
 	// Register the concrete type for the encoder and decoder
+	gob.Register(BigFloatWrapper{})
+	gob.Register(BigIntWrapper{})
 	gob.Register(BoolWrapper{})
 	gob.Register(BytesWrapper{})
+	gob.Register(DateWrapper{})
+	gob.Register(DecimalWrapper{})
+	gob.Register(DurationWrapper{})
 	gob.Register(Float64Wrapper{})
+	gob.Register(Float32Wrapper{})
+	gob.Register(HardwareAddrWrapper{})
 	gob.Register(Int64Wrapper{})
+	gob.Register(Int32Wrapper{})
+	gob.Register(Int16Wrapper{})
+	gob.Register(Int8Wrapper{})
+	gob.Register(IntervalWrapper{})
+	gob.Register(IPWrapper{})
+	gob.Register(JSONWrapper{})
+	gob.Register(LineStringWrapper{})
+	gob.Register(PointWrapper{})
+	gob.Register(PolygonWrapper{})
+	gob.Register(PrefixWrapper{})
 	gob.Register(StringWrapper{})
+	gob.Register(StringMapWrapper{})
 	gob.Register(TimeWrapper{})
+	gob.Register(TimeOfDayWrapper{})
 	gob.Register(Uint64Wrapper{})
+	gob.Register(Uint32Wrapper{})
+	gob.Register(Uint16Wrapper{})
+	gob.Register(Uint8Wrapper{})
+	gob.Register(UUIDWrapper{})
+}
+
+// BigFloatWrapper Обёртка для BigFloat
+type BigFloatWrapper struct {
+	Value  *big.Float
+	Valid  bool
+	Quoted bool
+}
+
+// BigIntWrapper Обёртка для BigInt
+type BigIntWrapper struct {
+	Value  *big.Int
+	Valid  bool
+	Quoted bool
 }
 
 // BoolWrapper Обёртка для Bool
@@ -30,32 +68,206 @@ type BytesWrapper struct {
 	Valid bool
 }
 
+// DateWrapper Обёртка для Date
+type DateWrapper struct {
+	Year  int
+	Month int
+	Day   int
+	Valid bool
+}
+
+// DecimalWrapper Обёртка для Decimal
+type DecimalWrapper struct {
+	Value *big.Int
+	Scale int32
+	Valid bool
+}
+
+// DurationWrapper Обёртка для Duration
+type DurationWrapper struct {
+	Value  time.Duration
+	Valid  bool
+	Format uint8
+}
+
 // Float64Wrapper Обёртка для Float64
 type Float64Wrapper struct {
 	Value float64
 	Valid bool
 }
 
+// Float32Wrapper Обёртка для Float32
+type Float32Wrapper struct {
+	Value float32
+	Valid bool
+}
+
+// HardwareAddrWrapper Обёртка для HardwareAddr
+type HardwareAddrWrapper struct {
+	Value []byte
+	Valid bool
+}
+
 // Int64Wrapper Обёртка для Int64
 type Int64Wrapper struct {
 	Value int64
 	Valid bool
 }
 
+// Int32Wrapper Обёртка для Int32
+type Int32Wrapper struct {
+	Value int32
+	Valid bool
+}
+
+// Int16Wrapper Обёртка для Int16
+type Int16Wrapper struct {
+	Value int16
+	Valid bool
+}
+
+// Int8Wrapper Обёртка для Int8
+type Int8Wrapper struct {
+	Value int8
+	Valid bool
+}
+
+// IntervalWrapper Обёртка для Interval
+type IntervalWrapper struct {
+	Months       int32
+	Days         int32
+	Microseconds int64
+	Valid        bool
+}
+
+// IPWrapper Обёртка для IP
+type IPWrapper struct {
+	Value []byte
+	Valid bool
+}
+
+// JSONWrapper Обёртка для JSON
+type JSONWrapper struct {
+	Value []byte
+	Valid bool
+}
+
+// LineStringWrapper Обёртка для LineString
+type LineStringWrapper struct {
+	Value [][2]float64
+	Valid bool
+	SRID  int32
+}
+
+// PointWrapper Обёртка для Point
+type PointWrapper struct {
+	X     float64
+	Y     float64
+	Valid bool
+	SRID  int32
+}
+
+// PolygonWrapper Обёртка для Polygon
+type PolygonWrapper struct {
+	Value [][][2]float64
+	Valid bool
+	SRID  int32
+}
+
+// PrefixWrapper Обёртка для Prefix
+type PrefixWrapper struct {
+	Value []byte
+	Valid bool
+}
+
 // StringWrapper Обёртка для String
 type StringWrapper struct {
 	Value string
 	Valid bool
 }
 
+// StringMapWrapper Обёртка для StringMap
+type StringMapWrapper struct {
+	Value map[string]string
+	Null  []string
+	Valid bool
+	JSON  bool
+}
+
 // TimeWrapper Обёртка для Time
 type TimeWrapper struct {
 	Value time.Time
 	Valid bool
 }
 
+// TimeOfDayWrapper Обёртка для TimeOfDay
+type TimeOfDayWrapper struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+	Offset     int
+	HasOffset  bool
+	Valid      bool
+}
+
 // Uint64Wrapper Обёртка для Uint64
 type Uint64Wrapper struct {
 	Value uint64
 	Valid bool
 }
+
+// Uint32Wrapper Обёртка для Uint32
+type Uint32Wrapper struct {
+	Value uint32
+	Valid bool
+}
+
+// Uint16Wrapper Обёртка для Uint16
+type Uint16Wrapper struct {
+	Value uint16
+	Valid bool
+}
+
+// Uint8Wrapper Обёртка для Uint8
+type Uint8Wrapper struct {
+	Value uint8
+	Valid bool
+}
+
+// UUIDWrapper Обёртка для UUID
+type UUIDWrapper struct {
+	Value  [16]byte
+	Valid  bool
+	Binary bool
+}
+
+// NullWrapper Обёртка для Null
+type NullWrapper[T any] struct {
+	Value T
+	Valid bool
+}
+
+// OptionalWrapper Обёртка для Optional
+type OptionalWrapper[T any] struct {
+	Value   T
+	Valid   bool
+	Present bool
+}
+
+// ArrayWrapper Обёртка для Array
+type ArrayWrapper[T any] struct {
+	Value []T
+	Dims  []int
+	Valid bool
+}
+
+// RangeWrapper Обёртка для Range
+type RangeWrapper[T any] struct {
+	Lower    T
+	Upper    T
+	LowerInc bool
+	UpperInc bool
+	Empty    bool
+	Valid    bool
+}
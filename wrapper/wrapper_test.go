@@ -7,11 +7,38 @@ import (
 )
 
 func TestExistsWrapers(t *testing.T) {
+	_ = &BigFloatWrapper{}
+	_ = &BigIntWrapper{}
 	_ = &BoolWrapper{}
 	_ = &BytesWrapper{}
+	_ = &DateWrapper{}
+	_ = &DecimalWrapper{}
+	_ = &DurationWrapper{}
 	_ = &Float64Wrapper{}
+	_ = &Float32Wrapper{}
+	_ = &HardwareAddrWrapper{}
 	_ = &Int64Wrapper{}
+	_ = &Int32Wrapper{}
+	_ = &Int16Wrapper{}
+	_ = &Int8Wrapper{}
+	_ = &IntervalWrapper{}
+	_ = &IPWrapper{}
+	_ = &JSONWrapper{}
+	_ = &LineStringWrapper{}
+	_ = &PointWrapper{}
+	_ = &PolygonWrapper{}
+	_ = &PrefixWrapper{}
 	_ = &StringWrapper{}
+	_ = &StringMapWrapper{}
 	_ = &TimeWrapper{}
+	_ = &TimeOfDayWrapper{}
 	_ = &Uint64Wrapper{}
+	_ = &Uint32Wrapper{}
+	_ = &Uint16Wrapper{}
+	_ = &Uint8Wrapper{}
+	_ = &UUIDWrapper{}
+	_ = &NullWrapper[int64]{}
+	_ = &OptionalWrapper[int64]{}
+	_ = &ArrayWrapper[int64]{}
+	_ = &RangeWrapper[int64]{}
 }